@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"metric-neo/internal/application"
 	"metric-neo/internal/infrastructure/chrono"
+	"metric-neo/internal/infrastructure/persistence"
 	"os"
 	"sync"
 
@@ -16,21 +17,36 @@ type App struct {
 	ctx           context.Context
 	configService *application.ConfigService
 	// Services werden erst initialisiert nach Setup
-	profileService    *application.ProfileService
-	projectileService *application.ProjectileService
-	sessionService    *application.SessionService
-	sightService      *application.SightService
+	profileService      *application.ProfileService
+	projectileService   *application.ProjectileService
+	sessionService      *application.SessionService
+	sightService        *application.SightService
+	analyticsService    *application.AnalyticsService
+	controlChartService *application.ControlChartService
 
-	chronoService      chrono.ChronoService
-	chronoVelocityChan chan float32
-	chronoErrorChan    chan error
-	chronoAutoRunning  bool
-	chronoMu           sync.Mutex
+	captureService *application.CaptureService
+
+	// storageRecovery ist das Ergebnis der Datenprüfung beim Start
+	storageRecovery application.StorageRecoveryDTO
+	// repos sind die offenen Repositories (SQLite hält eine Verbindung)
+	repos *application.Repositories
+
+	// startupError verhindert den Start der Services (z.B. Daten einer neueren Version)
+	startupError string
+
+	// chronoSupervisor gehört zur laufenden Aufnahme (nur für GetChronoState)
+	chronoSupervisor *chrono.Supervisor
+	chronoMu         sync.Mutex
+
+	// emit sendet ein Event an das Frontend (runtime.EventsEmit, in Tests ersetzbar)
+	emit func(name string, data ...interface{})
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
-	return &App{}
+	return &App{
+		emit: func(string, ...interface{}) {},
+	}
 }
 
 // startup is called when the app starts. The context is saved
@@ -40,6 +56,9 @@ func NewApp() *App {
 // Config wird geladen, Services erst nach Setup initialisiert
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+	a.emit = func(name string, data ...interface{}) {
+		runtime.EventsEmit(ctx, name, data...)
+	}
 
 	// Initialisiere ConfigService
 	configService, err := application.NewConfigService()
@@ -58,34 +77,142 @@ func (a *App) startup(ctx context.Context) {
 	}
 
 	// Setup bereits abgeschlossen - initialisiere Services
+	// Kein LogFatal: Das Frontend zeigt den Fehler (GetStartupError), statt
+	// dass sich das Fenster kommentarlos schließt
 	if err := a.initializeServices(); err != nil {
-		runtime.LogFatal(ctx, "Failed to initialize services: "+err.Error())
+		runtime.LogError(ctx, "Failed to initialize services: "+err.Error())
+	}
+}
+
+// shutdown wird beim Schließen des Fensters aufgerufen
+func (a *App) shutdown(ctx context.Context) {
+	a.SessionDisarmCapture()
+	a.closeRepositories()
+}
+
+// closeRepositories schließt die Repositories der vorherigen Initialisierung.
+func (a *App) closeRepositories() {
+	if a.repos == nil {
+		return
+	}
+	if err := a.repos.Close(); err != nil {
+		runtime.LogError(a.ctx, "Failed to close storage: "+err.Error())
 	}
+	a.repos = nil
 }
 
 // initializeServices erstellt alle Services mit Config
 //
 // Go-Pattern: Factory Method
 // Wird nach Setup oder beim Start (wenn Setup bereits erfolgt) aufgerufen
+// Eine laufende Aufnahme wird vorher beendet: Sie schreibt in die alten
+// Repositories, die hier geschlossen werden.
 func (a *App) initializeServices() error {
+	a.SessionDisarmCapture()
+
 	cfg := a.configService.GetConfig()
 	dataDir := cfg.DataDir
 
 	runtime.LogInfo(a.ctx, "Initializing services with data directory: "+dataDir)
 
-	// Erstelle Services
-	a.profileService = application.NewProfileService(dataDir)
-	a.projectileService = application.NewProjectileService(dataDir)
-	a.sessionService = application.NewSessionService(dataDir)
-	a.sightService = application.NewSightService(dataDir)
+	a.startupError = ""
+
+	// Nach einem Absturz: Temp-Dateien aufräumen, unlesbare Dateien melden
+	recovery, err := application.RecoverStorage(dataDir)
+	a.storageRecovery = recovery
+	if err != nil {
+		runtime.LogError(a.ctx, "Storage recovery failed: "+err.Error())
+	}
+	for _, f := range recovery.CorruptFiles {
+		runtime.LogWarning(a.ctx, "Unreadable data file moved to "+f.QuarantinedAs+": "+f.Reason)
+	}
+	for _, path := range recovery.RemovedTempFiles {
+		runtime.LogInfo(a.ctx, "Removed leftover temp file: "+path)
+	}
+
+	// Ältere Dateien auf das aktuelle Schema heben, bevor ein Repository sie liest
+	migration, err := application.MigrateDataDir(dataDir)
+	if err != nil {
+		a.startupError = err.Error()
+		return fmt.Errorf("data migration failed: %w", err)
+	}
+	if migration != nil {
+		a.storageRecovery.Migration = migration
+		runtime.LogInfo(a.ctx, fmt.Sprintf("Migrated %d data files from schema %d to %d, backup in %s",
+			migration.Documents, migration.FromVersion, migration.ToVersion, migration.BackupDir))
+	}
 
-	if a.chronoService == nil {
-		a.chronoService = chrono.NewSerialChrono()
+	// Repositories des Backends aus dem Manifest (JSON oder SQLite)
+	repos, err := application.OpenDataDir(dataDir)
+	if err != nil {
+		a.startupError = err.Error()
+		return fmt.Errorf("failed to open storage: %w", err)
 	}
+	a.closeRepositories()
+	a.repos = repos
+	runtime.LogInfo(a.ctx, "Storage backend: "+string(repos.Backend))
 
+	// Erstelle Services
+	a.profileService = application.NewProfileServiceWith(repos)
+	a.projectileService = application.NewProjectileServiceWith(repos)
+	a.sessionService = application.NewSessionServiceWith(repos)
+	a.sightService = application.NewSightServiceWith(repos)
+	a.analyticsService = application.NewAnalyticsServiceWith(repos)
+	a.controlChartService = application.NewControlChartServiceWith(repos)
+	a.applyEnergyLimits()
+	// Der Chrono wird erst verbunden, wenn eine Session scharf geschaltet wird
+	a.captureService = application.NewCaptureService(a.sessionService, a.emitEvent)
 	return nil
 }
 
+// GetStorageRecovery gibt zurück, was die Datenprüfung beim Start gefunden hat.
+// Das Frontend weist damit auf beiseitegelegte, unlesbare Dateien hin.
+func (a *App) GetStorageRecovery() application.StorageRecoveryDTO {
+	return a.storageRecovery
+}
+
+// GetStartupError gibt den Grund zurück, warum die Services nicht gestartet
+// wurden (leer = alles in Ordnung). Das Frontend zeigt dann nur diese Meldung.
+func (a *App) GetStartupError() string {
+	return a.startupError
+}
+
+// GetStorageInfo gibt das Speicher-Backend des Datenverzeichnisses zurück
+func (a *App) GetStorageInfo() application.Result[application.StorageInfoDTO] {
+	if a.configService == nil || a.configService.NeedsSetup() {
+		return application.FailWithMessage[application.StorageInfoDTO]("Services not initialized - setup not completed")
+	}
+	info, err := application.GetStorageInfo(a.configService.GetDataDir())
+	if err != nil {
+		return application.Fail[application.StorageInfoDTO](err)
+	}
+	return application.OK(info)
+}
+
+// ConvertStorage kopiert alle Daten in das andere Backend ("json" oder "sqlite")
+// und startet die Services darauf neu.
+//
+// Eine laufende Aufnahme wird vorher beendet: Sie schreibt ins alte Backend.
+func (a *App) ConvertStorage(backend string) application.Result[application.StorageConversionDTO] {
+	if a.sessionService == nil {
+		return application.FailWithMessage[application.StorageConversionDTO]("Services not initialized - setup not completed")
+	}
+	a.SessionDisarmCapture()
+
+	dataDir := a.configService.GetDataDir()
+	result, err := application.ConvertStorage(dataDir, persistence.StorageBackend(backend))
+	if err != nil {
+		runtime.LogError(a.ctx, "Storage conversion failed: "+err.Error())
+		return application.Fail[application.StorageConversionDTO](err)
+	}
+	runtime.LogInfo(a.ctx, fmt.Sprintf("Converted storage from %s to %s (%d sessions)", result.From, result.To, result.Sessions))
+
+	if err := a.initializeServices(); err != nil {
+		return application.Fail[application.StorageConversionDTO](err)
+	}
+	return application.OK(result)
+}
+
 // --- Setup-Flow für Frontend ---
 
 // NeedsSetup prüft ob Initial-Setup benötigt wird
@@ -182,116 +309,205 @@ func (a *App) GetChronoConfig() application.Result[application.ChronoConfigDTO]
 	return application.OK(a.configService.GetChronoConfig())
 }
 
+// GetChronoDrivers listet die verfügbaren Chronograph-Treiber (Protokolle)
+func (a *App) GetChronoDrivers() []application.ChronoDriverDTO {
+	return application.ListChronoDrivers()
+}
+
+// ChronoDiscoverPorts listet serielle Ports (mit USB-Metadaten).
+// probe=true lauscht auf jedem Port mit dem gewählten Treiber, um den Chrono zu erkennen.
+func (a *App) ChronoDiscoverPorts(probe bool, driver string, baudRate int) application.Result[[]application.ChronoPortDTO] {
+	ports, err := application.DiscoverChronoPorts(probe, driver, baudRate, application.DefaultProbeTimeout)
+	if err != nil {
+		return application.Fail[[]application.ChronoPortDTO](err)
+	}
+	return application.OK(ports)
+}
+
 // UpdateChronoConfig speichert Chrono-Konfiguration
-func (a *App) UpdateChronoConfig(enabled bool, port string, baudRate int, autoRecord bool) application.Result[application.ChronoConfigDTO] {
+func (a *App) UpdateChronoConfig(cfg application.ChronoConfigDTO) application.Result[application.ChronoConfigDTO] {
 	if a.configService == nil || a.configService.NeedsSetup() {
 		return application.FailWithMessage[application.ChronoConfigDTO]("Config not initialized - setup not completed")
 	}
 
-	cfg := application.ChronoConfigDTO{
-		Enabled:    enabled,
-		Port:       port,
-		BaudRate:   baudRate,
-		AutoRecord: autoRecord,
-	}
-
 	if err := a.configService.UpdateChronoConfig(cfg); err != nil {
 		return application.Fail[application.ChronoConfigDTO](err)
 	}
 
-	if !enabled || port == "" || baudRate <= 0 {
-		a.stopChrono()
+	// Port/Treiber können sich geändert haben: laufende Aufnahme neu verbinden
+	if status := a.SessionGetCaptureStatus(); status.Armed {
+		if result := a.SessionArmCapture(status.SessionID); !result.Success {
+			a.SessionDisarmCapture()
+		}
 	}
 
 	return application.OK(a.configService.GetChronoConfig())
 }
 
-// SessionPollChrono prüft, ob neue Chrono-Messwerte verfügbar sind und zeichnet sie automatisch auf.
-// Option A: Frontend pollt diese Methode regelmäßig.
-func (a *App) SessionPollChrono(sessionID string) application.Result[application.ChronoPollResultDTO] {
+// GetEnergyLimitsConfig gibt die Energiegrenzen (Rechtsraum + eigene Regeln) zurück
+func (a *App) GetEnergyLimitsConfig() application.Result[application.EnergyLimitsConfigDTO] {
 	if a.configService == nil || a.configService.NeedsSetup() {
-		return application.FailWithMessage[application.ChronoPollResultDTO]("Config not initialized - setup not completed")
+		return application.FailWithMessage[application.EnergyLimitsConfigDTO]("Config not initialized - setup not completed")
 	}
-	if a.sessionService == nil {
-		return application.FailWithMessage[application.ChronoPollResultDTO]("Services not initialized - setup not completed")
+	return application.OK(a.configService.GetEnergyLimitsConfig())
+}
+
+// GetEnergyJurisdictions listet die Rechtsräume mit vordefinierten Energiegrenzen
+func (a *App) GetEnergyJurisdictions() []application.EnergyJurisdictionDTO {
+	return application.ListEnergyJurisdictions()
+}
+
+// UpdateEnergyLimitsConfig speichert die Energiegrenzen und prüft Sessions ab sofort dagegen
+func (a *App) UpdateEnergyLimitsConfig(cfg application.EnergyLimitsConfigDTO) application.Result[application.EnergyLimitsConfigDTO] {
+	if a.configService == nil || a.configService.NeedsSetup() {
+		return application.FailWithMessage[application.EnergyLimitsConfigDTO]("Config not initialized - setup not completed")
 	}
-	if sessionID == "" {
-		return application.FailWithMessage[application.ChronoPollResultDTO]("Session-ID darf nicht leer sein")
+
+	if err := a.configService.UpdateEnergyLimitsConfig(cfg); err != nil {
+		return application.Fail[application.EnergyLimitsConfigDTO](err)
 	}
+	a.applyEnergyLimits()
 
-	cfg := a.configService.GetConfig()
-	if !cfg.ChronoEnabled || !cfg.ChronoAutoRecord {
-		return application.OK(application.ChronoPollResultDTO{Recorded: false})
+	return application.OK(a.configService.GetEnergyLimitsConfig())
+}
+
+// applyEnergyLimits gibt die Energiegrenzen der Config an den SessionService weiter.
+func (a *App) applyEnergyLimits() {
+	if a.sessionService == nil {
+		return
+	}
+	limits, err := a.configService.EnergyLimits()
+	if err != nil {
+		runtime.LogWarning(a.ctx, "Ignoring invalid energy limits in config: "+err.Error())
 	}
-	if cfg.ChronoPort == "" || cfg.ChronoBaudRate <= 0 {
-		return application.FailWithMessage[application.ChronoPollResultDTO]("Chrono ist nicht korrekt konfiguriert")
+	a.sessionService.SetEnergyLimits(limits)
+}
+
+// GetUnits gibt die Anzeige-Einheiten (Labels und Faktoren ab SI) zurück
+func (a *App) GetUnits() application.Result[application.UnitsDTO] {
+	if a.configService == nil || a.configService.NeedsSetup() {
+		return application.FailWithMessage[application.UnitsDTO]("Config not initialized - setup not completed")
 	}
+	return application.OK(a.configService.GetUnits())
+}
 
-	if err := a.ensureChronoRunning(cfg.ChronoPort, cfg.ChronoBaudRate); err != nil {
-		return application.Fail[application.ChronoPollResultDTO](err)
+// UpdateUnitSystem speichert das Einheitensystem der Anzeige ("metric", "imperial", "mixed")
+func (a *App) UpdateUnitSystem(system string) application.Result[application.UnitsDTO] {
+	if a.configService == nil || a.configService.NeedsSetup() {
+		return application.FailWithMessage[application.UnitsDTO]("Config not initialized - setup not completed")
 	}
 
-	select {
-	case err := <-a.chronoErrorChan:
-		return application.Fail[application.ChronoPollResultDTO](err)
-	case velocity := <-a.chronoVelocityChan:
-		recordResult := a.sessionService.RecordShot(sessionID, float64(velocity))
-		if !recordResult.Success {
-			return application.FailWithMessage[application.ChronoPollResultDTO](recordResult.Error)
-		}
-		v := float64(velocity)
-		dto := application.ChronoPollResultDTO{
-			Recorded:    true,
-			VelocityMPS: &v,
-			Session:     &recordResult.Data,
-		}
-		return application.OK(dto)
-	default:
-		return application.OK(application.ChronoPollResultDTO{Recorded: false})
+	if err := a.configService.UpdateUnitSystem(system); err != nil {
+		return application.Fail[application.UnitsDTO](err)
 	}
+
+	return application.OK(a.configService.GetUnits())
 }
 
-func (a *App) ensureChronoRunning(port string, baudRate int) error {
-	a.chronoMu.Lock()
-	defer a.chronoMu.Unlock()
+// SessionArmCapture macht eine Session für die Live-Aufnahme scharf.
+//
+// Das Backend verbindet den Chrono (Supervisor mit Reconnect) und speichert
+// jeden Messwert sofort. Das Frontend erfährt davon über die Events
+// "shot:recorded" (bzw. "shot:measured" ohne AutoRecord) und "capture:error".
+func (a *App) SessionArmCapture(sessionID string) application.Result[application.CaptureStatusDTO] {
+	if a.configService == nil || a.configService.NeedsSetup() {
+		return application.FailWithMessage[application.CaptureStatusDTO]("Config not initialized - setup not completed")
+	}
+	if a.captureService == nil {
+		return application.FailWithMessage[application.CaptureStatusDTO]("Services not initialized - setup not completed")
+	}
 
-	if a.chronoService == nil {
-		a.chronoService = chrono.NewSerialChrono()
+	cfg := a.configService.GetConfig()
+	if !cfg.ChronoEnabled {
+		return application.FailWithMessage[application.CaptureStatusDTO]("Chrono ist deaktiviert")
+	}
+	if cfg.ChronoPort == "" {
+		return application.FailWithMessage[application.CaptureStatusDTO]("Chrono ist nicht korrekt konfiguriert")
+	}
+	driver, err := chrono.LookupDriver(cfg.ChronoDriver)
+	if err != nil {
+		return application.Fail[application.CaptureStatusDTO](err)
 	}
 
-	if !a.chronoService.IsConnected() {
-		if err := a.chronoService.Connect(port, baudRate); err != nil {
-			return err
+	opts := chrono.SupervisorOptions{
+		Port:         cfg.ChronoPort,
+		SerialNumber: cfg.ChronoSerialNumber,
+		BaudRate:     cfg.ChronoBaudRate,
+		OnState:      a.onChronoState,
+	}
+	if cfg.ChronoWireLog {
+		file, err := a.sessionService.OpenWireLog(sessionID)
+		if err != nil {
+			return application.Fail[application.CaptureStatusDTO](err)
 		}
+		// Der Supervisor schließt das Log bei Stop()
+		opts.WireLog = chrono.NewWireLog(file)
 	}
 
-	if a.chronoVelocityChan == nil {
-		a.chronoVelocityChan = make(chan float32, 16)
+	supervisor := chrono.NewSupervisor(driver, opts)
+	result := a.captureService.Arm(sessionID, cfg.ChronoAutoRecord, supervisor)
+	if !result.Success && opts.WireLog != nil {
+		opts.WireLog.Close()
 	}
-	if a.chronoErrorChan == nil {
-		a.chronoErrorChan = make(chan error, 8)
+	if result.Success {
+		a.chronoMu.Lock()
+		a.chronoSupervisor = supervisor
+		a.chronoMu.Unlock()
 	}
+	return result
+}
 
-	if !a.chronoAutoRunning {
-		a.chronoService.StartAutoRead(a.chronoVelocityChan, a.chronoErrorChan)
-		a.chronoAutoRunning = true
+// SessionDisarmCapture beendet die Live-Aufnahme und trennt den Chrono.
+// Bereits empfangene Messwerte werden noch gespeichert.
+func (a *App) SessionDisarmCapture() application.Result[application.CaptureStatusDTO] {
+	if a.captureService == nil {
+		return application.OK(application.CaptureStatusDTO{})
 	}
 
-	return nil
+	result := a.captureService.Disarm()
+	a.chronoMu.Lock()
+	a.chronoSupervisor = nil
+	a.chronoMu.Unlock()
+	return result
 }
 
-func (a *App) stopChrono() {
-	a.chronoMu.Lock()
-	defer a.chronoMu.Unlock()
+// SessionGetCaptureStatus gibt zurück, ob und welche Session scharf ist.
+// Die Aufnahme läuft auch weiter, wenn das Frontend die Ansicht wechselt.
+func (a *App) SessionGetCaptureStatus() application.CaptureStatusDTO {
+	if a.captureService == nil {
+		return application.CaptureStatusDTO{}
+	}
+	return a.captureService.Status()
+}
 
-	if a.chronoService != nil && a.chronoAutoRunning {
-		a.chronoService.StopAutoRead()
-		a.chronoAutoRunning = false
+// emitEvent passt a.emit an application.EmitFunc an.
+// a.emit wird bei jedem Aufruf gelesen, damit Tests es ersetzen können.
+func (a *App) emitEvent(name string, data interface{}) {
+	a.emit(name, data)
+}
+
+// onChronoState meldet Zustandswechsel als "chrono:state" an das Frontend.
+func (a *App) onChronoState(change chrono.StateChange) {
+	// Gerät per USB-Seriennummer unter neuem Port-Namen gefunden: merken
+	// (UpdateChronoPort speichert nur bei geändertem Port)
+	if change.State == chrono.StateConnected && a.configService != nil {
+		_ = a.configService.UpdateChronoPort(change.Port)
 	}
 
-	if a.chronoService != nil && a.chronoService.IsConnected() {
-		_ = a.chronoService.Disconnect()
+	a.emit(application.ChronoStateEvent, application.NewChronoStateDTO(change))
+}
+
+// GetChronoState gibt den aktuellen Verbindungszustand des Chronographen zurück.
+// Änderungen kommen danach als Event "chrono:state".
+func (a *App) GetChronoState() application.ChronoStateDTO {
+	a.chronoMu.Lock()
+	supervisor := a.chronoSupervisor
+	a.chronoMu.Unlock()
+
+	if supervisor == nil {
+		return application.ChronoStateDTO{State: string(chrono.StateDisconnected)}
 	}
+	return application.NewChronoStateDTO(supervisor.State())
 }
 
 // GetSystemTheme gibt das System-Theme zurück (dark/light)
@@ -430,6 +646,14 @@ func (a *App) ProfileRemoveTwistRate(profileID string) application.Result[applic
 	return a.profileService.RemoveTwistRate(profileID)
 }
 
+// ProfileSetPowerPlant setzt Antrieb und Regler-Druck einer Luftdruckwaffe
+func (a *App) ProfileSetPowerPlant(profileID string, powerPlant string, regulatorSetpointBar *float64) application.Result[application.ProfileDTO] {
+	if a.profileService == nil {
+		return application.FailWithMessage[application.ProfileDTO]("Services not initialized - setup not completed")
+	}
+	return a.profileService.SetPowerPlant(profileID, powerPlant, regulatorSetpointBar)
+}
+
 // SightCreateSight erstellt eine neue Optik
 func (a *App) SightCreateSight(typeName string, modelName string, weightG float64, minMagnification float64, maxMagnification float64) application.Result[application.SightDTO] {
 	if a.sightService == nil {
@@ -473,11 +697,12 @@ func (a *App) SightDeleteSight(id string) application.Result[bool] {
 // ==================== PROJECTILE SERVICE DELEGATION ====================
 
 // ProjectileCreateProjectile erstellt ein neues Projektil/Munitionstyp
-func (a *App) ProjectileCreateProjectile(name string, weightGrams float64, bc float64) application.Result[application.ProjectileDTO] {
+// (BC mit Widerstandsmodell und Einheit, leer = G1 in lb/in²)
+func (a *App) ProjectileCreateProjectile(name string, weightGrams float64, drag application.DragDTO) application.Result[application.ProjectileDTO] {
 	if a.projectileService == nil {
 		return application.FailWithMessage[application.ProjectileDTO]("Services not initialized - setup not completed")
 	}
-	return a.projectileService.CreateProjectile(name, weightGrams, bc)
+	return a.projectileService.CreateProjectileWithDrag(name, weightGrams, drag)
 }
 
 // ProjectileLoadProjectile lädt ein Projektil nach ID
@@ -512,22 +737,137 @@ func (a *App) ProjectileUpdateBC(projectileID string, newBC float64) application
 	return a.projectileService.UpdateBC(projectileID, newBC)
 }
 
-// ProjectileUpdateProjectile aktualisiert die Basisdaten eines Projektils
-func (a *App) ProjectileUpdateProjectile(projectileID string, name string, weightGrams float64, bc float64) application.Result[application.ProjectileDTO] {
+// ProjectileUpdateProjectile aktualisiert die Basisdaten eines Projektils samt Widerstandsmodell
+func (a *App) ProjectileUpdateProjectile(projectileID string, name string, weightGrams float64, drag application.DragDTO) application.Result[application.ProjectileDTO] {
+	if a.projectileService == nil {
+		return application.FailWithMessage[application.ProjectileDTO]("Services not initialized - setup not completed")
+	}
+	return a.projectileService.UpdateProjectileWithDrag(projectileID, name, weightGrams, drag)
+}
+
+// ProjectileSetCaliber setzt das Kaliber eines Projektils in mm (null = unbekannt)
+func (a *App) ProjectileSetCaliber(projectileID string, caliberMM *float64) application.Result[application.ProjectileDTO] {
+	if a.projectileService == nil {
+		return application.FailWithMessage[application.ProjectileDTO]("Services not initialized - setup not completed")
+	}
+	return a.projectileService.SetCaliber(projectileID, caliberMM)
+}
+
+// ProjectileApplyBCEstimate übernimmt eine BC-Bestimmung als neuen BC des Projektils
+func (a *App) ProjectileApplyBCEstimate(estimate application.BCEstimateDTO) application.Result[application.ProjectileDTO] {
+	if a.projectileService == nil {
+		return application.FailWithMessage[application.ProjectileDTO]("Services not initialized - setup not completed")
+	}
+	return a.projectileService.ApplyBCEstimate(estimate)
+}
+
+// ProjectileAddLot legt eine neue Charge für ein Projektil an
+func (a *App) ProjectileAddLot(projectileID string, lot application.ProjectileLotDTO) application.Result[application.ProjectileDTO] {
+	if a.projectileService == nil {
+		return application.FailWithMessage[application.ProjectileDTO]("Services not initialized - setup not completed")
+	}
+	return a.projectileService.AddLot(projectileID, lot)
+}
+
+// ProjectileUpdateLot ändert eine Charge eines Projektils
+func (a *App) ProjectileUpdateLot(projectileID string, lot application.ProjectileLotDTO) application.Result[application.ProjectileDTO] {
 	if a.projectileService == nil {
 		return application.FailWithMessage[application.ProjectileDTO]("Services not initialized - setup not completed")
 	}
-	return a.projectileService.UpdateProjectile(projectileID, name, weightGrams, bc)
+	return a.projectileService.UpdateLot(projectileID, lot)
+}
+
+// ProjectileDeleteLot entfernt eine Charge eines Projektils
+func (a *App) ProjectileDeleteLot(projectileID string, lotID string) application.Result[application.ProjectileDTO] {
+	if a.projectileService == nil {
+		return application.FailWithMessage[application.ProjectileDTO]("Services not initialized - setup not completed")
+	}
+	return a.projectileService.DeleteLot(projectileID, lotID)
 }
 
 // ==================== SESSION SERVICE DELEGATION ====================
 
-// SessionCreateSession erstellt eine neue Session
-func (a *App) SessionCreateSession(profileID string, projectileID string, temperatureCelsius *float64, note string) application.Result[application.SessionDTO] {
+// SessionCreateSession erstellt eine neue Session (lotID "" = ohne Charge)
+func (a *App) SessionCreateSession(profileID string, projectileID string, lotID string, conditions application.ConditionsDTO, note string) application.Result[application.SessionDTO] {
+	if a.sessionService == nil {
+		return application.FailWithMessage[application.SessionDTO]("Services not initialized - setup not completed")
+	}
+	return a.sessionService.CreateSessionWithLot(profileID, projectileID, lotID, conditions, note)
+}
+
+// SessionCompareLots vergleicht die Statistiken aller Chargen eines Projektils
+func (a *App) SessionCompareLots(projectileID string) application.Result[[]application.LotStatisticsDTO] {
+	if a.sessionService == nil {
+		return application.FailWithMessage[[]application.LotStatisticsDTO]("Services not initialized - setup not completed")
+	}
+	return a.sessionService.CompareLots(projectileID)
+}
+
+// SessionCompareSessions vergleicht Sessions mit der ersten (Referenz):
+// Statistiken nebeneinander, Welch-t-Test, F-/Levene-Test und Effektstärke
+func (a *App) SessionCompareSessions(ids []string) application.Result[application.SessionComparisonDTO] {
+	if a.sessionService == nil {
+		return application.FailWithMessage[application.SessionComparisonDTO]("Services not initialized - setup not completed")
+	}
+	return a.sessionService.CompareSessions(ids...)
+}
+
+// SessionStartString beginnt eine neue Schussserie (z.B. nach dem Füllen),
+// auch während der Live-Aufnahme; fill ist der Fülldruck (nil = nicht erfasst)
+func (a *App) SessionStartString(sessionID string, name string, fill *application.FillDTO) application.Result[application.SessionDTO] {
+	if a.sessionService == nil {
+		return application.FailWithMessage[application.SessionDTO]("Services not initialized - setup not completed")
+	}
+	return a.sessionService.StartString(sessionID, name, fill)
+}
+
+// SessionSetStringFill setzt Start- und Enddruck einer Schussserie (nil entfernt sie)
+func (a *App) SessionSetStringFill(sessionID string, number int, fill *application.FillDTO) application.Result[application.SessionDTO] {
+	if a.sessionService == nil {
+		return application.FailWithMessage[application.SessionDTO]("Services not initialized - setup not completed")
+	}
+	return a.sessionService.SetStringFill(sessionID, number, fill)
+}
+
+// SessionRenameString benennt eine Schussserie um (number 1-basiert)
+func (a *App) SessionRenameString(sessionID string, number int, name string) application.Result[application.SessionDTO] {
 	if a.sessionService == nil {
 		return application.FailWithMessage[application.SessionDTO]("Services not initialized - setup not completed")
 	}
-	return a.sessionService.CreateSession(profileID, projectileID, temperatureCelsius, note)
+	return a.sessionService.RenameString(sessionID, number, name)
+}
+
+// SessionAnalyzeShotsPerFill berechnet die Geschwindigkeit über der
+// Schussnummer je Füllung und den nutzbaren Bereich (Sweet Spot)
+func (a *App) SessionAnalyzeShotsPerFill(sessionID string, request application.ShotsPerFillRequestDTO) application.Result[application.ShotsPerFillDTO] {
+	if a.sessionService == nil {
+		return application.FailWithMessage[application.ShotsPerFillDTO]("Services not initialized - setup not completed")
+	}
+	return a.sessionService.AnalyzeShotsPerFill(sessionID, request)
+}
+
+// SessionCalculateTrajectory berechnet die Flugbahntabelle einer Session
+func (a *App) SessionCalculateTrajectory(sessionID string, request application.TrajectoryRequestDTO) application.Result[application.TrajectoryDTO] {
+	if a.sessionService == nil {
+		return application.FailWithMessage[application.TrajectoryDTO]("Services not initialized - setup not completed")
+	}
+	return a.sessionService.CalculateTrajectory(sessionID, request)
+}
+
+// SessionSetDownrange verknüpft eine Session mit ihrer nahen Messung (nearSessionID "" = aufheben)
+func (a *App) SessionSetDownrange(sessionID string, nearSessionID string, distanceMeters float64) application.Result[application.SessionDTO] {
+	if a.sessionService == nil {
+		return application.FailWithMessage[application.SessionDTO]("Services not initialized - setup not completed")
+	}
+	return a.sessionService.SetDownrange(sessionID, nearSessionID, distanceMeters)
+}
+
+// SessionEstimateBC bestimmt den BC aus einer Session und ihrer nahen Messung
+func (a *App) SessionEstimateBC(sessionID string, request application.BCEstimateRequestDTO) application.Result[application.BCEstimateDTO] {
+	if a.sessionService == nil {
+		return application.FailWithMessage[application.BCEstimateDTO]("Services not initialized - setup not completed")
+	}
+	return a.sessionService.EstimateBC(sessionID, request)
 }
 
 // SessionRecordShot zeichnet einen neuen Schuss in einer Session auf
@@ -570,11 +910,30 @@ func (a *App) SessionListSessions() application.Result[[]application.SessionMeta
 	return a.sessionService.ListSessions()
 }
 
+// SessionQuerySessions filtert, sortiert und blättert die Session-Liste (über den Index)
+func (a *App) SessionQuerySessions(query application.SessionQueryDTO) application.Result[application.SessionPageDTO] {
+	if a.sessionService == nil {
+		return application.FailWithMessage[application.SessionPageDTO]("Services not initialized - setup not completed")
+	}
+	return a.sessionService.QuerySessions(query)
+}
+
+// SessionRebuildIndex baut den Session-Index neu auf
+func (a *App) SessionRebuildIndex() application.Result[int] {
+	if a.sessionService == nil {
+		return application.FailWithMessage[int]("Services not initialized - setup not completed")
+	}
+	return a.sessionService.RebuildSessionIndex()
+}
+
 // SessionDeleteSession löscht eine Session
 func (a *App) SessionDeleteSession(id string) application.Result[bool] {
 	if a.sessionService == nil {
 		return application.FailWithMessage[bool]("Services not initialized - setup not completed")
 	}
+	if status := a.SessionGetCaptureStatus(); status.Armed && status.SessionID == id {
+		a.SessionDisarmCapture()
+	}
 	return a.sessionService.DeleteSession(id)
 }
 
@@ -585,3 +944,92 @@ func (a *App) SessionUpdateNote(sessionID string, note string) application.Resul
 	}
 	return a.sessionService.UpdateNote(sessionID, note)
 }
+
+// SessionExportCSV exportiert Sessions als CSV (leere ids = alle Sessions).
+// Öffnet einen Speichern-Dialog und gibt den gewählten Pfad zurück.
+func (a *App) SessionExportCSV(ids []string, opts application.CSVOptionsDTO) application.Result[string] {
+	if a.sessionService == nil {
+		return application.FailWithMessage[string]("Services not initialized - setup not completed")
+	}
+
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Sessions als CSV exportieren",
+		DefaultFilename: "sessions.csv",
+		Filters:         []runtime.FileFilter{{DisplayName: "CSV (*.csv)", Pattern: "*.csv"}},
+	})
+	if err != nil {
+		return application.Fail[string](err)
+	}
+	if path == "" {
+		return application.FailWithMessage[string]("Export abgebrochen")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return application.Fail[string](err)
+	}
+	defer file.Close()
+
+	if err := a.sessionService.ExportCSV(file, ids, opts); err != nil {
+		return application.Fail[string](err)
+	}
+	// Erst Close meldet, ob die letzten Daten wirklich geschrieben wurden
+	if err := file.Close(); err != nil {
+		return application.Fail[string](err)
+	}
+
+	runtime.LogInfo(a.ctx, "Sessions exported to: "+path)
+	return application.OK(path)
+}
+
+// SessionImportCSV importiert Sessions aus einer CSV-Datei (Datei-Dialog).
+func (a *App) SessionImportCSV(opts application.CSVOptionsDTO) application.Result[application.CSVImportResultDTO] {
+	if a.sessionService == nil {
+		return application.FailWithMessage[application.CSVImportResultDTO]("Services not initialized - setup not completed")
+	}
+
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title:   "Sessions aus CSV importieren",
+		Filters: []runtime.FileFilter{{DisplayName: "CSV (*.csv)", Pattern: "*.csv"}},
+	})
+	if err != nil {
+		return application.Fail[application.CSVImportResultDTO](err)
+	}
+	if path == "" {
+		return application.FailWithMessage[application.CSVImportResultDTO]("Import abgebrochen")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return application.Fail[application.CSVImportResultDTO](err)
+	}
+	defer file.Close()
+
+	return a.sessionService.ImportCSV(file, opts)
+}
+
+// ==================== ANALYTICS SERVICE DELEGATION ====================
+
+// AnalyticsPerformanceTrend wertet den Leistungsverlauf eines Profils über alle Sessions aus
+func (a *App) AnalyticsPerformanceTrend(profileID string, request application.TrendRequestDTO) application.Result[application.PerformanceTrendDTO] {
+	if a.analyticsService == nil {
+		return application.FailWithMessage[application.PerformanceTrendDTO]("Services not initialized - setup not completed")
+	}
+	return a.analyticsService.AnalyzePerformanceTrend(profileID, request)
+}
+
+// AnalyticsAnalyzeShotsPerFill legt die Füllungen aller Sessions eines Profils übereinander (Sweet Spot, Füllfenster)
+func (a *App) AnalyticsAnalyzeShotsPerFill(profileID string, request application.ShotsPerFillRequestDTO) application.Result[application.ShotsPerFillDTO] {
+	if a.analyticsService == nil {
+		return application.FailWithMessage[application.ShotsPerFillDTO]("Services not initialized - setup not completed")
+	}
+	return a.analyticsService.AnalyzeShotsPerFill(profileID, request)
+}
+
+// AnalyticsControlCharts berechnet X-quer/R- und I-MR-Regelkarte über die Schuss-Historie eines Profils
+func (a *App) AnalyticsControlCharts(profileID string, request application.ControlChartRequestDTO) application.Result[application.ControlChartsDTO] {
+	if a.controlChartService == nil {
+		return application.FailWithMessage[application.ControlChartsDTO]("Services not initialized - setup not completed")
+	}
+	return a.controlChartService.ControlCharts(profileID, request)
+}
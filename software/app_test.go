@@ -0,0 +1,263 @@
+//go:build linux
+
+package main
+
+import (
+	"math"
+	"metric-neo/internal/application"
+	"metric-neo/internal/infrastructure/chrono"
+	"metric-neo/internal/infrastructure/chrono/chronosim"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// appEvents sammelt die Events, die die App an das Frontend sendet.
+type appEvents struct {
+	mu       sync.Mutex
+	recorded []application.ShotRecordedDTO
+	errors   []string
+	states   []string
+}
+
+func (e *appEvents) emit(name string, data ...interface{}) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	switch name {
+	case application.ShotRecordedEvent:
+		e.recorded = append(e.recorded, data[0].(application.ShotRecordedDTO))
+	case application.CaptureErrorEvent:
+		e.errors = append(e.errors, data[0].(application.CaptureErrorDTO).Error)
+	case application.ChronoStateEvent:
+		state := data[0].(application.ChronoStateDTO).State
+		// Aufeinanderfolgende gleiche Zustände zusammenfassen
+		if len(e.states) == 0 || e.states[len(e.states)-1] != state {
+			e.states = append(e.states, state)
+		}
+	}
+}
+
+// waitRecorded wartet auf n "shot:recorded" Events.
+func (e *appEvents) waitRecorded(t *testing.T, n int) []application.ShotRecordedDTO {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		e.mu.Lock()
+		recorded, errs := append([]application.ShotRecordedDTO(nil), e.recorded...), e.errors
+		e.mu.Unlock()
+
+		if len(recorded) >= n {
+			return recorded
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout: %d of %d shots recorded (errors: %v)", len(recorded), n, errs)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// newTestApp erstellt eine App mit temporärem Config- und Datenverzeichnis.
+// Der Wails-Context wird nicht benötigt: Events landen in appEvents.
+func newTestApp(t *testing.T, chronoCfg application.ChronoConfigDTO) (*App, *appEvents) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	configService, err := application.NewConfigService()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataDir := t.TempDir()
+	if err := configService.CompleteSetup(dataDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := configService.UpdateChronoConfig(chronoCfg); err != nil {
+		t.Fatal(err)
+	}
+
+	events := &appEvents{}
+	app := NewApp()
+	app.emit = events.emit
+	app.configService = configService
+	app.profileService = application.NewProfileService(dataDir)
+	app.projectileService = application.NewProjectileService(dataDir)
+	app.sessionService = application.NewSessionService(dataDir)
+	app.sightService = application.NewSightService(dataDir)
+	app.captureService = application.NewCaptureService(app.sessionService, app.emitEvent)
+	t.Cleanup(func() { app.SessionDisarmCapture() })
+	return app, events
+}
+
+func createTestSession(t *testing.T, app *App) string {
+	t.Helper()
+	profile := app.ProfileCreateProfile("Steyr", "air_rifle", 420, 500, 50)
+	projectile := app.ProjectileCreateProjectile("JSB Exact", 0.547, application.DragDTO{BC: 0.024})
+	session := app.SessionCreateSession(profile.Data.ID, projectile.Data.ID, "", application.ConditionsDTO{}, "")
+	if !session.Success {
+		t.Fatalf("SessionCreateSession failed: %s", session.Error)
+	}
+	return session.Data.ID
+}
+
+// waitChronoState wartet, bis der Supervisor den Zustand erreicht hat.
+func waitChronoState(t *testing.T, app *App, want chrono.ConnectionState) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for app.GetChronoState().State != string(want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout waiting for chrono state %s, got %+v", want, app.GetChronoState())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestApp_SessionArmCapture_EndToEnd(t *testing.T) {
+	sim, err := chronosim.New(chronosim.Options{Encoding: chronosim.EncodeFPS})
+	if err != nil {
+		t.Skipf("pseudo terminal not available: %v", err)
+	}
+	defer sim.Close()
+
+	app, events := newTestApp(t, application.ChronoConfigDTO{
+		Enabled:    true,
+		Port:       sim.Path(),
+		AutoRecord: true,
+		Driver:     "generic-fps",
+		WireLog:    true,
+	})
+	sessionID := createTestSession(t, app)
+
+	if result := app.SessionArmCapture(sessionID); !result.Success || !result.Data.Armed {
+		t.Fatalf("SessionArmCapture: %+v", result)
+	}
+	if status := app.SessionGetCaptureStatus(); status.SessionID != sessionID {
+		t.Errorf("capture status = %+v, want session %s", status, sessionID)
+	}
+	waitChronoState(t, app, chrono.StateConnected)
+
+	// Schnelle Serie ohne Pausen: kein Schuss darf verloren gehen
+	shots, want := chronosim.NormalShots(7, 175, 2, 20)
+	if err := sim.Play(append([]chronosim.Event{chronosim.Garbage("READY")}, shots...)...); err != nil {
+		t.Fatal(err)
+	}
+
+	recorded := events.waitRecorded(t, len(want))
+	for i, event := range recorded {
+		if math.Abs(event.VelocityMPS-want[i]) > 0.05 {
+			t.Errorf("shot %d = %.2f m/s, want %.2f", i, event.VelocityMPS, want[i])
+		}
+	}
+	last := recorded[len(recorded)-1]
+	if len(last.Session.Shots) != len(want) || last.Statistics.TotalShotCount != len(want) {
+		t.Errorf("last event: %d shots, statistics %d, want %d", len(last.Session.Shots), last.Statistics.TotalShotCount, len(want))
+	}
+	events.mu.Lock()
+	if len(events.errors) != 1 {
+		t.Errorf("capture errors = %v, want 1 for the garbage line", events.errors)
+	}
+	events.mu.Unlock()
+
+	if result := app.SessionDisarmCapture(); !result.Success || result.Data.Armed {
+		t.Errorf("SessionDisarmCapture: %+v", result)
+	}
+	if state := app.GetChronoState().State; state != string(chrono.StateDisconnected) {
+		t.Errorf("chrono state after disarm = %s, want disconnected", state)
+	}
+
+	// Gespeichert, nicht nur im Event
+	stored := app.SessionLoadSession(sessionID)
+	if len(stored.Data.Shots) != len(want) {
+		t.Errorf("stored shots = %d, want %d", len(stored.Data.Shots), len(want))
+	}
+
+	// Rohdaten liegen neben der Session und passen zum Parser
+	file, err := os.Open(app.sessionService.WireLogPath(sessionID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	wire, err := chrono.NewWireLogReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	driver, _ := chrono.LookupDriver("generic-fps")
+	checked, mismatches, err := chrono.VerifyWireLog(wire, driver)
+	if err != nil || checked != len(want)+1 || len(mismatches) != 0 {
+		t.Errorf("wire log: checked %d frames (want %d), mismatches %v, err %v", checked, len(want)+1, mismatches, err)
+	}
+
+	app.SessionDeleteSession(sessionID)
+	if _, err := os.Stat(app.sessionService.WireLogPath(sessionID)); !os.IsNotExist(err) {
+		t.Errorf("deleting the session must delete its wire log: %v", err)
+	}
+}
+
+func TestApp_SessionArmCapture_Disabled(t *testing.T) {
+	app, _ := newTestApp(t, application.ChronoConfigDTO{Enabled: false, Port: "/dev/null"})
+	sessionID := createTestSession(t, app)
+
+	if result := app.SessionArmCapture(sessionID); result.Success {
+		t.Errorf("disabled chrono must not arm: %+v", result)
+	}
+	if app.chronoSupervisor != nil {
+		t.Error("disabled chrono must not open the port")
+	}
+}
+
+func TestApp_DeleteArmedSession(t *testing.T) {
+	app, _ := newTestApp(t, application.ChronoConfigDTO{Enabled: true, Port: "/dev/does-not-exist"})
+	sessionID := createTestSession(t, app)
+
+	if result := app.SessionArmCapture(sessionID); !result.Success {
+		t.Fatalf("SessionArmCapture: %+v", result)
+	}
+	app.SessionDeleteSession(sessionID)
+	if status := app.SessionGetCaptureStatus(); status.Armed {
+		t.Errorf("deleting the armed session must disarm: %+v", status)
+	}
+}
+
+func TestApp_ChronoReconnect_EmitsStateEvents(t *testing.T) {
+	sim, err := chronosim.New(chronosim.Options{Link: filepath.Join(t.TempDir(), "usb-chrono")})
+	if err != nil {
+		t.Skipf("pseudo terminal not available: %v", err)
+	}
+	defer sim.Close()
+
+	app, events := newTestApp(t, application.ChronoConfigDTO{Enabled: true, Port: sim.Path(), AutoRecord: true})
+	sessionID := createTestSession(t, app)
+
+	app.SessionArmCapture(sessionID)
+	waitChronoState(t, app, chrono.StateConnected)
+
+	// Wackelkontakt: Gerät kurz weg, Supervisor verbindet neu
+	if err := sim.Play(chronosim.Replug(300 * time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	waitChronoState(t, app, chrono.StateDisconnected)
+	waitChronoState(t, app, chrono.StateConnected)
+
+	if err := sim.Play(chronosim.Shot(175.5)); err != nil {
+		t.Fatal(err)
+	}
+	recorded := events.waitRecorded(t, 1)
+	if math.Abs(recorded[0].VelocityMPS-175.5) > 0.01 {
+		t.Errorf("shot after reconnect = %.2f, want 175.50", recorded[0].VelocityMPS)
+	}
+
+	events.mu.Lock()
+	defer events.mu.Unlock()
+	if len(events.errors) != 1 {
+		t.Errorf("capture errors = %v, want exactly the disconnect", events.errors)
+	}
+	// connecting → connected → disconnected → (connecting ↔ disconnected)* → connected
+	states := events.states
+	if len(states) < 5 || states[0] != "connecting" || states[1] != "connected" ||
+		states[2] != "disconnected" || states[len(states)-1] != "connected" {
+		t.Errorf("state events = %v", states)
+	}
+}
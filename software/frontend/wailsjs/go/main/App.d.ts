@@ -2,18 +2,44 @@
 // This file is automatically generated. DO NOT EDIT
 import {application} from '../models';
 
+export function AnalyticsAnalyzeShotsPerFill(arg1:string,arg2:application.ShotsPerFillRequestDTO):Promise<application.Result_metric_neo_internal_application_ShotsPerFillDTO_>;
+
+export function AnalyticsControlCharts(arg1:string,arg2:application.ControlChartRequestDTO):Promise<application.Result_metric_neo_internal_application_ControlChartsDTO_>;
+
+export function AnalyticsPerformanceTrend(arg1:string,arg2:application.TrendRequestDTO):Promise<application.Result_metric_neo_internal_application_PerformanceTrendDTO_>;
+
 export function ChangeDataDirectory():Promise<application.Result_string_>;
 
+export function ChronoDiscoverPorts(arg1:boolean,arg2:string,arg3:number):Promise<application.Result___metric_neo_internal_application_ChronoPortDTO_>;
+
 export function CompleteSetup(arg1:string):Promise<application.Result_bool_>;
 
+export function ConvertStorage(arg1:string):Promise<application.Result_metric_neo_internal_application_StorageConversionDTO_>;
+
 export function GetChronoConfig():Promise<application.Result_metric_neo_internal_application_ChronoConfigDTO_>;
 
+export function GetChronoDrivers():Promise<Array<application.ChronoDriverDTO>>;
+
+export function GetChronoState():Promise<application.ChronoStateDTO>;
+
 export function GetCurrentDataDir():Promise<string>;
 
+export function GetEnergyJurisdictions():Promise<Array<application.EnergyJurisdictionDTO>>;
+
+export function GetEnergyLimitsConfig():Promise<application.Result_metric_neo_internal_application_EnergyLimitsConfigDTO_>;
+
+export function GetStartupError():Promise<string>;
+
+export function GetStorageInfo():Promise<application.Result_metric_neo_internal_application_StorageInfoDTO_>;
+
+export function GetStorageRecovery():Promise<application.StorageRecoveryDTO>;
+
 export function GetSuggestedDataDir():Promise<string>;
 
 export function GetSystemTheme():Promise<string>;
 
+export function GetUnits():Promise<application.Result_metric_neo_internal_application_UnitsDTO_>;
+
 export function Greet(arg1:string):Promise<string>;
 
 export function NeedsSetup():Promise<boolean>;
@@ -34,11 +60,19 @@ export function ProfileRemoveTwistRate(arg1:string):Promise<application.Result_m
 
 export function ProfileSetOptic(arg1:string,arg2:string,arg3:string,arg4:number,arg5:number,arg6:number):Promise<application.Result_metric_neo_internal_application_ProfileDTO_>;
 
+export function ProfileSetPowerPlant(arg1:string,arg2:string,arg3:any):Promise<application.Result_metric_neo_internal_application_ProfileDTO_>;
+
 export function ProfileSetTwistRate(arg1:string,arg2:number):Promise<application.Result_metric_neo_internal_application_ProfileDTO_>;
 
 export function ProfileUpdateProfile(arg1:string,arg2:string,arg3:string,arg4:number,arg5:number,arg6:number):Promise<application.Result_metric_neo_internal_application_ProfileDTO_>;
 
-export function ProjectileCreateProjectile(arg1:string,arg2:number,arg3:number):Promise<application.Result_metric_neo_internal_application_ProjectileDTO_>;
+export function ProjectileAddLot(arg1:string,arg2:application.ProjectileLotDTO):Promise<application.Result_metric_neo_internal_application_ProjectileDTO_>;
+
+export function ProjectileApplyBCEstimate(arg1:application.BCEstimateDTO):Promise<application.Result_metric_neo_internal_application_ProjectileDTO_>;
+
+export function ProjectileCreateProjectile(arg1:string,arg2:number,arg3:application.DragDTO):Promise<application.Result_metric_neo_internal_application_ProjectileDTO_>;
+
+export function ProjectileDeleteLot(arg1:string,arg2:string):Promise<application.Result_metric_neo_internal_application_ProjectileDTO_>;
 
 export function ProjectileDeleteProjectile(arg1:string):Promise<application.Result_bool_>;
 
@@ -46,28 +80,62 @@ export function ProjectileListProjectiles():Promise<application.Result___metric_
 
 export function ProjectileLoadProjectile(arg1:string):Promise<application.Result_metric_neo_internal_application_ProjectileDTO_>;
 
+export function ProjectileSetCaliber(arg1:string,arg2:any):Promise<application.Result_metric_neo_internal_application_ProjectileDTO_>;
+
 export function ProjectileUpdateBC(arg1:string,arg2:number):Promise<application.Result_metric_neo_internal_application_ProjectileDTO_>;
 
-export function ProjectileUpdateProjectile(arg1:string,arg2:string,arg3:number,arg4:number):Promise<application.Result_metric_neo_internal_application_ProjectileDTO_>;
+export function ProjectileUpdateLot(arg1:string,arg2:application.ProjectileLotDTO):Promise<application.Result_metric_neo_internal_application_ProjectileDTO_>;
+
+export function ProjectileUpdateProjectile(arg1:string,arg2:string,arg3:number,arg4:application.DragDTO):Promise<application.Result_metric_neo_internal_application_ProjectileDTO_>;
 
 export function SelectDataDirectory():Promise<application.Result_string_>;
 
-export function SessionCreateSession(arg1:string,arg2:string,arg3:any,arg4:string):Promise<application.Result_metric_neo_internal_application_SessionDTO_>;
+export function SessionAnalyzeShotsPerFill(arg1:string,arg2:application.ShotsPerFillRequestDTO):Promise<application.Result_metric_neo_internal_application_ShotsPerFillDTO_>;
+
+export function SessionArmCapture(arg1:string):Promise<application.Result_metric_neo_internal_application_CaptureStatusDTO_>;
+
+export function SessionCalculateTrajectory(arg1:string,arg2:application.TrajectoryRequestDTO):Promise<application.Result_metric_neo_internal_application_TrajectoryDTO_>;
+
+export function SessionCompareLots(arg1:string):Promise<application.Result___metric_neo_internal_application_LotStatisticsDTO_>;
+
+export function SessionCompareSessions(arg1:Array<string>):Promise<application.Result_metric_neo_internal_application_SessionComparisonDTO_>;
+
+export function SessionCreateSession(arg1:string,arg2:string,arg3:string,arg4:application.ConditionsDTO,arg5:string):Promise<application.Result_metric_neo_internal_application_SessionDTO_>;
 
 export function SessionDeleteSession(arg1:string):Promise<application.Result_bool_>;
 
+export function SessionDisarmCapture():Promise<application.Result_metric_neo_internal_application_CaptureStatusDTO_>;
+
+export function SessionEstimateBC(arg1:string,arg2:application.BCEstimateRequestDTO):Promise<application.Result_metric_neo_internal_application_BCEstimateDTO_>;
+
+export function SessionExportCSV(arg1:Array<string>,arg2:application.CSVOptionsDTO):Promise<application.Result_string_>;
+
+export function SessionGetCaptureStatus():Promise<application.CaptureStatusDTO>;
+
 export function SessionGetStatistics(arg1:string):Promise<application.Result_metric_neo_internal_application_StatisticsDTO_>;
 
+export function SessionImportCSV(arg1:application.CSVOptionsDTO):Promise<application.Result_metric_neo_internal_application_CSVImportResultDTO_>;
+
 export function SessionListSessions():Promise<application.Result___metric_neo_internal_application_SessionMetaDTO_>;
 
 export function SessionLoadSession(arg1:string):Promise<application.Result_metric_neo_internal_application_SessionDTO_>;
 
 export function SessionMarkShotInvalid(arg1:string,arg2:number):Promise<application.Result_metric_neo_internal_application_SessionDTO_>;
 
-export function SessionPollChrono(arg1:string):Promise<application.Result_metric_neo_internal_application_ChronoPollResultDTO_>;
+export function SessionQuerySessions(arg1:application.SessionQueryDTO):Promise<application.Result_metric_neo_internal_application_SessionPageDTO_>;
+
+export function SessionRebuildIndex():Promise<application.Result_int_>;
 
 export function SessionRecordShot(arg1:string,arg2:number):Promise<application.Result_metric_neo_internal_application_SessionDTO_>;
 
+export function SessionRenameString(arg1:string,arg2:number,arg3:string):Promise<application.Result_metric_neo_internal_application_SessionDTO_>;
+
+export function SessionSetDownrange(arg1:string,arg2:string,arg3:number):Promise<application.Result_metric_neo_internal_application_SessionDTO_>;
+
+export function SessionSetStringFill(arg1:string,arg2:number,arg3:application.FillDTO):Promise<application.Result_metric_neo_internal_application_SessionDTO_>;
+
+export function SessionStartString(arg1:string,arg2:string,arg3:application.FillDTO):Promise<application.Result_metric_neo_internal_application_SessionDTO_>;
+
 export function SessionUpdateNote(arg1:string,arg2:string):Promise<application.Result_metric_neo_internal_application_SessionDTO_>;
 
 export function SightCreateSight(arg1:string,arg2:string,arg3:number,arg4:number,arg5:number):Promise<application.Result_metric_neo_internal_application_SightDTO_>;
@@ -80,4 +148,8 @@ export function SightLoadSight(arg1:string):Promise<application.Result_metric_ne
 
 export function SightUpdateSight(arg1:string,arg2:string,arg3:string,arg4:number,arg5:number,arg6:number):Promise<application.Result_metric_neo_internal_application_SightDTO_>;
 
-export function UpdateChronoConfig(arg1:boolean,arg2:string,arg3:number,arg4:boolean):Promise<application.Result_metric_neo_internal_application_ChronoConfigDTO_>;
+export function UpdateChronoConfig(arg1:application.ChronoConfigDTO):Promise<application.Result_metric_neo_internal_application_ChronoConfigDTO_>;
+
+export function UpdateEnergyLimitsConfig(arg1:application.EnergyLimitsConfigDTO):Promise<application.Result_metric_neo_internal_application_EnergyLimitsConfigDTO_>;
+
+export function UpdateUnitSystem(arg1:string):Promise<application.Result_metric_neo_internal_application_UnitsDTO_>;
@@ -2,22 +2,70 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AnalyticsAnalyzeShotsPerFill(arg1, arg2) {
+  return window['go']['main']['App']['AnalyticsAnalyzeShotsPerFill'](arg1, arg2);
+}
+
+export function AnalyticsControlCharts(arg1, arg2) {
+  return window['go']['main']['App']['AnalyticsControlCharts'](arg1, arg2);
+}
+
+export function AnalyticsPerformanceTrend(arg1, arg2) {
+  return window['go']['main']['App']['AnalyticsPerformanceTrend'](arg1, arg2);
+}
+
 export function ChangeDataDirectory() {
   return window['go']['main']['App']['ChangeDataDirectory']();
 }
 
+export function ChronoDiscoverPorts(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ChronoDiscoverPorts'](arg1, arg2, arg3);
+}
+
 export function CompleteSetup(arg1) {
   return window['go']['main']['App']['CompleteSetup'](arg1);
 }
 
+export function ConvertStorage(arg1) {
+  return window['go']['main']['App']['ConvertStorage'](arg1);
+}
+
 export function GetChronoConfig() {
   return window['go']['main']['App']['GetChronoConfig']();
 }
 
+export function GetChronoDrivers() {
+  return window['go']['main']['App']['GetChronoDrivers']();
+}
+
+export function GetChronoState() {
+  return window['go']['main']['App']['GetChronoState']();
+}
+
 export function GetCurrentDataDir() {
   return window['go']['main']['App']['GetCurrentDataDir']();
 }
 
+export function GetEnergyJurisdictions() {
+  return window['go']['main']['App']['GetEnergyJurisdictions']();
+}
+
+export function GetEnergyLimitsConfig() {
+  return window['go']['main']['App']['GetEnergyLimitsConfig']();
+}
+
+export function GetStartupError() {
+  return window['go']['main']['App']['GetStartupError']();
+}
+
+export function GetStorageInfo() {
+  return window['go']['main']['App']['GetStorageInfo']();
+}
+
+export function GetStorageRecovery() {
+  return window['go']['main']['App']['GetStorageRecovery']();
+}
+
 export function GetSuggestedDataDir() {
   return window['go']['main']['App']['GetSuggestedDataDir']();
 }
@@ -26,6 +74,10 @@ export function GetSystemTheme() {
   return window['go']['main']['App']['GetSystemTheme']();
 }
 
+export function GetUnits() {
+  return window['go']['main']['App']['GetUnits']();
+}
+
 export function Greet(arg1) {
   return window['go']['main']['App']['Greet'](arg1);
 }
@@ -66,6 +118,10 @@ export function ProfileSetOptic(arg1, arg2, arg3, arg4, arg5, arg6) {
   return window['go']['main']['App']['ProfileSetOptic'](arg1, arg2, arg3, arg4, arg5, arg6);
 }
 
+export function ProfileSetPowerPlant(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ProfileSetPowerPlant'](arg1, arg2, arg3);
+}
+
 export function ProfileSetTwistRate(arg1, arg2) {
   return window['go']['main']['App']['ProfileSetTwistRate'](arg1, arg2);
 }
@@ -74,10 +130,22 @@ export function ProfileUpdateProfile(arg1, arg2, arg3, arg4, arg5, arg6) {
   return window['go']['main']['App']['ProfileUpdateProfile'](arg1, arg2, arg3, arg4, arg5, arg6);
 }
 
+export function ProjectileAddLot(arg1, arg2) {
+  return window['go']['main']['App']['ProjectileAddLot'](arg1, arg2);
+}
+
+export function ProjectileApplyBCEstimate(arg1) {
+  return window['go']['main']['App']['ProjectileApplyBCEstimate'](arg1);
+}
+
 export function ProjectileCreateProjectile(arg1, arg2, arg3) {
   return window['go']['main']['App']['ProjectileCreateProjectile'](arg1, arg2, arg3);
 }
 
+export function ProjectileDeleteLot(arg1, arg2) {
+  return window['go']['main']['App']['ProjectileDeleteLot'](arg1, arg2);
+}
+
 export function ProjectileDeleteProjectile(arg1) {
   return window['go']['main']['App']['ProjectileDeleteProjectile'](arg1);
 }
@@ -90,10 +158,18 @@ export function ProjectileLoadProjectile(arg1) {
   return window['go']['main']['App']['ProjectileLoadProjectile'](arg1);
 }
 
+export function ProjectileSetCaliber(arg1, arg2) {
+  return window['go']['main']['App']['ProjectileSetCaliber'](arg1, arg2);
+}
+
 export function ProjectileUpdateBC(arg1, arg2) {
   return window['go']['main']['App']['ProjectileUpdateBC'](arg1, arg2);
 }
 
+export function ProjectileUpdateLot(arg1, arg2) {
+  return window['go']['main']['App']['ProjectileUpdateLot'](arg1, arg2);
+}
+
 export function ProjectileUpdateProjectile(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['ProjectileUpdateProjectile'](arg1, arg2, arg3, arg4);
 }
@@ -102,18 +178,58 @@ export function SelectDataDirectory() {
   return window['go']['main']['App']['SelectDataDirectory']();
 }
 
-export function SessionCreateSession(arg1, arg2, arg3, arg4) {
-  return window['go']['main']['App']['SessionCreateSession'](arg1, arg2, arg3, arg4);
+export function SessionAnalyzeShotsPerFill(arg1, arg2) {
+  return window['go']['main']['App']['SessionAnalyzeShotsPerFill'](arg1, arg2);
+}
+
+export function SessionArmCapture(arg1) {
+  return window['go']['main']['App']['SessionArmCapture'](arg1);
+}
+
+export function SessionCalculateTrajectory(arg1, arg2) {
+  return window['go']['main']['App']['SessionCalculateTrajectory'](arg1, arg2);
+}
+
+export function SessionCompareLots(arg1) {
+  return window['go']['main']['App']['SessionCompareLots'](arg1);
+}
+
+export function SessionCompareSessions(arg1) {
+  return window['go']['main']['App']['SessionCompareSessions'](arg1);
+}
+
+export function SessionCreateSession(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['SessionCreateSession'](arg1, arg2, arg3, arg4, arg5);
 }
 
 export function SessionDeleteSession(arg1) {
   return window['go']['main']['App']['SessionDeleteSession'](arg1);
 }
 
+export function SessionDisarmCapture() {
+  return window['go']['main']['App']['SessionDisarmCapture']();
+}
+
+export function SessionEstimateBC(arg1, arg2) {
+  return window['go']['main']['App']['SessionEstimateBC'](arg1, arg2);
+}
+
+export function SessionExportCSV(arg1, arg2) {
+  return window['go']['main']['App']['SessionExportCSV'](arg1, arg2);
+}
+
+export function SessionGetCaptureStatus() {
+  return window['go']['main']['App']['SessionGetCaptureStatus']();
+}
+
 export function SessionGetStatistics(arg1) {
   return window['go']['main']['App']['SessionGetStatistics'](arg1);
 }
 
+export function SessionImportCSV(arg1) {
+  return window['go']['main']['App']['SessionImportCSV'](arg1);
+}
+
 export function SessionListSessions() {
   return window['go']['main']['App']['SessionListSessions']();
 }
@@ -126,14 +242,34 @@ export function SessionMarkShotInvalid(arg1, arg2) {
   return window['go']['main']['App']['SessionMarkShotInvalid'](arg1, arg2);
 }
 
-export function SessionPollChrono(arg1) {
-  return window['go']['main']['App']['SessionPollChrono'](arg1);
+export function SessionQuerySessions(arg1) {
+  return window['go']['main']['App']['SessionQuerySessions'](arg1);
+}
+
+export function SessionRebuildIndex() {
+  return window['go']['main']['App']['SessionRebuildIndex']();
 }
 
 export function SessionRecordShot(arg1, arg2) {
   return window['go']['main']['App']['SessionRecordShot'](arg1, arg2);
 }
 
+export function SessionRenameString(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SessionRenameString'](arg1, arg2, arg3);
+}
+
+export function SessionSetDownrange(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SessionSetDownrange'](arg1, arg2, arg3);
+}
+
+export function SessionSetStringFill(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SessionSetStringFill'](arg1, arg2, arg3);
+}
+
+export function SessionStartString(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SessionStartString'](arg1, arg2, arg3);
+}
+
 export function SessionUpdateNote(arg1, arg2) {
   return window['go']['main']['App']['SessionUpdateNote'](arg1, arg2);
 }
@@ -158,6 +294,14 @@ export function SightUpdateSight(arg1, arg2, arg3, arg4, arg5, arg6) {
   return window['go']['main']['App']['SightUpdateSight'](arg1, arg2, arg3, arg4, arg5, arg6);
 }
 
-export function UpdateChronoConfig(arg1, arg2, arg3, arg4) {
-  return window['go']['main']['App']['UpdateChronoConfig'](arg1, arg2, arg3, arg4);
+export function UpdateChronoConfig(arg1) {
+  return window['go']['main']['App']['UpdateChronoConfig'](arg1);
+}
+
+export function UpdateEnergyLimitsConfig(arg1) {
+  return window['go']['main']['App']['UpdateEnergyLimitsConfig'](arg1);
+}
+
+export function UpdateUnitSystem(arg1) {
+  return window['go']['main']['App']['UpdateUnitSystem'](arg1);
 }
@@ -1,10 +1,169 @@
 export namespace application {
 	
+	export class BCEstimateDTO {
+	    nearSessionId: string;
+	    farSessionId: string;
+	    projectileId: string;
+	    projectileName: string;
+	    distanceMeters: number;
+	    dragModel: string;
+	    bcUnit: string;
+	    bc: number;
+	    bcLower?: number;
+	    bcUpper?: number;
+	    currentBC: number;
+	    paired: boolean;
+	    pairCount: number;
+	    nearShotCount: number;
+	    farShotCount: number;
+	    nearVelocityMPS: number;
+	    farVelocityMPS: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BCEstimateDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.nearSessionId = source["nearSessionId"];
+	        this.farSessionId = source["farSessionId"];
+	        this.projectileId = source["projectileId"];
+	        this.projectileName = source["projectileName"];
+	        this.distanceMeters = source["distanceMeters"];
+	        this.dragModel = source["dragModel"];
+	        this.bcUnit = source["bcUnit"];
+	        this.bc = source["bc"];
+	        this.bcLower = source["bcLower"];
+	        this.bcUpper = source["bcUpper"];
+	        this.currentBC = source["currentBC"];
+	        this.paired = source["paired"];
+	        this.pairCount = source["pairCount"];
+	        this.nearShotCount = source["nearShotCount"];
+	        this.farShotCount = source["farShotCount"];
+	        this.nearVelocityMPS = source["nearVelocityMPS"];
+	        this.farVelocityMPS = source["farVelocityMPS"];
+	    }
+	}
+	export class BCEstimateRequestDTO {
+	    dragModel: string;
+	    paired: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new BCEstimateRequestDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dragModel = source["dragModel"];
+	        this.paired = source["paired"];
+	    }
+	}
+	export class SessionMetaDTO {
+	    id: string;
+	    profileId: string;
+	    profileName: string;
+	    projectileId: string;
+	    projectileName: string;
+	    lotId?: string;
+	    lotNumber?: string;
+	    shotCount: number;
+	    validShotCount: number;
+	    createdAt: string;
+	    note: string;
+	    avgVelocityMPS?: number;
+	    avgEnergyJoules?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionMetaDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.profileId = source["profileId"];
+	        this.profileName = source["profileName"];
+	        this.projectileId = source["projectileId"];
+	        this.projectileName = source["projectileName"];
+	        this.lotId = source["lotId"];
+	        this.lotNumber = source["lotNumber"];
+	        this.shotCount = source["shotCount"];
+	        this.validShotCount = source["validShotCount"];
+	        this.createdAt = source["createdAt"];
+	        this.note = source["note"];
+	        this.avgVelocityMPS = source["avgVelocityMPS"];
+	        this.avgEnergyJoules = source["avgEnergyJoules"];
+	    }
+	}
+	export class CSVImportResultDTO {
+	    sessions: SessionMetaDTO[];
+	    shotCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CSVImportResultDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessions = this.convertValues(source["sessions"], SessionMetaDTO);
+	        this.shotCount = source["shotCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CSVOptionsDTO {
+	    unitSystem: string;
+	    decimalSeparator: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CSVOptionsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.unitSystem = source["unitSystem"];
+	        this.decimalSeparator = source["decimalSeparator"];
+	    }
+	}
+	export class CaptureStatusDTO {
+	    armed: boolean;
+	    sessionId?: string;
+	    autoRecord: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CaptureStatusDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.armed = source["armed"];
+	        this.sessionId = source["sessionId"];
+	        this.autoRecord = source["autoRecord"];
+	    }
+	}
 	export class ChronoConfigDTO {
 	    enabled: boolean;
 	    port: string;
 	    baudRate: number;
 	    autoRecord: boolean;
+	    driver: string;
+	    serialNumber: string;
+	    wireLog: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new ChronoConfigDTO(source);
@@ -16,94 +175,1926 @@ export namespace application {
 	        this.port = source["port"];
 	        this.baudRate = source["baudRate"];
 	        this.autoRecord = source["autoRecord"];
+	        this.driver = source["driver"];
+	        this.serialNumber = source["serialNumber"];
+	        this.wireLog = source["wireLog"];
 	    }
 	}
-	export class ShotDTO {
-	    velocityMPS: number;
-	    energyJoules: number;
-	    timestamp: string;
-	    valid: boolean;
+	export class ChronoDriverDTO {
+	    name: string;
+	    description: string;
+	    unit: string;
+	    baudRate: number;
+	    mode: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ShotDTO(source);
+	        return new ChronoDriverDTO(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.velocityMPS = source["velocityMPS"];
-	        this.energyJoules = source["energyJoules"];
-	        this.timestamp = source["timestamp"];
-	        this.valid = source["valid"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.unit = source["unit"];
+	        this.baudRate = source["baudRate"];
+	        this.mode = source["mode"];
 	    }
 	}
-	export class ProjectileDTO {
-	    id: string;
+	export class ChronoPortDTO {
 	    name: string;
-	    weightGrams: number;
-	    bc: number;
+	    isUSB: boolean;
+	    vid: string;
+	    pid: string;
+	    serialNumber: string;
+	    product: string;
+	    status?: string;
+	    message?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ProjectileDTO(source);
+	        return new ChronoPortDTO(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
 	        this.name = source["name"];
-	        this.weightGrams = source["weightGrams"];
-	        this.bc = source["bc"];
+	        this.isUSB = source["isUSB"];
+	        this.vid = source["vid"];
+	        this.pid = source["pid"];
+	        this.serialNumber = source["serialNumber"];
+	        this.product = source["product"];
+	        this.status = source["status"];
+	        this.message = source["message"];
 	    }
 	}
-	export class OpticDTO {
-	    type: string;
-	    modelName: string;
-	    weightG: number;
-	    minMagnification: number;
-	    maxMagnification: number;
+	export class ChronoStateDTO {
+	    state: string;
+	    port: string;
+	    attempt: number;
+	    retryInMs: number;
+	    error?: string;
+	    timestamp: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new OpticDTO(source);
+	        return new ChronoStateDTO(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.type = source["type"];
-	        this.modelName = source["modelName"];
-	        this.weightG = source["weightG"];
-	        this.minMagnification = source["minMagnification"];
-	        this.maxMagnification = source["maxMagnification"];
+	        this.state = source["state"];
+	        this.port = source["port"];
+	        this.attempt = source["attempt"];
+	        this.retryInMs = source["retryInMs"];
+	        this.error = source["error"];
+	        this.timestamp = source["timestamp"];
 	    }
 	}
-	export class ProfileDTO {
-	    id: string;
+	export class FillDTO {
+	    startPressureBar: number;
+	    endPressureBar?: number;
+	    dropPerShotBar?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FillDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.startPressureBar = source["startPressureBar"];
+	        this.endPressureBar = source["endPressureBar"];
+	        this.dropPerShotBar = source["dropPerShotBar"];
+	    }
+	}
+	export class StringStatisticsDTO {
+	    sessionId?: string;
+	    number: number;
 	    name: string;
-	    category: string;
-	    barrelLengthMM: number;
-	    triggerWeightG: number;
-	    sightHeightMM: number;
-	    optic?: OpticDTO;
-	    opticID?: string;
-	    twistRateMM?: number;
-	    defaultAmmoID?: string;
-	    totalWeightG: number;
+	    firstShot: number;
+	    shotCount: number;
+	    validShotCount: number;
+	    avgVelocityMPS: number;
+	    sampleStandardDeviation: number;
+	    minVelocityMPS: number;
+	    maxVelocityMPS: number;
+	    extremeSpread: number;
+	    avgEnergyJoules: number;
+	    fill?: FillDTO;
 	
 	    static createFrom(source: any = {}) {
-	        return new ProfileDTO(source);
+	        return new StringStatisticsDTO(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
+	        this.sessionId = source["sessionId"];
+	        this.number = source["number"];
 	        this.name = source["name"];
+	        this.firstShot = source["firstShot"];
+	        this.shotCount = source["shotCount"];
+	        this.validShotCount = source["validShotCount"];
+	        this.avgVelocityMPS = source["avgVelocityMPS"];
+	        this.sampleStandardDeviation = source["sampleStandardDeviation"];
+	        this.minVelocityMPS = source["minVelocityMPS"];
+	        this.maxVelocityMPS = source["maxVelocityMPS"];
+	        this.extremeSpread = source["extremeSpread"];
+	        this.avgEnergyJoules = source["avgEnergyJoules"];
+	        this.fill = this.convertValues(source["fill"], FillDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class EnergyCheckDTO {
+	    label: string;
+	    category: string;
+	    limitJoules: number;
+	    warnJoules: number;
+	    verdict: string;
+	    validShotCount: number;
+	    exceededShots: number;
+	    nearShots: number;
+	    maxShotJoules: number;
+	    meanJoules: number;
+	    upperBoundJoules?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new EnergyCheckDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.label = source["label"];
 	        this.category = source["category"];
-	        this.barrelLengthMM = source["barrelLengthMM"];
-	        this.triggerWeightG = source["triggerWeightG"];
-	        this.sightHeightMM = source["sightHeightMM"];
-	        this.optic = this.convertValues(source["optic"], OpticDTO);
-	        this.opticID = source["opticID"];
-	        this.twistRateMM = source["twistRateMM"];
-	        this.defaultAmmoID = source["defaultAmmoID"];
-	        this.totalWeightG = source["totalWeightG"];
+	        this.limitJoules = source["limitJoules"];
+	        this.warnJoules = source["warnJoules"];
+	        this.verdict = source["verdict"];
+	        this.validShotCount = source["validShotCount"];
+	        this.exceededShots = source["exceededShots"];
+	        this.nearShots = source["nearShots"];
+	        this.maxShotJoules = source["maxShotJoules"];
+	        this.meanJoules = source["meanJoules"];
+	        this.upperBoundJoules = source["upperBoundJoules"];
+	    }
+	}
+	export class OutlierDTO {
+	    shotIndex: number;
+	    velocityMPS: number;
+	    deviationMPS: number;
+	    methods: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new OutlierDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.shotIndex = source["shotIndex"];
+	        this.velocityMPS = source["velocityMPS"];
+	        this.deviationMPS = source["deviationMPS"];
+	        this.methods = source["methods"];
+	    }
+	}
+	export class IntervalDTO {
+	    lower: number;
+	    upper: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new IntervalDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.lower = source["lower"];
+	        this.upper = source["upper"];
+	    }
+	}
+	export class PercentileDTO {
+	    percent: number;
+	    velocityMPS: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PercentileDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.percent = source["percent"];
+	        this.velocityMPS = source["velocityMPS"];
+	    }
+	}
+	export class StatisticsDTO {
+	    avgVelocityMPS: number;
+	    standardDeviation: number;
+	    minVelocityMPS: number;
+	    maxVelocityMPS: number;
+	    extremeSpread: number;
+	    avgEnergyJoules: number;
+	    avgVelocityFPS: number;
+	    avgEnergyFtLbf: number;
+	    validShotCount: number;
+	    avgEnergyDensityJCM2?: number;
+	    totalShotCount: number;
+	    sampleStandardDeviation: number;
+	    coefficientOfVariation: number;
+	    medianVelocityMPS: number;
+	    percentiles: PercentileDTO[];
+	    meanAbsoluteDeviation: number;
+	    meanCI?: IntervalDTO;
+	    standardDeviationCI?: IntervalDTO;
+	    outlierSuggestions: OutlierDTO[];
+	    energyCheck?: EnergyCheckDTO;
+	    strings?: StringStatisticsDTO[];
+	
+	    static createFrom(source: any = {}) {
+	        return new StatisticsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.avgVelocityMPS = source["avgVelocityMPS"];
+	        this.standardDeviation = source["standardDeviation"];
+	        this.minVelocityMPS = source["minVelocityMPS"];
+	        this.maxVelocityMPS = source["maxVelocityMPS"];
+	        this.extremeSpread = source["extremeSpread"];
+	        this.avgEnergyJoules = source["avgEnergyJoules"];
+	        this.avgVelocityFPS = source["avgVelocityFPS"];
+	        this.avgEnergyFtLbf = source["avgEnergyFtLbf"];
+	        this.validShotCount = source["validShotCount"];
+	        this.avgEnergyDensityJCM2 = source["avgEnergyDensityJCM2"];
+	        this.totalShotCount = source["totalShotCount"];
+	        this.sampleStandardDeviation = source["sampleStandardDeviation"];
+	        this.coefficientOfVariation = source["coefficientOfVariation"];
+	        this.medianVelocityMPS = source["medianVelocityMPS"];
+	        this.percentiles = this.convertValues(source["percentiles"], PercentileDTO);
+	        this.meanAbsoluteDeviation = source["meanAbsoluteDeviation"];
+	        this.meanCI = this.convertValues(source["meanCI"], IntervalDTO);
+	        this.standardDeviationCI = this.convertValues(source["standardDeviationCI"], IntervalDTO);
+	        this.outlierSuggestions = this.convertValues(source["outlierSuggestions"], OutlierDTO);
+	        this.energyCheck = this.convertValues(source["energyCheck"], EnergyCheckDTO);
+	        this.strings = this.convertValues(source["strings"], StringStatisticsDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ComparedSessionDTO {
+	    sessionId: string;
+	    createdAt: string;
+	    profileName: string;
+	    projectileName: string;
+	    lotNumber?: string;
+	    statistics: StatisticsDTO;
+	
+	    static createFrom(source: any = {}) {
+	        return new ComparedSessionDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.createdAt = source["createdAt"];
+	        this.profileName = source["profileName"];
+	        this.projectileName = source["projectileName"];
+	        this.lotNumber = source["lotNumber"];
+	        this.statistics = this.convertValues(source["statistics"], StatisticsDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ConditionsDTO {
+	    temperatureCelsius?: number;
+	    pressureHPa?: number;
+	    humidityPercent?: number;
+	    altitudeMeters?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConditionsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.temperatureCelsius = source["temperatureCelsius"];
+	        this.pressureHPa = source["pressureHPa"];
+	        this.humidityPercent = source["humidityPercent"];
+	        this.altitudeMeters = source["altitudeMeters"];
+	    }
+	}
+	export class ControlPointDTO {
+	    sessionId: string;
+	    createdAt: string;
+	    shotNumber: number;
+	    value: number;
+	    baseline: boolean;
+	    rules: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ControlPointDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.createdAt = source["createdAt"];
+	        this.shotNumber = source["shotNumber"];
+	        this.value = source["value"];
+	        this.baseline = source["baseline"];
+	        this.rules = source["rules"];
+	    }
+	}
+	export class ControlChartDTO {
+	    kind: string;
+	    centerLine: number;
+	    ucl: number;
+	    lcl: number;
+	    sigma: number;
+	    points: ControlPointDTO[];
+	    violationCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ControlChartDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.centerLine = source["centerLine"];
+	        this.ucl = source["ucl"];
+	        this.lcl = source["lcl"];
+	        this.sigma = source["sigma"];
+	        this.points = this.convertValues(source["points"], ControlPointDTO);
+	        this.violationCount = source["violationCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ControlChartRequestDTO {
+	    projectileId: string;
+	    subgroupSize: number;
+	    baselineSessions: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ControlChartRequestDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.projectileId = source["projectileId"];
+	        this.subgroupSize = source["subgroupSize"];
+	        this.baselineSessions = source["baselineSessions"];
+	    }
+	}
+	export class ControlChartsDTO {
+	    profileId: string;
+	    profileName: string;
+	    projectileId?: string;
+	    subgroupSize: number;
+	    baselineSessions: number;
+	    baselineSubgroups: number;
+	    baselineShots: number;
+	    sessionCount: number;
+	    discardedShots: number;
+	    inControl: boolean;
+	    xBar: ControlChartDTO;
+	    range: ControlChartDTO;
+	    individuals: ControlChartDTO;
+	    movingRange: ControlChartDTO;
+	
+	    static createFrom(source: any = {}) {
+	        return new ControlChartsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.profileId = source["profileId"];
+	        this.profileName = source["profileName"];
+	        this.projectileId = source["projectileId"];
+	        this.subgroupSize = source["subgroupSize"];
+	        this.baselineSessions = source["baselineSessions"];
+	        this.baselineSubgroups = source["baselineSubgroups"];
+	        this.baselineShots = source["baselineShots"];
+	        this.sessionCount = source["sessionCount"];
+	        this.discardedShots = source["discardedShots"];
+	        this.inControl = source["inControl"];
+	        this.xBar = this.convertValues(source["xBar"], ControlChartDTO);
+	        this.range = this.convertValues(source["range"], ControlChartDTO);
+	        this.individuals = this.convertValues(source["individuals"], ControlChartDTO);
+	        this.movingRange = this.convertValues(source["movingRange"], ControlChartDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class CorruptFileDTO {
+	    path: string;
+	    quarantinedAs: string;
+	    reason: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CorruptFileDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.quarantinedAs = source["quarantinedAs"];
+	        this.reason = source["reason"];
+	    }
+	}
+	export class DataMigrationDTO {
+	    fromVersion: number;
+	    toVersion: number;
+	    documents: number;
+	    backupDir: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DataMigrationDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.fromVersion = source["fromVersion"];
+	        this.toVersion = source["toVersion"];
+	        this.documents = source["documents"];
+	        this.backupDir = source["backupDir"];
+	    }
+	}
+	export class DownrangeDTO {
+	    nearSessionId: string;
+	    distanceMeters: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DownrangeDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.nearSessionId = source["nearSessionId"];
+	        this.distanceMeters = source["distanceMeters"];
+	    }
+	}
+	export class DragPointDTO {
+	    mach: number;
+	    cd: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DragPointDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mach = source["mach"];
+	        this.cd = source["cd"];
+	    }
+	}
+	export class DragDTO {
+	    bc: number;
+	    dragModel: string;
+	    bcUnit: string;
+	    dragTable?: DragPointDTO[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DragDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.bc = source["bc"];
+	        this.dragModel = source["dragModel"];
+	        this.bcUnit = source["bcUnit"];
+	        this.dragTable = this.convertValues(source["dragTable"], DragPointDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class EffectSizeDTO {
+	    cohensD: number;
+	    hedgesG: number;
+	    magnitude: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new EffectSizeDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.cohensD = source["cohensD"];
+	        this.hedgesG = source["hedgesG"];
+	        this.magnitude = source["magnitude"];
+	    }
+	}
+	
+	export class EnergyLimitRule {
+	    category: string;
+	    maxJoules: number;
+	    label?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new EnergyLimitRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.category = source["category"];
+	        this.maxJoules = source["maxJoules"];
+	        this.label = source["label"];
+	    }
+	}
+	export class EnergyJurisdictionDTO {
+	    code: string;
+	    limits: EnergyLimitRule[];
+	
+	    static createFrom(source: any = {}) {
+	        return new EnergyJurisdictionDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.limits = this.convertValues(source["limits"], EnergyLimitRule);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class EnergyLimitsConfigDTO {
+	    jurisdiction: string;
+	    rules: EnergyLimitRule[];
+	    effective: EnergyLimitRule[];
+	
+	    static createFrom(source: any = {}) {
+	        return new EnergyLimitsConfigDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.jurisdiction = source["jurisdiction"];
+	        this.rules = this.convertValues(source["rules"], EnergyLimitRule);
+	        this.effective = this.convertValues(source["effective"], EnergyLimitRule);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FillCurvePointDTO {
+	    shotNumber: number;
+	    meanVelocityMPS: number;
+	    minVelocityMPS: number;
+	    maxVelocityMPS: number;
+	    count: number;
+	    pressureBar?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FillCurvePointDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.shotNumber = source["shotNumber"];
+	        this.meanVelocityMPS = source["meanVelocityMPS"];
+	        this.minVelocityMPS = source["minVelocityMPS"];
+	        this.maxVelocityMPS = source["maxVelocityMPS"];
+	        this.count = source["count"];
+	        this.pressureBar = source["pressureBar"];
+	    }
+	}
+	
+	export class FillWindowDTO {
+	    fillPressureBar: number;
+	    refillPressureBar: number;
+	    pressurePerShotBar: number;
+	    strings: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FillWindowDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.fillPressureBar = source["fillPressureBar"];
+	        this.refillPressureBar = source["refillPressureBar"];
+	        this.pressurePerShotBar = source["pressurePerShotBar"];
+	        this.strings = source["strings"];
+	    }
+	}
+	
+	export class LotStatisticsDTO {
+	    lotId: string;
+	    lotNumber: string;
+	    averageWeightGrams?: number;
+	    sessionCount: number;
+	    validShotCount: number;
+	    avgVelocityMPS: number;
+	    standardDeviation: number;
+	    minVelocityMPS: number;
+	    maxVelocityMPS: number;
+	    extremeSpread: number;
+	    avgEnergyJoules: number;
+	    firstSessionAt: string;
+	    lastSessionAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LotStatisticsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.lotId = source["lotId"];
+	        this.lotNumber = source["lotNumber"];
+	        this.averageWeightGrams = source["averageWeightGrams"];
+	        this.sessionCount = source["sessionCount"];
+	        this.validShotCount = source["validShotCount"];
+	        this.avgVelocityMPS = source["avgVelocityMPS"];
+	        this.standardDeviation = source["standardDeviation"];
+	        this.minVelocityMPS = source["minVelocityMPS"];
+	        this.maxVelocityMPS = source["maxVelocityMPS"];
+	        this.extremeSpread = source["extremeSpread"];
+	        this.avgEnergyJoules = source["avgEnergyJoules"];
+	        this.firstSessionAt = source["firstSessionAt"];
+	        this.lastSessionAt = source["lastSessionAt"];
+	    }
+	}
+	export class MeanTestDTO {
+	    t: number;
+	    df: number;
+	    p: number;
+	    significant: boolean;
+	    differenceCI: IntervalDTO;
+	
+	    static createFrom(source: any = {}) {
+	        return new MeanTestDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.t = source["t"];
+	        this.df = source["df"];
+	        this.p = source["p"];
+	        this.significant = source["significant"];
+	        this.differenceCI = this.convertValues(source["differenceCI"], IntervalDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class OpticDTO {
+	    type: string;
+	    modelName: string;
+	    weightG: number;
+	    minMagnification: number;
+	    maxMagnification: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new OpticDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.modelName = source["modelName"];
+	        this.weightG = source["weightG"];
+	        this.minMagnification = source["minMagnification"];
+	        this.maxMagnification = source["maxMagnification"];
+	    }
+	}
+	
+	
+	export class TrendPointDTO {
+	    sessionId: string;
+	    createdAt: string;
+	    projectileId: string;
+	    projectileName: string;
+	    temperatureCelsius?: number;
+	    validShotCount: number;
+	    avgVelocityMPS: number;
+	    standardDeviation: number;
+	    extremeSpread: number;
+	    adjustedVelocityMPS: number;
+	    temperatureAdjusted: boolean;
+	    baseline: boolean;
+	    zScore: number;
+	    cusumLow: number;
+	    cusumHigh: number;
+	    alerts: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TrendPointDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.createdAt = source["createdAt"];
+	        this.projectileId = source["projectileId"];
+	        this.projectileName = source["projectileName"];
+	        this.temperatureCelsius = source["temperatureCelsius"];
+	        this.validShotCount = source["validShotCount"];
+	        this.avgVelocityMPS = source["avgVelocityMPS"];
+	        this.standardDeviation = source["standardDeviation"];
+	        this.extremeSpread = source["extremeSpread"];
+	        this.adjustedVelocityMPS = source["adjustedVelocityMPS"];
+	        this.temperatureAdjusted = source["temperatureAdjusted"];
+	        this.baseline = source["baseline"];
+	        this.zScore = source["zScore"];
+	        this.cusumLow = source["cusumLow"];
+	        this.cusumHigh = source["cusumHigh"];
+	        this.alerts = source["alerts"];
+	    }
+	}
+	export class PerformanceTrendDTO {
+	    profileId: string;
+	    profileName: string;
+	    status: string;
+	    baselineSessions: number;
+	    baselineMeanMPS: number;
+	    baselineSDMPS: number;
+	    betweenSessionMPS: number;
+	    cusumK: number;
+	    cusumH: number;
+	    slopeMPSPerDay?: number;
+	    dropDetectedSessionId?: string;
+	    dropStartSessionId?: string;
+	    skippedSessions: number;
+	    points: TrendPointDTO[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PerformanceTrendDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.profileId = source["profileId"];
+	        this.profileName = source["profileName"];
+	        this.status = source["status"];
+	        this.baselineSessions = source["baselineSessions"];
+	        this.baselineMeanMPS = source["baselineMeanMPS"];
+	        this.baselineSDMPS = source["baselineSDMPS"];
+	        this.betweenSessionMPS = source["betweenSessionMPS"];
+	        this.cusumK = source["cusumK"];
+	        this.cusumH = source["cusumH"];
+	        this.slopeMPSPerDay = source["slopeMPSPerDay"];
+	        this.dropDetectedSessionId = source["dropDetectedSessionId"];
+	        this.dropStartSessionId = source["dropStartSessionId"];
+	        this.skippedSessions = source["skippedSessions"];
+	        this.points = this.convertValues(source["points"], TrendPointDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProfileDTO {
+	    id: string;
+	    name: string;
+	    category: string;
+	    barrelLengthMM: number;
+	    triggerWeightG: number;
+	    sightHeightMM: number;
+	    optic?: OpticDTO;
+	    opticID?: string;
+	    twistRateMM?: number;
+	    defaultAmmoID?: string;
+	    totalWeightG: number;
+	    powerPlant?: string;
+	    regulatorSetpointBar?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProfileDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.category = source["category"];
+	        this.barrelLengthMM = source["barrelLengthMM"];
+	        this.triggerWeightG = source["triggerWeightG"];
+	        this.sightHeightMM = source["sightHeightMM"];
+	        this.optic = this.convertValues(source["optic"], OpticDTO);
+	        this.opticID = source["opticID"];
+	        this.twistRateMM = source["twistRateMM"];
+	        this.defaultAmmoID = source["defaultAmmoID"];
+	        this.totalWeightG = source["totalWeightG"];
+	        this.powerPlant = source["powerPlant"];
+	        this.regulatorSetpointBar = source["regulatorSetpointBar"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProjectileLotDTO {
+	    id: string;
+	    number: string;
+	    purchaseDate?: string;
+	    averageWeightGrams?: number;
+	    countOnHand: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProjectileLotDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.number = source["number"];
+	        this.purchaseDate = source["purchaseDate"];
+	        this.averageWeightGrams = source["averageWeightGrams"];
+	        this.countOnHand = source["countOnHand"];
+	    }
+	}
+	export class ProjectileDTO {
+	    id: string;
+	    name: string;
+	    weightGrams: number;
+	    bc: number;
+	    dragModel: string;
+	    bcUnit: string;
+	    dragTable?: DragPointDTO[];
+	    caliberMM?: number;
+	    lots: ProjectileLotDTO[];
+	    lot?: ProjectileLotDTO;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProjectileDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.weightGrams = source["weightGrams"];
+	        this.bc = source["bc"];
+	        this.dragModel = source["dragModel"];
+	        this.bcUnit = source["bcUnit"];
+	        this.dragTable = this.convertValues(source["dragTable"], DragPointDTO);
+	        this.caliberMM = source["caliberMM"];
+	        this.lots = this.convertValues(source["lots"], ProjectileLotDTO);
+	        this.lot = this.convertValues(source["lot"], ProjectileLotDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class Result___metric_neo_internal_application_ChronoPortDTO_ {
+	    data: ChronoPortDTO[];
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result___metric_neo_internal_application_ChronoPortDTO_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = this.convertValues(source["data"], ChronoPortDTO);
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Result___metric_neo_internal_application_LotStatisticsDTO_ {
+	    data: LotStatisticsDTO[];
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result___metric_neo_internal_application_LotStatisticsDTO_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = this.convertValues(source["data"], LotStatisticsDTO);
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Result___metric_neo_internal_application_ProfileDTO_ {
+	    data: ProfileDTO[];
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result___metric_neo_internal_application_ProfileDTO_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = this.convertValues(source["data"], ProfileDTO);
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Result___metric_neo_internal_application_ProjectileDTO_ {
+	    data: ProjectileDTO[];
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result___metric_neo_internal_application_ProjectileDTO_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = this.convertValues(source["data"], ProjectileDTO);
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Result___metric_neo_internal_application_SessionMetaDTO_ {
+	    data: SessionMetaDTO[];
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result___metric_neo_internal_application_SessionMetaDTO_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = this.convertValues(source["data"], SessionMetaDTO);
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SightDTO {
+	    id: string;
+	    type: string;
+	    modelName: string;
+	    weightG: number;
+	    minMagnification: number;
+	    maxMagnification: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SightDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.type = source["type"];
+	        this.modelName = source["modelName"];
+	        this.weightG = source["weightG"];
+	        this.minMagnification = source["minMagnification"];
+	        this.maxMagnification = source["maxMagnification"];
+	    }
+	}
+	export class Result___metric_neo_internal_application_SightDTO_ {
+	    data: SightDTO[];
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result___metric_neo_internal_application_SightDTO_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = this.convertValues(source["data"], SightDTO);
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Result_bool_ {
+	    data: boolean;
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result_bool_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = source["data"];
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	}
+	export class Result_int_ {
+	    data: number;
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result_int_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = source["data"];
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	}
+	export class Result_metric_neo_internal_application_BCEstimateDTO_ {
+	    data: BCEstimateDTO;
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result_metric_neo_internal_application_BCEstimateDTO_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = this.convertValues(source["data"], BCEstimateDTO);
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Result_metric_neo_internal_application_CSVImportResultDTO_ {
+	    data: CSVImportResultDTO;
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result_metric_neo_internal_application_CSVImportResultDTO_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = this.convertValues(source["data"], CSVImportResultDTO);
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Result_metric_neo_internal_application_CaptureStatusDTO_ {
+	    data: CaptureStatusDTO;
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result_metric_neo_internal_application_CaptureStatusDTO_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = this.convertValues(source["data"], CaptureStatusDTO);
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Result_metric_neo_internal_application_ChronoConfigDTO_ {
+	    data: ChronoConfigDTO;
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result_metric_neo_internal_application_ChronoConfigDTO_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = this.convertValues(source["data"], ChronoConfigDTO);
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Result_metric_neo_internal_application_ControlChartsDTO_ {
+	    data: ControlChartsDTO;
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result_metric_neo_internal_application_ControlChartsDTO_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = this.convertValues(source["data"], ControlChartsDTO);
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Result_metric_neo_internal_application_EnergyLimitsConfigDTO_ {
+	    data: EnergyLimitsConfigDTO;
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result_metric_neo_internal_application_EnergyLimitsConfigDTO_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = this.convertValues(source["data"], EnergyLimitsConfigDTO);
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Result_metric_neo_internal_application_PerformanceTrendDTO_ {
+	    data: PerformanceTrendDTO;
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result_metric_neo_internal_application_PerformanceTrendDTO_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = this.convertValues(source["data"], PerformanceTrendDTO);
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Result_metric_neo_internal_application_ProfileDTO_ {
+	    data: ProfileDTO;
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result_metric_neo_internal_application_ProfileDTO_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = this.convertValues(source["data"], ProfileDTO);
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Result_metric_neo_internal_application_ProjectileDTO_ {
+	    data: ProjectileDTO;
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result_metric_neo_internal_application_ProjectileDTO_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = this.convertValues(source["data"], ProjectileDTO);
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class VarianceTestDTO {
+	    f: number;
+	    df1: number;
+	    df2: number;
+	    fP: number;
+	    levene?: number;
+	    leveneP?: number;
+	    significant: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new VarianceTestDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.f = source["f"];
+	        this.df1 = source["df1"];
+	        this.df2 = source["df2"];
+	        this.fP = source["fP"];
+	        this.levene = source["levene"];
+	        this.leveneP = source["leveneP"];
+	        this.significant = source["significant"];
+	    }
+	}
+	export class SessionTestDTO {
+	    referenceId: string;
+	    sessionId: string;
+	    meanDifferenceMPS: number;
+	    means?: MeanTestDTO;
+	    variances?: VarianceTestDTO;
+	    effect?: EffectSizeDTO;
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionTestDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.referenceId = source["referenceId"];
+	        this.sessionId = source["sessionId"];
+	        this.meanDifferenceMPS = source["meanDifferenceMPS"];
+	        this.means = this.convertValues(source["means"], MeanTestDTO);
+	        this.variances = this.convertValues(source["variances"], VarianceTestDTO);
+	        this.effect = this.convertValues(source["effect"], EffectSizeDTO);
+	        this.warnings = source["warnings"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SessionComparisonDTO {
+	    sessions: ComparedSessionDTO[];
+	    tests: SessionTestDTO[];
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionComparisonDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessions = this.convertValues(source["sessions"], ComparedSessionDTO);
+	        this.tests = this.convertValues(source["tests"], SessionTestDTO);
+	        this.warnings = source["warnings"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Result_metric_neo_internal_application_SessionComparisonDTO_ {
+	    data: SessionComparisonDTO;
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result_metric_neo_internal_application_SessionComparisonDTO_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = this.convertValues(source["data"], SessionComparisonDTO);
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ShotStringDTO {
+	    number: number;
+	    name: string;
+	    firstShot: number;
+	    shotCount: number;
+	    startedAt: string;
+	    fill?: FillDTO;
+	
+	    static createFrom(source: any = {}) {
+	        return new ShotStringDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.number = source["number"];
+	        this.name = source["name"];
+	        this.firstShot = source["firstShot"];
+	        this.shotCount = source["shotCount"];
+	        this.startedAt = source["startedAt"];
+	        this.fill = this.convertValues(source["fill"], FillDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ShotDTO {
+	    velocityMPS: number;
+	    energyJoules: number;
+	    velocityFPS: number;
+	    energyFtLbf: number;
+	    energyDensityJCM2?: number;
+	    timestamp: string;
+	    valid: boolean;
+	    energyVerdict?: string;
+	    stringNumber: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ShotDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.velocityMPS = source["velocityMPS"];
+	        this.energyJoules = source["energyJoules"];
+	        this.velocityFPS = source["velocityFPS"];
+	        this.energyFtLbf = source["energyFtLbf"];
+	        this.energyDensityJCM2 = source["energyDensityJCM2"];
+	        this.timestamp = source["timestamp"];
+	        this.valid = source["valid"];
+	        this.energyVerdict = source["energyVerdict"];
+	        this.stringNumber = source["stringNumber"];
+	    }
+	}
+	export class SessionDTO {
+	    id: string;
+	    profileSnapshot: ProfileDTO;
+	    projectileSnapshot: ProjectileDTO;
+	    shots: ShotDTO[];
+	    temperatureCelsius?: number;
+	    pressureHPa?: number;
+	    humidityPercent?: number;
+	    altitudeMeters?: number;
+	    note: string;
+	    createdAt: string;
+	    airDensityKgM3?: number;
+	    densityAltitudeMeters?: number;
+	    downrange?: DownrangeDTO;
+	    strings: ShotStringDTO[];
+	    energyCheck?: EnergyCheckDTO;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.profileSnapshot = this.convertValues(source["profileSnapshot"], ProfileDTO);
+	        this.projectileSnapshot = this.convertValues(source["projectileSnapshot"], ProjectileDTO);
+	        this.shots = this.convertValues(source["shots"], ShotDTO);
+	        this.temperatureCelsius = source["temperatureCelsius"];
+	        this.pressureHPa = source["pressureHPa"];
+	        this.humidityPercent = source["humidityPercent"];
+	        this.altitudeMeters = source["altitudeMeters"];
+	        this.note = source["note"];
+	        this.createdAt = source["createdAt"];
+	        this.airDensityKgM3 = source["airDensityKgM3"];
+	        this.densityAltitudeMeters = source["densityAltitudeMeters"];
+	        this.downrange = this.convertValues(source["downrange"], DownrangeDTO);
+	        this.strings = this.convertValues(source["strings"], ShotStringDTO);
+	        this.energyCheck = this.convertValues(source["energyCheck"], EnergyCheckDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Result_metric_neo_internal_application_SessionDTO_ {
+	    data: SessionDTO;
+	    error: string;
+	    success: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result_metric_neo_internal_application_SessionDTO_(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = this.convertValues(source["data"], SessionDTO);
+	        this.error = source["error"];
+	        this.success = source["success"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SessionPageDTO {
+	    sessions: SessionMetaDTO[];
+	    total: number;
+	    offset: number;
+	    limit: number;
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionPageDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessions = this.convertValues(source["sessions"], SessionMetaDTO);
+	        this.total = source["total"];
+	        this.offset = source["offset"];
+	        this.limit = source["limit"];
+	        this.warnings = source["warnings"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -124,28 +2115,20 @@ export namespace application {
 		    return a;
 		}
 	}
-	export class SessionDTO {
-	    id: string;
-	    profileSnapshot: ProfileDTO;
-	    projectileSnapshot: ProjectileDTO;
-	    shots: ShotDTO[];
-	    temperatureCelsius?: number;
-	    note: string;
-	    createdAt: string;
+	export class Result_metric_neo_internal_application_SessionPageDTO_ {
+	    data: SessionPageDTO;
+	    error: string;
+	    success: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new SessionDTO(source);
+	        return new Result_metric_neo_internal_application_SessionPageDTO_(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.profileSnapshot = this.convertValues(source["profileSnapshot"], ProfileDTO);
-	        this.projectileSnapshot = this.convertValues(source["projectileSnapshot"], ProjectileDTO);
-	        this.shots = this.convertValues(source["shots"], ShotDTO);
-	        this.temperatureCelsius = source["temperatureCelsius"];
-	        this.note = source["note"];
-	        this.createdAt = source["createdAt"];
+	        this.data = this.convertValues(source["data"], SessionPageDTO);
+	        this.error = source["error"];
+	        this.success = source["success"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -166,20 +2149,64 @@ export namespace application {
 		    return a;
 		}
 	}
-	export class ChronoPollResultDTO {
-	    recorded: boolean;
-	    velocityMPS?: number;
-	    session?: SessionDTO;
+	export class SweetSpotDTO {
+	    firstShot: number;
+	    lastShot: number;
+	    shotCount: number;
+	    meanVelocityMPS: number;
+	    spreadMPS: number;
+	    extremeSpread: number;
+	    sampleStandardDeviation: number;
+	    completeStrings: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new ChronoPollResultDTO(source);
+	        return new SweetSpotDTO(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.recorded = source["recorded"];
-	        this.velocityMPS = source["velocityMPS"];
-	        this.session = this.convertValues(source["session"], SessionDTO);
+	        this.firstShot = source["firstShot"];
+	        this.lastShot = source["lastShot"];
+	        this.shotCount = source["shotCount"];
+	        this.meanVelocityMPS = source["meanVelocityMPS"];
+	        this.spreadMPS = source["spreadMPS"];
+	        this.extremeSpread = source["extremeSpread"];
+	        this.sampleStandardDeviation = source["sampleStandardDeviation"];
+	        this.completeStrings = source["completeStrings"];
+	    }
+	}
+	export class ShotsPerFillDTO {
+	    sessionId?: string;
+	    profileId: string;
+	    profileName: string;
+	    sessionCount: number;
+	    toleranceMPS: number;
+	    strings: StringStatisticsDTO[];
+	    curve: FillCurvePointDTO[];
+	    powerPlant?: string;
+	    regulatorSetpointBar?: number;
+	    sweetSpot?: SweetSpotDTO;
+	    fillWindow?: FillWindowDTO;
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ShotsPerFillDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.profileId = source["profileId"];
+	        this.profileName = source["profileName"];
+	        this.sessionCount = source["sessionCount"];
+	        this.toleranceMPS = source["toleranceMPS"];
+	        this.strings = this.convertValues(source["strings"], StringStatisticsDTO);
+	        this.curve = this.convertValues(source["curve"], FillCurvePointDTO);
+	        this.powerPlant = source["powerPlant"];
+	        this.regulatorSetpointBar = source["regulatorSetpointBar"];
+	        this.sweetSpot = this.convertValues(source["sweetSpot"], SweetSpotDTO);
+	        this.fillWindow = this.convertValues(source["fillWindow"], FillWindowDTO);
+	        this.warnings = source["warnings"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -200,21 +2227,18 @@ export namespace application {
 		    return a;
 		}
 	}
-	
-	
-	
-	export class Result___metric_neo_internal_application_ProfileDTO_ {
-	    data: ProfileDTO[];
+	export class Result_metric_neo_internal_application_ShotsPerFillDTO_ {
+	    data: ShotsPerFillDTO;
 	    error: string;
 	    success: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new Result___metric_neo_internal_application_ProfileDTO_(source);
+	        return new Result_metric_neo_internal_application_ShotsPerFillDTO_(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.data = this.convertValues(source["data"], ProfileDTO);
+	        this.data = this.convertValues(source["data"], ShotsPerFillDTO);
 	        this.error = source["error"];
 	        this.success = source["success"];
 	    }
@@ -237,18 +2261,18 @@ export namespace application {
 		    return a;
 		}
 	}
-	export class Result___metric_neo_internal_application_ProjectileDTO_ {
-	    data: ProjectileDTO[];
+	export class Result_metric_neo_internal_application_SightDTO_ {
+	    data: SightDTO;
 	    error: string;
 	    success: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new Result___metric_neo_internal_application_ProjectileDTO_(source);
+	        return new Result_metric_neo_internal_application_SightDTO_(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.data = this.convertValues(source["data"], ProjectileDTO);
+	        this.data = this.convertValues(source["data"], SightDTO);
 	        this.error = source["error"];
 	        this.success = source["success"];
 	    }
@@ -271,46 +2295,18 @@ export namespace application {
 		    return a;
 		}
 	}
-	export class SessionMetaDTO {
-	    id: string;
-	    profileName: string;
-	    projectileName: string;
-	    shotCount: number;
-	    validShotCount: number;
-	    createdAt: string;
-	    note: string;
-	    avgVelocityMPS?: number;
-	    avgEnergyJoules?: number;
-	
-	    static createFrom(source: any = {}) {
-	        return new SessionMetaDTO(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.profileName = source["profileName"];
-	        this.projectileName = source["projectileName"];
-	        this.shotCount = source["shotCount"];
-	        this.validShotCount = source["validShotCount"];
-	        this.createdAt = source["createdAt"];
-	        this.note = source["note"];
-	        this.avgVelocityMPS = source["avgVelocityMPS"];
-	        this.avgEnergyJoules = source["avgEnergyJoules"];
-	    }
-	}
-	export class Result___metric_neo_internal_application_SessionMetaDTO_ {
-	    data: SessionMetaDTO[];
+	export class Result_metric_neo_internal_application_StatisticsDTO_ {
+	    data: StatisticsDTO;
 	    error: string;
 	    success: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new Result___metric_neo_internal_application_SessionMetaDTO_(source);
+	        return new Result_metric_neo_internal_application_StatisticsDTO_(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.data = this.convertValues(source["data"], SessionMetaDTO);
+	        this.data = this.convertValues(source["data"], StatisticsDTO);
 	        this.error = source["error"];
 	        this.success = source["success"];
 	    }
@@ -333,40 +2329,42 @@ export namespace application {
 		    return a;
 		}
 	}
-	export class SightDTO {
-	    id: string;
-	    type: string;
-	    modelName: string;
-	    weightG: number;
-	    minMagnification: number;
-	    maxMagnification: number;
+	export class StorageConversionDTO {
+	    from: string;
+	    to: string;
+	    profiles: number;
+	    projectiles: number;
+	    sights: number;
+	    sessions: number;
+	    backupDir: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new SightDTO(source);
+	        return new StorageConversionDTO(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.type = source["type"];
-	        this.modelName = source["modelName"];
-	        this.weightG = source["weightG"];
-	        this.minMagnification = source["minMagnification"];
-	        this.maxMagnification = source["maxMagnification"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.profiles = source["profiles"];
+	        this.projectiles = source["projectiles"];
+	        this.sights = source["sights"];
+	        this.sessions = source["sessions"];
+	        this.backupDir = source["backupDir"];
 	    }
 	}
-	export class Result___metric_neo_internal_application_SightDTO_ {
-	    data: SightDTO[];
+	export class Result_metric_neo_internal_application_StorageConversionDTO_ {
+	    data: StorageConversionDTO;
 	    error: string;
 	    success: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new Result___metric_neo_internal_application_SightDTO_(source);
+	        return new Result_metric_neo_internal_application_StorageConversionDTO_(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.data = this.convertValues(source["data"], SightDTO);
+	        this.data = this.convertValues(source["data"], StorageConversionDTO);
 	        this.error = source["error"];
 	        this.success = source["success"];
 	    }
@@ -389,34 +2387,32 @@ export namespace application {
 		    return a;
 		}
 	}
-	export class Result_bool_ {
-	    data: boolean;
-	    error: string;
-	    success: boolean;
+	export class StorageInfoDTO {
+	    backend: string;
+	    sqlitePath: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new Result_bool_(source);
+	        return new StorageInfoDTO(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.data = source["data"];
-	        this.error = source["error"];
-	        this.success = source["success"];
+	        this.backend = source["backend"];
+	        this.sqlitePath = source["sqlitePath"];
 	    }
 	}
-	export class Result_metric_neo_internal_application_ChronoConfigDTO_ {
-	    data: ChronoConfigDTO;
+	export class Result_metric_neo_internal_application_StorageInfoDTO_ {
+	    data: StorageInfoDTO;
 	    error: string;
 	    success: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new Result_metric_neo_internal_application_ChronoConfigDTO_(source);
+	        return new Result_metric_neo_internal_application_StorageInfoDTO_(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.data = this.convertValues(source["data"], ChronoConfigDTO);
+	        this.data = this.convertValues(source["data"], StorageInfoDTO);
 	        this.error = source["error"];
 	        this.success = source["success"];
 	    }
@@ -439,20 +2435,64 @@ export namespace application {
 		    return a;
 		}
 	}
-	export class Result_metric_neo_internal_application_ChronoPollResultDTO_ {
-	    data: ChronoPollResultDTO;
-	    error: string;
-	    success: boolean;
+	export class TrajectoryPointDTO {
+	    rangeMeters: number;
+	    pathMM: number;
+	    dropMM: number;
+	    windDriftMM: number;
+	    timeOfFlightSeconds: number;
+	    velocityMPS: number;
+	    energyJoules: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Result_metric_neo_internal_application_ChronoPollResultDTO_(source);
+	        return new TrajectoryPointDTO(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.data = this.convertValues(source["data"], ChronoPollResultDTO);
-	        this.error = source["error"];
-	        this.success = source["success"];
+	        this.rangeMeters = source["rangeMeters"];
+	        this.pathMM = source["pathMM"];
+	        this.dropMM = source["dropMM"];
+	        this.windDriftMM = source["windDriftMM"];
+	        this.timeOfFlightSeconds = source["timeOfFlightSeconds"];
+	        this.velocityMPS = source["velocityMPS"];
+	        this.energyJoules = source["energyJoules"];
+	    }
+	}
+	export class TrajectoryDTO {
+	    sessionId: string;
+	    dragModel: string;
+	    bc: number;
+	    bcUnit: string;
+	    muzzleVelocityMPS: number;
+	    weightGrams: number;
+	    sightHeightMM: number;
+	    zeroRangeMeters: number;
+	    crosswindMPS: number;
+	    airDensityKgM3: number;
+	    speedOfSoundMPS: number;
+	    zeroAngleMRAD: number;
+	    points: TrajectoryPointDTO[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TrajectoryDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.dragModel = source["dragModel"];
+	        this.bc = source["bc"];
+	        this.bcUnit = source["bcUnit"];
+	        this.muzzleVelocityMPS = source["muzzleVelocityMPS"];
+	        this.weightGrams = source["weightGrams"];
+	        this.sightHeightMM = source["sightHeightMM"];
+	        this.zeroRangeMeters = source["zeroRangeMeters"];
+	        this.crosswindMPS = source["crosswindMPS"];
+	        this.airDensityKgM3 = source["airDensityKgM3"];
+	        this.speedOfSoundMPS = source["speedOfSoundMPS"];
+	        this.zeroAngleMRAD = source["zeroAngleMRAD"];
+	        this.points = this.convertValues(source["points"], TrajectoryPointDTO);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -473,18 +2513,18 @@ export namespace application {
 		    return a;
 		}
 	}
-	export class Result_metric_neo_internal_application_ProfileDTO_ {
-	    data: ProfileDTO;
+	export class Result_metric_neo_internal_application_TrajectoryDTO_ {
+	    data: TrajectoryDTO;
 	    error: string;
 	    success: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new Result_metric_neo_internal_application_ProfileDTO_(source);
+	        return new Result_metric_neo_internal_application_TrajectoryDTO_(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.data = this.convertValues(source["data"], ProfileDTO);
+	        this.data = this.convertValues(source["data"], TrajectoryDTO);
 	        this.error = source["error"];
 	        this.success = source["success"];
 	    }
@@ -507,20 +2547,46 @@ export namespace application {
 		    return a;
 		}
 	}
-	export class Result_metric_neo_internal_application_ProjectileDTO_ {
-	    data: ProjectileDTO;
-	    error: string;
-	    success: boolean;
+	export class UnitDTO {
+	    label: string;
+	    factor: number;
+	    decimals: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Result_metric_neo_internal_application_ProjectileDTO_(source);
+	        return new UnitDTO(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.data = this.convertValues(source["data"], ProjectileDTO);
-	        this.error = source["error"];
-	        this.success = source["success"];
+	        this.label = source["label"];
+	        this.factor = source["factor"];
+	        this.decimals = source["decimals"];
+	    }
+	}
+	export class UnitsDTO {
+	    system: string;
+	    velocity: UnitDTO;
+	    energy: UnitDTO;
+	    mass: UnitDTO;
+	    length: UnitDTO;
+	    energyDensity: UnitDTO;
+	    fillPressure: UnitDTO;
+	    systems: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new UnitsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.system = source["system"];
+	        this.velocity = this.convertValues(source["velocity"], UnitDTO);
+	        this.energy = this.convertValues(source["energy"], UnitDTO);
+	        this.mass = this.convertValues(source["mass"], UnitDTO);
+	        this.length = this.convertValues(source["length"], UnitDTO);
+	        this.energyDensity = this.convertValues(source["energyDensity"], UnitDTO);
+	        this.fillPressure = this.convertValues(source["fillPressure"], UnitDTO);
+	        this.systems = source["systems"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -541,18 +2607,18 @@ export namespace application {
 		    return a;
 		}
 	}
-	export class Result_metric_neo_internal_application_SessionDTO_ {
-	    data: SessionDTO;
+	export class Result_metric_neo_internal_application_UnitsDTO_ {
+	    data: UnitsDTO;
 	    error: string;
 	    success: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new Result_metric_neo_internal_application_SessionDTO_(source);
+	        return new Result_metric_neo_internal_application_UnitsDTO_(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.data = this.convertValues(source["data"], SessionDTO);
+	        this.data = this.convertValues(source["data"], UnitsDTO);
 	        this.error = source["error"];
 	        this.success = source["success"];
 	    }
@@ -575,80 +2641,88 @@ export namespace application {
 		    return a;
 		}
 	}
-	export class Result_metric_neo_internal_application_SightDTO_ {
-	    data: SightDTO;
+	export class Result_string_ {
+	    data: string;
 	    error: string;
 	    success: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new Result_metric_neo_internal_application_SightDTO_(source);
+	        return new Result_string_(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.data = this.convertValues(source["data"], SightDTO);
+	        this.data = source["data"];
 	        this.error = source["error"];
 	        this.success = source["success"];
 	    }
+	}
 	
-		convertValues(a: any, classs: any, asMap: boolean = false): any {
-		    if (!a) {
-		        return a;
-		    }
-		    if (a.slice && a.map) {
-		        return (a as any[]).map(elem => this.convertValues(elem, classs));
-		    } else if ("object" === typeof a) {
-		        if (asMap) {
-		            for (const key of Object.keys(a)) {
-		                a[key] = new classs(a[key]);
-		            }
-		            return a;
-		        }
-		        return new classs(a);
-		    }
-		    return a;
-		}
+	
+	
+	
+	export class SessionQueryDTO {
+	    profileId: string;
+	    projectileId: string;
+	    lotId: string;
+	    from: string;
+	    to: string;
+	    sort: string;
+	    offset: number;
+	    limit: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionQueryDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.profileId = source["profileId"];
+	        this.projectileId = source["projectileId"];
+	        this.lotId = source["lotId"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.sort = source["sort"];
+	        this.offset = source["offset"];
+	        this.limit = source["limit"];
+	    }
 	}
-	export class StatisticsDTO {
-	    avgVelocityMPS: number;
-	    standardDeviation: number;
-	    minVelocityMPS: number;
-	    maxVelocityMPS: number;
-	    extremeSpread: number;
-	    avgEnergyJoules: number;
-	    validShotCount: number;
-	    totalShotCount: number;
+	
+	
+	
+	
+	export class ShotsPerFillRequestDTO {
+	    toleranceMPS: number;
+	    projectileId: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new StatisticsDTO(source);
+	        return new ShotsPerFillRequestDTO(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.avgVelocityMPS = source["avgVelocityMPS"];
-	        this.standardDeviation = source["standardDeviation"];
-	        this.minVelocityMPS = source["minVelocityMPS"];
-	        this.maxVelocityMPS = source["maxVelocityMPS"];
-	        this.extremeSpread = source["extremeSpread"];
-	        this.avgEnergyJoules = source["avgEnergyJoules"];
-	        this.validShotCount = source["validShotCount"];
-	        this.totalShotCount = source["totalShotCount"];
+	        this.toleranceMPS = source["toleranceMPS"];
+	        this.projectileId = source["projectileId"];
 	    }
 	}
-	export class Result_metric_neo_internal_application_StatisticsDTO_ {
-	    data: StatisticsDTO;
-	    error: string;
-	    success: boolean;
+	
+	
+	
+	
+	export class StorageRecoveryDTO {
+	    removedTempFiles: string[];
+	    corruptFiles: CorruptFileDTO[];
+	    migration?: DataMigrationDTO;
 	
 	    static createFrom(source: any = {}) {
-	        return new Result_metric_neo_internal_application_StatisticsDTO_(source);
+	        return new StorageRecoveryDTO(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.data = this.convertValues(source["data"], StatisticsDTO);
-	        this.error = source["error"];
-	        this.success = source["success"];
+	        this.removedTempFiles = source["removedTempFiles"];
+	        this.corruptFiles = this.convertValues(source["corruptFiles"], CorruptFileDTO);
+	        this.migration = this.convertValues(source["migration"], DataMigrationDTO);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -669,24 +2743,47 @@ export namespace application {
 		    return a;
 		}
 	}
-	export class Result_string_ {
-	    data: string;
-	    error: string;
-	    success: boolean;
+	
+	
+	
+	
+	export class TrajectoryRequestDTO {
+	    zeroRangeMeters: number;
+	    maxRangeMeters: number;
+	    stepMeters: number;
+	    crosswindMPS: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Result_string_(source);
+	        return new TrajectoryRequestDTO(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.data = source["data"];
-	        this.error = source["error"];
-	        this.success = source["success"];
+	        this.zeroRangeMeters = source["zeroRangeMeters"];
+	        this.maxRangeMeters = source["maxRangeMeters"];
+	        this.stepMeters = source["stepMeters"];
+	        this.crosswindMPS = source["crosswindMPS"];
 	    }
 	}
 	
+	export class TrendRequestDTO {
+	    projectileId: string;
+	    baselineSessions: number;
+	    normalizeProjectile: boolean;
+	    temperatureCoefficient?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TrendRequestDTO(source);
+	    }
 	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.projectileId = source["projectileId"];
+	        this.baselineSessions = source["baselineSessions"];
+	        this.normalizeProjectile = source["normalizeProjectile"];
+	        this.temperatureCoefficient = source["temperatureCoefficient"];
+	    }
+	}
 	
 	
 
@@ -0,0 +1,77 @@
+package application
+
+// TrendRequestDTO steuert die Trend-Analyse eines Profils.
+type TrendRequestDTO struct {
+	// ProjectileID beschränkt die Analyse auf ein Projectile ("" = alle)
+	ProjectileID string `json:"projectileId"`
+
+	// BaselineSessions ist die Zahl der ersten Sessions, die den Sollwert
+	// bilden (0 = DefaultTrendBaselineSessions)
+	BaselineSessions int `json:"baselineSessions"`
+
+	// NormalizeProjectile rechnet jede Session auf das Projectile der ersten
+	// Session um (Verhältnis der Baselines je Projectile)
+	NormalizeProjectile bool `json:"normalizeProjectile"`
+
+	// TemperatureCoefficient ist die Änderung der Geschwindigkeit in % pro °C.
+	// Gesetzt, werden Sessions mit Temperatur auf 15 °C umgerechnet.
+	TemperatureCoefficient *float64 `json:"temperatureCoefficient,omitempty"`
+}
+
+// PerformanceTrendDTO ist der Verlauf eines Profils über alle Sessions.
+type PerformanceTrendDTO struct {
+	ProfileID   string `json:"profileId"`
+	ProfileName string `json:"profileName"`
+
+	// Status: TrendStable, TrendDrop, TrendRise oder TrendInsufficient
+	Status string `json:"status"`
+
+	// Sollwert aus den ersten BaselineSessions Sessions (bereinigt)
+	BaselineSessions  int     `json:"baselineSessions"`
+	BaselineMeanMPS   float64 `json:"baselineMeanMPS"`
+	BaselineSDMPS     float64 `json:"baselineSDMPS"`     // gepoolte SD innerhalb der Sessions
+	BetweenSessionMPS float64 `json:"betweenSessionMPS"` // Streuung von Tag zu Tag
+
+	// CUSUM-Parameter in Vielfachen von σ
+	CUSUMK float64 `json:"cusumK"`
+	CUSUMH float64 `json:"cusumH"`
+
+	// SlopeMPSPerDay ist die Steigung der Regressionsgeraden (ab 2 Tagen)
+	SlopeMPSPerDay *float64 `json:"slopeMPSPerDay,omitempty"`
+
+	// Erster Alarm der unteren CUSUM und die Session, ab der der Abfall
+	// vermutlich begann (letzte Null der CUSUM davor)
+	DropDetectedSessionID string `json:"dropDetectedSessionId,omitempty"`
+	DropStartSessionID    string `json:"dropStartSessionId,omitempty"`
+
+	// SkippedSessions zählt Sessions ohne gültige Schüsse
+	SkippedSessions int             `json:"skippedSessions"`
+	Points          []TrendPointDTO `json:"points"`
+}
+
+// TrendPointDTO ist eine Session in der Zeitreihe.
+type TrendPointDTO struct {
+	SessionID          string   `json:"sessionId"`
+	CreatedAt          string   `json:"createdAt"` // ISO 8601
+	ProjectileID       string   `json:"projectileId"`
+	ProjectileName     string   `json:"projectileName"`
+	TemperatureCelsius *float64 `json:"temperatureCelsius,omitempty"`
+	ValidShotCount     int      `json:"validShotCount"`
+
+	AvgVelocityMPS    float64 `json:"avgVelocityMPS"`
+	StandardDeviation float64 `json:"standardDeviation"` // Stichprobe (n-1)
+	ExtremeSpread     float64 `json:"extremeSpread"`
+
+	// AdjustedVelocityMPS ist der Mittelwert nach Temperatur- und
+	// Projectile-Bereinigung (ohne Bereinigung = AvgVelocityMPS)
+	AdjustedVelocityMPS float64 `json:"adjustedVelocityMPS"`
+	TemperatureAdjusted bool    `json:"temperatureAdjusted"`
+
+	Baseline  bool    `json:"baseline"`  // gehört zum Sollwert
+	ZScore    float64 `json:"zScore"`    // (bereinigt - Sollwert) / σ
+	CUSUMLow  float64 `json:"cusumLow"`  // Summe der Abfälle
+	CUSUMHigh float64 `json:"cusumHigh"` // Summe der Anstiege
+
+	// Alerts: TrendAlertDrop, TrendAlertRise, TrendAlertSDIncrease
+	Alerts []string `json:"alerts"`
+}
@@ -0,0 +1,37 @@
+package application
+
+// AnalyzeShotsPerFill legt die Serien aller Sessions eines Profils
+// übereinander, wie SessionService.AnalyzeShotsPerFill für eine Session.
+//
+// Mehr Füllungen glätten die Kurve; das Füllfenster gilt für das Profil.
+// Antrieb und Regler kommen aus dem aktuellen Profil, für ein gelöschtes
+// Profil aus dem jüngsten Snapshot.
+func (s *AnalyticsService) AnalyzeShotsPerFill(profileID string, request ShotsPerFillRequestDTO) Result[ShotsPerFillDTO] {
+	if profileID == "" {
+		return FailWithMessage[ShotsPerFillDTO]("Profil-ID darf nicht leer sein")
+	}
+	tolerance, err := sweetSpotTolerance(request)
+	if err != nil {
+		return Fail[ShotsPerFillDTO](err)
+	}
+
+	name, sessions, err := loadProfileSessions(s.sessionRepo, s.profileRepo, profileID, request.ProjectileID)
+	if err != nil {
+		return Fail[ShotsPerFillDTO](err)
+	}
+
+	profile, err := s.profileRepo.Load(profileID)
+	if err != nil && len(sessions) > 0 {
+		profile = sessions[len(sessions)-1].ProfileSnapshot
+	}
+	result := newShotsPerFill(profile, tolerance)
+	result.ProfileID, result.ProfileName = profileID, name
+	result.SessionCount = len(sessions)
+
+	var strs []fillString
+	for _, session := range sessions {
+		strs = addFillStrings(strs, session, &result)
+	}
+	analyzeShotsPerFill(strs, &result)
+	return OK(result)
+}
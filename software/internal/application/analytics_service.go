@@ -0,0 +1,67 @@
+package application
+
+import (
+	"fmt"
+	"metric-neo/internal/domain/entities"
+	"metric-neo/internal/infrastructure/persistence"
+)
+
+// AnalyticsService wertet Sessions über mehrere Messtage hinweg aus.
+//
+// Der SessionService beantwortet Fragen zu einer Session; hier geht es um
+// den Verlauf eines Sportgeräts: Lässt die Leistung nach (undichtes Ventil,
+// verschlissene Dichtung)? Der Service liest nur, er ändert keine Session.
+type AnalyticsService struct {
+	sessionRepo SessionRepository
+	profileRepo ProfileRepository
+}
+
+// NewAnalyticsService erstellt einen neuen AnalyticsService auf dem JSON-Backend.
+func NewAnalyticsService(dataDir string) *AnalyticsService {
+	return NewAnalyticsServiceWith(NewJSONRepositories(dataDir))
+}
+
+// NewAnalyticsServiceWith erstellt einen AnalyticsService auf beliebigen Repositories.
+func NewAnalyticsServiceWith(repos *Repositories) *AnalyticsService {
+	return &AnalyticsService{
+		sessionRepo: repos.Sessions,
+		profileRepo: repos.Profiles,
+	}
+}
+
+// loadProfileSessions lädt alle Sessions eines Profils, älteste zuerst
+// (projectileID "" = alle Projectiles), und den Namen des Profils.
+//
+// Maßgeblich ist ProfileSnapshot.ID. Ist das Profil gelöscht, kommt der Name
+// aus dem jüngsten Snapshot; ohne Profil und ohne Sessions ist die ID unbekannt.
+func loadProfileSessions(sessionRepo SessionRepository, profileRepo ProfileRepository, profileID, projectileID string) (string, []*entities.Session, error) {
+	entries, _, err := sessionRepo.Query(persistence.SessionQuery{
+		ProfileID:    profileID,
+		ProjectileID: projectileID,
+		OldestFirst:  true,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	var name string
+	profile, err := profileRepo.Load(profileID)
+	if err == nil {
+		name = profile.Name
+	} else if len(entries) == 0 {
+		return "", nil, fmt.Errorf("Profil nicht gefunden: %s", profileID)
+	}
+
+	sessions := make([]*entities.Session, 0, len(entries))
+	for _, entry := range entries {
+		session, err := sessionRepo.Load(entry.ID)
+		if err != nil {
+			return "", nil, err
+		}
+		if profile == nil {
+			name = session.ProfileSnapshot.Name
+		}
+		sessions = append(sessions, session)
+	}
+	return name, sessions, nil
+}
@@ -0,0 +1,203 @@
+package application
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// analyticsFixture legt ein Profil an und zeichnet Sessions auf.
+type analyticsFixture struct {
+	t        *testing.T
+	sessions *SessionService
+	profile  ProfileDTO
+}
+
+func newAnalyticsFixture(t *testing.T, dir string) *analyticsFixture {
+	profile := NewProfileService(dir).CreateProfile("Steyr", "air_rifle", 420.0, 500.0, 50.0)
+	if !profile.Success {
+		t.Fatalf("CreateProfile failed: %s", profile.Error)
+	}
+	return &analyticsFixture{t: t, sessions: NewSessionService(dir), profile: profile.Data}
+}
+
+func (f *analyticsFixture) record(projectileID string, temperature *float64, velocities ...float64) string {
+	f.t.Helper()
+	result := f.sessions.CreateSession(f.profile.ID, projectileID, temperature, "")
+	if !result.Success {
+		f.t.Fatalf("CreateSession failed: %s", result.Error)
+	}
+	for _, v := range velocities {
+		f.sessions.RecordShot(result.Data.ID, v)
+	}
+	return result.Data.ID
+}
+
+func TestAnalyticsService_AnalyzePerformanceTrend(t *testing.T) {
+	dir := t.TempDir()
+	f := newAnalyticsFixture(t, dir)
+	projectile := NewProjectileService(dir).CreateProjectile("JSB", 0.547, 0.024).Data
+	analytics := NewAnalyticsService(dir)
+
+	// Baseline: SD 1 in jeder Session, Mittelwerte 280 ± 0.5
+	f.record(projectile.ID, nil, 279.0, 280.0, 281.0)
+	f.record(projectile.ID, nil, 279.5, 280.5, 281.5)
+	f.record(projectile.ID, nil, 278.5, 279.5, 280.5)
+
+	result := analytics.AnalyzePerformanceTrend(f.profile.ID, TrendRequestDTO{})
+	if !result.Success || result.Data.Status != TrendInsufficient || len(result.Data.Points) != 3 {
+		t.Fatalf("baseline only: %+v (%s)", result.Data, result.Error)
+	}
+
+	stable := f.record(projectile.ID, nil, 279.2, 280.2, 281.2)
+	f.record(projectile.ID, nil) // ohne Schüsse: übersprungen
+	drop := f.record(projectile.ID, nil, 276.0, 277.0, 278.0)
+	f.record(projectile.ID, nil, 275.5, 276.5, 277.5)
+	scatter := f.record(projectile.ID, nil, 266.0, 276.0, 286.0)
+
+	result = analytics.AnalyzePerformanceTrend(f.profile.ID, TrendRequestDTO{})
+	if !result.Success {
+		t.Fatalf("AnalyzePerformanceTrend failed: %s", result.Error)
+	}
+	trend := result.Data
+	if trend.ProfileName != "Steyr" || trend.SkippedSessions != 1 || len(trend.Points) != 7 {
+		t.Fatalf("trend: %+v", trend)
+	}
+	if trend.BaselineMeanMPS != 280.0 || math.Abs(trend.BaselineSDMPS-1.0) > 1e-9 || trend.BetweenSessionMPS != 0 {
+		t.Errorf("baseline = %.2f ± %.2f (between %.2f), want 280 ± 1 (0)",
+			trend.BaselineMeanMPS, trend.BaselineSDMPS, trend.BetweenSessionMPS)
+	}
+
+	// -3 m/s bei σ = 1/√3: die erste langsame Session schlägt an
+	if trend.Status != TrendDrop || trend.DropDetectedSessionID != drop || trend.DropStartSessionID != drop {
+		t.Errorf("drop: status %s, detected %s, start %s, want %s", trend.Status, trend.DropDetectedSessionID, trend.DropStartSessionID, drop)
+	}
+	for _, point := range trend.Points {
+		switch point.SessionID {
+		case stable:
+			if len(point.Alerts) != 0 || point.CUSUMLow != 0 {
+				t.Errorf("stable session: %+v", point)
+			}
+		case scatter:
+			if !slices.Contains(point.Alerts, TrendAlertSDIncrease) || !slices.Contains(point.Alerts, TrendAlertDrop) {
+				t.Errorf("scatter alerts = %v, want drop and sd_increase", point.Alerts)
+			}
+		}
+	}
+
+	if r := analytics.AnalyzePerformanceTrend(f.profile.ID, TrendRequestDTO{BaselineSessions: 1}); r.Success {
+		t.Error("baseline of 1 session should fail")
+	}
+	if r := analytics.AnalyzePerformanceTrend("unknown", TrendRequestDTO{}); r.Success {
+		t.Error("unknown profile should fail")
+	}
+}
+
+func TestAnalyticsService_TrendNormalization(t *testing.T) {
+	dir := t.TempDir()
+	f := newAnalyticsFixture(t, dir)
+	projectiles := NewProjectileService(dir)
+	jsb := projectiles.CreateProjectile("JSB", 0.547, 0.024).Data
+	heavy := projectiles.CreateProjectile("Baracuda", 0.691, 0.030).Data
+	analytics := NewAnalyticsService(dir)
+
+	// Das schwere Diabolo ist 10 % langsamer - die Waffe ist unverändert
+	for i := 0; i < 3; i++ {
+		f.record(jsb.ID, nil, 279.0, 280.0, 281.0)
+		f.record(heavy.ID, nil, 251.1, 252.0, 252.9)
+	}
+
+	mixed := analytics.AnalyzePerformanceTrend(f.profile.ID, TrendRequestDTO{})
+	// Ohne Bereinigung landet der Unterschied in der Streuung von Tag zu Tag
+	if !mixed.Success || mixed.Data.BetweenSessionMPS < 10 {
+		t.Errorf("unnormalized between-session SD = %.2f, want > 10", mixed.Data.BetweenSessionMPS)
+	}
+	normalized := analytics.AnalyzePerformanceTrend(f.profile.ID, TrendRequestDTO{NormalizeProjectile: true})
+	if !normalized.Success || normalized.Data.Status != TrendStable || normalized.Data.BetweenSessionMPS != 0 {
+		t.Errorf("normalized: status %s, between-session SD %.2f, want stable and 0",
+			normalized.Data.Status, normalized.Data.BetweenSessionMPS)
+	}
+	if got := normalized.Data.Points[1].AdjustedVelocityMPS; math.Abs(got-280.0) > 1e-9 {
+		t.Errorf("normalized heavy pellet = %.3f, want 280", got)
+	}
+
+	only := analytics.AnalyzePerformanceTrend(f.profile.ID, TrendRequestDTO{ProjectileID: heavy.ID})
+	if len(only.Data.Points) != 3 || only.Data.Points[0].ProjectileName != "Baracuda" {
+		t.Errorf("projectile filter: %+v", only.Data.Points)
+	}
+
+	// 0.2 %/°C: bei 25 °C 2 % schneller als bei 15 °C
+	temp := 25.0
+	id := f.record(jsb.ID, &temp, 285.6)
+	coefficient := 0.2
+	adjusted := analytics.AnalyzePerformanceTrend(f.profile.ID, TrendRequestDTO{TemperatureCoefficient: &coefficient})
+	last := adjusted.Data.Points[len(adjusted.Data.Points)-1]
+	if last.SessionID != id || !last.TemperatureAdjusted || math.Abs(last.AdjustedVelocityMPS-280.0) > 1e-9 {
+		t.Errorf("temperature adjusted: %+v", last)
+	}
+}
+
+func TestAnalyticsService_AnalyzeShotsPerFill(t *testing.T) {
+	dir := t.TempDir()
+	f := newAnalyticsFixture(t, dir)
+	profiles := NewProfileService(dir)
+	regulator := 150.0
+	if r := profiles.SetPowerPlant(f.profile.ID, "pcp", &regulator); !r.Success {
+		t.Fatalf("SetPowerPlant failed: %s", r.Error)
+	}
+	projectile := NewProjectileService(dir).CreateProjectile("JSB", 0.547, 0.024).Data
+	analytics := NewAnalyticsService(dir)
+
+	// Eine Füllung je Session, 250 -> 190 bar in 6 Schüssen
+	end := 190.0
+	fill := &FillDTO{StartPressureBar: 250, EndPressureBar: &end}
+	first := f.record(projectile.ID, nil, 270.0, 278.0, 280.0, 281.0, 280.0, 272.0)
+	second := f.record(projectile.ID, nil, 272.0, 279.0, 281.0, 280.0, 281.0, 270.0)
+
+	single := f.sessions.AnalyzeShotsPerFill(first, ShotsPerFillRequestDTO{}).Data
+	if single.PowerPlant != "pcp" || !slices.Equal(single.Warnings, []string{FillWarningNoFillPressures, FillWarningSingleString}) {
+		t.Errorf("without pressures: %q, warnings %v", single.PowerPlant, single.Warnings)
+	}
+
+	for _, id := range []string{first, second} {
+		session := f.sessions.SetStringFill(id, 1, fill)
+		if !session.Success || len(session.Data.Strings) != 1 || *session.Data.Strings[0].Fill.DropPerShotBar != 10 {
+			t.Fatalf("SetStringFill failed: %+v (%s)", session.Data.Strings, session.Error)
+		}
+	}
+
+	result := analytics.AnalyzeShotsPerFill(f.profile.ID, ShotsPerFillRequestDTO{})
+	if !result.Success {
+		t.Fatalf("AnalyzeShotsPerFill failed: %s", result.Error)
+	}
+	dto := result.Data
+	if dto.SessionCount != 2 || len(dto.Strings) != 2 || dto.Strings[1].SessionID != second || len(dto.Warnings) != 0 {
+		t.Errorf("sessions = %d, strings %+v, warnings %v", dto.SessionCount, dto.Strings, dto.Warnings)
+	}
+	if p := dto.Curve[0].PressureBar; p == nil || *p != 250 || *dto.Curve[5].PressureBar != 200 {
+		t.Errorf("curve pressures = %+v", dto.Curve)
+	}
+	spot, window := dto.SweetSpot, dto.FillWindow
+	if spot == nil || spot.FirstShot != 2 || spot.LastShot != 5 || window == nil ||
+		window.FillPressureBar != 240 || window.RefillPressureBar != 200 || window.PressurePerShotBar != 10 || window.Strings != 2 {
+		t.Errorf("sweet spot = %+v, fill window %+v", spot, window)
+	}
+
+	// Regler über dem Nachfülldruck, dann Federantrieb
+	regulator = 210.0
+	profiles.SetPowerPlant(f.profile.ID, "pcp", &regulator)
+	if w := analytics.AnalyzeShotsPerFill(f.profile.ID, ShotsPerFillRequestDTO{}).Data.Warnings; !slices.Equal(w, []string{FillWarningBelowRegulator}) {
+		t.Errorf("regulated warnings = %v", w)
+	}
+	profiles.SetPowerPlant(f.profile.ID, "spring", nil)
+	if w := analytics.AnalyzeShotsPerFill(f.profile.ID, ShotsPerFillRequestDTO{}).Data.Warnings; !slices.Equal(w, []string{FillWarningNotPCP}) {
+		t.Errorf("spring warnings = %v", w)
+	}
+
+	if r := analytics.AnalyzeShotsPerFill("unknown", ShotsPerFillRequestDTO{}); r.Success {
+		t.Error("unknown profile should fail")
+	}
+	if r := f.sessions.SetStringFill(first, 1, &FillDTO{StartPressureBar: 150, EndPressureBar: &end}); r.Success {
+		t.Error("an end pressure above the start pressure should fail")
+	}
+}
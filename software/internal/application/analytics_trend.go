@@ -0,0 +1,306 @@
+package application
+
+import (
+	"math"
+	"metric-neo/internal/domain/entities"
+	"metric-neo/internal/domain/valueobjects"
+	"time"
+)
+
+// Leistungstrend eines Profils über alle Sessions.
+//
+// Jede Session wird zu einem Punkt (Mittelwert, SD, ES). Die ersten
+// Sessions bilden den Sollwert; danach summiert eine CUSUM (kumulierte
+// Summe, Page 1954) die Abweichungen. Einzelne schlechte Tage gleichen
+// sich aus, ein anhaltender Abfall um ein σ schlägt nach wenigen Sessions
+// an - das typische Bild eines undichten PCP-Ventils.
+//
+// σ eines Session-Mittelwerts hat zwei Anteile: die Streuung innerhalb der
+// Session (SD/√n) und die von Tag zu Tag (Wetter, Füllung, Einschießen).
+// Ohne den zweiten Anteil würde jede Session mit vielen Schüssen Alarm
+// schlagen.
+
+const (
+	// TrendStable: kein Alarm
+	TrendStable = "stable"
+	// TrendDrop: die untere CUSUM hat angeschlagen
+	TrendDrop = "drop"
+	// TrendRise: die obere CUSUM hat angeschlagen (z.B. Regler driftet)
+	TrendRise = "rise"
+	// TrendInsufficient: zu wenige Sessions für Sollwert und Prüfung
+	TrendInsufficient = "insufficient"
+)
+
+const (
+	// TrendAlertDrop: untere CUSUM über h
+	TrendAlertDrop = "drop"
+	// TrendAlertRise: obere CUSUM über h
+	TrendAlertRise = "rise"
+	// TrendAlertSDIncrease: SD der Session liegt über dem 99-%-Quantil der
+	// Baseline-SD (Chi-Quadrat-Test) - die Waffe streut mehr
+	TrendAlertSDIncrease = "sd_increase"
+)
+
+// DefaultTrendBaselineSessions ist die Zahl der Sessions für den Sollwert.
+const DefaultTrendBaselineSessions = 3
+
+const (
+	// CUSUM-Parameter nach Montgomery: k = 0.5σ (erkennt Verschiebungen ab
+	// 1σ), h = 4σ (im Mittel ein Fehlalarm in ~170 stabilen Sessions)
+	trendCUSUMK = 0.5
+	trendCUSUMH = 4.0
+
+	trendSDAlpha = 0.01
+)
+
+// trendSample ist ein Punkt der Zeitreihe samt Rechengrößen.
+type trendSample struct {
+	point     TrendPointDTO
+	createdAt time.Time
+	n         int
+	sd        float64 // bereinigt wie point.AdjustedVelocityMPS
+}
+
+// AnalyzePerformanceTrend wertet alle Sessions eines Profils aus.
+//
+// Grundlage sind die Snapshots: Zählt ProfileSnapshot.ID, nicht das
+// aktuelle Profil - auch Sessions eines inzwischen geänderten Profils.
+func (s *AnalyticsService) AnalyzePerformanceTrend(profileID string, request TrendRequestDTO) Result[PerformanceTrendDTO] {
+	if profileID == "" {
+		return FailWithMessage[PerformanceTrendDTO]("Profil-ID darf nicht leer sein")
+	}
+	baseline := request.BaselineSessions
+	if baseline == 0 {
+		baseline = DefaultTrendBaselineSessions
+	}
+	if baseline < 2 {
+		return FailWithMessage[PerformanceTrendDTO]("Baseline braucht mindestens 2 Sessions")
+	}
+
+	name, sessions, err := loadProfileSessions(s.sessionRepo, s.profileRepo, profileID, request.ProjectileID)
+	if err != nil {
+		return Fail[PerformanceTrendDTO](err)
+	}
+
+	dto := PerformanceTrendDTO{
+		ProfileID:        profileID,
+		ProfileName:      name,
+		Status:           TrendInsufficient,
+		BaselineSessions: baseline,
+		CUSUMK:           trendCUSUMK,
+		CUSUMH:           trendCUSUMH,
+		Points:           []TrendPointDTO{},
+	}
+
+	var samples []trendSample
+	for _, session := range sessions {
+		sample, ok := newTrendSample(session, request.TemperatureCoefficient)
+		if !ok {
+			dto.SkippedSessions++
+			continue
+		}
+		samples = append(samples, sample)
+	}
+
+	if request.NormalizeProjectile {
+		normalizeProjectiles(samples, baseline)
+	}
+	for i := range samples {
+		samples[i].point.Baseline = i < baseline
+	}
+
+	analyzeTrend(samples, baseline, &dto)
+	for _, sample := range samples {
+		dto.Points = append(dto.Points, sample.point)
+	}
+	return OK(dto)
+}
+
+// newTrendSample fasst die gültigen Schüsse einer Session zusammen.
+// ok = false ohne gültige Schüsse.
+//
+// Mit tempCoefficient (% pro °C) wird auf 15 °C umgerechnet: gemessen wird
+// v·(1 + c·ΔT), die Bereinigung teilt den Faktor wieder heraus.
+func newTrendSample(session *entities.Session, tempCoefficient *float64) (trendSample, bool) {
+	var velocities []float64
+	for _, shot := range session.Shots {
+		if shot.Valid {
+			velocities = append(velocities, shot.Velocity.MetersPerSecond())
+		}
+	}
+	if len(velocities) == 0 {
+		return trendSample{}, false
+	}
+
+	mean, sd := meanAndSampleSD(velocities)
+	minimum, maximum := velocities[0], velocities[0]
+	for _, v := range velocities {
+		minimum, maximum = math.Min(minimum, v), math.Max(maximum, v)
+	}
+
+	point := TrendPointDTO{
+		SessionID:           session.ID,
+		CreatedAt:           session.CreatedAt.Format(time.RFC3339),
+		ProjectileID:        session.ProjectileSnapshot.ID,
+		ProjectileName:      session.ProjectileSnapshot.Name,
+		ValidShotCount:      len(velocities),
+		AvgVelocityMPS:      mean,
+		StandardDeviation:   sd,
+		ExtremeSpread:       maximum - minimum,
+		AdjustedVelocityMPS: mean,
+		Alerts:              []string{},
+	}
+	adjustedSD := sd
+
+	if session.Temperature != nil {
+		celsius := session.Temperature.Celsius()
+		point.TemperatureCelsius = &celsius
+		if tempCoefficient != nil {
+			factor := 1 + *tempCoefficient/100*(celsius-valueobjects.StandardTemperatureCelsius)
+			if factor > 0 {
+				point.AdjustedVelocityMPS /= factor
+				adjustedSD /= factor
+				point.TemperatureAdjusted = true
+			}
+		}
+	}
+
+	return trendSample{
+		point:     point,
+		createdAt: session.CreatedAt,
+		n:         len(velocities),
+		sd:        adjustedSD,
+	}, true
+}
+
+// normalizeProjectiles rechnet alle Sessions auf das Projectile der ersten
+// Session um. Der Faktor je Projectile ist das Verhältnis der Mittelwerte
+// seiner ersten baseline Sessions - ein leichteres Diabolo ist schneller,
+// ohne dass sich an der Waffe etwas geändert hätte.
+func normalizeProjectiles(samples []trendSample, baseline int) {
+	if len(samples) == 0 {
+		return
+	}
+
+	sums := map[string]float64{}
+	counts := map[string]int{}
+	for _, sample := range samples {
+		id := sample.point.ProjectileID
+		if counts[id] < baseline {
+			sums[id] += sample.point.AdjustedVelocityMPS
+			counts[id]++
+		}
+	}
+
+	reference := samples[0].point.ProjectileID
+	referenceMean := sums[reference] / float64(counts[reference])
+	for i := range samples {
+		id := samples[i].point.ProjectileID
+		factor := referenceMean / (sums[id] / float64(counts[id]))
+		samples[i].point.AdjustedVelocityMPS *= factor
+		samples[i].sd *= factor
+	}
+}
+
+// analyzeTrend bestimmt Sollwert, CUSUM und Alarme.
+func analyzeTrend(samples []trendSample, baseline int, dto *PerformanceTrendDTO) {
+	dto.SlopeMPSPerDay = trendSlope(samples)
+	if len(samples) <= baseline {
+		return
+	}
+
+	// Sollwert und σ-Anteile aus den Baseline-Sessions
+	var means []float64
+	var pooled, df, withinOfMean float64
+	for _, sample := range samples[:baseline] {
+		means = append(means, sample.point.AdjustedVelocityMPS)
+		pooled += float64(sample.n-1) * sample.sd * sample.sd
+		df += float64(sample.n - 1)
+	}
+	if df == 0 {
+		return
+	}
+	pooled /= df
+	for _, sample := range samples[:baseline] {
+		withinOfMean += pooled / float64(sample.n)
+	}
+	withinOfMean /= float64(baseline)
+
+	target, meansSD := meanAndSampleSD(means)
+	between := math.Max(0, meansSD*meansSD-withinOfMean)
+	if pooled == 0 && between == 0 {
+		return
+	}
+
+	dto.BaselineMeanMPS = target
+	dto.BaselineSDMPS = math.Sqrt(pooled)
+	dto.BetweenSessionMPS = math.Sqrt(between)
+	dto.Status = TrendStable
+
+	var low, high float64
+	lastZero := -1 // letzte Session mit CUSUMLow = 0
+	for i := range samples {
+		point := &samples[i].point
+		sigma := math.Sqrt(between + pooled/float64(samples[i].n))
+		point.ZScore = (point.AdjustedVelocityMPS - target) / sigma
+
+		low = math.Max(0, low-point.ZScore-trendCUSUMK)
+		high = math.Max(0, high+point.ZScore-trendCUSUMK)
+		point.CUSUMLow, point.CUSUMHigh = low, high
+
+		if low == 0 {
+			lastZero = i
+		}
+		if low > trendCUSUMH {
+			point.Alerts = append(point.Alerts, TrendAlertDrop)
+			if dto.DropDetectedSessionID == "" {
+				dto.DropDetectedSessionID = point.SessionID
+				dto.DropStartSessionID = samples[lastZero+1].point.SessionID
+			}
+			dto.Status = TrendDrop
+		}
+		if high > trendCUSUMH {
+			point.Alerts = append(point.Alerts, TrendAlertRise)
+			if dto.Status == TrendStable {
+				dto.Status = TrendRise
+			}
+		}
+
+		// (n-1)·s²/σ² ist Chi-Quadrat-verteilt; die Baseline-SD gilt als bekannt
+		n := samples[i].n
+		if !point.Baseline && n >= 2 && pooled > 0 {
+			statistic := float64(n-1) * samples[i].sd * samples[i].sd / pooled
+			if statistic > chiSquareQuantile(1-trendSDAlpha, n-1) {
+				point.Alerts = append(point.Alerts, TrendAlertSDIncrease)
+			}
+		}
+	}
+}
+
+// trendSlope ist die Steigung der Regressionsgeraden (m/s pro Tag) der
+// bereinigten Mittelwerte. nil, wenn die Sessions keinen Tag auseinanderliegen.
+func trendSlope(samples []trendSample) *float64 {
+	if len(samples) < 2 {
+		return nil
+	}
+	first := samples[0].createdAt
+	if samples[len(samples)-1].createdAt.Sub(first) < 24*time.Hour {
+		return nil
+	}
+
+	var days, velocities []float64
+	for _, sample := range samples {
+		days = append(days, sample.createdAt.Sub(first).Hours()/24)
+		velocities = append(velocities, sample.point.AdjustedVelocityMPS)
+	}
+	meanDay, _ := meanAndSampleSD(days)
+	meanVelocity, _ := meanAndSampleSD(velocities)
+
+	var sxy, sxx float64
+	for i := range days {
+		sxy += (days[i] - meanDay) * (velocities[i] - meanVelocity)
+		sxx += (days[i] - meanDay) * (days[i] - meanDay)
+	}
+	slope := sxy / sxx
+	return &slope
+}
@@ -0,0 +1,274 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"metric-neo/internal/domain/valueobjects"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event-Namen der Live-Aufnahme (Wails runtime.EventsEmit).
+const (
+	// ShotRecordedEvent: Schuss wurde in der scharfen Session gespeichert
+	ShotRecordedEvent = "shot:recorded"
+	// ShotMeasuredEvent: Messwert empfangen, aber nicht gespeichert (AutoRecord aus)
+	ShotMeasuredEvent = "shot:measured"
+	// CaptureErrorEvent: Lese- oder Speicherfehler während der Aufnahme
+	CaptureErrorEvent = "capture:error"
+)
+
+// ShotRecordedDTO ist die Nutzlast von "shot:recorded".
+// Enthält die aktualisierte Session und frische Statistiken, damit das
+// Frontend nichts nachladen muss.
+type ShotRecordedDTO struct {
+	SessionID   string        `json:"sessionId"`
+	VelocityMPS float64       `json:"velocityMPS"`
+	Session     SessionDTO    `json:"session"`
+	Statistics  StatisticsDTO `json:"statistics"`
+}
+
+// ShotMeasuredDTO ist die Nutzlast von "shot:measured".
+type ShotMeasuredDTO struct {
+	SessionID   string  `json:"sessionId"`
+	VelocityMPS float64 `json:"velocityMPS"`
+}
+
+// CaptureErrorDTO ist die Nutzlast von "capture:error".
+type CaptureErrorDTO struct {
+	SessionID string `json:"sessionId"`
+	Error     string `json:"error"`
+}
+
+// CaptureStatusDTO beschreibt, ob und für welche Session aufgenommen wird.
+type CaptureStatusDTO struct {
+	Armed      bool   `json:"armed"`
+	SessionID  string `json:"sessionId,omitempty"`
+	AutoRecord bool   `json:"autoRecord"`
+}
+
+// VelocitySource liefert Messwerte, z.B. chrono.Supervisor.
+// Nach Stop() darf die Quelle nicht mehr in die Channels schreiben.
+type VelocitySource interface {
+	Start(velocityChannel chan<- float32, errorChannel chan<- error)
+	Stop()
+}
+
+// EmitFunc sendet ein Event an den Aufrufer (Frontend, CLI).
+type EmitFunc func(name string, data interface{})
+
+// defaultSaveRetryInterval ist der Abstand, in dem ungespeicherte Schüsse
+// erneut gespeichert werden (z.B. Datenträger kurz voll oder gesperrt).
+const defaultSaveRetryInterval = 2 * time.Second
+
+// CaptureService nimmt Schüsse einer Messquelle in eine "scharfe" Session auf.
+//
+// Das Backend besitzt die Aufnahmeschleife: Jeder Messwert wird sofort per
+// SessionService.RecordShot gespeichert und danach als Event gemeldet.
+// Ist das Frontend beschäftigt, geht kein Schuss verloren - gespeichert
+// ist er bereits, das Event ist nur die Benachrichtigung. Schlägt das
+// Speichern fehl, bleibt der Schuss in der Schleife und wird in
+// Aufnahmereihenfolge erneut gespeichert.
+//
+// GO-KONZEPT: Besitz von Channels
+// Arm() erstellt die Channels, die Quelle schreibt hinein, die Schleife liest.
+// Disarm() stoppt erst die Quelle, schließt dann die Channels - die Schleife
+// speichert noch gepufferte Messwerte und endet, wenn beide leer sind.
+type CaptureService struct {
+	sessions *SessionService
+	emit     EmitFunc
+
+	// retryInterval: Abstand der Speicherversuche für ungespeicherte Schüsse
+	retryInterval time.Duration
+
+	// armMu serialisiert Arm/Disarm (Disarm wartet auf die Schleife)
+	armMu sync.Mutex
+
+	mu     sync.Mutex
+	status CaptureStatusDTO
+	source VelocitySource
+	done   chan struct{}
+	// velocities/errs gehören zur laufenden Aufnahme
+	velocities chan float32
+	errs       chan error
+}
+
+// NewCaptureService erstellt einen CaptureService.
+// emit darf nil sein (dann werden keine Events gesendet).
+func NewCaptureService(sessions *SessionService, emit EmitFunc) *CaptureService {
+	if emit == nil {
+		emit = func(string, interface{}) {}
+	}
+	return &CaptureService{sessions: sessions, emit: emit, retryInterval: defaultSaveRetryInterval}
+}
+
+// Arm macht eine Session scharf und startet die Quelle.
+//
+// Ist bereits eine Session scharf, wird deren Aufnahme zuerst beendet.
+// autoRecord=false meldet Messwerte nur (ShotMeasuredEvent), ohne sie zu speichern.
+func (c *CaptureService) Arm(sessionID string, autoRecord bool, source VelocitySource) Result[CaptureStatusDTO] {
+	if sessionID == "" {
+		return FailWithMessage[CaptureStatusDTO]("Session-ID darf nicht leer sein")
+	}
+	if source == nil {
+		return FailWithMessage[CaptureStatusDTO]("Keine Messquelle angegeben")
+	}
+	if result := c.sessions.LoadSession(sessionID); !result.Success {
+		return FailWithMessage[CaptureStatusDTO](result.Error)
+	}
+
+	c.armMu.Lock()
+	defer c.armMu.Unlock()
+
+	c.disarm()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.status = CaptureStatusDTO{Armed: true, SessionID: sessionID, AutoRecord: autoRecord}
+	c.source = source
+	c.velocities = make(chan float32, 64)
+	c.errs = make(chan error, 16)
+	c.done = make(chan struct{})
+
+	go c.loop(c.status, c.velocities, c.errs, c.done)
+	source.Start(c.velocities, c.errs)
+
+	return OK(c.status)
+}
+
+// Disarm beendet die Aufnahme. Bereits empfangene Messwerte werden noch gespeichert.
+// Ohne scharfe Session ist Disarm wirkungslos.
+func (c *CaptureService) Disarm() Result[CaptureStatusDTO] {
+	c.armMu.Lock()
+	defer c.armMu.Unlock()
+
+	c.disarm()
+	return OK(c.Status())
+}
+
+func (c *CaptureService) disarm() {
+	c.mu.Lock()
+	source, done := c.source, c.done
+	velocities, errs := c.velocities, c.errs
+	c.source, c.done, c.velocities, c.errs = nil, nil, nil, nil
+	c.status = CaptureStatusDTO{}
+	c.mu.Unlock()
+
+	if source != nil {
+		source.Stop()
+		close(velocities)
+		close(errs)
+		<-done
+	}
+}
+
+// Status gibt den aktuellen Aufnahmestatus zurück.
+func (c *CaptureService) Status() CaptureStatusDTO {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.status
+}
+
+// loop verarbeitet Messwerte und Fehler, bis beide Channels geschlossen sind.
+func (c *CaptureService) loop(status CaptureStatusDTO, velocities <-chan float32, errs <-chan error, done chan<- struct{}) {
+	defer close(done)
+
+	// pending hält Schüsse, deren Speichern fehlschlug, in Aufnahmereihenfolge
+	var pending []float64
+	var retry <-chan time.Time
+	for velocities != nil || errs != nil {
+		select {
+		case velocity, ok := <-velocities:
+			if !ok {
+				velocities = nil
+				continue
+			}
+			pending = c.handleVelocity(status, float64(velocity), pending)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			c.emitError(status, err.Error())
+		case <-retry:
+			retry = nil
+			pending, _ = c.recordPending(status, pending)
+		}
+
+		if len(pending) == 0 {
+			retry = nil
+		} else if retry == nil {
+			retry = time.After(c.retryInterval)
+		}
+	}
+
+	// Aufnahme beendet: letzter Versuch, danach die Werte melden, damit sie
+	// von Hand nachgetragen werden können
+	if pending, err := c.recordPending(status, pending); err != nil {
+		values := make([]string, len(pending))
+		for i, v := range pending {
+			values[i] = fmt.Sprintf("%.2f", v)
+		}
+		c.emitError(status, fmt.Sprintf("%d Schüsse nicht gespeichert (%s m/s): %v", len(pending), strings.Join(values, ", "), err))
+	}
+}
+
+// handleVelocity speichert einen Messwert nach den noch ausstehenden und
+// gibt zurück, was weiterhin ungespeichert ist.
+func (c *CaptureService) handleVelocity(status CaptureStatusDTO, velocityMPS float64, pending []float64) []float64 {
+	if !status.AutoRecord {
+		c.emit(ShotMeasuredEvent, ShotMeasuredDTO{SessionID: status.SessionID, VelocityMPS: velocityMPS})
+		return pending
+	}
+
+	// Ein ungültiger Messwert wird nie speicherbar: melden statt wiederholen
+	if _, err := valueobjects.NewVelocity(velocityMPS); err != nil {
+		c.emitError(status, err.Error())
+		return pending
+	}
+
+	pending, err := c.recordPending(status, append(pending, velocityMPS))
+	if err != nil {
+		c.emitError(status, fmt.Sprintf("Schuss nicht gespeichert, %d ausstehend (neuer Versuch folgt): %v", len(pending), err))
+	}
+	return pending
+}
+
+// recordPending speichert die Schüsse der Reihe nach. Beim ersten Fehler
+// bricht es ab, damit die Reihenfolge erhalten bleibt, und gibt die
+// ungespeicherten Schüsse mit dem Fehler zurück.
+func (c *CaptureService) recordPending(status CaptureStatusDTO, pending []float64) ([]float64, error) {
+	for len(pending) > 0 {
+		if err := c.recordShot(status, pending[0]); err != nil {
+			return pending, err
+		}
+		pending = pending[1:]
+	}
+	return nil, nil
+}
+
+// recordShot speichert einen Schuss und meldet ihn mit frischen Statistiken.
+func (c *CaptureService) recordShot(status CaptureStatusDTO, velocityMPS float64) error {
+	recorded := c.sessions.RecordShot(status.SessionID, velocityMPS)
+	if !recorded.Success {
+		return errors.New(recorded.Error)
+	}
+
+	dto := ShotRecordedDTO{
+		SessionID:   status.SessionID,
+		VelocityMPS: velocityMPS,
+		Session:     recorded.Data,
+	}
+	if stats := c.sessions.GetStatistics(status.SessionID); stats.Success {
+		dto.Statistics = stats.Data
+	}
+	c.emit(ShotRecordedEvent, dto)
+	return nil
+}
+
+func (c *CaptureService) emitError(status CaptureStatusDTO, message string) {
+	c.emit(CaptureErrorEvent, CaptureErrorDTO{SessionID: status.SessionID, Error: message})
+}
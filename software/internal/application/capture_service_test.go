@@ -0,0 +1,210 @@
+package application
+
+import (
+	"errors"
+	"metric-neo/internal/domain/entities"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSource liefert vorgegebene Messwerte, sobald Start aufgerufen wird.
+type fakeSource struct {
+	velocities []float32
+	errs       []error
+	stopped    bool
+}
+
+func (f *fakeSource) Start(velocityChannel chan<- float32, errorChannel chan<- error) {
+	for _, err := range f.errs {
+		errorChannel <- err
+	}
+	for _, v := range f.velocities {
+		velocityChannel <- v
+	}
+}
+
+func (f *fakeSource) Stop() {
+	f.stopped = true
+}
+
+// eventLog sammelt Events aus der Aufnahmeschleife.
+type eventLog struct {
+	mu     sync.Mutex
+	events map[string][]interface{}
+}
+
+func (l *eventLog) emit(name string, data interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.events == nil {
+		l.events = map[string][]interface{}{}
+	}
+	l.events[name] = append(l.events[name], data)
+}
+
+func newCaptureTestSession(t *testing.T) (*SessionService, string) {
+	t.Helper()
+	dir := t.TempDir()
+	profile := NewProfileService(dir).CreateProfile("Test Profile", "air_rifle", 420.0, 500.0, 50.0)
+	projectile := NewProjectileService(dir).CreateProjectile("JSB Exact", 0.547, 0.024)
+	sessions := NewSessionService(dir)
+	session := sessions.CreateSession(profile.Data.ID, projectile.Data.ID, nil, "")
+	if !session.Success {
+		t.Fatalf("CreateSession failed: %s", session.Error)
+	}
+	return sessions, session.Data.ID
+}
+
+func TestCaptureService_RecordsEveryShot(t *testing.T) {
+	sessions, sessionID := newCaptureTestSession(t)
+	log := &eventLog{}
+	capture := NewCaptureService(sessions, log.emit)
+
+	// Mehr Schüsse als der Channel puffert, bevor jemand Disarm ruft
+	source := &fakeSource{errs: []error{errors.New("invalid velocity format")}}
+	for i := 0; i < 100; i++ {
+		source.velocities = append(source.velocities, 170+float32(i%10))
+	}
+
+	armed := capture.Arm(sessionID, true, source)
+	if !armed.Success || !armed.Data.Armed || armed.Data.SessionID != sessionID {
+		t.Fatalf("Arm: %+v", armed)
+	}
+	if status := capture.Status(); !status.Armed {
+		t.Error("Status should report armed session")
+	}
+
+	if result := capture.Disarm(); !result.Success || result.Data.Armed {
+		t.Fatalf("Disarm: %+v", result)
+	}
+	if !source.stopped {
+		t.Error("Disarm must stop the source")
+	}
+
+	session := sessions.LoadSession(sessionID)
+	if len(session.Data.Shots) != 100 {
+		t.Errorf("stored shots = %d, want 100", len(session.Data.Shots))
+	}
+
+	recorded := log.events[ShotRecordedEvent]
+	if len(recorded) != 100 {
+		t.Fatalf("shot:recorded events = %d, want 100", len(recorded))
+	}
+	last := recorded[99].(ShotRecordedDTO)
+	if len(last.Session.Shots) != 100 || last.Statistics.TotalShotCount != 100 {
+		t.Errorf("last event: %d shots, stats %d, want 100", len(last.Session.Shots), last.Statistics.TotalShotCount)
+	}
+	if len(log.events[CaptureErrorEvent]) != 1 {
+		t.Errorf("capture:error events = %d, want 1", len(log.events[CaptureErrorEvent]))
+	}
+}
+
+func TestCaptureService_WithoutAutoRecord(t *testing.T) {
+	sessions, sessionID := newCaptureTestSession(t)
+	log := &eventLog{}
+	capture := NewCaptureService(sessions, log.emit)
+
+	capture.Arm(sessionID, false, &fakeSource{velocities: []float32{175.5}})
+	capture.Disarm()
+
+	if shots := sessions.LoadSession(sessionID).Data.Shots; len(shots) != 0 {
+		t.Errorf("stored shots = %d, want 0", len(shots))
+	}
+	measured := log.events[ShotMeasuredEvent]
+	if len(measured) != 1 || measured[0].(ShotMeasuredDTO).VelocityMPS != 175.5 {
+		t.Errorf("shot:measured events = %v", measured)
+	}
+}
+
+func TestCaptureService_ArmValidation(t *testing.T) {
+	sessions, sessionID := newCaptureTestSession(t)
+	capture := NewCaptureService(sessions, nil)
+
+	if result := capture.Arm("does-not-exist", true, &fakeSource{}); result.Success {
+		t.Error("Arm with unknown session should fail")
+	}
+	if result := capture.Arm(sessionID, true, nil); result.Success {
+		t.Error("Arm without source should fail")
+	}
+
+	// Neu scharf schalten beendet die vorherige Aufnahme
+	first := &fakeSource{}
+	capture.Arm(sessionID, true, first)
+	capture.Arm(sessionID, true, &fakeSource{})
+	if !first.stopped {
+		t.Error("re-arming must stop the previous source")
+	}
+	capture.Disarm()
+}
+
+// flakySessionRepo lässt nach passes erfolgreichen die nächsten failures
+// Speichervorgänge scheitern.
+type flakySessionRepo struct {
+	SessionRepository
+	mu       sync.Mutex
+	passes   int
+	failures int
+}
+
+func (r *flakySessionRepo) Save(session *entities.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.passes > 0 {
+		r.passes--
+	} else if r.failures > 0 {
+		r.failures--
+		return errors.New("disk full")
+	}
+	return r.SessionRepository.Save(session)
+}
+
+func (r *flakySessionRepo) fail(n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures = n
+}
+
+func TestCaptureService_RetriesFailedSaves(t *testing.T) {
+	dir := t.TempDir()
+	profile := NewProfileService(dir).CreateProfile("Test Profile", "air_rifle", 420.0, 500.0, 50.0)
+	projectile := NewProjectileService(dir).CreateProjectile("JSB Exact", 0.547, 0.024)
+	repos := NewJSONRepositories(dir)
+	flaky := &flakySessionRepo{SessionRepository: repos.Sessions}
+	repos.Sessions = flaky
+	sessions := NewSessionServiceWith(repos)
+
+	log := &eventLog{}
+	capture := NewCaptureService(sessions, log.emit)
+	capture.retryInterval = time.Millisecond
+
+	// Die ersten beiden Speicherversuche scheitern: kein Schuss geht verloren,
+	// die Reihenfolge bleibt erhalten
+	session := sessions.CreateSession(profile.Data.ID, projectile.Data.ID, nil, "")
+	flaky.fail(2)
+	capture.Arm(session.Data.ID, true, &fakeSource{velocities: []float32{175, 176, 177}})
+	capture.Disarm()
+
+	shots := sessions.LoadSession(session.Data.ID).Data.Shots
+	if len(shots) != 3 || shots[0].VelocityMPS != 175 || shots[2].VelocityMPS != 177 {
+		t.Errorf("stored shots = %+v, want 175, 176, 177", shots)
+	}
+	if len(log.events[CaptureErrorEvent]) == 0 {
+		t.Error("failed save should emit capture:error")
+	}
+
+	// Bleibt das Speichern bis zum Entschärfen kaputt, werden die Werte gemeldet
+	log = &eventLog{}
+	capture = NewCaptureService(sessions, log.emit)
+	capture.retryInterval = time.Millisecond
+	session = sessions.CreateSession(profile.Data.ID, projectile.Data.ID, nil, "")
+	flaky.fail(1000)
+	capture.Arm(session.Data.ID, true, &fakeSource{velocities: []float32{175, 176}})
+	capture.Disarm()
+
+	errs := log.events[CaptureErrorEvent]
+	if len(errs) == 0 || !strings.Contains(errs[len(errs)-1].(CaptureErrorDTO).Error, "2 Schüsse nicht gespeichert (175.00, 176.00 m/s)") {
+		t.Errorf("last capture:error should list the unsaved shots, got %v", errs)
+	}
+}
@@ -0,0 +1,74 @@
+package application
+
+import (
+	"metric-neo/internal/infrastructure/chrono"
+	"time"
+)
+
+// ChronoPortDTO beschreibt einen seriellen Port für die Port-Auswahl.
+type ChronoPortDTO struct {
+	Name         string `json:"name"`
+	IsUSB        bool   `json:"isUSB"`
+	VID          string `json:"vid"`
+	PID          string `json:"pid"`
+	SerialNumber string `json:"serialNumber"`
+	Product      string `json:"product"`
+
+	// Nur bei Probe gesetzt: "detected", "unrecognized", "silent", "unavailable"
+	Status  string `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// DefaultProbeTimeout ist die Lauschzeit pro Port bei der Geräteerkennung.
+const DefaultProbeTimeout = 1500 * time.Millisecond
+
+// DiscoverChronoPorts listet die seriellen Ports des Systems.
+//
+// probe=true öffnet zusätzlich jeden Port mit dem Treiber und lauscht
+// timeout lang (parallel). Erkannte Chronographen stehen vorne.
+func DiscoverChronoPorts(probe bool, driverName string, baudRate int, timeout time.Duration) ([]ChronoPortDTO, error) {
+	ports, err := chrono.ListPorts()
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]ChronoPortDTO, len(ports))
+	for i, p := range ports {
+		dtos[i] = ChronoPortDTO{
+			Name:         p.Name,
+			IsUSB:        p.IsUSB,
+			VID:          p.VID,
+			PID:          p.PID,
+			SerialNumber: p.SerialNumber,
+			Product:      p.Product,
+		}
+	}
+	if !probe {
+		return dtos, nil
+	}
+
+	driver, err := chrono.LookupDriver(driverName)
+	if err != nil {
+		return nil, err
+	}
+	if timeout <= 0 {
+		timeout = DefaultProbeTimeout
+	}
+
+	detected := make([]ChronoPortDTO, 0, len(dtos))
+	others := make([]ChronoPortDTO, 0, len(dtos))
+	for i, result := range chrono.ProbePorts(ports, baudRate, driver, timeout) {
+		dto := dtos[i]
+		dto.Status = string(result.Status)
+		if result.Err != nil {
+			dto.Message = result.Err.Error()
+		}
+		if result.Status == chrono.ProbeDetected {
+			detected = append(detected, dto)
+		} else {
+			others = append(others, dto)
+		}
+	}
+
+	return append(detected, others...), nil
+}
@@ -0,0 +1,35 @@
+package application
+
+import (
+	"metric-neo/internal/infrastructure/chrono"
+	"time"
+)
+
+// ChronoStateEvent ist der Name des Wails-Events bei Zustandswechseln der Chrono-Verbindung.
+const ChronoStateEvent = "chrono:state"
+
+// ChronoStateDTO beschreibt den Verbindungszustand des Chronographen.
+// State: "disconnected", "connecting", "connected" oder "degraded".
+type ChronoStateDTO struct {
+	State     string `json:"state"`
+	Port      string `json:"port"`
+	Attempt   int    `json:"attempt"`
+	RetryInMs int64  `json:"retryInMs"`
+	Error     string `json:"error,omitempty"`
+	Timestamp string `json:"timestamp"`
+}
+
+// NewChronoStateDTO wandelt einen Zustandswechsel des Supervisors in ein DTO um.
+func NewChronoStateDTO(change chrono.StateChange) ChronoStateDTO {
+	dto := ChronoStateDTO{
+		State:     string(change.State),
+		Port:      change.Port,
+		Attempt:   change.Attempt,
+		RetryInMs: change.RetryIn.Milliseconds(),
+		Timestamp: change.At.Format(time.RFC3339),
+	}
+	if change.Err != nil {
+		dto.Error = change.Err.Error()
+	}
+	return dto
+}
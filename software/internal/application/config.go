@@ -2,6 +2,9 @@ package application
 
 import (
 	"encoding/json"
+	"metric-neo/internal/domain/entities"
+	"metric-neo/internal/domain/valueobjects"
+	"metric-neo/internal/infrastructure/persistence"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -16,6 +19,52 @@ type Config struct {
 	ChronoPort       string `json:"chronoPort,omitempty"`
 	ChronoBaudRate   int    `json:"chronoBaudRate,omitempty"`
 	ChronoAutoRecord bool   `json:"chronoAutoRecord,omitempty"`
+	ChronoDriver     string `json:"chronoDriver,omitempty"` // leer = LMBR (siehe chrono.DefaultDriverName)
+
+	// USB-Seriennummer des Chrono-Adapters: findet das Gerät wieder,
+	// wenn sich der Port-Name ändert (/dev/ttyUSB0 -> /dev/ttyUSB1)
+	ChronoSerialNumber string `json:"chronoSerialNumber,omitempty"`
+
+	// Rohdaten jeder Aufnahme in sessions/<id>.wire.log mitschreiben
+	ChronoWireLog bool `json:"chronoWireLog,omitempty"`
+
+	// Energiegrenzen: Vorgabe eines Rechtsraums (z.B. "DE") und eigene
+	// Regeln, die sie je Kategorie ersetzen (siehe entities.ResolveEnergyLimits)
+	EnergyJurisdiction string            `json:"energyJurisdiction,omitempty"`
+	EnergyLimits       []EnergyLimitRule `json:"energyLimits,omitempty"`
+
+	// Anzeige-Einheiten: "metric" (Default), "imperial" oder "mixed"
+	// (siehe units.go). Gespeichert wird immer in SI.
+	UnitSystem string `json:"unitSystem,omitempty"`
+}
+
+// EnergyLimitRule ist eine eigene Energiegrenze für eine Kategorie.
+type EnergyLimitRule struct {
+	Category  string  `json:"category"` // entities.ProfileCategory
+	MaxJoules float64 `json:"maxJoules"`
+	Label     string  `json:"label,omitempty"`
+}
+
+// ResolveEnergyLimits bestimmt die wirksamen Energiegrenzen der Config.
+// nil-Config = keine Grenzen.
+func (cfg *Config) ResolveEnergyLimits() ([]entities.EnergyLimit, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	return resolveEnergyLimits(cfg.EnergyJurisdiction, cfg.EnergyLimits)
+}
+
+// resolveEnergyLimits wandelt die Regeln der Config in Domain-Grenzen um.
+func resolveEnergyLimits(jurisdiction string, rules []EnergyLimitRule) ([]entities.EnergyLimit, error) {
+	custom := make([]entities.EnergyLimit, 0, len(rules))
+	for _, rule := range rules {
+		custom = append(custom, entities.EnergyLimit{
+			Category: entities.ProfileCategory(rule.Category),
+			Max:      valueobjects.Energy(rule.MaxJoules),
+			Label:    rule.Label,
+		})
+	}
+	return entities.ResolveEnergyLimits(jurisdiction, custom)
 }
 
 // GetConfigPath gibt den Pfad zur config.json zurück
@@ -132,7 +181,8 @@ func SaveConfig(cfg *Config) error {
 		return err
 	}
 
-	return os.WriteFile(configPath, data, 0644)
+	// Atomar: ein Absturz beim Speichern darf die Konfiguration nicht leeren
+	return persistence.WriteFileAtomic(configPath, data, 0644)
 }
 
 // CreateConfig erstellt neue Config mit gewähltem Verzeichnis
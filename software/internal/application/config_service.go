@@ -2,8 +2,12 @@ package application
 
 import (
 	"fmt"
+	"metric-neo/internal/domain/entities"
+	"metric-neo/internal/infrastructure/chrono"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 )
 
 // ConfigService verwaltet Setup und Config-Zugriff
@@ -14,6 +18,9 @@ import (
 // - Setup-Status prüfen
 // - Default-Verzeichnisse vorschlagen
 type ConfigService struct {
+	// mu schützt config: Der Chrono-Supervisor merkt sich neue Ports auf
+	// seiner eigenen Goroutine, während Bindings die Einstellungen ändern.
+	mu     sync.Mutex
 	config *Config
 }
 
@@ -23,6 +30,79 @@ type ChronoConfigDTO struct {
 	Port       string `json:"port"`
 	BaudRate   int    `json:"baudRate"`
 	AutoRecord bool   `json:"autoRecord"`
+	Driver     string `json:"driver"` // Name aus ListChronoDrivers()
+
+	// SerialNumber merkt sich den USB-Adapter (leer = nur Port verwenden)
+	SerialNumber string `json:"serialNumber"`
+
+	// WireLog schreibt die empfangenen Rohdaten pro Session mit (Replay, Fehlersuche)
+	WireLog bool `json:"wireLog"`
+}
+
+// EnergyLimitsConfigDTO kapselt die Energiegrenzen für UI/Bindings.
+type EnergyLimitsConfigDTO struct {
+	Jurisdiction string            `json:"jurisdiction"` // "" = keine Vorgabe
+	Rules        []EnergyLimitRule `json:"rules"`        // eigene Regeln
+
+	// Effective sind die wirksamen Grenzen (Vorgabe + eigene Regeln, nur lesend)
+	Effective []EnergyLimitRule `json:"effective"`
+}
+
+// EnergyJurisdictionDTO beschreibt die Vorgabe eines Rechtsraums für die Auswahl in der UI.
+type EnergyJurisdictionDTO struct {
+	Code   string            `json:"code"` // z.B. "DE"
+	Limits []EnergyLimitRule `json:"limits"`
+}
+
+// ListEnergyJurisdictions gibt alle Rechtsräume mit ihren Grenzen zurück.
+func ListEnergyJurisdictions() []EnergyJurisdictionDTO {
+	codes := entities.Jurisdictions()
+	dtos := make([]EnergyJurisdictionDTO, 0, len(codes))
+	for _, code := range codes {
+		dtos = append(dtos, EnergyJurisdictionDTO{
+			Code:   code,
+			Limits: energyLimitRules(entities.EnergyLimitPresets[code]),
+		})
+	}
+	return dtos
+}
+
+// energyLimitRules wandelt Domain-Grenzen in Regeln für die UI um.
+func energyLimitRules(limits []entities.EnergyLimit) []EnergyLimitRule {
+	rules := make([]EnergyLimitRule, 0, len(limits))
+	for _, limit := range limits {
+		rules = append(rules, EnergyLimitRule{
+			Category:  string(limit.Category),
+			MaxJoules: limit.Max.Joules(),
+			Label:     limit.Label,
+		})
+	}
+	return rules
+}
+
+// ChronoDriverDTO beschreibt einen Chronograph-Treiber für die Auswahl in der UI.
+type ChronoDriverDTO struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Unit        string `json:"unit"`     // "m/s" oder "fps"
+	BaudRate    int    `json:"baudRate"` // Default des Geräts
+	Mode        string `json:"mode"`     // z.B. "8N1"
+}
+
+// ListChronoDrivers gibt alle registrierten Chronograph-Treiber zurück.
+func ListChronoDrivers() []ChronoDriverDTO {
+	drivers := chrono.Drivers()
+	dtos := make([]ChronoDriverDTO, 0, len(drivers))
+	for _, d := range drivers {
+		dtos = append(dtos, ChronoDriverDTO{
+			Name:        d.Name,
+			Description: d.Description,
+			Unit:        string(d.Unit),
+			BaudRate:    d.BaudRate,
+			Mode:        d.ModeString(),
+		})
+	}
+	return dtos
 }
 
 // NewConfigService erstellt neuen ConfigService
@@ -42,6 +122,8 @@ func NewConfigService() (*ConfigService, error) {
 
 // NeedsSetup prüft ob Initial-Setup benötigt wird
 func (s *ConfigService) NeedsSetup() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return s.config == nil
 }
 
@@ -93,19 +175,25 @@ func (s *ConfigService) CompleteSetup(dataDir string) error {
 		}
 	}
 
+	s.mu.Lock()
 	s.config = cfg
+	s.mu.Unlock()
 	return nil
 }
 
-// GetConfig gibt die geladene Config zurück
+// GetConfig gibt eine Kopie der geladenen Config zurück
 //
 // Go-Pattern: Getter mit Validierung
 // Panics wenn Setup nicht abgeschlossen (Caller-Fehler)
+// Die Kopie bleibt gültig, auch wenn die Config danach geändert wird.
 func (s *ConfigService) GetConfig() *Config {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.config == nil {
 		panic("config not initialized - setup must be completed first")
 	}
-	return s.config
+	cfg := *s.config
+	return &cfg
 }
 
 // GetDataDir gibt das konfigurierte Daten-Verzeichnis zurück
@@ -119,12 +207,16 @@ func (s *ConfigService) GetDataDir() string {
 // WICHTIG: Verschiebt NICHT die Daten automatisch!
 // App muss vorher fragen ob Daten kopiert werden sollen
 func (s *ConfigService) ChangeDataDir(newDataDir string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.config.DataDir = newDataDir
 	return SaveConfig(s.config)
 }
 
 // GetChronoConfig gibt die aktuelle Chrono-Konfiguration zurück.
 func (s *ConfigService) GetChronoConfig() ChronoConfigDTO {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.config == nil {
 		return ChronoConfigDTO{}
 	}
@@ -134,19 +226,135 @@ func (s *ConfigService) GetChronoConfig() ChronoConfigDTO {
 		Port:       s.config.ChronoPort,
 		BaudRate:   s.config.ChronoBaudRate,
 		AutoRecord: s.config.ChronoAutoRecord,
+		Driver:     chronoDriverName(s.config.ChronoDriver),
+
+		SerialNumber: s.config.ChronoSerialNumber,
+		WireLog:      s.config.ChronoWireLog,
 	}
 }
 
+// chronoDriverName gibt den effektiven Treibernamen zurück (leer = Default).
+func chronoDriverName(name string) string {
+	if name == "" {
+		return chrono.DefaultDriverName
+	}
+	return name
+}
+
 // UpdateChronoConfig speichert die Chrono-Konfiguration.
 func (s *ConfigService) UpdateChronoConfig(cfg ChronoConfigDTO) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.config == nil {
 		return fmt.Errorf("config not initialized")
 	}
 
+	if _, err := chrono.LookupDriver(cfg.Driver); err != nil {
+		return err
+	}
+
 	s.config.ChronoEnabled = cfg.Enabled
 	s.config.ChronoPort = cfg.Port
 	s.config.ChronoBaudRate = cfg.BaudRate
 	s.config.ChronoAutoRecord = cfg.AutoRecord
+	s.config.ChronoDriver = chronoDriverName(cfg.Driver)
+	s.config.ChronoSerialNumber = cfg.SerialNumber
+	s.config.ChronoWireLog = cfg.WireLog
+
+	return SaveConfig(s.config)
+}
+
+// GetEnergyLimitsConfig gibt die Energiegrenzen der Konfiguration zurück.
+func (s *ConfigService) GetEnergyLimitsConfig() EnergyLimitsConfigDTO {
+	dto := EnergyLimitsConfigDTO{Rules: []EnergyLimitRule{}, Effective: []EnergyLimitRule{}}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.config == nil {
+		return dto
+	}
+
+	dto.Jurisdiction = s.config.EnergyJurisdiction
+	dto.Rules = append(dto.Rules, s.config.EnergyLimits...)
+	// Ungültige Regeln (von Hand editierte config.json) wirken nicht
+	if limits, err := s.config.ResolveEnergyLimits(); err == nil {
+		dto.Effective = energyLimitRules(limits)
+	}
+	return dto
+}
+
+// UpdateEnergyLimitsConfig prüft und speichert die Energiegrenzen.
+func (s *ConfigService) UpdateEnergyLimitsConfig(cfg EnergyLimitsConfigDTO) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.config == nil {
+		return fmt.Errorf("config not initialized")
+	}
+
+	jurisdiction := strings.ToUpper(strings.TrimSpace(cfg.Jurisdiction))
+	if _, err := resolveEnergyLimits(jurisdiction, cfg.Rules); err != nil {
+		return err
+	}
+
+	s.config.EnergyJurisdiction = jurisdiction
+	s.config.EnergyLimits = cfg.Rules
+	return SaveConfig(s.config)
+}
+
+// EnergyLimits gibt die wirksamen Energiegrenzen zurück (nil = keine).
+func (s *ConfigService) EnergyLimits() ([]entities.EnergyLimit, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.config.ResolveEnergyLimits()
+}
+
+// GetUnits gibt die Anzeige-Einheiten der Konfiguration zurück.
+// Ohne Config oder bei einem unbekannten System: metric.
+func (s *ConfigService) GetUnits() UnitsDTO {
+	return s.Presenter().Units()
+}
+
+// Presenter gibt den Presenter für das Einheitensystem der Config zurück.
+func (s *ConfigService) Presenter() Presenter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.config == nil {
+		return Presenter{System: UnitSystemMetric}
+	}
+	presenter, err := NewPresenter(s.config.UnitSystem)
+	if err != nil {
+		return Presenter{System: UnitSystemMetric}
+	}
+	return presenter
+}
+
+// UpdateUnitSystem prüft und speichert das Einheitensystem der Anzeige.
+func (s *ConfigService) UpdateUnitSystem(system string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.config == nil {
+		return fmt.Errorf("config not initialized")
+	}
+
+	system, err := ParseUnitSystem(system)
+	if err != nil {
+		return err
+	}
+
+	s.config.UnitSystem = system
+	return SaveConfig(s.config)
+}
+
+// UpdateChronoPort speichert einen neu aufgelösten Port (Gerät per Seriennummer wiedergefunden).
+func (s *ConfigService) UpdateChronoPort(port string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.config == nil {
+		return fmt.Errorf("config not initialized")
+	}
+	if s.config.ChronoPort == port {
+		return nil
+	}
 
+	s.config.ChronoPort = port
 	return SaveConfig(s.config)
 }
@@ -0,0 +1,47 @@
+package application
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestConfigService_ConcurrentAccess(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	service, err := NewConfigService()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := service.CompleteSetup(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Der Supervisor merkt sich Ports, während die UI Einstellungen ändert
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			service.UpdateChronoPort(fmt.Sprintf("/dev/ttyUSB%d", i%2))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			service.UpdateUnitSystem(UnitSystemImperial)
+			_ = service.GetConfig().ChronoPort
+			_ = service.GetChronoConfig()
+		}
+	}()
+	wg.Wait()
+
+	if service.GetUnits().System != UnitSystemImperial {
+		t.Errorf("unit system = %s, want imperial", service.GetUnits().System)
+	}
+
+	// GetConfig liefert eine Kopie: Änderungen daran wirken nicht zurück
+	service.GetConfig().ChronoPort = "/dev/null"
+	if port := service.GetChronoConfig().Port; port == "/dev/null" {
+		t.Error("GetConfig must return a copy")
+	}
+}
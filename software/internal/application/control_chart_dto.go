@@ -0,0 +1,71 @@
+package application
+
+// ControlChartRequestDTO steuert die Regelkarten eines Profils.
+type ControlChartRequestDTO struct {
+	// ProjectileID beschränkt die Karten auf ein Projectile ("" = alle).
+	// Verschiedene Projectiles in einer Karte sind selten sinnvoll.
+	ProjectileID string `json:"projectileId"`
+
+	// SubgroupSize ist die Größe der Untergruppen für X-quer/R
+	// (0 = DefaultSubgroupSize, erlaubt 2-10)
+	SubgroupSize int `json:"subgroupSize"`
+
+	// BaselineSessions ist die Zahl der ersten Sessions, aus denen die
+	// Eingriffsgrenzen berechnet werden (0 = DefaultControlBaselineSessions)
+	BaselineSessions int `json:"baselineSessions"`
+}
+
+// ControlChartsDTO enthält die vier Regelkarten über die Schuss-Historie
+// eines Profils. Alle Werte in m/s.
+type ControlChartsDTO struct {
+	ProfileID    string `json:"profileId"`
+	ProfileName  string `json:"profileName"`
+	ProjectileID string `json:"projectileId,omitempty"`
+
+	SubgroupSize      int `json:"subgroupSize"`
+	BaselineSessions  int `json:"baselineSessions"`
+	BaselineSubgroups int `json:"baselineSubgroups"`
+	BaselineShots     int `json:"baselineShots"`
+	SessionCount      int `json:"sessionCount"`
+
+	// DiscardedShots sind Schüsse am Ende einer Session, die keine volle
+	// Untergruppe mehr bilden (nur X-quer/R)
+	DiscardedShots int `json:"discardedShots"`
+
+	// InControl: keine Verletzung nach der Baseline in einer der Karten
+	InControl bool `json:"inControl"`
+
+	XBar        ControlChartDTO `json:"xBar"`
+	Range       ControlChartDTO `json:"range"`
+	Individuals ControlChartDTO `json:"individuals"`
+	MovingRange ControlChartDTO `json:"movingRange"`
+}
+
+// ControlChartDTO ist eine Regelkarte: Mittellinie, Grenzen und Punkte.
+type ControlChartDTO struct {
+	Kind       string  `json:"kind"` // ChartXBar, ChartRange, ChartIndividuals, ChartMovingRange
+	CenterLine float64 `json:"centerLine"`
+	UCL        float64 `json:"ucl"` // obere Eingriffsgrenze
+	LCL        float64 `json:"lcl"` // untere Eingriffsgrenze
+
+	// Sigma ist 1σ der Kartengröße (Zonen A/B/C der Western-Electric-Regeln)
+	Sigma float64 `json:"sigma"`
+
+	Points         []ControlPointDTO `json:"points"`
+	ViolationCount int               `json:"violationCount"` // Punkte mit Regelverletzung nach der Baseline
+}
+
+// ControlPointDTO ist ein Punkt einer Regelkarte.
+type ControlPointDTO struct {
+	SessionID string `json:"sessionId"`
+	CreatedAt string `json:"createdAt"` // ISO 8601, der Session
+
+	// ShotNumber ist der erste Schuss des Punkts (1-basiert wie in der
+	// Session-Ansicht, auch ungültige Schüsse zählen mit)
+	ShotNumber int     `json:"shotNumber"`
+	Value      float64 `json:"value"`
+	Baseline   bool    `json:"baseline"`
+
+	// Rules sind die verletzten Western-Electric-Regeln (1-4)
+	Rules []int `json:"rules"`
+}
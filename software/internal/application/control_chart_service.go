@@ -0,0 +1,331 @@
+package application
+
+import (
+	"fmt"
+	"math"
+	"metric-neo/internal/domain/entities"
+	"time"
+)
+
+// ControlChartService berechnet Regelkarten (Statistische Prozesslenkung)
+// über die Schuss-Historie eines Profils.
+//
+// Die SD einer Session sagt, wie gleichmäßig die Waffe an einem Tag war.
+// Regelkarten sagen, ob sie über viele Tage gleich bleibt: Die ersten
+// Sessions legen die Eingriffsgrenzen fest, jeder spätere Punkt wird daran
+// gemessen. Die Karten sind fertig zum Zeichnen - das Frontend rechnet nichts.
+type ControlChartService struct {
+	sessionRepo SessionRepository
+	profileRepo ProfileRepository
+}
+
+// NewControlChartService erstellt einen neuen ControlChartService auf dem JSON-Backend.
+func NewControlChartService(dataDir string) *ControlChartService {
+	return NewControlChartServiceWith(NewJSONRepositories(dataDir))
+}
+
+// NewControlChartServiceWith erstellt einen ControlChartService auf beliebigen Repositories.
+func NewControlChartServiceWith(repos *Repositories) *ControlChartService {
+	return &ControlChartService{
+		sessionRepo: repos.Sessions,
+		profileRepo: repos.Profiles,
+	}
+}
+
+// Arten der Regelkarten
+const (
+	ChartXBar        = "xbar" // Mittelwerte der Untergruppen
+	ChartRange       = "r"    // Spannweiten der Untergruppen
+	ChartIndividuals = "i"    // Einzelwerte
+	ChartMovingRange = "mr"   // gleitende Spannweite aufeinanderfolgender Schüsse
+)
+
+const (
+	// DefaultSubgroupSize: 5 Schüsse je Untergruppe (übliche Wahl)
+	DefaultSubgroupSize = 5
+
+	// DefaultControlBaselineSessions ist die Zahl der Sessions, aus denen
+	// die Eingriffsgrenzen berechnet werden
+	DefaultControlBaselineSessions = 3
+)
+
+// controlConstants sind die Faktoren für Untergruppen der Größe n = 2..10
+// (ASTM STP 15D): A2 für X-quer, D3/D4 für R.
+var controlConstants = map[int]struct{ a2, d3, d4 float64 }{
+	2:  {1.880, 0, 3.267},
+	3:  {1.023, 0, 2.574},
+	4:  {0.729, 0, 2.282},
+	5:  {0.577, 0, 2.114},
+	6:  {0.483, 0, 2.004},
+	7:  {0.419, 0.076, 1.924},
+	8:  {0.373, 0.136, 1.864},
+	9:  {0.337, 0.184, 1.816},
+	10: {0.308, 0.223, 1.777},
+}
+
+// Faktoren der I-MR-Karte (gleitende Spannweite aus 2 Werten):
+// 3/d2 = 3/1.128 und D4 für n = 2.
+const (
+	individualsE2 = 2.660
+	movingRangeD4 = 3.267
+)
+
+// controlSubgroup ist eine Untergruppe aufeinanderfolgender gültiger Schüsse.
+type controlSubgroup struct {
+	values []float64
+	point  ControlPointDTO
+}
+
+// ControlCharts berechnet X-quer/R- und I-MR-Karte eines Profils.
+//
+// Grundlage sind die gültigen Schüsse in Aufnahme-Reihenfolge:
+//   - X-quer/R: je Session aufeinanderfolgende Schüsse in Untergruppen der
+//     Größe n; ein Rest am Ende der Session bildet keine Untergruppe
+//   - I-MR: jeder Schuss; die gleitende Spannweite nur innerhalb einer
+//     Session (der Wechsel des Messtags ist kein Schuss-zu-Schuss-Rauschen)
+//
+// Die Grenzen kommen aus den ersten BaselineSessions Sessions. Spätere
+// Punkte werden nach den Western-Electric-Regeln geprüft.
+func (s *ControlChartService) ControlCharts(profileID string, request ControlChartRequestDTO) Result[ControlChartsDTO] {
+	if profileID == "" {
+		return FailWithMessage[ControlChartsDTO]("Profil-ID darf nicht leer sein")
+	}
+	n := request.SubgroupSize
+	if n == 0 {
+		n = DefaultSubgroupSize
+	}
+	constants, ok := controlConstants[n]
+	if !ok {
+		return FailWithMessage[ControlChartsDTO](fmt.Sprintf("Untergruppen-Größe muss zwischen 2 und 10 liegen, nicht %d", n))
+	}
+	baseline := request.BaselineSessions
+	if baseline == 0 {
+		baseline = DefaultControlBaselineSessions
+	}
+	if baseline < 1 {
+		return FailWithMessage[ControlChartsDTO]("Baseline braucht mindestens 1 Session")
+	}
+
+	name, sessions, err := loadProfileSessions(s.sessionRepo, s.profileRepo, profileID, request.ProjectileID)
+	if err != nil {
+		return Fail[ControlChartsDTO](err)
+	}
+
+	dto := ControlChartsDTO{
+		ProfileID:        profileID,
+		ProfileName:      name,
+		ProjectileID:     request.ProjectileID,
+		SubgroupSize:     n,
+		BaselineSessions: baseline,
+		SessionCount:     len(sessions),
+	}
+
+	var subgroups []controlSubgroup
+	var individuals, movingRanges []ControlPointDTO
+	for i, session := range sessions {
+		inBaseline := i < baseline
+		var group *controlSubgroup
+		var previous *float64
+
+		for index, shot := range session.Shots {
+			if !shot.Valid {
+				continue
+			}
+			v := shot.Velocity.MetersPerSecond()
+			point := newControlPoint(session, index, v, inBaseline)
+			individuals = append(individuals, point)
+			if inBaseline {
+				dto.BaselineShots++
+			}
+
+			if previous != nil {
+				mr := point
+				mr.Value, mr.Rules = math.Abs(v-*previous), []int{}
+				movingRanges = append(movingRanges, mr)
+			}
+			previous = &v
+
+			if group == nil {
+				group = &controlSubgroup{point: point}
+			}
+			group.values = append(group.values, v)
+			if len(group.values) == n {
+				subgroups = append(subgroups, *group)
+				if inBaseline {
+					dto.BaselineSubgroups++
+				}
+				group = nil
+			}
+		}
+		if group != nil {
+			dto.DiscardedShots += len(group.values)
+		}
+	}
+
+	if dto.BaselineSubgroups < 2 {
+		return FailWithMessage[ControlChartsDTO](fmt.Sprintf(
+			"Baseline braucht mindestens 2 Untergruppen zu je %d gültigen Schüssen (gefunden: %d)", n, dto.BaselineSubgroups))
+	}
+
+	dto.XBar, dto.Range = xBarRCharts(subgroups, constants.a2, constants.d3, constants.d4)
+	dto.Individuals, dto.MovingRange = individualsCharts(individuals, movingRanges)
+	dto.InControl = dto.XBar.ViolationCount+dto.Range.ViolationCount+
+		dto.Individuals.ViolationCount+dto.MovingRange.ViolationCount == 0
+	return OK(dto)
+}
+
+func newControlPoint(session *entities.Session, index int, value float64, baseline bool) ControlPointDTO {
+	return ControlPointDTO{
+		SessionID:  session.ID,
+		CreatedAt:  session.CreatedAt.Format(time.RFC3339),
+		ShotNumber: index + 1,
+		Value:      value,
+		Baseline:   baseline,
+		Rules:      []int{},
+	}
+}
+
+// xBarRCharts berechnet X-quer- und R-Karte (Grenzen aus den Baseline-Untergruppen).
+func xBarRCharts(subgroups []controlSubgroup, a2, d3, d4 float64) (ControlChartDTO, ControlChartDTO) {
+	means := make([]ControlPointDTO, len(subgroups))
+	ranges := make([]ControlPointDTO, len(subgroups))
+	var grandMean, meanRange float64
+	var count int
+	for i, group := range subgroups {
+		mean, _ := meanAndSampleSD(group.values)
+		minimum, maximum := group.values[0], group.values[0]
+		for _, v := range group.values {
+			minimum, maximum = math.Min(minimum, v), math.Max(maximum, v)
+		}
+
+		means[i], ranges[i] = group.point, group.point
+		means[i].Value, ranges[i].Value = mean, maximum-minimum
+		means[i].Rules, ranges[i].Rules = []int{}, []int{}
+		if group.point.Baseline {
+			grandMean += mean
+			meanRange += maximum - minimum
+			count++
+		}
+	}
+	grandMean /= float64(count)
+	meanRange /= float64(count)
+
+	xBar := newControlChart(ChartXBar, grandMean, grandMean+a2*meanRange, grandMean-a2*meanRange, means)
+	r := newControlChart(ChartRange, meanRange, d4*meanRange, d3*meanRange, ranges)
+	checkWesternElectric(&xBar)
+	checkLimits(&r)
+	return xBar, r
+}
+
+// individualsCharts berechnet I- und MR-Karte (Grenzen aus der Baseline).
+func individualsCharts(individuals, movingRanges []ControlPointDTO) (ControlChartDTO, ControlChartDTO) {
+	var mean, meanMR float64
+	var count, countMR int
+	for _, point := range individuals {
+		if point.Baseline {
+			mean += point.Value
+			count++
+		}
+	}
+	for _, point := range movingRanges {
+		if point.Baseline {
+			meanMR += point.Value
+			countMR++
+		}
+	}
+	mean /= float64(count)
+	if countMR > 0 {
+		meanMR /= float64(countMR)
+	}
+	if movingRanges == nil {
+		movingRanges = []ControlPointDTO{}
+	}
+
+	i := newControlChart(ChartIndividuals, mean, mean+individualsE2*meanMR, mean-individualsE2*meanMR, individuals)
+	mr := newControlChart(ChartMovingRange, meanMR, movingRangeD4*meanMR, 0, movingRanges)
+	checkWesternElectric(&i)
+	checkLimits(&mr)
+	return i, mr
+}
+
+func newControlChart(kind string, center, ucl, lcl float64, points []ControlPointDTO) ControlChartDTO {
+	return ControlChartDTO{
+		Kind:       kind,
+		CenterLine: center,
+		UCL:        ucl,
+		LCL:        lcl,
+		Sigma:      (ucl - center) / 3,
+		Points:     points,
+	}
+}
+
+// checkLimits prüft nur Regel 1 (Punkt außerhalb der Eingriffsgrenzen).
+// Für Spannweiten-Karten üblich: Ihre Verteilung ist schief, die
+// Zonen-Regeln 2-4 setzen Symmetrie voraus.
+func checkLimits(chart *ControlChartDTO) {
+	for i := range chart.Points {
+		point := &chart.Points[i]
+		if point.Value > chart.UCL || chart.LCL > 0 && point.Value < chart.LCL {
+			addViolation(chart, point, 1)
+		}
+	}
+}
+
+// checkWesternElectric prüft die vier Western-Electric-Regeln:
+//
+//	1: ein Punkt außerhalb von 3σ
+//	2: 2 von 3 aufeinanderfolgenden Punkten außerhalb von 2σ (gleiche Seite)
+//	3: 4 von 5 aufeinanderfolgenden Punkten außerhalb von 1σ (gleiche Seite)
+//	4: 8 aufeinanderfolgende Punkte auf einer Seite der Mittellinie
+//
+// Ein Punkt wird markiert, wenn er selbst zu der auffälligen Folge gehört.
+// Folgen dürfen in die Baseline zurückreichen.
+func checkWesternElectric(chart *ControlChartDTO) {
+	if chart.Sigma == 0 {
+		return
+	}
+	z := make([]float64, len(chart.Points))
+	for i, point := range chart.Points {
+		z[i] = (point.Value - chart.CenterLine) / chart.Sigma
+	}
+
+	// beyond zählt in z[from..to] die Punkte jenseits von limit auf der Seite von sign
+	beyond := func(from, to int, limit, sign float64) int {
+		count := 0
+		for j := max(from, 0); j <= to; j++ {
+			if z[j]*sign > limit {
+				count++
+			}
+		}
+		return count
+	}
+
+	for i := range chart.Points {
+		sign := 1.0
+		if z[i] < 0 {
+			sign = -1
+		}
+		point := &chart.Points[i]
+		if math.Abs(z[i]) > 3 {
+			addViolation(chart, point, 1)
+		}
+		if z[i]*sign > 2 && beyond(i-2, i, 2, sign) >= 2 {
+			addViolation(chart, point, 2)
+		}
+		if z[i]*sign > 1 && beyond(i-4, i, 1, sign) >= 4 {
+			addViolation(chart, point, 3)
+		}
+		if i >= 7 && z[i] != 0 && beyond(i-7, i, 0, sign) == 8 {
+			addViolation(chart, point, 4)
+		}
+	}
+}
+
+// addViolation vermerkt eine Regelverletzung. Gezählt werden nur Punkte
+// nach der Baseline - die Baseline definiert die Grenzen.
+func addViolation(chart *ControlChartDTO, point *ControlPointDTO, rule int) {
+	if len(point.Rules) == 0 && !point.Baseline {
+		chart.ViolationCount++
+	}
+	point.Rules = append(point.Rules, rule)
+}
@@ -0,0 +1,106 @@
+package application
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestControlChartService_ControlCharts(t *testing.T) {
+	dir := t.TempDir()
+	f := newAnalyticsFixture(t, dir)
+	projectile := NewProjectileService(dir).CreateProjectile("JSB", 0.547, 0.024).Data
+	charts := NewControlChartService(dir)
+
+	// Baseline: je Untergruppe Mittelwert 280 und Spannweite 2,
+	// gleitende Spannweite im Mittel 1
+	for i := 0; i < 3; i++ {
+		f.record(projectile.ID, nil, 279.0, 281.0, 280.0, 279.5, 280.5, 280.5, 279.5, 280.0, 281.0, 279.0)
+	}
+	if r := charts.ControlCharts(f.profile.ID, ControlChartRequestDTO{SubgroupSize: 11}); r.Success {
+		t.Error("subgroup size 11 should fail")
+	}
+	if r := charts.ControlCharts(f.profile.ID, ControlChartRequestDTO{SubgroupSize: 10, BaselineSessions: 1}); r.Success {
+		t.Error("a baseline with one subgroup should fail")
+	}
+
+	shifted := f.record(projectile.ID, nil, 283.0, 283.5, 282.5, 283.0, 283.0)
+	scattered := f.record(projectile.ID, nil, 277.0, 283.0, 280.0, 280.0, 280.0, 280.0, 280.0) // 2 Schüsse Rest
+
+	result := charts.ControlCharts(f.profile.ID, ControlChartRequestDTO{})
+	if !result.Success {
+		t.Fatalf("ControlCharts failed: %s", result.Error)
+	}
+	dto := result.Data
+	if dto.BaselineSubgroups != 6 || dto.BaselineShots != 30 || dto.DiscardedShots != 2 || dto.InControl {
+		t.Fatalf("charts: %+v", dto)
+	}
+
+	// X-quer: 280 ± A2·R̄ = 280 ± 0.577·2; R: D4·R̄ = 2.114·2
+	xBar, r := dto.XBar, dto.Range
+	if xBar.CenterLine != 280 || math.Abs(xBar.UCL-281.154) > 1e-9 || math.Abs(r.UCL-4.228) > 1e-9 || r.LCL != 0 {
+		t.Errorf("limits: x̄ %.3f [%.3f, %.3f], R %.3f [%.3f, %.3f]", xBar.CenterLine, xBar.LCL, xBar.UCL, r.CenterLine, r.LCL, r.UCL)
+	}
+	if len(xBar.Points) != 8 || xBar.Points[6].SessionID != shifted || !slices.Contains(xBar.Points[6].Rules, 1) {
+		t.Errorf("shifted subgroup: %+v", xBar.Points[6])
+	}
+	if last := r.Points[7]; last.SessionID != scattered || !slices.Equal(last.Rules, []int{1}) || last.Value != 6 {
+		t.Errorf("scattered subgroup range: %+v", last)
+	}
+	if xBar.ViolationCount != 1 || r.ViolationCount != 1 {
+		t.Errorf("violations: x̄ %d, R %d, want 1 and 1", xBar.ViolationCount, r.ViolationCount)
+	}
+
+	// I-MR: 280 ± 2.66·MR̄, MR̄ = 1
+	i, mr := dto.Individuals, dto.MovingRange
+	if len(i.Points) != 42 || math.Abs(mr.CenterLine-1) > 1e-9 || math.Abs(i.UCL-282.66) > 1e-9 {
+		t.Errorf("I-MR: %d points, MR̄ %.3f, UCL %.3f", len(i.Points), mr.CenterLine, i.UCL)
+	}
+	if first := i.Points[30]; first.SessionID != shifted || first.ShotNumber != 1 || !slices.Contains(first.Rules, 1) {
+		t.Errorf("first shifted shot: %+v", first)
+	}
+	for _, point := range i.Points[:30] {
+		if len(point.Rules) != 0 {
+			t.Errorf("baseline shot %d has violations %v", point.ShotNumber, point.Rules)
+		}
+	}
+	// Session-Wechsel ist keine gleitende Spannweite: 3·9 + 4 + 6
+	if len(mr.Points) != 37 {
+		t.Errorf("moving ranges = %d, want 37", len(mr.Points))
+	}
+}
+
+func TestCheckWesternElectric(t *testing.T) {
+	tests := []struct {
+		name  string
+		z     []float64
+		index int // Punkt, der die Regel verletzt
+		rule  int
+	}{
+		{"beyond 3 sigma", []float64{0, 3.5}, 1, 1},
+		{"2 of 3 beyond 2 sigma", []float64{2.5, 0, 2.5}, 2, 2},
+		{"4 of 5 beyond 1 sigma", []float64{-1.5, -1.5, 0, -1.5, -1.5}, 4, 3},
+		{"8 on one side", []float64{0.5, 0.5, 0.5, 0.5, 0.5, 0.5, 0.5, 0.5}, 7, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points := make([]ControlPointDTO, len(tt.z))
+			for i, z := range tt.z {
+				points[i] = ControlPointDTO{Value: 100 + z, Rules: []int{}}
+			}
+			chart := newControlChart(ChartIndividuals, 100, 103, 97, points)
+			checkWesternElectric(&chart)
+
+			for i, point := range chart.Points {
+				want := i == tt.index
+				if got := slices.Contains(point.Rules, tt.rule); got != want {
+					t.Errorf("point %d rules = %v, rule %d expected: %v", i, point.Rules, tt.rule, want)
+				}
+			}
+			if chart.ViolationCount != 1 {
+				t.Errorf("ViolationCount = %d, want 1", chart.ViolationCount)
+			}
+		})
+	}
+}
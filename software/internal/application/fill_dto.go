@@ -0,0 +1,134 @@
+package application
+
+import (
+	"metric-neo/internal/domain/entities"
+	"metric-neo/internal/domain/valueobjects"
+)
+
+// ShotsPerFillRequestDTO steuert die Auswertung der Serien einer Session
+// oder aller Sessions eines Profils.
+type ShotsPerFillRequestDTO struct {
+	// ToleranceMPS ist die erlaubte Spreizung der Kurve im Sweet Spot
+	// (0 = DefaultSweetSpotToleranceMPS)
+	ToleranceMPS float64 `json:"toleranceMPS"`
+
+	// ProjectileID beschränkt die Auswertung eines Profils auf ein
+	// Projectile ("" = alle); für eine einzelne Session ohne Bedeutung
+	ProjectileID string `json:"projectileId"`
+}
+
+// ShotsPerFillDTO ist die Geschwindigkeit über der Schussnummer je Füllung
+// und der nutzbare Bereich ("Sweet Spot") daraus. Geschwindigkeiten in m/s,
+// Drücke in bar.
+//
+// Mit erfassten Fülldrücken kommen der Druck je Schussnummer und das
+// empfohlene Füllfenster dazu.
+type ShotsPerFillDTO struct {
+	SessionID    string                `json:"sessionId,omitempty"` // leer bei Auswertung eines Profils
+	ProfileID    string                `json:"profileId"`
+	ProfileName  string                `json:"profileName"`
+	SessionCount int                   `json:"sessionCount"`
+	ToleranceMPS float64               `json:"toleranceMPS"`
+	Strings      []StringStatisticsDTO `json:"strings"`
+	Curve        []FillCurvePointDTO   `json:"curve"`
+
+	// Antrieb und Regler aus dem Profil ("" = unbekannt, nil = ungeregelt)
+	PowerPlant           string   `json:"powerPlant,omitempty"`
+	RegulatorSetpointBar *float64 `json:"regulatorSetpointBar,omitempty"`
+
+	// SweetSpot ist nil ohne gültige Schüsse
+	SweetSpot *SweetSpotDTO `json:"sweetSpot,omitempty"`
+
+	// FillWindow ist nil ohne Serien mit Start- und Enddruck
+	FillWindow *FillWindowDTO `json:"fillWindow,omitempty"`
+
+	// Warnings: FillWarning* Konstanten
+	Warnings []string `json:"warnings"`
+}
+
+// FillCurvePointDTO ist die mittlere Geschwindigkeit des n-ten Schusses
+// aller Serien.
+type FillCurvePointDTO struct {
+	// ShotNumber zählt ab Serienbeginn; ungültige Schüsse zählen mit,
+	// auch sie haben Luft verbraucht
+	ShotNumber      int     `json:"shotNumber"`
+	MeanVelocityMPS float64 `json:"meanVelocityMPS"`
+	MinVelocityMPS  float64 `json:"minVelocityMPS"`
+	MaxVelocityMPS  float64 `json:"maxVelocityMPS"`
+	Count           int     `json:"count"` // gültige Schüsse aus allen Serien
+
+	// PressureBar ist der geschätzte Tankdruck vor diesem Schuss, gemittelt
+	// über die Serien mit Start- und Enddruck (nil = keine)
+	PressureBar *float64 `json:"pressureBar,omitempty"`
+}
+
+// SweetSpotDTO ist der längste Bereich der Kurve ohne fehlende
+// Schussnummern, in dem ihre Spreizung höchstens ToleranceMPS beträgt.
+type SweetSpotDTO struct {
+	FirstShot int `json:"firstShot"` // Schussnummer in der Serie, 1-basiert
+	LastShot  int `json:"lastShot"`
+	ShotCount int `json:"shotCount"` // nutzbare Schüsse pro Füllung
+
+	MeanVelocityMPS float64 `json:"meanVelocityMPS"`
+	SpreadMPS       float64 `json:"spreadMPS"` // max - min der Kurve im Bereich
+
+	// Über alle gültigen Einzelschüsse im Bereich
+	ExtremeSpread           float64 `json:"extremeSpread"`
+	SampleStandardDeviation float64 `json:"sampleStandardDeviation"`
+
+	// CompleteStrings: Serien, die bis LastShot reichen
+	CompleteStrings int `json:"completeStrings"`
+}
+
+// FillWindowDTO ist der empfohlene Druckbereich für den Sweet Spot.
+//
+// Wer auf FillPressureBar füllt, beginnt direkt im Sweet Spot und füllt
+// nach SweetSpot.ShotCount Schüssen bei RefillPressureBar nach.
+type FillWindowDTO struct {
+	FillPressureBar    float64 `json:"fillPressureBar"`    // vor dem ersten Schuss im Sweet Spot
+	RefillPressureBar  float64 `json:"refillPressureBar"`  // nach dem letzten Schuss im Sweet Spot
+	PressurePerShotBar float64 `json:"pressurePerShotBar"` // mittlerer Druckverlust
+
+	// Strings: Serien mit Start- und Enddruck, die bis LastShot reichen
+	Strings int `json:"strings"`
+}
+
+// FillDTO ist die Füllung einer Serie: Tankdruck vor dem ersten und nach
+// dem letzten Schuss in bar.
+type FillDTO struct {
+	StartPressureBar float64  `json:"startPressureBar"`
+	EndPressureBar   *float64 `json:"endPressureBar,omitempty"` // nil = nicht abgelesen
+
+	// DropPerShotBar ist berechnet: (Start - Ende) / Schüsse der Serie
+	DropPerShotBar *float64 `json:"dropPerShotBar,omitempty"`
+}
+
+func fillToDTO(fill *entities.FillEvent, shotCount int) *FillDTO {
+	if fill == nil {
+		return nil
+	}
+	dto := &FillDTO{StartPressureBar: fill.StartPressure.Bar()}
+	if fill.EndPressure != nil {
+		end := fill.EndPressure.Bar()
+		dto.EndPressureBar = &end
+	}
+	if drop, ok := fill.DropPerShot(shotCount); ok {
+		dto.DropPerShotBar = &drop
+	}
+	return dto
+}
+
+func fillFromDTO(dto *FillDTO) (*entities.FillEvent, error) {
+	if dto == nil {
+		return nil, nil
+	}
+	start, err := valueobjects.NewFillPressure(dto.StartPressureBar)
+	if err != nil {
+		return nil, err
+	}
+	end, err := optionalFillPressure(dto.EndPressureBar)
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewFillEvent(start, end)
+}
@@ -17,6 +17,10 @@ type ProfileDTO struct {
 	TwistRateMM    *float64  `json:"twistRateMM,omitempty"`
 	DefaultAmmoID  *string   `json:"defaultAmmoID,omitempty"`
 	TotalWeightG   float64   `json:"totalWeightG"` // Calculated
+
+	// PowerPlant: "spring", "co2", "pcp" oder leer (nur Luftdruckwaffen)
+	PowerPlant           string   `json:"powerPlant,omitempty"`
+	RegulatorSetpointBar *float64 `json:"regulatorSetpointBar,omitempty"` // nil = ungeregelt
 }
 
 type OpticDTO struct {
@@ -62,6 +66,8 @@ func ProfileToDTO(p *entities.Profile) ProfileDTO {
 		dto.DefaultAmmoID = p.DefaultAmmoID
 	}
 
+	setPowerPlantDTO(&dto, p)
+
 	return dto
 }
 
@@ -118,12 +124,43 @@ func DTOToProfile(dto ProfileDTO) (*entities.Profile, error) {
 		profile.OpticID = dto.OpticID
 	}
 
+	if dto.PowerPlant != "" {
+		regulator, err := optionalFillPressure(dto.RegulatorSetpointBar)
+		if err != nil {
+			return nil, err
+		}
+		if err := profile.SetPowerPlant(entities.PowerPlant(dto.PowerPlant), regulator); err != nil {
+			return nil, err
+		}
+	}
+
 	// Setze ID (für Updates)
 	profile.ID = dto.ID
 
 	return profile, nil
 }
 
+// setPowerPlantDTO übernimmt Antrieb und Regler ins DTO.
+func setPowerPlantDTO(dto *ProfileDTO, p *entities.Profile) {
+	dto.PowerPlant = string(p.PowerPlant)
+	if p.RegulatorSetpoint != nil {
+		bar := p.RegulatorSetpoint.Bar()
+		dto.RegulatorSetpointBar = &bar
+	}
+}
+
+// optionalFillPressure validiert einen optionalen Druck in bar.
+func optionalFillPressure(bar *float64) (*valueobjects.FillPressure, error) {
+	if bar == nil {
+		return nil, nil
+	}
+	pressure, err := valueobjects.NewFillPressure(*bar)
+	if err != nil {
+		return nil, err
+	}
+	return &pressure, nil
+}
+
 func opticDTOToEntity(dto *OpticDTO) (*entities.SightingSystem, error) {
 	weight, err := valueobjects.NewMass(dto.WeightG)
 	if err != nil {
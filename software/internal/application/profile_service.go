@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"metric-neo/internal/domain/entities"
 	"metric-neo/internal/domain/valueobjects"
-	"metric-neo/internal/infrastructure/persistence"
-	"path/filepath"
 )
 
 // ProfileService orchestriert Profile-Use-Cases.
@@ -17,16 +15,21 @@ import (
 // ARCHITECTURE LAYERS:
 // Wails Binding -> ProfileService -> Domain (Entities/ValueObjects) -> Repository -> JSON
 type ProfileService struct {
-	repo *persistence.ProfileRepository
-	sightRepo *persistence.SightRepository
+	repo ProfileRepository
+	sightRepo SightRepository
 }
 
-// NewProfileService erstellt einen neuen ProfileService.
+// NewProfileService erstellt einen neuen ProfileService auf dem JSON-Backend.
 // dataDir ist das Root-Verzeichnis, Repository nutzt inventory/profiles/
 func NewProfileService(dataDir string) *ProfileService {
+	return NewProfileServiceWith(NewJSONRepositories(dataDir))
+}
+
+// NewProfileServiceWith erstellt einen ProfileService auf beliebigen Repositories.
+func NewProfileServiceWith(repos *Repositories) *ProfileService {
 	return &ProfileService{
-		repo:      persistence.NewProfileRepository(filepath.Join(dataDir, "inventory", "profiles")),
-		sightRepo: persistence.NewSightRepository(filepath.Join(dataDir, "inventory", "sights")),
+		repo:      repos.Profiles,
+		sightRepo: repos.Sights,
 	}
 }
 
@@ -190,6 +193,10 @@ func (s *ProfileService) UpdateProfile(
 
 	profile.Name = name
 	profile.Category = cat
+	if !cat.IsAirGun() {
+		// Antrieb und Regler gibt es nur bei Luftdruckwaffen
+		profile.PowerPlant, profile.RegulatorSetpoint = "", nil
+	}
 	profile.BarrelLength = barrelLength
 	profile.TriggerWeight = triggerWeight
 	profile.SightHeight = sightHeight
@@ -337,3 +344,32 @@ func (s *ProfileService) RemoveTwistRate(profileID string) Result[ProfileDTO] {
 
 	return OK(ProfileToDTO(profile))
 }
+
+// SetPowerPlant setzt den Antrieb einer Luftdruckwaffe ("spring", "co2",
+// "pcp"; "" entfernt ihn) und den Regler-Druck einer PCP (nil = ungeregelt).
+func (s *ProfileService) SetPowerPlant(profileID string, powerPlant string, regulatorSetpointBar *float64) Result[ProfileDTO] {
+	profile, err := s.repo.Load(profileID)
+	if err != nil {
+		return FailWithMessage[ProfileDTO]("Profile nicht gefunden")
+	}
+
+	plant := entities.PowerPlant(powerPlant)
+	if plant != "" && !plant.IsValid() {
+		return FailWithMessage[ProfileDTO]("Ungültiger Antrieb")
+	}
+
+	regulator, err := optionalFillPressure(regulatorSetpointBar)
+	if err != nil {
+		return Fail[ProfileDTO](err)
+	}
+
+	if err := profile.SetPowerPlant(plant, regulator); err != nil {
+		return Fail[ProfileDTO](err)
+	}
+
+	if err := s.repo.Save(profile); err != nil {
+		return Fail[ProfileDTO](err)
+	}
+
+	return OK(ProfileToDTO(profile))
+}
@@ -130,6 +130,36 @@ func TestProfileService_SetOptic(t *testing.T) {
 	t.Logf("✓ Optic added: %s (Total weight: %.0fg)", dto.Optic.ModelName, dto.TotalWeightG)
 }
 
+func TestProfileService_SetPowerPlant(t *testing.T) {
+	dir := t.TempDir()
+	service := NewProfileService(dir)
+
+	createResult := service.CreateProfile("FX Impact", "air_rifle", 600.0, 300.0, 50.0)
+	if !createResult.Success {
+		t.Fatal("Failed to create profile")
+	}
+	profileID := createResult.Data.ID
+
+	regulator := 130.0
+	result := service.SetPowerPlant(profileID, "pcp", &regulator)
+	if !result.Success {
+		t.Fatalf("SetPowerPlant failed: %s", result.Error)
+	}
+	if result.Data.PowerPlant != "pcp" || *result.Data.RegulatorSetpointBar != 130 {
+		t.Errorf("power plant = %q, regulator %v", result.Data.PowerPlant, result.Data.RegulatorSetpointBar)
+	}
+
+	if result := service.SetPowerPlant(profileID, "co2", &regulator); result.Success {
+		t.Error("Expected error for a regulator without pcp")
+	}
+
+	// Wechsel zur Feuerwaffe entfernt den Antrieb
+	updated := service.UpdateProfile(profileID, "FX Impact", "firearm", 600.0, 300.0, 50.0)
+	if !updated.Success || updated.Data.PowerPlant != "" || updated.Data.RegulatorSetpointBar != nil {
+		t.Errorf("UpdateProfile kept the power plant: %+v (%s)", updated.Data, updated.Error)
+	}
+}
+
 func TestProfileService_DeleteProfile(t *testing.T) {
 	dir := t.TempDir()
 	service := NewProfileService(dir)
@@ -1,8 +1,10 @@
 package application
 
 import (
+	"metric-neo/internal/domain/ballistics"
 	"metric-neo/internal/domain/entities"
 	"metric-neo/internal/domain/valueobjects"
+	"time"
 )
 
 // ProjectileDTO ist die Wails-kompatible Repräsentation eines Projectile.
@@ -16,17 +18,144 @@ type ProjectileDTO struct {
 	ID          string  `json:"id"`
 	Name        string  `json:"name"`
 	WeightGrams float64 `json:"weightGrams"`
-	BC          float64 `json:"bc"` // Ballistic Coefficient
+	BC          float64 `json:"bc"`        // Ballistic Coefficient in bcUnit
+	DragModel   string  `json:"dragModel"` // "G1", "G7", "GA", "CUSTOM"
+	BCUnit      string  `json:"bcUnit"`    // "lb/in2", "kg/m2"
+	// DragTable ist die eigene Widerstandskurve (nur bei CUSTOM)
+	DragTable []DragPointDTO `json:"dragTable,omitempty"`
+	// CaliberMM ist der Geschossdurchmesser (nil = unbekannt)
+	CaliberMM *float64 `json:"caliberMM,omitempty"`
+
+	// Lots sind die Chargen im Bestand (nur Stammdaten)
+	Lots []ProjectileLotDTO `json:"lots"`
+	// Lot ist die Charge einer Session (nur im Snapshot)
+	Lot *ProjectileLotDTO `json:"lot,omitempty"`
+}
+
+// DragDTO beschreibt den BC eines Projectiles mit seinem Bezug.
+// Leere Felder: G1 in lb/in² (die übliche Herstellerangabe).
+type DragDTO struct {
+	BC        float64        `json:"bc"`
+	DragModel string         `json:"dragModel"`
+	BCUnit    string         `json:"bcUnit"`
+	DragTable []DragPointDTO `json:"dragTable,omitempty"` // nur CUSTOM
+}
+
+// DragPointDTO ist ein Stützpunkt einer eigenen Widerstandskurve.
+type DragPointDTO struct {
+	Mach float64 `json:"mach"`
+	Cd   float64 `json:"cd"`
+}
+
+// ProjectileLotDTO ist die Wails-kompatible Repräsentation einer Charge.
+type ProjectileLotDTO struct {
+	ID                 string   `json:"id"`
+	Number             string   `json:"number"`
+	PurchaseDate       string   `json:"purchaseDate,omitempty"`       // YYYY-MM-DD
+	AverageWeightGrams *float64 `json:"averageWeightGrams,omitempty"` // gemessen
+	CountOnHand        int      `json:"countOnHand"`
 }
 
 // ProjectileToDTO konvertiert Domain-Entity zu DTO.
 func ProjectileToDTO(p *entities.Projectile) ProjectileDTO {
-	return ProjectileDTO{
+	dto := ProjectileDTO{
 		ID:          p.ID,
 		Name:        p.Name,
 		WeightGrams: p.Weight.Grams(),
 		BC:          p.BC,
+		DragModel:   string(p.DragModel),
+		BCUnit:      string(p.BCUnit),
+		Lots:        make([]ProjectileLotDTO, 0, len(p.Lots)),
+	}
+	if p.Caliber != nil {
+		mm := p.Caliber.Millimeters()
+		dto.CaliberMM = &mm
+	}
+	for _, point := range p.DragTable {
+		dto.DragTable = append(dto.DragTable, DragPointDTO{Mach: point.Mach, Cd: point.Cd})
+	}
+	for i := range p.Lots {
+		dto.Lots = append(dto.Lots, lotToDTO(&p.Lots[i]))
+	}
+	if p.Lot != nil {
+		lot := lotToDTO(p.Lot)
+		dto.Lot = &lot
+	}
+	return dto
+}
+
+// apply setzt BC, Modell, Einheit und Kurve auf dem Projectile (mit Validierung).
+func (d DragDTO) apply(p *entities.Projectile) error {
+	model, err := ballistics.ParseDragModel(d.DragModel)
+	if err != nil {
+		return err
+	}
+	unit, err := ballistics.ParseBCUnit(d.BCUnit)
+	if err != nil {
+		return err
+	}
+	var table ballistics.DragTable
+	for _, point := range d.DragTable {
+		table = append(table, ballistics.DragPoint{Mach: point.Mach, Cd: point.Cd})
+	}
+	return p.SetDrag(d.BC, model, unit, table)
+}
+
+// applyCaliber setzt das Kaliber in mm (nil = unbekannt, mit Validierung).
+func applyCaliber(p *entities.Projectile, caliberMM *float64) error {
+	if caliberMM == nil {
+		return p.SetCaliber(nil)
+	}
+	caliber, err := valueobjects.NewLength(*caliberMM)
+	if err != nil {
+		return err
+	}
+	return p.SetCaliber(&caliber)
+}
+
+// lotToDTO konvertiert eine Charge zu DTO.
+func lotToDTO(l *entities.ProjectileLot) ProjectileLotDTO {
+	dto := ProjectileLotDTO{
+		ID:          l.ID,
+		Number:      l.Number,
+		CountOnHand: l.CountOnHand,
+	}
+	if l.PurchaseDate != nil {
+		dto.PurchaseDate = l.PurchaseDate.Format("2006-01-02")
+	}
+	if l.AverageWeight != nil {
+		grams := l.AverageWeight.Grams()
+		dto.AverageWeightGrams = &grams
+	}
+	return dto
+}
+
+// dtoToLot konvertiert ein Chargen-DTO zu Domain-Entity (mit Validierung).
+func dtoToLot(dto ProjectileLotDTO) (*entities.ProjectileLot, error) {
+	lot, err := entities.NewProjectileLot(dto.Number, dto.CountOnHand)
+	if err != nil {
+		return nil, err
 	}
+	if dto.ID != "" {
+		lot.ID = dto.ID
+	}
+
+	if dto.PurchaseDate != "" {
+		date, err := time.Parse("2006-01-02", dto.PurchaseDate)
+		if err != nil {
+			return nil, err
+		}
+		lot.SetPurchaseDate(date)
+	}
+
+	if dto.AverageWeightGrams != nil {
+		weight, err := valueobjects.NewMass(*dto.AverageWeightGrams)
+		if err != nil {
+			return nil, err
+		}
+		lot.SetAverageWeight(weight)
+	}
+	return lot, nil
 }
 
 // DTOToProjectile konvertiert DTO zu Domain-Entity.
@@ -44,6 +173,15 @@ func DTOToProjectile(dto ProjectileDTO) (*entities.Projectile, error) {
 		return nil, err
 	}
 
+	drag := DragDTO{BC: dto.BC, DragModel: dto.DragModel, BCUnit: dto.BCUnit, DragTable: dto.DragTable}
+	if err := drag.apply(projectile); err != nil {
+		return nil, err
+	}
+
+	if err := applyCaliber(projectile, dto.CaliberMM); err != nil {
+		return nil, err
+	}
+
 	// Setze ID (für Updates)
 	projectile.ID = dto.ID
 
@@ -2,10 +2,10 @@ package application
 
 import (
 	"fmt"
+	"math"
+	"metric-neo/internal/domain/ballistics"
 	"metric-neo/internal/domain/entities"
 	"metric-neo/internal/domain/valueobjects"
-	"metric-neo/internal/infrastructure/persistence"
-	"path/filepath"
 )
 
 // ProjectileService orchestriert Projectile-Use-Cases.
@@ -13,43 +13,57 @@ import (
 // EINFACHER als ProfileService da Projectile keine komplexen
 // optionalen Felder oder Relationen haben.
 type ProjectileService struct {
-	repo *persistence.ProjectileRepository
+	repo ProjectileRepository
 }
 
-// NewProjectileService erstellt einen neuen ProjectileService.
+// NewProjectileService erstellt einen neuen ProjectileService auf dem JSON-Backend.
 // dataDir ist das Root-Verzeichnis, Repository nutzt inventory/projectiles/
 func NewProjectileService(dataDir string) *ProjectileService {
-	return &ProjectileService{
-		repo: persistence.NewProjectileRepository(filepath.Join(dataDir, "inventory", "projectiles")),
-	}
+	return NewProjectileServiceWith(NewJSONRepositories(dataDir))
+}
+
+// NewProjectileServiceWith erstellt einen ProjectileService auf beliebigen Repositories.
+func NewProjectileServiceWith(repos *Repositories) *ProjectileService {
+	return &ProjectileService{repo: repos.Projectiles}
 }
 
-// CreateProjectile erstellt ein neues Projectile.
+// CreateProjectile erstellt ein neues Projectile mit einem G1-BC in lb/in².
 func (s *ProjectileService) CreateProjectile(
 	name string,
 	weightGrams float64,
 	bc float64,
+) Result[ProjectileDTO] {
+	return s.CreateProjectileWithDrag(name, weightGrams, DragDTO{BC: bc})
+}
+
+// CreateProjectileWithDrag erstellt ein neues Projectile mit Widerstandsmodell.
+//
+// Der BC wird gegen sein Modell geprüft (plausibler Bereich je Modell,
+// eigene Kurve bei CUSTOM), nicht mehr pauschal gegen 0..1.
+func (s *ProjectileService) CreateProjectileWithDrag(
+	name string,
+	weightGrams float64,
+	drag DragDTO,
 ) Result[ProjectileDTO] {
 	// Validierung
 	if name == "" {
 		return FailWithMessage[ProjectileDTO]("Name darf nicht leer sein")
 	}
 
-	if bc < 0 || bc > 1 {
-		return FailWithMessage[ProjectileDTO]("BC muss zwischen 0 und 1 liegen")
-	}
-
 	// Value Object erstellen
 	weight, err := valueobjects.NewMass(weightGrams)
 	if err != nil {
 		return Fail[ProjectileDTO](err)
 	}
 
-	// Entity erstellen (mit bc Parameter!)
-	projectile, err := entities.NewProjectile(name, weight, bc)
+	// Entity erstellen (BC 0, das Modell setzt drag.apply)
+	projectile, err := entities.NewProjectile(name, weight, 0)
 	if err != nil {
 		return Fail[ProjectileDTO](err)
 	}
+	if err := drag.apply(projectile); err != nil {
+		return Fail[ProjectileDTO](err)
+	}
 
 	// Speichern
 	if err := s.repo.Save(projectile); err != nil {
@@ -91,11 +105,37 @@ func (s *ProjectileService) ListProjectiles() Result[[]ProjectileDTO] {
 }
 
 // UpdateProjectile aktualisiert die Basisdaten eines Projectiles.
+// Modell und Einheit des BC bleiben unverändert.
 func (s *ProjectileService) UpdateProjectile(
 	projectileID string,
 	name string,
 	weightGrams float64,
 	bc float64,
+) Result[ProjectileDTO] {
+	return s.updateProjectile(projectileID, name, weightGrams, func(p *entities.Projectile) error {
+		return p.UpdateBC(bc)
+	})
+}
+
+// UpdateProjectileWithDrag aktualisiert Basisdaten, BC und Widerstandsmodell.
+//
+// Sessions behalten ihren Snapshot: Ein geändertes Modell gilt nur für neue
+// Sessions, alte rechnen weiter mit dem Modell ihrer Messung.
+func (s *ProjectileService) UpdateProjectileWithDrag(
+	projectileID string,
+	name string,
+	weightGrams float64,
+	drag DragDTO,
+) Result[ProjectileDTO] {
+	return s.updateProjectile(projectileID, name, weightGrams, drag.apply)
+}
+
+// updateProjectile validiert, lädt, ändert Name/Gewicht, wendet setBC an und speichert.
+func (s *ProjectileService) updateProjectile(
+	projectileID string,
+	name string,
+	weightGrams float64,
+	setBC func(*entities.Projectile) error,
 ) Result[ProjectileDTO] {
 	if projectileID == "" {
 		return FailWithMessage[ProjectileDTO]("ID darf nicht leer sein")
@@ -105,10 +145,6 @@ func (s *ProjectileService) UpdateProjectile(
 		return FailWithMessage[ProjectileDTO]("Name darf nicht leer sein")
 	}
 
-	if bc < 0 || bc > 1 {
-		return FailWithMessage[ProjectileDTO]("BC muss zwischen 0 und 1 liegen")
-	}
-
 	weight, err := valueobjects.NewMass(weightGrams)
 	if err != nil {
 		return Fail[ProjectileDTO](err)
@@ -121,7 +157,9 @@ func (s *ProjectileService) UpdateProjectile(
 
 	projectile.Name = name
 	projectile.Weight = weight
-	projectile.BC = bc
+	if err := setBC(projectile); err != nil {
+		return Fail[ProjectileDTO](err)
+	}
 
 	if err := s.repo.Save(projectile); err != nil {
 		return Fail[ProjectileDTO](err)
@@ -131,23 +169,22 @@ func (s *ProjectileService) UpdateProjectile(
 }
 
 // UpdateBC aktualisiert den Ballistic Coefficient eines Projectiles.
+// Der Wert ist in Modell und Einheit des Projectiles angegeben.
 func (s *ProjectileService) UpdateBC(id string, newBC float64) Result[ProjectileDTO] {
 	if id == "" {
 		return FailWithMessage[ProjectileDTO]("ID darf nicht leer sein")
 	}
 
-	if newBC < 0 || newBC > 1 {
-		return FailWithMessage[ProjectileDTO]("BC muss zwischen 0 und 1 liegen")
-	}
-
 	// Lade Projectile
 	projectile, err := s.repo.Load(id)
 	if err != nil {
 		return FailWithMessage[ProjectileDTO]("Projectile nicht gefunden")
 	}
 
-	// Update BC
-	projectile.UpdateBC(newBC)
+	// Update BC (prüft gegen das Modell)
+	if err := projectile.UpdateBC(newBC); err != nil {
+		return Fail[ProjectileDTO](err)
+	}
 
 	// Speichern
 	if err := s.repo.Save(projectile); err != nil {
@@ -157,6 +194,134 @@ func (s *ProjectileService) UpdateBC(id string, newBC float64) Result[Projectile
 	return OK(ProjectileToDTO(projectile))
 }
 
+// SetCaliber setzt das Kaliber eines Projectiles in mm (nil = unbekannt).
+//
+// Das Kaliber wird nur für die Energiedichte gebraucht. Sessions behalten
+// ihren Snapshot: Energiedichten gibt es nur für neue Sessions.
+func (s *ProjectileService) SetCaliber(id string, caliberMM *float64) Result[ProjectileDTO] {
+	if id == "" {
+		return FailWithMessage[ProjectileDTO]("ID darf nicht leer sein")
+	}
+
+	projectile, err := s.repo.Load(id)
+	if err != nil {
+		return FailWithMessage[ProjectileDTO]("Projectile nicht gefunden")
+	}
+
+	if err := applyCaliber(projectile, caliberMM); err != nil {
+		return Fail[ProjectileDTO](err)
+	}
+
+	if err := s.repo.Save(projectile); err != nil {
+		return Fail[ProjectileDTO](err)
+	}
+
+	return OK(ProjectileToDTO(projectile))
+}
+
+// ApplyBCEstimate übernimmt eine BC-Bestimmung (SessionService.EstimateBC)
+// als neuen BC des Projectiles (über UpdateBC).
+//
+// Die Bestimmung muss für das Modell des Projectiles gerechnet sein - ein
+// G7-BC ist als G1-BC falsch. Der Wert wird in die Einheit des Projectiles
+// umgerechnet und auf 4 Nachkommastellen gerundet.
+func (s *ProjectileService) ApplyBCEstimate(estimate BCEstimateDTO) Result[ProjectileDTO] {
+	if estimate.ProjectileID == "" {
+		return FailWithMessage[ProjectileDTO]("ID darf nicht leer sein")
+	}
+
+	projectile, err := s.repo.Load(estimate.ProjectileID)
+	if err != nil {
+		return FailWithMessage[ProjectileDTO]("Projectile nicht gefunden")
+	}
+
+	model, err := ballistics.ParseDragModel(estimate.DragModel)
+	if err != nil {
+		return Fail[ProjectileDTO](err)
+	}
+	if model != projectile.DragModel {
+		return FailWithMessage[ProjectileDTO](fmt.Sprintf("BC wurde für %s bestimmt, Projectile %s verwendet %s",
+			model, projectile.Name, projectile.DragModel))
+	}
+	unit, err := ballistics.ParseBCUnit(estimate.BCUnit)
+	if err != nil {
+		return Fail[ProjectileDTO](err)
+	}
+
+	bc := projectile.BCUnit.FromImperial(unit.ToImperial(estimate.BC))
+	return s.UpdateBC(projectile.ID, math.Round(bc*1e4)/1e4)
+}
+
+// AddLot legt eine neue Charge für ein Projectile an.
+func (s *ProjectileService) AddLot(projectileID string, lot ProjectileLotDTO) Result[ProjectileDTO] {
+	if projectileID == "" {
+		return FailWithMessage[ProjectileDTO]("ID darf nicht leer sein")
+	}
+	lot.ID = ""
+	return s.saveLot(projectileID, lot, (*entities.Projectile).AddLot)
+}
+
+// UpdateLot ändert eine vorhandene Charge (lot.ID muss gesetzt sein).
+func (s *ProjectileService) UpdateLot(projectileID string, lot ProjectileLotDTO) Result[ProjectileDTO] {
+	if projectileID == "" || lot.ID == "" {
+		return FailWithMessage[ProjectileDTO]("ID darf nicht leer sein")
+	}
+	return s.saveLot(projectileID, lot, (*entities.Projectile).UpdateLot)
+}
+
+// saveLot validiert die Charge, wendet apply auf das Projectile an und speichert.
+//
+// GO-KONZEPT: Method Expressions
+// (*entities.Projectile).AddLot ist eine normale Funktion mit dem
+// Receiver als erstem Parameter: func(*Projectile, *ProjectileLot) error
+func (s *ProjectileService) saveLot(
+	projectileID string,
+	dto ProjectileLotDTO,
+	apply func(*entities.Projectile, *entities.ProjectileLot) error,
+) Result[ProjectileDTO] {
+	lot, err := dtoToLot(dto)
+	if err != nil {
+		return Fail[ProjectileDTO](err)
+	}
+
+	projectile, err := s.repo.Load(projectileID)
+	if err != nil {
+		return FailWithMessage[ProjectileDTO]("Projectile nicht gefunden")
+	}
+
+	if err := apply(projectile, lot); err != nil {
+		return Fail[ProjectileDTO](err)
+	}
+
+	if err := s.repo.Save(projectile); err != nil {
+		return Fail[ProjectileDTO](err)
+	}
+
+	return OK(ProjectileToDTO(projectile))
+}
+
+// DeleteLot entfernt eine Charge. Sessions mit dieser Charge behalten ihren Snapshot.
+func (s *ProjectileService) DeleteLot(projectileID string, lotID string) Result[ProjectileDTO] {
+	if projectileID == "" || lotID == "" {
+		return FailWithMessage[ProjectileDTO]("ID darf nicht leer sein")
+	}
+
+	projectile, err := s.repo.Load(projectileID)
+	if err != nil {
+		return FailWithMessage[ProjectileDTO]("Projectile nicht gefunden")
+	}
+
+	if err := projectile.RemoveLot(lotID); err != nil {
+		return FailWithMessage[ProjectileDTO](fmt.Sprintf("Charge nicht gefunden: %s", lotID))
+	}
+
+	if err := s.repo.Save(projectile); err != nil {
+		return Fail[ProjectileDTO](err)
+	}
+
+	return OK(ProjectileToDTO(projectile))
+}
+
 // DeleteProjectile löscht ein Projectile.
 func (s *ProjectileService) DeleteProjectile(id string) Result[bool] {
 	if id == "" {
@@ -95,3 +95,118 @@ func TestProjectileService_ListProjectiles(t *testing.T) {
 		t.Logf("  - %s (%.3fg, BC: %.3f)", p.Name, p.WeightGrams, p.BC)
 	}
 }
+
+func TestProjectileService_DragModel(t *testing.T) {
+	dir := t.TempDir()
+	service := NewProjectileService(dir)
+
+	// Ohne Angabe: G1 in lb/in²
+	plain := service.CreateProjectile("JSB Exact", 0.547, 0.024)
+	if plain.Data.DragModel != "G1" || plain.Data.BCUnit != "lb/in2" {
+		t.Errorf("defaults = %s %s, want G1 lb/in2", plain.Data.DragModel, plain.Data.BCUnit)
+	}
+
+	// Grenzen je Modell: 0.5 ist ein plausibler G1-, aber kein GA-BC
+	if r := service.CreateProjectileWithDrag("Slug", 2.0, DragDTO{BC: 0.5, DragModel: "GA"}); r.Success {
+		t.Error("GA BC 0.5 should fail")
+	}
+	if r := service.CreateProjectileWithDrag("Own curve", 0.547, DragDTO{BC: 1, DragModel: "CUSTOM"}); r.Success {
+		t.Error("CUSTOM without table should fail")
+	}
+
+	custom := service.CreateProjectileWithDrag("Own curve", 0.547, DragDTO{
+		BC:        1,
+		DragModel: "custom",
+		DragTable: []DragPointDTO{{Mach: 0.3, Cd: 0.25}, {Mach: 0.8, Cd: 0.3}},
+	})
+	if !custom.Success {
+		t.Fatalf("CreateProjectileWithDrag(CUSTOM) failed: %s", custom.Error)
+	}
+	loaded := service.LoadProjectile(custom.Data.ID)
+	if loaded.Data.DragModel != "CUSTOM" || len(loaded.Data.DragTable) != 2 || loaded.Data.DragTable[1].Cd != 0.3 {
+		t.Errorf("custom curve not persisted: %+v", loaded.Data)
+	}
+
+	// UpdateProjectile und UpdateBC behalten das Modell und prüfen dagegen
+	ga := service.CreateProjectileWithDrag("H&N", 0.67, DragDTO{BC: 16.9, DragModel: "GA", BCUnit: "kg/m2"})
+	if r := service.UpdateBC(ga.Data.ID, 0.024); r.Success {
+		t.Error("UpdateBC(0.024) should fail for a BC in kg/m2")
+	}
+	updated := service.UpdateProjectile(ga.Data.ID, "H&N Baracuda", 0.69, 17.5)
+	if !updated.Success || updated.Data.DragModel != "GA" || updated.Data.BCUnit != "kg/m2" {
+		t.Errorf("UpdateProjectile = %+v, %s", updated.Data, updated.Error)
+	}
+}
+
+func TestProjectileService_Caliber(t *testing.T) {
+	dir := t.TempDir()
+	service := NewProjectileService(dir)
+
+	created := service.CreateProjectile("JSB Exact", 0.547, 0.024)
+	if created.Data.CaliberMM != nil {
+		t.Errorf("new projectile caliber = %v, want unknown", *created.Data.CaliberMM)
+	}
+
+	caliber := 4.52
+	if r := service.SetCaliber(created.Data.ID, &caliber); !r.Success {
+		t.Fatalf("SetCaliber(4.52) failed: %s", r.Error)
+	}
+	loaded := service.LoadProjectile(created.Data.ID)
+	if loaded.Data.CaliberMM == nil || *loaded.Data.CaliberMM != 4.52 {
+		t.Errorf("caliber not persisted: %+v", loaded.Data.CaliberMM)
+	}
+
+	// 177 ist das Kaliber in Zoll·1000, nicht in mm
+	tooLarge := 177.0
+	if r := service.SetCaliber(created.Data.ID, &tooLarge); r.Success {
+		t.Error("SetCaliber(177 mm) should fail")
+	}
+
+	if r := service.SetCaliber(created.Data.ID, nil); !r.Success || r.Data.CaliberMM != nil {
+		t.Errorf("SetCaliber(nil) = %+v, %s", r.Data.CaliberMM, r.Error)
+	}
+}
+
+func TestProjectileService_Lots(t *testing.T) {
+	dir := t.TempDir()
+	service := NewProjectileService(dir)
+
+	projectile := service.CreateProjectile("JSB Exact", 0.547, 0.024)
+	weight := 0.551
+	added := service.AddLot(projectile.Data.ID, ProjectileLotDTO{
+		Number:             "LOT-2023-11-A",
+		PurchaseDate:       "2023-11-04",
+		AverageWeightGrams: &weight,
+		CountOnHand:        500,
+	})
+	if !added.Success {
+		t.Fatalf("AddLot failed: %s", added.Error)
+	}
+	lot := added.Data.Lots[0]
+	if lot.ID == "" || lot.PurchaseDate != "2023-11-04" || *lot.AverageWeightGrams != 0.551 {
+		t.Errorf("unexpected lot: %+v", lot)
+	}
+
+	// Doppelte Losnummer und ungültiges Datum werden abgelehnt
+	if r := service.AddLot(projectile.Data.ID, ProjectileLotDTO{Number: "lot-2023-11-a"}); r.Success {
+		t.Error("duplicate lot number should fail")
+	}
+	if r := service.AddLot(projectile.Data.ID, ProjectileLotDTO{Number: "B", PurchaseDate: "04.11.2023"}); r.Success {
+		t.Error("invalid purchase date should fail")
+	}
+
+	lot.CountOnHand = 350
+	updated := service.UpdateLot(projectile.Data.ID, lot)
+	if !updated.Success || updated.Data.Lots[0].CountOnHand != 350 {
+		t.Fatalf("UpdateLot failed: %s", updated.Error)
+	}
+
+	loaded := service.LoadProjectile(projectile.Data.ID)
+	if len(loaded.Data.Lots) != 1 || loaded.Data.Lots[0].CountOnHand != 350 {
+		t.Errorf("lot not persisted: %+v", loaded.Data.Lots)
+	}
+
+	if r := service.DeleteLot(projectile.Data.ID, lot.ID); !r.Success || len(r.Data.Lots) != 0 {
+		t.Errorf("DeleteLot failed: %s", r.Error)
+	}
+}
@@ -0,0 +1,129 @@
+package application
+
+import (
+	"fmt"
+	"metric-neo/internal/domain/entities"
+	"metric-neo/internal/infrastructure/persistence"
+	"metric-neo/internal/infrastructure/persistence/sqlite"
+	"os"
+)
+
+// GO-KONZEPT: Interfaces beim Verwender (consumer-side interfaces)
+// Die Services legen fest, was sie von einer Speicherung brauchen.
+// persistence (JSON, Standard) und persistence/sqlite erfüllen die
+// Interfaces implizit - kein "implements", kein Import in Gegenrichtung.
+
+// ProfileRepository speichert Profile.
+type ProfileRepository interface {
+	Save(profile *entities.Profile) error
+	Load(id string) (*entities.Profile, error)
+	List() ([]string, error)
+	Delete(id string) error
+}
+
+// ProjectileRepository speichert Projectiles.
+type ProjectileRepository interface {
+	Save(p *entities.Projectile) error
+	Load(id string) (*entities.Projectile, error)
+	List() ([]*entities.Projectile, error)
+	Delete(id string) error
+}
+
+// SightRepository speichert Optiken.
+type SightRepository interface {
+	Save(sight *entities.SightingSystem) error
+	Load(id string) (*entities.SightingSystem, error)
+	List() ([]*entities.SightingSystem, error)
+	Delete(id string) error
+}
+
+// SessionRepository speichert Sessions samt Metadaten-Abfrage und Rohdaten-Logs.
+type SessionRepository interface {
+	Save(session *entities.Session) error
+	Load(id string) (*entities.Session, error)
+	List() ([]string, error)
+	Delete(id string) error
+	Query(q persistence.SessionQuery) ([]persistence.SessionIndexEntry, int, error)
+	RebuildIndex() (int, error)
+	// IndexWarning meldet einen Index-Fehler, der Save und Query nicht
+	// scheitern ließ (nil = keiner)
+	IndexWarning() error
+	WireLogPath(id string) string
+	OpenWireLog(id string) (*os.File, error)
+}
+
+// Compile-Zeit-Prüfung: Beide Backends erfüllen alle Interfaces
+var (
+	_ ProfileRepository    = (*persistence.ProfileRepository)(nil)
+	_ ProjectileRepository = (*persistence.ProjectileRepository)(nil)
+	_ SightRepository      = (*persistence.SightRepository)(nil)
+	_ SessionRepository    = (*persistence.SessionRepository)(nil)
+	_ ProfileRepository    = (*sqlite.ProfileRepository)(nil)
+	_ ProjectileRepository = (*sqlite.ProjectileRepository)(nil)
+	_ SightRepository      = (*sqlite.SightRepository)(nil)
+	_ SessionRepository    = (*sqlite.SessionRepository)(nil)
+)
+
+// Repositories bündelt die Repositories eines Datenverzeichnisses.
+type Repositories struct {
+	Backend     persistence.StorageBackend
+	Profiles    ProfileRepository
+	Projectiles ProjectileRepository
+	Sights      SightRepository
+	Sessions    SessionRepository
+
+	// close gibt Ressourcen des Backends frei (SQLite-Verbindung)
+	close func() error
+}
+
+// NewJSONRepositories erstellt die JSON-Repositories (ADR 003).
+func NewJSONRepositories(dataDir string) *Repositories {
+	return &Repositories{
+		Backend:     persistence.BackendJSON,
+		Profiles:    persistence.NewProfileRepository(persistence.DocumentDir(dataDir, persistence.KindProfile)),
+		Projectiles: persistence.NewProjectileRepository(persistence.DocumentDir(dataDir, persistence.KindProjectile)),
+		Sights:      persistence.NewSightRepository(persistence.DocumentDir(dataDir, persistence.KindSight)),
+		Sessions:    persistence.NewSessionRepository(persistence.DocumentDir(dataDir, persistence.KindSession)),
+		close:       func() error { return nil },
+	}
+}
+
+// OpenRepositories öffnet die Repositories des angegebenen Backends.
+func OpenRepositories(dataDir string, backend persistence.StorageBackend) (*Repositories, error) {
+	switch backend {
+	case "", persistence.BackendJSON:
+		return NewJSONRepositories(dataDir), nil
+	case persistence.BackendSQLite:
+		db, err := sqlite.Open(dataDir)
+		if err != nil {
+			return nil, err
+		}
+		return &Repositories{
+			Backend:     persistence.BackendSQLite,
+			Profiles:    db.Profiles(),
+			Projectiles: db.Projectiles(),
+			Sights:      db.Sights(),
+			Sessions:    db.Sessions(),
+			close:       db.Close,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", backend)
+	}
+}
+
+// OpenDataDir öffnet die Repositories mit dem Backend aus dem Manifest.
+func OpenDataDir(dataDir string) (*Repositories, error) {
+	backend, err := persistence.ReadStorageBackend(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	return OpenRepositories(dataDir, backend)
+}
+
+// Close gibt die Ressourcen des Backends frei.
+func (r *Repositories) Close() error {
+	if r == nil || r.close == nil {
+		return nil
+	}
+	return r.close()
+}
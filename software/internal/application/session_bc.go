@@ -0,0 +1,231 @@
+package application
+
+import (
+	"fmt"
+	"math"
+	"metric-neo/internal/domain/ballistics"
+	"metric-neo/internal/domain/entities"
+	"metric-neo/internal/domain/valueobjects"
+)
+
+// SetDownrange markiert eine Session als Messung distanceMeters hinter
+// nearSessionID. Beide Sessions müssen dasselbe Projectile verwenden.
+// nearSessionID "" hebt die Verknüpfung auf.
+func (s *SessionService) SetDownrange(sessionID string, nearSessionID string, distanceMeters float64) Result[SessionDTO] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sessionID == "" {
+		return FailWithMessage[SessionDTO]("Session-ID darf nicht leer sein")
+	}
+
+	session, err := s.sessionRepo.Load(sessionID)
+	if err != nil {
+		return FailWithMessage[SessionDTO]("Session nicht gefunden")
+	}
+
+	if nearSessionID == "" {
+		session.ClearDownrange()
+	} else {
+		near, err := s.sessionRepo.Load(nearSessionID)
+		if err != nil {
+			return FailWithMessage[SessionDTO](fmt.Sprintf("Nahe Session nicht gefunden: %s", nearSessionID))
+		}
+		if near.ProjectileSnapshot.ID != session.ProjectileSnapshot.ID {
+			return FailWithMessage[SessionDTO](fmt.Sprintf("Nahe Session verwendet ein anderes Projectile (%s statt %s)",
+				near.ProjectileSnapshot.Name, session.ProjectileSnapshot.Name))
+		}
+		if err := session.SetDownrange(nearSessionID, distanceMeters); err != nil {
+			return Fail[SessionDTO](err)
+		}
+	}
+
+	if err := s.sessionRepo.Save(session); err != nil {
+		return Fail[SessionDTO](err)
+	}
+
+	return OK(s.toDTO(session))
+}
+
+// EstimateBC bestimmt den BC aus einer fernen Session und ihrer nahen Session.
+//
+// Projektil, Widerstandskurve und Atmosphäre kommen aus der nahen Session.
+// Zwei Auswertungen:
+//   - paarweise (zwei Chronographen): ein BC je Schusspaar, Intervall aus
+//     deren Streuung (t-Verteilung)
+//   - über die Mittelwerte (ein Chronograph, nacheinander): ein BC aus beiden
+//     Mittelwerten, Intervall aus deren Standardfehlern, fortgepflanzt über
+//     die Steigung des BC nach beiden Geschwindigkeiten
+//
+// Das Ergebnis ist ein Vorschlag; übernommen wird er mit
+// ProjectileService.ApplyBCEstimate.
+func (s *SessionService) EstimateBC(sessionID string, request BCEstimateRequestDTO) Result[BCEstimateDTO] {
+	if sessionID == "" {
+		return FailWithMessage[BCEstimateDTO]("Session-ID darf nicht leer sein")
+	}
+
+	far, err := s.sessionRepo.Load(sessionID)
+	if err != nil {
+		return FailWithMessage[BCEstimateDTO](fmt.Sprintf("Session nicht gefunden: %s", sessionID))
+	}
+	if far.Downrange == nil {
+		return FailWithMessage[BCEstimateDTO]("Session ist nicht mit einer nahen Session verknüpft")
+	}
+	near, err := s.sessionRepo.Load(far.Downrange.NearSessionID)
+	if err != nil {
+		return FailWithMessage[BCEstimateDTO](fmt.Sprintf("Nahe Session nicht gefunden: %s", far.Downrange.NearSessionID))
+	}
+
+	projectile := near.ProjectileSnapshot
+	model := projectile.DragModel
+	if request.DragModel != "" {
+		model = ballistics.DragModel(request.DragModel)
+	}
+	// Snapshots vor Schema 2 ohne Modell meinten G1
+	dragModel, err := ballistics.ParseDragModel(string(model))
+	if err != nil {
+		return Fail[BCEstimateDTO](err)
+	}
+	unit := projectile.BCUnit
+	if unit == "" {
+		unit = ballistics.BCUnitImperial
+	}
+
+	fit := func(nearMPS, farMPS float64) (float64, error) {
+		return ballistics.FitBC(ballistics.FitInput{
+			Near:           valueobjects.Velocity(nearMPS),
+			Far:            valueobjects.Velocity(farMPS),
+			DistanceMeters: far.Downrange.DistanceMeters,
+			DragModel:      dragModel,
+			DragTable:      projectile.DragTable,
+			Atmosphere:     near.Atmosphere(),
+		})
+	}
+
+	dto := BCEstimateDTO{
+		NearSessionID:  near.ID,
+		FarSessionID:   far.ID,
+		ProjectileID:   projectile.ID,
+		ProjectileName: projectile.Name,
+		DistanceMeters: far.Downrange.DistanceMeters,
+		DragModel:      dragModel.String(),
+		BCUnit:         string(unit),
+		CurrentBC:      projectile.BC,
+		Paired:         request.Paired,
+		NearShotCount:  near.ValidShotCount(),
+		FarShotCount:   far.ValidShotCount(),
+	}
+
+	// bc und margin (halbe Intervallbreite) in lb/in²
+	var bc, margin float64
+	if request.Paired {
+		bc, margin, err = estimatePaired(near, far, fit, &dto)
+	} else {
+		bc, margin, err = estimateFromMeans(near, far, fit, &dto)
+	}
+	if err != nil {
+		return Fail[BCEstimateDTO](err)
+	}
+
+	dto.BC = unit.FromImperial(bc)
+	if margin > 0 {
+		lower := unit.FromImperial(math.Max(bc-margin, 0))
+		upper := unit.FromImperial(bc + margin)
+		dto.BCLower, dto.BCUpper = &lower, &upper
+	}
+	return OK(dto)
+}
+
+// bcFit bestimmt den BC (lb/in²) aus naher und ferner Geschwindigkeit.
+type bcFit func(nearMPS, farMPS float64) (float64, error)
+
+// estimatePaired wertet Schuss i nah gegen Schuss i fern aus.
+// Ergebnis: mittlerer BC und halbe Breite des 95-%-Intervalls.
+func estimatePaired(near, far *entities.Session, fit bcFit, dto *BCEstimateDTO) (float64, float64, error) {
+	if len(near.Shots) != len(far.Shots) {
+		return 0, 0, fmt.Errorf("Paarweise Auswertung braucht gleich viele Schüsse (nah %d, fern %d)",
+			len(near.Shots), len(far.Shots))
+	}
+
+	var bcs, nearVelocities, farVelocities []float64
+	for i := range near.Shots {
+		// Ein ungültiger Schuss macht das ganze Paar ungültig
+		if !near.Shots[i].Valid || !far.Shots[i].Valid {
+			continue
+		}
+		nearMPS := near.Shots[i].Velocity.MetersPerSecond()
+		farMPS := far.Shots[i].Velocity.MetersPerSecond()
+		bc, err := fit(nearMPS, farMPS)
+		if err != nil {
+			return 0, 0, fmt.Errorf("Schuss %d: %w", i+1, err)
+		}
+		bcs = append(bcs, bc)
+		nearVelocities = append(nearVelocities, nearMPS)
+		farVelocities = append(farVelocities, farMPS)
+	}
+	if len(bcs) == 0 {
+		return 0, 0, fmt.Errorf("Keine gültigen Schusspaare")
+	}
+
+	dto.PairCount = len(bcs)
+	dto.NearVelocityMPS, _ = meanAndSampleSD(nearVelocities)
+	dto.FarVelocityMPS, _ = meanAndSampleSD(farVelocities)
+
+	mean, sd := meanAndSampleSD(bcs)
+	if len(bcs) < 2 {
+		return mean, 0, nil
+	}
+	return mean, studentT95(len(bcs)-1) * sd / math.Sqrt(float64(len(bcs))), nil
+}
+
+// estimateFromMeans wertet die Mittelwerte beider Sessions aus.
+// Ergebnis: BC und halbe Breite des 95-%-Intervalls.
+func estimateFromMeans(near, far *entities.Session, fit bcFit, dto *BCEstimateDTO) (float64, float64, error) {
+	nearMPS, nearSE, err := meanAndStandardError(near)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Nahe Session: %w", err)
+	}
+	farMPS, farSE, err := meanAndStandardError(far)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Ferne Session: %w", err)
+	}
+	dto.NearVelocityMPS, dto.FarVelocityMPS = nearMPS, farMPS
+
+	bc, err := fit(nearMPS, farMPS)
+	if err != nil {
+		return 0, 0, err
+	}
+	if near.ValidShotCount() < 2 || far.ValidShotCount() < 2 {
+		return bc, 0, nil
+	}
+
+	// Gaußsche Fehlerfortpflanzung: Änderung des BC, wenn jeder Mittelwert
+	// um seinen Standardfehler verschoben wird. Liegt die Verschiebung
+	// außerhalb des plausiblen Bereichs, entfällt das Intervall.
+	byNear, errNear := fit(nearMPS+nearSE, farMPS)
+	byFar, errFar := fit(nearMPS, farMPS+farSE)
+	if errNear != nil || errFar != nil {
+		return bc, 0, nil
+	}
+	sigma := math.Hypot(byNear-bc, byFar-bc)
+
+	// Konservativ: Freiheitsgrade der kleineren Session
+	df := min(near.ValidShotCount(), far.ValidShotCount()) - 1
+	return bc, studentT95(df) * sigma, nil
+}
+
+// meanAndStandardError liefert Mittelwert und Standardfehler (SD/√n) der
+// gültigen Schüsse einer Session.
+func meanAndStandardError(session *entities.Session) (float64, float64, error) {
+	var velocities []float64
+	for _, shot := range session.Shots {
+		if shot.Valid {
+			velocities = append(velocities, shot.Velocity.MetersPerSecond())
+		}
+	}
+	if len(velocities) == 0 {
+		return 0, 0, fmt.Errorf("keine gültigen Schüsse")
+	}
+	mean, sd := meanAndSampleSD(velocities)
+	return mean, sd / math.Sqrt(float64(len(velocities))), nil
+}
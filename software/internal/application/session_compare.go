@@ -0,0 +1,256 @@
+package application
+
+import (
+	"fmt"
+	"math"
+	"metric-neo/internal/domain/entities"
+	"time"
+)
+
+// Vergleich von Sessions mit Signifikanztests.
+//
+// Nach einem Diabolo-Wechsel oder dem Einstellen des Reglers zeigt die
+// Statistik fast immer eine Differenz - die Frage ist, ob sie größer ist als
+// der Zufall einer kleinen Serie. Geprüft werden die gültigen Schüsse jeder
+// Session gegen die der ersten (Referenz).
+
+const (
+	// ComparisonAlpha ist das Signifikanzniveau aller Tests
+	ComparisonAlpha = 0.05
+
+	// MinComparisonShots: darunter erkennen die Tests nur große Unterschiede
+	// und der F-Test reagiert stark auf nicht normalverteilte Schüsse
+	MinComparisonShots = 10
+)
+
+// Warnungen in SessionTestDTO.Warnings und SessionComparisonDTO.Warnings.
+const (
+	CompareWarningTooFewShots  = "too_few_shots"        // < 2 gültige Schüsse, keine Tests
+	CompareWarningSmallSample  = "small_sample"         // < MinComparisonShots gültige Schüsse
+	CompareWarningZeroVariance = "zero_variance"        // keine Streuung, Varianztests entfallen
+	CompareWarningMultiple     = "multiple_comparisons" // mehrere Tests, p-Werte nicht korrigiert
+)
+
+// Einordnung der Effektstärke nach Cohen (|g| < 0.2, 0.5, 0.8).
+const (
+	EffectNegligible = "negligible"
+	EffectSmall      = "small"
+	EffectMedium     = "medium"
+	EffectLarge      = "large"
+)
+
+// CompareSessions stellt die Statistiken mehrerer Sessions nebeneinander und
+// testet jede weitere Session gegen die erste:
+//   - Welchs t-Test auf gleiche Mittelwerte (ohne Annahme gleicher Varianzen)
+//   - F-Test und Levene-Test (Brown-Forsythe) auf gleiche Varianzen
+//   - Effektstärke Cohens d und Hedges' g
+//
+// GO-KONZEPT: Variadische Parameter
+// ids ...string nimmt beliebig viele IDs; ein vorhandenes Slice wird mit
+// CompareSessions(ids...) übergeben.
+func (s *SessionService) CompareSessions(ids ...string) Result[SessionComparisonDTO] {
+	if len(ids) < 2 {
+		return FailWithMessage[SessionComparisonDTO]("Mindestens zwei Sessions zum Vergleichen angeben")
+	}
+
+	comparison := SessionComparisonDTO{
+		Sessions: make([]ComparedSessionDTO, 0, len(ids)),
+		Tests:    make([]SessionTestDTO, 0, len(ids)-1),
+		Warnings: []string{},
+	}
+	velocities := make([][]float64, 0, len(ids))
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if id == "" {
+			return FailWithMessage[SessionComparisonDTO]("Session-ID darf nicht leer sein")
+		}
+		if seen[id] {
+			return FailWithMessage[SessionComparisonDTO](fmt.Sprintf("Session doppelt angegeben: %s", id))
+		}
+		seen[id] = true
+
+		session, err := s.sessionRepo.Load(id)
+		if err != nil {
+			return FailWithMessage[SessionComparisonDTO](fmt.Sprintf("Session nicht gefunden: %s", id))
+		}
+		stats, err := s.statistics(session)
+		if err != nil {
+			return Fail[SessionComparisonDTO](err)
+		}
+
+		column := ComparedSessionDTO{
+			SessionID:      session.ID,
+			CreatedAt:      session.CreatedAt.Format(time.RFC3339),
+			ProfileName:    session.ProfileSnapshot.Name,
+			ProjectileName: session.ProjectileSnapshot.Name,
+			Statistics:     stats,
+		}
+		if session.ProjectileSnapshot.Lot != nil {
+			column.LotNumber = session.ProjectileSnapshot.Lot.Number
+		}
+		comparison.Sessions = append(comparison.Sessions, column)
+		velocities = append(velocities, validVelocities(session))
+	}
+
+	for i := 1; i < len(ids); i++ {
+		test := compareSamples(velocities[0], velocities[i])
+		test.ReferenceID, test.SessionID = ids[0], ids[i]
+		comparison.Tests = append(comparison.Tests, test)
+	}
+	if len(comparison.Tests) > 1 {
+		comparison.Warnings = append(comparison.Warnings, CompareWarningMultiple)
+	}
+	return OK(comparison)
+}
+
+// validVelocities sind die gültigen Schüsse einer Session in m/s.
+func validVelocities(session *entities.Session) []float64 {
+	var values []float64
+	for _, shot := range session.Shots {
+		if shot.Valid {
+			values = append(values, shot.Velocity.MetersPerSecond())
+		}
+	}
+	return values
+}
+
+// compareSamples testet b gegen die Referenz a.
+func compareSamples(a, b []float64) SessionTestDTO {
+	test := SessionTestDTO{Warnings: []string{}}
+	n1, n2 := len(a), len(b)
+	if n1 < 2 || n2 < 2 {
+		test.Warnings = append(test.Warnings, CompareWarningTooFewShots)
+		if n1 > 0 && n2 > 0 {
+			m1, _ := meanAndSampleSD(a)
+			m2, _ := meanAndSampleSD(b)
+			test.MeanDifferenceMPS = m2 - m1
+		}
+		return test
+	}
+	if n1 < MinComparisonShots || n2 < MinComparisonShots {
+		test.Warnings = append(test.Warnings, CompareWarningSmallSample)
+	}
+
+	m1, sd1 := meanAndSampleSD(a)
+	m2, sd2 := meanAndSampleSD(b)
+	v1, v2 := sd1*sd1, sd2*sd2
+	test.MeanDifferenceMPS = m2 - m1
+
+	if v1 == 0 || v2 == 0 {
+		test.Warnings = append(test.Warnings, CompareWarningZeroVariance)
+	}
+	if v1 == 0 && v2 == 0 {
+		return test
+	}
+
+	test.Means = welchTest(m2-m1, v1, v2, float64(n1), float64(n2))
+
+	// Gepoolte Stichproben-SD; Hedges' Korrektur J = 1 - 3/(4(n1+n2)-9)
+	pooled := math.Sqrt((float64(n1-1)*v1 + float64(n2-1)*v2) / float64(n1+n2-2))
+	d := (m2 - m1) / pooled
+	g := d * (1 - 3/(4*float64(n1+n2)-9))
+	test.Effect = &EffectSizeDTO{CohensD: d, HedgesG: g, Magnitude: effectMagnitude(g)}
+
+	if v1 > 0 && v2 > 0 {
+		test.Variances = varianceTests(a, b, v1, v2)
+	}
+	return test
+}
+
+// welchTest ist der t-Test für diff = m2 - m1 mit den Stichproben-Varianzen
+// v1, v2 und Freiheitsgraden nach Welch-Satterthwaite.
+func welchTest(diff, v1, v2, n1, n2 float64) *MeanTestDTO {
+	q1, q2 := v1/n1, v2/n2
+	se := math.Sqrt(q1 + q2)
+	df := (q1 + q2) * (q1 + q2) / (q1*q1/(n1-1) + q2*q2/(n2-1))
+	t := diff / se
+	p := studentTTwoSidedP(t, df)
+
+	// Quantil für gebrochene Freiheitsgrade direkt über die Verteilungsfunktion
+	quantile := bisect(func(x float64) float64 {
+		return 1 - 0.5*studentTTwoSidedP(x, df)
+	}, 1-ComparisonAlpha/2, 0, 1e6)
+
+	return &MeanTestDTO{
+		T:            t,
+		DF:           df,
+		P:            p,
+		Significant:  p < ComparisonAlpha,
+		DifferenceCI: IntervalDTO{Lower: diff - quantile*se, Upper: diff + quantile*se},
+	}
+}
+
+// varianceTests vergleicht die Streuung von b mit der von a (v1, v2 > 0).
+func varianceTests(a, b []float64, v1, v2 float64) *VarianceTestDTO {
+	df1, df2 := len(b)-1, len(a)-1
+	f := v2 / v1
+	cdf := fDistributionCDF(f, float64(df1), float64(df2))
+	result := &VarianceTestDTO{
+		F:   f,
+		DF1: df1,
+		DF2: df2,
+		FP:  math.Min(1, 2*math.Min(cdf, 1-cdf)),
+	}
+	result.Significant = result.FP < ComparisonAlpha
+
+	if w, ok := brownForsythe(a, b); ok {
+		p := 1 - fDistributionCDF(w, 1, float64(len(a)+len(b)-2))
+		result.Levene, result.LeveneP = &w, &p
+		result.Significant = p < ComparisonAlpha
+	}
+	return result
+}
+
+// brownForsythe ist die Levene-Statistik zweier Gruppen mit den absoluten
+// Abweichungen vom Gruppen-Median: eine Varianzanalyse dieser Abweichungen.
+// ok = false, wenn sie innerhalb der Gruppen nicht streuen.
+func brownForsythe(a, b []float64) (w float64, ok bool) {
+	groups := [2][]float64{deviationsFromMedian(a), deviationsFromMedian(b)}
+	total := float64(len(a) + len(b))
+
+	var grandSum float64
+	var means [2]float64
+	for i, z := range groups {
+		for _, v := range z {
+			means[i] += v
+			grandSum += v
+		}
+		means[i] /= float64(len(z))
+	}
+	grand := grandSum / total
+
+	var between, within float64
+	for i, z := range groups {
+		between += float64(len(z)) * (means[i] - grand) * (means[i] - grand)
+		for _, v := range z {
+			within += (v - means[i]) * (v - means[i])
+		}
+	}
+	if within == 0 {
+		return 0, false
+	}
+	return (total - 2) * between / within, true
+}
+
+func deviationsFromMedian(values []float64) []float64 {
+	med := median(values)
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = math.Abs(v - med)
+	}
+	return deviations
+}
+
+// effectMagnitude ordnet Hedges' g nach Cohens Faustregeln ein.
+func effectMagnitude(g float64) string {
+	switch g = math.Abs(g); {
+	case g < 0.2:
+		return EffectNegligible
+	case g < 0.5:
+		return EffectSmall
+	case g < 0.8:
+		return EffectMedium
+	default:
+		return EffectLarge
+	}
+}
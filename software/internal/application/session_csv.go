@@ -0,0 +1,940 @@
+package application
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"metric-neo/internal/domain/ballistics"
+	"metric-neo/internal/domain/entities"
+	"metric-neo/internal/domain/valueobjects"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CSV-Export/-Import von Sessions.
+//
+// FORMAT: Eine Zeile pro Shot. Session-, Profile- und Projectile-Snapshot
+// werden in jeder Zeile wiederholt, damit die Datei ohne weitere Dateien
+// in Tabellenkalkulationen ausgewertet werden kann.
+//
+// Die Einheit steckt im Spaltennamen (z.B. projectile_weight_g vs.
+// projectile_weight_gr). Der Import erkennt daran das Einheitensystem.
+// Gespeichert wird - wie überall - in SI-Einheiten (domain-model.md 5.4).
+
+// CSVOptionsDTO steuert Einheiten und Zahlenformat für CSV-Export/-Import.
+//
+// DecimalSeparator "," erzeugt Excel-kompatible Dateien für den DACH-Raum
+// (Spaltentrenner ist dann ";", sonst ",").
+type CSVOptionsDTO struct {
+	UnitSystem       string `json:"unitSystem"`       // "metric" (Default) oder "imperial" (kein "mixed")
+	DecimalSeparator string `json:"decimalSeparator"` // "." (Default) oder ","; beim Import "" = automatisch
+}
+
+// CSVImportResultDTO beschreibt das Ergebnis eines CSV-Imports.
+type CSVImportResultDTO struct {
+	Sessions  []SessionMetaDTO `json:"sessions"`
+	ShotCount int              `json:"shotCount"`
+}
+
+// csvColumns beschreibt eine Spalte je Einheitensystem.
+type csvColumn struct {
+	metric   string
+	imperial string
+}
+
+func (c csvColumn) name(unitSystem string) string {
+	if unitSystem == UnitSystemImperial && c.imperial != "" {
+		return c.imperial
+	}
+	return c.metric
+}
+
+var (
+	colSessionID          = csvColumn{metric: "session_id"}
+	colSessionCreatedAt   = csvColumn{metric: "session_created_at"}
+	colSessionNote        = csvColumn{metric: "session_note"}
+	colSessionTemperature = csvColumn{metric: "session_temperature_c", imperial: "session_temperature_f"}
+	colSessionPressure    = csvColumn{metric: "session_pressure_hpa", imperial: "session_pressure_inhg"}
+	colSessionHumidity    = csvColumn{metric: "session_humidity_pct"}
+	colSessionAltitude    = csvColumn{metric: "session_altitude_m", imperial: "session_altitude_ft"}
+	colProfileID          = csvColumn{metric: "profile_id"}
+	colProfileName        = csvColumn{metric: "profile_name"}
+	colProfileCategory    = csvColumn{metric: "profile_category"}
+	colProfileBarrel      = csvColumn{metric: "profile_barrel_length_mm", imperial: "profile_barrel_length_in"}
+	colProfileTrigger     = csvColumn{metric: "profile_trigger_weight_g"}
+	colProfileSightHeight = csvColumn{metric: "profile_sight_height_mm", imperial: "profile_sight_height_in"}
+	colProfileTwistRate   = csvColumn{metric: "profile_twist_rate_mm", imperial: "profile_twist_rate_in"}
+	colProfileOptic       = csvColumn{metric: "profile_optic"}
+	colProfilePowerPlant  = csvColumn{metric: "profile_power_plant"}
+	colProfileRegulator   = csvColumn{metric: "profile_regulator_bar", imperial: "profile_regulator_psi"}
+	colProjectileID       = csvColumn{metric: "projectile_id"}
+	colProjectileName     = csvColumn{metric: "projectile_name"}
+	colProjectileWeight   = csvColumn{metric: "projectile_weight_g", imperial: "projectile_weight_gr"}
+	colProjectileBC       = csvColumn{metric: "projectile_bc"}
+	colProjectileDrag     = csvColumn{metric: "projectile_drag_model"}
+	colProjectileBCUnit   = csvColumn{metric: "projectile_bc_unit"}
+	colProjectileCaliber  = csvColumn{metric: "projectile_caliber_mm", imperial: "projectile_caliber_in"}
+	colProjectileLotID    = csvColumn{metric: "projectile_lot_id"}
+	colProjectileLot      = csvColumn{metric: "projectile_lot"}
+	colShotIndex          = csvColumn{metric: "shot_index"}
+	colStringNumber       = csvColumn{metric: "string_number"} // Schussserie, 1-basiert
+	colStringName         = csvColumn{metric: "string_name"}
+	colStringStart        = csvColumn{metric: "string_start_pressure_bar", imperial: "string_start_pressure_psi"} // Fülldruck (PCP)
+	colStringEnd          = csvColumn{metric: "string_end_pressure_bar", imperial: "string_end_pressure_psi"}
+	colShotTimestamp      = csvColumn{metric: "shot_timestamp"}
+	colVelocityMPS        = csvColumn{metric: "velocity_mps"}
+	colVelocityFPS        = csvColumn{metric: "velocity_fps"}
+	colEnergy             = csvColumn{metric: "energy_j", imperial: "energy_ftlbf"}
+	colEnergyDensity      = csvColumn{metric: "energy_density_jcm2", imperial: "energy_density_ftlbf_in2"} // leer ohne Kaliber
+	colValid              = csvColumn{metric: "valid"}
+
+	// Prüfung gegen die Energiegrenze (leer = keine Grenze); der Import ignoriert sie
+	colEnergyLimit          = csvColumn{metric: "energy_limit_j", imperial: "energy_limit_ftlbf"}
+	colEnergyVerdict        = csvColumn{metric: "energy_verdict"}
+	colSessionEnergyVerdict = csvColumn{metric: "session_energy_verdict"}
+)
+
+// csvLayout ist die Spaltenreihenfolge beim Export.
+var csvLayout = []csvColumn{
+	colSessionID, colSessionCreatedAt, colSessionNote, colSessionTemperature,
+	colSessionPressure, colSessionHumidity, colSessionAltitude,
+	colProfileID, colProfileName, colProfileCategory, colProfileBarrel,
+	colProfileTrigger, colProfileSightHeight, colProfileTwistRate, colProfileOptic,
+	colProfilePowerPlant, colProfileRegulator,
+	colProjectileID, colProjectileName, colProjectileWeight, colProjectileBC,
+	colProjectileDrag, colProjectileBCUnit, colProjectileCaliber, colProjectileLotID, colProjectileLot,
+	colShotIndex, colStringNumber, colStringName, colStringStart, colStringEnd, colShotTimestamp, colVelocityMPS, colVelocityFPS, colEnergy, colEnergyDensity, colValid,
+	colEnergyLimit, colEnergyVerdict, colSessionEnergyVerdict,
+}
+
+// normalize prüft die Optionen und setzt Defaults.
+func (o CSVOptionsDTO) normalize(forImport bool) (CSVOptionsDTO, error) {
+	switch o.UnitSystem {
+	case "":
+		o.UnitSystem = UnitSystemMetric
+	case UnitSystemMetric, UnitSystemImperial:
+	default:
+		return o, fmt.Errorf("Unbekanntes Einheitensystem: %s", o.UnitSystem)
+	}
+
+	switch o.DecimalSeparator {
+	case "":
+		if !forImport {
+			o.DecimalSeparator = "."
+		}
+	case ".", ",":
+	default:
+		return o, fmt.Errorf("Ungültiges Dezimaltrennzeichen: %q", o.DecimalSeparator)
+	}
+	return o, nil
+}
+
+// fieldDelimiter gibt den Spaltentrenner passend zum Dezimaltrennzeichen zurück.
+func (o CSVOptionsDTO) fieldDelimiter() rune {
+	if o.DecimalSeparator == "," {
+		return ';'
+	}
+	return ','
+}
+
+// WriteSessionsCSV schreibt Sessions als CSV (eine Zeile pro Shot).
+//
+// GO-KONZEPT: io.Writer
+// Der Export kennt kein Ziel - Datei, HTTP-Response oder Buffer im Test.
+func WriteSessionsCSV(w io.Writer, sessions []*entities.Session, opts CSVOptionsDTO) error {
+	return WriteSessionsCSVWithLimits(w, sessions, opts, nil)
+}
+
+// WriteSessionsCSVWithLimits schreibt Sessions als CSV und prüft jeden Shot
+// gegen die Energiegrenze seiner Kategorie (siehe checkEnergy).
+func WriteSessionsCSVWithLimits(w io.Writer, sessions []*entities.Session, opts CSVOptionsDTO, limits []entities.EnergyLimit) error {
+	opts, err := opts.normalize(false)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = opts.fieldDelimiter()
+
+	header := make([]string, len(csvLayout))
+	for i, col := range csvLayout {
+		header[i] = col.name(opts.UnitSystem)
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	imperial := opts.UnitSystem == UnitSystemImperial
+	num := func(v float64, decimals int) string {
+		s := strconv.FormatFloat(v, 'f', decimals, 64)
+		if opts.DecimalSeparator == "," {
+			s = strings.Replace(s, ".", ",", 1)
+		}
+		return s
+	}
+	length := func(l valueobjects.Length) string {
+		if imperial {
+			return num(l.Inches(), 4)
+		}
+		return num(l.Millimeters(), 2)
+	}
+	fillPressure := func(p *valueobjects.FillPressure) string {
+		switch {
+		case p == nil:
+			return ""
+		case imperial:
+			return num(p.PSI(), 0)
+		}
+		return num(p.Bar(), 1)
+	}
+
+	for _, session := range sessions {
+		profile := session.ProfileSnapshot
+		projectile := session.ProjectileSnapshot
+		if profile == nil || projectile == nil {
+			return fmt.Errorf("Session %s hat keine Snapshots", session.ID)
+		}
+
+		temperature := ""
+		if session.Temperature != nil {
+			if imperial {
+				temperature = num(session.Temperature.Fahrenheit(), 1)
+			} else {
+				temperature = num(session.Temperature.Celsius(), 1)
+			}
+		}
+
+		pressure, humidity, altitude := "", "", ""
+		if session.Pressure != nil {
+			if imperial {
+				pressure = num(session.Pressure.InHg(), 2)
+			} else {
+				pressure = num(session.Pressure.HectoPascals(), 1)
+			}
+		}
+		if session.Humidity != nil {
+			humidity = num(session.Humidity.Percent(), 0)
+		}
+		if session.Altitude != nil {
+			if imperial {
+				altitude = num(session.Altitude.Feet(), 0)
+			} else {
+				altitude = num(session.Altitude.Meters(), 0)
+			}
+		}
+
+		twistRate := ""
+		if profile.TwistRate != nil {
+			twistRate = length(*profile.TwistRate)
+		}
+
+		optic := ""
+		if profile.Optic != nil {
+			optic = profile.Optic.ModelName
+		}
+		regulator := fillPressure(profile.RegulatorSetpoint)
+
+		lotID, lotNumber := "", ""
+		if projectile.Lot != nil {
+			lotID, lotNumber = projectile.Lot.ID, projectile.Lot.Number
+		}
+
+		weight := num(projectile.Weight.Grams(), 3)
+		if imperial {
+			weight = num(projectile.Weight.Grains(), 2)
+		}
+
+		caliber := ""
+		if projectile.Caliber != nil {
+			caliber = length(*projectile.Caliber)
+		}
+
+		energyLimit, sessionVerdict := "", ""
+		verdicts := make([]entities.EnergyVerdict, len(session.Shots))
+		if limit := entities.EnergyLimitFor(limits, profile.Category); limit != nil {
+			var check EnergyCheckDTO
+			check, verdicts = checkEnergy(session, *limit)
+			sessionVerdict = check.Verdict
+			energyLimit = num(limit.Max.Joules(), 2)
+			if imperial {
+				energyLimit = num(limit.Max.FootPounds(), 2)
+			}
+		}
+
+		for i, shot := range session.Shots {
+			str, stringName, startPressure, endPressure := session.StringOf(i), "", "", ""
+			if len(session.Strings) > 0 {
+				stringName = session.Strings[str].Name
+				if fill := session.Strings[str].Fill; fill != nil {
+					startPressure, endPressure = fillPressure(&fill.StartPressure), fillPressure(fill.EndPressure)
+				}
+			}
+			energy := shot.CalculateEnergy(projectile.Weight)
+			energyValue := num(energy.Joules(), 2)
+			if imperial {
+				energyValue = num(energy.FootPounds(), 2)
+			}
+			density := ""
+			if d, ok := projectile.EnergyDensity(energy); ok {
+				density = num(d.JoulesPerSquareCentimeter(), 2)
+				if imperial {
+					density = num(d.FootPoundsPerSquareInch(), 2)
+				}
+			}
+
+			record := []string{
+				session.ID,
+				session.CreatedAt.Format(time.RFC3339Nano),
+				session.Note,
+				temperature,
+				pressure,
+				humidity,
+				altitude,
+				profile.ID,
+				profile.Name,
+				string(profile.Category),
+				length(profile.BarrelLength),
+				num(profile.TriggerWeight.Grams(), 1),
+				length(profile.SightHeight),
+				twistRate,
+				optic,
+				string(profile.PowerPlant),
+				regulator,
+				projectile.ID,
+				projectile.Name,
+				weight,
+				num(projectile.BC, 4),
+				string(projectile.DragModel),
+				string(projectile.BCUnit),
+				caliber,
+				lotID,
+				lotNumber,
+				strconv.Itoa(i + 1),
+				strconv.Itoa(str + 1),
+				stringName,
+				startPressure,
+				endPressure,
+				shot.Timestamp.Format(time.RFC3339Nano),
+				num(shot.Velocity.MetersPerSecond(), 2),
+				num(shot.Velocity.FeetPerSecond(), 1),
+				energyValue,
+				density,
+				strconv.FormatBool(shot.Valid),
+				energyLimit,
+				string(verdicts[i]),
+				sessionVerdict,
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadSessionsCSV liest Sessions aus einer CSV-Datei.
+//
+// Zeilen werden über session_id gruppiert (leer = eine neue Session).
+// Shots werden mit NewShotAt und ihrem Original-Zeitstempel erzeugt.
+// Fehlen Profile-/Projectile-Spalten, wird lookup (Inventar) anhand der ID genutzt.
+//
+// Das Einheitensystem wird aus den Spaltennamen erkannt,
+// das Dezimaltrennzeichen aus opts oder automatisch aus dem Spaltentrenner.
+func ReadSessionsCSV(r io.Reader, opts CSVOptionsDTO, lookup SnapshotLookup) ([]*entities.Session, error) {
+	opts, err := opts.normalize(true)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimPrefix(string(data), "\ufeff") // Excel-BOM
+
+	// Spaltentrenner automatisch erkennen (";" = DACH-Format)
+	firstLine := text
+	if idx := strings.IndexAny(text, "\r\n"); idx >= 0 {
+		firstLine = text[:idx]
+	}
+	delimiter := ','
+	if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		delimiter = ';'
+	}
+	if opts.DecimalSeparator == "" {
+		opts.DecimalSeparator = "."
+		if delimiter == ';' {
+			opts.DecimalSeparator = ","
+		}
+	}
+
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("CSV konnte nicht gelesen werden: %w", err)
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("CSV enthält keine Schüsse")
+	}
+
+	rows := newCSVRows(records[0], opts.DecimalSeparator)
+	if !rows.has(colVelocityMPS.metric) && !rows.has(colVelocityFPS.metric) {
+		return nil, fmt.Errorf("CSV benötigt die Spalte %s oder %s", colVelocityMPS.metric, colVelocityFPS.metric)
+	}
+
+	// GO-KONZEPT: Map + Slice für stabile Reihenfolge
+	// Die Map gruppiert, der Slice merkt sich die Reihenfolge des Auftretens.
+	groups := map[string][]csvRow{}
+	var order []string
+	for i, record := range records[1:] {
+		row := csvRow{line: i + 2, record: record, cols: rows}
+		if row.isEmpty() {
+			continue
+		}
+		key := row.text(colSessionID.metric)
+		// Die ID wird zum Dateinamen; nur UUIDs (wie beim Export) zulassen
+		if _, err := uuid.Parse(key); key != "" && err != nil {
+			return nil, row.errorf("%s ist keine UUID: %q", colSessionID.metric, key)
+		}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], row)
+	}
+
+	sessions := make([]*entities.Session, 0, len(order))
+	for _, key := range order {
+		session, err := buildSessionFromCSV(key, groups[key], lookup)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+// SnapshotLookup liefert Stammdaten für Zeilen ohne vollständige Snapshot-Spalten.
+type SnapshotLookup interface {
+	LookupProfile(id string) (*entities.Profile, error)
+	LookupProjectile(id string) (*entities.Projectile, error)
+}
+
+// buildSessionFromCSV baut eine Session aus den Zeilen einer Gruppe.
+func buildSessionFromCSV(sessionID string, rows []csvRow, lookup SnapshotLookup) (*entities.Session, error) {
+	first := rows[0]
+
+	profile, err := first.profile(lookup)
+	if err != nil {
+		return nil, err
+	}
+	projectile, err := first.projectile(lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	session := entities.NewSession(profile, projectile)
+	// Snapshot-IDs aus der Datei übernehmen (Audit Trail zum Original)
+	session.ProfileSnapshot.ID = profile.ID
+	session.ProjectileSnapshot.ID = projectile.ID
+	session.ProjectileSnapshot.Lots = nil
+	if err := first.lot(session.ProjectileSnapshot, projectile); err != nil {
+		return nil, err
+	}
+	if sessionID != "" {
+		session.ID = sessionID
+	}
+	session.SetNote(first.text(colSessionNote.metric))
+
+	if temp, ok, err := first.temperature(); err != nil {
+		return nil, err
+	} else if ok {
+		session.SetTemperature(temp)
+	}
+	if err := first.conditions(session); err != nil {
+		return nil, err
+	}
+
+	createdAt, hasCreatedAt, err := first.time(colSessionCreatedAt.metric)
+	if err != nil {
+		return nil, err
+	}
+
+	// Shots nach shot_index sortieren (falls vorhanden), sonst Dateireihenfolge
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, errA := rows[i].int(colShotIndex.metric)
+		b, errB := rows[j].int(colShotIndex.metric)
+		return errA == nil && errB == nil && a < b
+	})
+
+	currentString := 1
+	for i, row := range rows {
+		velocity, err := row.velocity()
+		if err != nil {
+			return nil, err
+		}
+
+		timestamp, ok, err := row.time(colShotTimestamp.metric)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			// Papierprotokolle haben oft keine Uhrzeit pro Schuss
+			if !hasCreatedAt {
+				return nil, row.errorf("shot_timestamp oder session_created_at fehlt")
+			}
+			timestamp = createdAt
+		}
+		if !hasCreatedAt || timestamp.Before(createdAt) {
+			createdAt = timestamp
+			hasCreatedAt = true
+		}
+
+		// Neue Serie bei wechselnder string_number (ohne Spalte: eine Serie)
+		if row.text(colStringNumber.metric) != "" {
+			number, err := row.int(colStringNumber.metric)
+			if err != nil || number < 1 {
+				return nil, row.errorf("ungültige string_number %q", row.text(colStringNumber.metric))
+			}
+			name := row.text(colStringName.metric)
+			fill, err := row.fill()
+			if err != nil {
+				return nil, err
+			}
+			if (i == 0 && (name != "" || fill != nil)) || (i > 0 && number != currentString) {
+				str, err := session.StartString(name)
+				if err != nil {
+					return nil, row.errorf("%v", err)
+				}
+				str.StartedAt = timestamp
+				str.Fill = fill
+			}
+			currentString = number
+		}
+
+		shot := entities.NewShotAt(velocity, timestamp)
+		if valid, ok, err := row.bool(colValid.metric); err != nil {
+			return nil, err
+		} else if ok && !valid {
+			shot.MarkInvalid()
+		}
+		session.AddShot(shot)
+	}
+
+	session.CreatedAt = createdAt
+	return session, nil
+}
+
+// csvColumns ordnet Spaltennamen ihren Index zu.
+type csvColumns struct {
+	index            map[string]int
+	decimalSeparator string
+}
+
+func newCSVRows(header []string, decimalSeparator string) csvColumns {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	return csvColumns{index: index, decimalSeparator: decimalSeparator}
+}
+
+func (c csvColumns) has(name string) bool {
+	_, ok := c.index[name]
+	return ok
+}
+
+// csvRow ist eine Datenzeile mit Zugriff über Spaltennamen.
+type csvRow struct {
+	line   int
+	record []string
+	cols   csvColumns
+}
+
+func (r csvRow) errorf(format string, args ...any) error {
+	return fmt.Errorf("CSV Zeile %d: %s", r.line, fmt.Sprintf(format, args...))
+}
+
+func (r csvRow) isEmpty() bool {
+	for _, v := range r.record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (r csvRow) text(name string) string {
+	idx, ok := r.cols.index[name]
+	if !ok || idx >= len(r.record) {
+		return ""
+	}
+	return strings.TrimSpace(r.record[idx])
+}
+
+// float liest eine Zahl; ok=false wenn Spalte fehlt oder leer ist.
+func (r csvRow) float(name string) (float64, bool, error) {
+	raw := r.text(name)
+	if raw == "" {
+		return 0, false, nil
+	}
+	if r.cols.decimalSeparator == "," {
+		raw = strings.ReplaceAll(raw, ".", "") // Tausenderpunkte
+		raw = strings.Replace(raw, ",", ".", 1)
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, false, r.errorf("%s: ungültige Zahl %q", name, r.text(name))
+	}
+	return v, true, nil
+}
+
+// floatAny liest die erste vorhandene Spalte (metrisch oder imperial).
+func (r csvRow) floatAny(col csvColumn) (value float64, imperial bool, ok bool, err error) {
+	if v, ok, err := r.float(col.metric); err != nil || ok {
+		return v, false, ok, err
+	}
+	if col.imperial != "" {
+		if v, ok, err := r.float(col.imperial); err != nil || ok {
+			return v, true, ok, err
+		}
+	}
+	return 0, false, false, nil
+}
+
+func (r csvRow) int(name string) (int, error) {
+	return strconv.Atoi(r.text(name))
+}
+
+func (r csvRow) bool(name string) (bool, bool, error) {
+	switch strings.ToLower(r.text(name)) {
+	case "":
+		return false, false, nil
+	case "true", "1", "yes", "ja", "x":
+		return true, true, nil
+	case "false", "0", "no", "nein":
+		return false, true, nil
+	}
+	return false, false, r.errorf("%s: ungültiger Wahrheitswert %q", name, r.text(name))
+}
+
+// time akzeptiert RFC 3339 sowie die üblichen Tabellen-Formate.
+func (r csvRow) time(name string) (time.Time, bool, error) {
+	raw := r.text(name)
+	if raw == "" {
+		return time.Time{}, false, nil
+	}
+	layouts := []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02 15:04", "02.01.2006 15:04:05", "02.01.2006 15:04", "2006-01-02", "02.01.2006"}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, raw, time.Local); err == nil {
+			return t, true, nil
+		}
+	}
+	return time.Time{}, false, r.errorf("%s: ungültiges Datum %q", name, raw)
+}
+
+func (r csvRow) length(col csvColumn) (valueobjects.Length, bool, error) {
+	v, imperial, ok, err := r.floatAny(col)
+	if err != nil || !ok {
+		return 0, ok, err
+	}
+	if imperial {
+		l, err := valueobjects.NewLengthFromInches(v)
+		return l, true, err
+	}
+	l, err := valueobjects.NewLength(v)
+	return l, true, err
+}
+
+func (r csvRow) temperature() (valueobjects.Temperature, bool, error) {
+	v, imperial, ok, err := r.floatAny(colSessionTemperature)
+	if err != nil || !ok {
+		return 0, ok, err
+	}
+	if imperial {
+		t, err := valueobjects.NewTemperatureFromFahrenheit(v)
+		return t, true, err
+	}
+	t, err := valueobjects.NewTemperature(v)
+	return t, true, err
+}
+
+// conditions liest Luftdruck, Feuchte und Höhe (alle optional).
+func (r csvRow) conditions(session *entities.Session) error {
+	if v, imperial, ok, err := r.floatAny(colSessionPressure); err != nil {
+		return err
+	} else if ok {
+		newPressure := valueobjects.NewPressure
+		if imperial {
+			newPressure = valueobjects.NewPressureFromInHg
+		}
+		pressure, err := newPressure(v)
+		if err != nil {
+			return r.errorf("%v", err)
+		}
+		session.SetPressure(pressure)
+	}
+
+	if v, ok, err := r.float(colSessionHumidity.metric); err != nil {
+		return err
+	} else if ok {
+		humidity, err := valueobjects.NewHumidity(v)
+		if err != nil {
+			return r.errorf("%v", err)
+		}
+		session.SetHumidity(humidity)
+	}
+
+	if v, imperial, ok, err := r.floatAny(colSessionAltitude); err != nil {
+		return err
+	} else if ok {
+		newAltitude := valueobjects.NewAltitude
+		if imperial {
+			newAltitude = valueobjects.NewAltitudeFromFeet
+		}
+		altitude, err := newAltitude(v)
+		if err != nil {
+			return r.errorf("%v", err)
+		}
+		session.SetAltitude(altitude)
+	}
+	return nil
+}
+
+func (r csvRow) velocity() (valueobjects.Velocity, error) {
+	if v, ok, err := r.float(colVelocityMPS.metric); err != nil {
+		return 0, err
+	} else if ok {
+		velocity, err := valueobjects.NewVelocity(v)
+		if err != nil {
+			return 0, r.errorf("%v", err)
+		}
+		return velocity, nil
+	}
+
+	v, ok, err := r.float(colVelocityFPS.metric)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, r.errorf("Geschwindigkeit fehlt")
+	}
+	velocity, err := valueobjects.VelocityFromFPS(v)
+	if err != nil {
+		return 0, r.errorf("%v", err)
+	}
+	return velocity, nil
+}
+
+// profile baut den Profile-Snapshot aus den Spalten oder lädt ihn aus dem Inventar.
+func (r csvRow) profile(lookup SnapshotLookup) (*entities.Profile, error) {
+	id := r.text(colProfileID.metric)
+	name := r.text(colProfileName.metric)
+	triggerG, hasTrigger, err := r.float(colProfileTrigger.metric)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unvollständige Spalten: Stammdaten aus dem Inventar verwenden
+	if name == "" || !hasTrigger {
+		if id != "" && lookup != nil {
+			if p, err := lookup.LookupProfile(id); err == nil {
+				return p, nil
+			}
+		}
+		if name == "" {
+			return nil, r.errorf("profile_name fehlt und Profile %q ist nicht im Inventar", id)
+		}
+		return nil, r.errorf("profile_trigger_weight_g fehlt und Profile %q ist nicht im Inventar", id)
+	}
+
+	category := entities.ProfileCategory(r.text(colProfileCategory.metric))
+	if category == "" {
+		category = entities.CategoryAirRifle
+	}
+
+	triggerWeight, err := valueobjects.NewMass(triggerG)
+	if err != nil {
+		return nil, r.errorf("%v", err)
+	}
+	barrelLength, _, err := r.length(colProfileBarrel)
+	if err != nil {
+		return nil, r.errorf("%v", err)
+	}
+	sightHeight, _, err := r.length(colProfileSightHeight)
+	if err != nil {
+		return nil, r.errorf("%v", err)
+	}
+
+	profile, err := entities.NewProfile(name, category, barrelLength, triggerWeight, sightHeight)
+	if err != nil {
+		return nil, r.errorf("%v", err)
+	}
+	if id != "" {
+		profile.ID = id
+	}
+
+	if twist, ok, err := r.length(colProfileTwistRate); err != nil {
+		return nil, r.errorf("%v", err)
+	} else if ok {
+		profile.SetTwistRate(twist)
+	}
+
+	if plant := r.text(colProfilePowerPlant.metric); plant != "" {
+		regulator, _, err := r.fillPressure(colProfileRegulator)
+		if err != nil {
+			return nil, err
+		}
+		if err := profile.SetPowerPlant(entities.PowerPlant(plant), regulator); err != nil {
+			return nil, r.errorf("%v", err)
+		}
+	}
+
+	return profile, nil
+}
+
+// fill liest den Fülldruck der Serie (nil ohne Startdruck).
+func (r csvRow) fill() (*entities.FillEvent, error) {
+	start, ok, err := r.fillPressure(colStringStart)
+	if err != nil || !ok {
+		return nil, err
+	}
+	end, _, err := r.fillPressure(colStringEnd)
+	if err != nil {
+		return nil, err
+	}
+	fill, err := entities.NewFillEvent(*start, end)
+	if err != nil {
+		return nil, r.errorf("%v", err)
+	}
+	return fill, nil
+}
+
+// fillPressure liest einen Tankdruck in bar oder psi (nil = leer).
+func (r csvRow) fillPressure(col csvColumn) (*valueobjects.FillPressure, bool, error) {
+	v, imperial, ok, err := r.floatAny(col)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	newFillPressure := valueobjects.NewFillPressure
+	if imperial {
+		newFillPressure = valueobjects.NewFillPressureFromPSI
+	}
+	pressure, err := newFillPressure(v)
+	if err != nil {
+		return nil, false, r.errorf("%v", err)
+	}
+	return &pressure, true, nil
+}
+
+// lot setzt die Charge des Snapshots aus projectile_lot/projectile_lot_id.
+// Ohne ID wird die Charge anhand der Losnummer im Inventar-Projectile gesucht,
+// damit Sessions derselben Charge beim Vergleich zusammenfallen.
+func (r csvRow) lot(snapshot, inventory *entities.Projectile) error {
+	number := r.text(colProjectileLot.metric)
+	if number == "" {
+		return nil
+	}
+
+	lot, err := entities.NewProjectileLot(number, 0)
+	if err != nil {
+		return r.errorf("%v", err)
+	}
+	if id := r.text(colProjectileLotID.metric); id != "" {
+		lot.ID = id
+	} else if known := inventory.FindLot(number); known != nil {
+		lot = entities.CopyLot(known)
+	}
+	snapshot.Lot = lot
+	return nil
+}
+
+// projectile baut den Projectile-Snapshot aus den Spalten oder lädt ihn aus dem Inventar.
+func (r csvRow) projectile(lookup SnapshotLookup) (*entities.Projectile, error) {
+	id := r.text(colProjectileID.metric)
+	name := r.text(colProjectileName.metric)
+	weightValue, grains, hasWeight, err := r.floatAny(colProjectileWeight)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" || !hasWeight {
+		if id != "" && lookup != nil {
+			if p, err := lookup.LookupProjectile(id); err == nil {
+				return p, nil
+			}
+		}
+		if name == "" {
+			return nil, r.errorf("projectile_name fehlt und Projectile %q ist nicht im Inventar", id)
+		}
+		return nil, r.errorf("projectile_weight_g fehlt und Projectile %q ist nicht im Inventar", id)
+	}
+
+	var weight valueobjects.Mass
+	if grains {
+		weight, err = valueobjects.MassFromGrain(weightValue)
+	} else {
+		weight, err = valueobjects.NewMass(weightValue)
+	}
+	if err != nil {
+		return nil, r.errorf("%v", err)
+	}
+
+	bc, _, err := r.float(colProjectileBC.metric)
+	if err != nil {
+		return nil, err
+	}
+	// Fehlende Spalten (ältere Exporte): G1 in lb/in²
+	model, err := ballistics.ParseDragModel(r.text(colProjectileDrag.metric))
+	if err != nil {
+		return nil, r.errorf("%v", err)
+	}
+	unit, err := ballistics.ParseBCUnit(r.text(colProjectileBCUnit.metric))
+	if err != nil {
+		return nil, r.errorf("%v", err)
+	}
+
+	// Die eigene Kurve steht nicht in der CSV, nur im Inventar
+	var table ballistics.DragTable
+	if model == ballistics.Custom && id != "" && lookup != nil {
+		if p, err := lookup.LookupProjectile(id); err == nil {
+			table = p.DragTable
+		}
+	}
+	if model == ballistics.Custom && table == nil {
+		return nil, r.errorf("projectile_drag_model CUSTOM braucht die Widerstandstabelle von Projectile %q aus dem Inventar", id)
+	}
+
+	projectile, err := entities.NewProjectile(name, weight, 0)
+	if err != nil {
+		return nil, r.errorf("%v", err)
+	}
+	if err := projectile.SetDrag(bc, model, unit, table); err != nil {
+		return nil, r.errorf("%v", err)
+	}
+	caliber, hasCaliber, err := r.length(colProjectileCaliber)
+	if err != nil {
+		return nil, r.errorf("%v", err)
+	}
+	if hasCaliber {
+		if err := projectile.SetCaliber(&caliber); err != nil {
+			return nil, r.errorf("%v", err)
+		}
+	}
+	if id != "" {
+		projectile.ID = id
+	} else {
+		projectile.ID = uuid.New().String()
+	}
+
+	return projectile, nil
+}
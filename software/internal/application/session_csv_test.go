@@ -0,0 +1,300 @@
+package application
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+// setupCSVSession erstellt eine Session mit drei Schüssen (einer ungültig).
+func setupCSVSession(t *testing.T, dir string) (*SessionService, SessionDTO) {
+	t.Helper()
+
+	profileResult := NewProfileService(dir).CreateProfile("Steyr", "air_rifle", 420.0, 500.0, 50.0)
+	projectileService := NewProjectileService(dir)
+	projectileResult := projectileService.CreateProjectileWithDrag("JSB Exact", 0.547, DragDTO{BC: 0.024, DragModel: "GA"})
+	if !profileResult.Success || !projectileResult.Success {
+		t.Fatal("Setup failed")
+	}
+	caliber := 4.5
+	if r := projectileService.SetCaliber(projectileResult.Data.ID, &caliber); !r.Success {
+		t.Fatalf("SetCaliber failed: %s", r.Error)
+	}
+
+	sessionService := NewSessionService(dir)
+	temp, pressure, humidity, altitude := 21.5, 965.3, 55.0, 520.0
+	conditions := ConditionsDTO{TemperatureCelsius: &temp, PressureHPa: &pressure, HumidityPercent: &humidity, AltitudeMeters: &altitude}
+	created := sessionService.CreateSessionWithLot(profileResult.Data.ID, projectileResult.Data.ID, "", conditions, "Training; Halle")
+	if !created.Success {
+		t.Fatalf("CreateSession failed: %s", created.Error)
+	}
+
+	for _, v := range []float64{175.0, 176.5, 150.0} {
+		sessionService.RecordShot(created.Data.ID, v)
+	}
+	result := sessionService.MarkShotInvalid(created.Data.ID, 2)
+	if !result.Success {
+		t.Fatalf("MarkShotInvalid failed: %s", result.Error)
+	}
+
+	return sessionService, result.Data
+}
+
+func TestSessionService_CSVRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		opts CSVOptionsDTO
+	}{
+		{"metric dot", CSVOptionsDTO{UnitSystem: UnitSystemMetric, DecimalSeparator: "."}},
+		{"imperial comma", CSVOptionsDTO{UnitSystem: UnitSystemImperial, DecimalSeparator: ","}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, original := setupCSVSession(t, t.TempDir())
+
+			var buf bytes.Buffer
+			if err := source.ExportCSV(&buf, []string{original.ID}, tt.opts); err != nil {
+				t.Fatalf("ExportCSV failed: %v", err)
+			}
+
+			// Import in ein leeres Datenverzeichnis (ohne Inventar)
+			target := NewSessionService(t.TempDir())
+			result := target.ImportCSV(&buf, CSVOptionsDTO{})
+			if !result.Success {
+				t.Fatalf("ImportCSV failed: %s\n%s", result.Error, buf.String())
+			}
+			if len(result.Data.Sessions) != 1 || result.Data.ShotCount != 3 {
+				t.Fatalf("unexpected import result: %+v", result.Data)
+			}
+
+			imported := target.LoadSession(original.ID)
+			if !imported.Success {
+				t.Fatalf("imported session not found: %s", imported.Error)
+			}
+			got := imported.Data
+
+			if got.Note != original.Note {
+				t.Errorf("note = %q, want %q", got.Note, original.Note)
+			}
+			if got.TemperatureCelsius == nil || math.Abs(*got.TemperatureCelsius-21.5) > 0.1 {
+				t.Errorf("temperature = %v, want 21.5", got.TemperatureCelsius)
+			}
+			if got.PressureHPa == nil || math.Abs(*got.PressureHPa-965.3) > 0.5 {
+				t.Errorf("pressure = %v, want 965.3", got.PressureHPa)
+			}
+			if got.HumidityPercent == nil || *got.HumidityPercent != 55 {
+				t.Errorf("humidity = %v, want 55", got.HumidityPercent)
+			}
+			if got.AltitudeMeters == nil || math.Abs(*got.AltitudeMeters-520) > 1 {
+				t.Errorf("altitude = %v, want 520", got.AltitudeMeters)
+			}
+			if got.ProfileSnapshot.ID != original.ProfileSnapshot.ID || got.ProfileSnapshot.Name != "Steyr" {
+				t.Errorf("profile snapshot = %+v", got.ProfileSnapshot)
+			}
+			if math.Abs(got.ProfileSnapshot.BarrelLengthMM-420.0) > 0.01 {
+				t.Errorf("barrel length = %.3f, want 420", got.ProfileSnapshot.BarrelLengthMM)
+			}
+			if math.Abs(got.ProjectileSnapshot.WeightGrams-0.547) > 0.0005 {
+				t.Errorf("projectile weight = %.4f, want 0.547", got.ProjectileSnapshot.WeightGrams)
+			}
+			if got.ProjectileSnapshot.DragModel != "GA" || got.ProjectileSnapshot.BCUnit != "lb/in2" {
+				t.Errorf("drag model = %s %s, want GA lb/in2", got.ProjectileSnapshot.DragModel, got.ProjectileSnapshot.BCUnit)
+			}
+			if c := got.ProjectileSnapshot.CaliberMM; c == nil || math.Abs(*c-4.5) > 0.01 {
+				t.Errorf("caliber = %v, want 4.5 mm", c)
+			}
+			if got.CreatedAt != original.CreatedAt {
+				t.Errorf("created_at = %v, want %v", got.CreatedAt, original.CreatedAt)
+			}
+
+			for i, shot := range got.Shots {
+				want := original.Shots[i]
+				if math.Abs(shot.VelocityMPS-want.VelocityMPS) > 0.01 {
+					t.Errorf("shot %d velocity = %.2f, want %.2f", i, shot.VelocityMPS, want.VelocityMPS)
+				}
+				if shot.Timestamp != want.Timestamp {
+					t.Errorf("shot %d timestamp = %v, want %v", i, shot.Timestamp, want.Timestamp)
+				}
+				if shot.Valid != want.Valid {
+					t.Errorf("shot %d valid = %v, want %v", i, shot.Valid, want.Valid)
+				}
+			}
+		})
+	}
+}
+
+func TestSessionService_CSVRoundTrip_Strings(t *testing.T) {
+	source, original := setupCSVSession(t, t.TempDir())
+	source.StartString(original.ID, "Fill 2", nil)
+	source.RecordShot(original.ID, 174.0)
+	if r := source.RenameString(original.ID, 1, "Fill 1"); !r.Success {
+		t.Fatalf("RenameString failed: %s", r.Error)
+	}
+
+	var buf bytes.Buffer
+	if err := source.ExportCSV(&buf, []string{original.ID}, CSVOptionsDTO{}); err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	target := NewSessionService(t.TempDir())
+	if result := target.ImportCSV(&buf, CSVOptionsDTO{}); !result.Success {
+		t.Fatalf("ImportCSV failed: %s", result.Error)
+	}
+
+	got := target.LoadSession(original.ID).Data
+	if len(got.Strings) != 2 || got.Strings[0].Name != "Fill 1" || got.Strings[1].Name != "Fill 2" {
+		t.Fatalf("strings = %+v", got.Strings)
+	}
+	if got.Strings[1].FirstShot != 4 || got.Shots[3].StringNumber != 2 || got.Shots[2].StringNumber != 1 {
+		t.Errorf("shots assigned to wrong strings: %+v", got.Strings)
+	}
+}
+
+func TestSessionService_CSVRoundTrip_FillPressure(t *testing.T) {
+	dir := t.TempDir()
+	source, setup := setupCSVSession(t, dir)
+	regulator := 120.0
+	if r := NewProfileService(dir).SetPowerPlant(setup.ProfileSnapshot.ID, "pcp", &regulator); !r.Success {
+		t.Fatalf("SetPowerPlant failed: %s", r.Error)
+	}
+	original := source.CreateSession(setup.ProfileSnapshot.ID, setup.ProjectileSnapshot.ID, nil, "").Data
+	source.StartString(original.ID, "", &FillDTO{StartPressureBar: 250})
+	source.RecordShot(original.ID, 280.0)
+	source.RecordShot(original.ID, 281.0)
+	end := 240.0
+	source.SetStringFill(original.ID, 1, &FillDTO{StartPressureBar: 250, EndPressureBar: &end})
+	source.StartString(original.ID, "Fill 2", &FillDTO{StartPressureBar: 200})
+	source.RecordShot(original.ID, 279.0)
+
+	// psi hin und zurück: auf 0.1 bar genau
+	var buf bytes.Buffer
+	if err := source.ExportCSV(&buf, []string{original.ID}, CSVOptionsDTO{UnitSystem: UnitSystemImperial}); err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	if header := strings.SplitN(buf.String(), "\n", 2)[0]; !strings.Contains(header, "string_start_pressure_psi") || !strings.Contains(header, "profile_regulator_psi") {
+		t.Errorf("header missing pressure columns: %s", header)
+	}
+	target := NewSessionService(t.TempDir())
+	if result := target.ImportCSV(&buf, CSVOptionsDTO{}); !result.Success {
+		t.Fatalf("ImportCSV failed: %s", result.Error)
+	}
+
+	got := target.LoadSession(original.ID).Data
+	if p := got.ProfileSnapshot; p.PowerPlant != "pcp" || p.RegulatorSetpointBar == nil || math.Abs(*p.RegulatorSetpointBar-120) > 0.1 {
+		t.Errorf("profile = %q, regulator %v", p.PowerPlant, p.RegulatorSetpointBar)
+	}
+	if len(got.Strings) != 2 || got.Strings[0].Fill == nil || got.Strings[0].Fill.EndPressureBar == nil ||
+		math.Abs(*got.Strings[0].Fill.EndPressureBar-240) > 0.1 || got.Strings[1].Fill.EndPressureBar != nil ||
+		math.Abs(got.Strings[1].Fill.StartPressureBar-200) > 0.1 {
+		t.Fatalf("strings = %+v", got.Strings)
+	}
+}
+
+func TestSessionService_ExportCSV_Format(t *testing.T) {
+	service, session := setupCSVSession(t, t.TempDir())
+
+	var buf bytes.Buffer
+	opts := CSVOptionsDTO{UnitSystem: UnitSystemImperial, DecimalSeparator: ","}
+	if err := service.ExportCSV(&buf, []string{session.ID}, opts); err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected header + 3 rows, got %d lines", len(lines))
+	}
+	for _, col := range []string{"velocity_mps", "velocity_fps", "energy_ftlbf", "projectile_weight_gr", "session_temperature_f", "projectile_caliber_in", "energy_density_ftlbf_in2"} {
+		if !strings.Contains(lines[0], col) {
+			t.Errorf("header missing %s: %s", col, lines[0])
+		}
+	}
+	// 175 m/s = 574,1 fps, Dezimalkomma mit Semikolon als Trenner
+	if !strings.Contains(lines[1], ";175,00;574,1;") {
+		t.Errorf("unexpected row format: %s", lines[1])
+	}
+	// 0,547 g = 8,44 gr
+	if !strings.Contains(lines[1], ";8,44;") {
+		t.Errorf("projectile weight not in grains: %s", lines[1])
+	}
+}
+
+func TestSessionService_ImportCSV_PaperLog(t *testing.T) {
+	dir := t.TempDir()
+	profile := NewProfileService(dir).CreateProfile("Steyr", "air_rifle", 420.0, 500.0, 50.0)
+	projectile := NewProjectileService(dir).CreateProjectile("JSB Exact", 0.547, 0.024)
+	service := NewSessionService(dir)
+
+	// Minimales Papierprotokoll: Stammdaten aus dem Inventar, keine Uhrzeit pro Schuss
+	csv := "session_created_at;profile_id;projectile_id;velocity_fps;valid\n" +
+		"14.03.2025 10:00;" + profile.Data.ID + ";" + projectile.Data.ID + ";574,1;ja\n" +
+		"14.03.2025 10:00;" + profile.Data.ID + ";" + projectile.Data.ID + ";580,0;nein\n"
+
+	result := service.ImportCSV(strings.NewReader(csv), CSVOptionsDTO{})
+	if !result.Success {
+		t.Fatalf("ImportCSV failed: %s", result.Error)
+	}
+
+	session := service.LoadSession(result.Data.Sessions[0].ID).Data
+	if session.ProfileSnapshot.Name != "Steyr" || session.ProjectileSnapshot.Name != "JSB Exact" {
+		t.Error("snapshots not resolved from inventory")
+	}
+	if len(session.Shots) != 2 || session.Shots[1].Valid {
+		t.Errorf("unexpected shots: %+v", session.Shots)
+	}
+	if math.Abs(session.Shots[0].VelocityMPS-175.0) > 0.05 {
+		t.Errorf("velocity = %.2f, want ~175", session.Shots[0].VelocityMPS)
+	}
+}
+
+func TestSessionService_ImportCSV_Errors(t *testing.T) {
+	service, session := setupCSVSession(t, t.TempDir())
+
+	// Bereits vorhandene Session wird nicht überschrieben
+	var buf bytes.Buffer
+	if err := service.ExportCSV(&buf, []string{session.ID}, CSVOptionsDTO{}); err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	if result := service.ImportCSV(&buf, CSVOptionsDTO{}); result.Success {
+		t.Error("import of existing session should fail")
+	}
+
+	// Fehler enthalten die Zeilennummer
+	csv := "profile_name,profile_trigger_weight_g,projectile_name,projectile_weight_g,shot_timestamp,velocity_mps\n" +
+		"Steyr,500,JSB,0.547,2025-03-14T10:00:00Z,175\n" +
+		"Steyr,500,JSB,0.547,2025-03-14T10:00:05Z,abc\n"
+	result := service.ImportCSV(strings.NewReader(csv), CSVOptionsDTO{})
+	if result.Success || !strings.Contains(result.Error, "Zeile 3") {
+		t.Errorf("expected error for line 3, got: %+v", result)
+	}
+
+	if result := service.ImportCSV(strings.NewReader(csv), CSVOptionsDTO{UnitSystem: "cubits"}); result.Success {
+		t.Error("unknown unit system should fail")
+	}
+
+	// Scheitert das Speichern der zweiten Session, bleibt auch die erste nicht
+	// liegen: Der Import lässt sich danach unverändert wiederholen
+	repos := NewJSONRepositories(t.TempDir())
+	flaky := &flakySessionRepo{SessionRepository: repos.Sessions, passes: 1, failures: 1}
+	repos.Sessions = flaky
+	importer := NewSessionServiceWith(repos)
+	csv = "session_id,session_created_at,profile_name,profile_trigger_weight_g,projectile_name,projectile_weight_g,velocity_mps\n" +
+		"6f1c2a8e-3b1d-4c55-9a0e-2d7f4b9c1a01,2025-03-14T10:00:00Z,Steyr,500,JSB,0.547,175\n" +
+		"6f1c2a8e-3b1d-4c55-9a0e-2d7f4b9c1a02,2025-03-15T10:00:00Z,Steyr,500,JSB,0.547,176\n"
+	if result := importer.ImportCSV(strings.NewReader(csv), CSVOptionsDTO{}); result.Success || !strings.Contains(result.Error, "nichts importiert") {
+		t.Errorf("expected rolled back import, got: %+v", result)
+	}
+	if ids, _ := repos.Sessions.List(); len(ids) != 0 {
+		t.Errorf("sessions left after failed import: %v", ids)
+	}
+	if result := importer.ImportCSV(strings.NewReader(csv), CSVOptionsDTO{}); !result.Success || len(result.Data.Sessions) != 2 {
+		t.Errorf("retried import = %+v", result)
+	}
+
+	// session_id wird zum Dateinamen: nur UUIDs, kein Pfad
+	csv = "session_id,profile_name,profile_trigger_weight_g,projectile_name,projectile_weight_g,velocity_mps\n" +
+		"../../pwned,Steyr,500,JSB,0.547,175\n"
+	if result := service.ImportCSV(strings.NewReader(csv), CSVOptionsDTO{}); result.Success || !strings.Contains(result.Error, "Zeile 2") {
+		t.Errorf("expected error for session_id in line 2, got: %+v", result)
+	}
+}
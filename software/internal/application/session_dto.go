@@ -2,7 +2,7 @@ package application
 
 import (
 	"metric-neo/internal/domain/entities"
-	"metric-neo/internal/domain/valueobjects"
+	"metric-neo/internal/infrastructure/persistence"
 	"time"
 )
 
@@ -17,24 +17,72 @@ type SessionDTO struct {
 	ProjectileSnapshot ProjectileDTO `json:"projectileSnapshot"` // Embedded snapshot
 	Shots              []ShotDTO     `json:"shots"`
 	TemperatureCelsius *float64      `json:"temperatureCelsius,omitempty"`
+	PressureHPa        *float64      `json:"pressureHPa,omitempty"`
+	HumidityPercent    *float64      `json:"humidityPercent,omitempty"`
+	AltitudeMeters     *float64      `json:"altitudeMeters,omitempty"`
 	Note               string        `json:"note"`
 	CreatedAt          string        `json:"createdAt"` // ISO 8601 timestamp
+
+	// Abgeleitet aus den Umgebungsbedingungen (nur wenn Druck oder Höhe bekannt)
+	AirDensityKgM3        *float64 `json:"airDensityKgM3,omitempty"`
+	DensityAltitudeMeters *float64 `json:"densityAltitudeMeters,omitempty"`
+
+	Downrange *DownrangeDTO `json:"downrange,omitempty"` // nur bei ferner Messung
+
+	// Schussserien (z.B. je Füllung); leer = Session nicht unterteilt
+	Strings []ShotStringDTO `json:"strings"`
+
+	// Prüfung gegen die Energiegrenze der Kategorie (nil = keine Grenze)
+	EnergyCheck *EnergyCheckDTO `json:"energyCheck,omitempty"`
+}
+
+// ConditionsDTO sind die Umgebungsbedingungen beim Anlegen einer Session.
+// nil = nicht gemessen.
+type ConditionsDTO struct {
+	TemperatureCelsius *float64 `json:"temperatureCelsius,omitempty"`
+	PressureHPa        *float64 `json:"pressureHPa,omitempty"` // Stationsdruck, nicht QNH
+	HumidityPercent    *float64 `json:"humidityPercent,omitempty"`
+	AltitudeMeters     *float64 `json:"altitudeMeters,omitempty"`
 }
 
 // ShotDTO ist die Wails-kompatible Repräsentation eines Shot.
 type ShotDTO struct {
 	VelocityMPS  float64 `json:"velocityMPS"` // Meters per second
 	EnergyJoules float64 `json:"energyJoules"`
-	Timestamp    string  `json:"timestamp"` // ISO 8601
-	Valid        bool    `json:"valid"`
+	VelocityFPS  float64 `json:"velocityFPS"` // Feet per second
+	EnergyFtLbf  float64 `json:"energyFtLbf"`
+
+	// Energie pro Querschnittsfläche; nil = Kaliber des Projectiles unbekannt
+	EnergyDensityJCM2 *float64 `json:"energyDensityJCM2,omitempty"`
+	Timestamp         string   `json:"timestamp"` // ISO 8601
+	Valid             bool     `json:"valid"`
+
+	// "ok", "near" oder "exceeded"; leer = keine Energiegrenze
+	EnergyVerdict string `json:"energyVerdict,omitempty"`
+
+	StringNumber int `json:"stringNumber"` // Serie des Schusses (1-basiert)
+}
+
+// ShotStringDTO ist eine Schussserie einer Session.
+type ShotStringDTO struct {
+	Number    int      `json:"number"` // 1-basiert
+	Name      string   `json:"name"`
+	FirstShot int      `json:"firstShot"` // Schussnummer wie in der Session-Ansicht (1-basiert)
+	ShotCount int      `json:"shotCount"`
+	StartedAt string   `json:"startedAt"` // ISO 8601
+	Fill      *FillDTO `json:"fill,omitempty"`
 }
 
 // SessionMetaDTO ist eine leichtgewichtige Version für Listen-Ansichten.
 // Enthält KEINE Shots, nur Metadata für Performance.
 type SessionMetaDTO struct {
 	ID              string  `json:"id"`
-	ProfileName     string  `json:"profileName"`    // Aus Snapshot
-	ProjectileName  string  `json:"projectileName"` // Aus Snapshot
+	ProfileID       string  `json:"profileId"`           // Aus Snapshot
+	ProfileName     string  `json:"profileName"`         // Aus Snapshot
+	ProjectileID    string  `json:"projectileId"`        // Aus Snapshot
+	ProjectileName  string  `json:"projectileName"`      // Aus Snapshot
+	LotID           string  `json:"lotId,omitempty"`     // Aus Snapshot
+	LotNumber       string  `json:"lotNumber,omitempty"` // Aus Snapshot
 	ShotCount       int     `json:"shotCount"`
 	ValidShotCount  int     `json:"validShotCount"`
 	CreatedAt       string  `json:"createdAt"`
@@ -43,6 +91,30 @@ type SessionMetaDTO struct {
 	AvgEnergyJoules float64 `json:"avgEnergyJoules,omitempty"`
 }
 
+// SessionQueryDTO filtert und blättert die Session-Liste.
+// Leere Felder filtern nicht; Limit 0 = alle.
+type SessionQueryDTO struct {
+	ProfileID    string `json:"profileId"`
+	ProjectileID string `json:"projectileId"`
+	LotID        string `json:"lotId"`
+	From         string `json:"from"` // YYYY-MM-DD oder RFC3339, inklusive
+	To           string `json:"to"`   // YYYY-MM-DD (ganzer Tag) oder RFC3339, inklusive
+	Sort         string `json:"sort"` // "newest" (Standard) oder "oldest"
+	Offset       int    `json:"offset"`
+	Limit        int    `json:"limit"`
+}
+
+// SessionPageDTO ist eine Seite der Session-Liste.
+type SessionPageDTO struct {
+	Sessions []SessionMetaDTO `json:"sessions"`
+	Total    int              `json:"total"` // Treffer insgesamt (vor Offset/Limit)
+	Offset   int              `json:"offset"`
+	Limit    int              `json:"limit"`
+
+	// Warnings: nicht fatale Probleme, z.B. ein nicht speicherbarer Index
+	Warnings []string `json:"warnings"`
+}
+
 // StatisticsDTO enthält berechnete Statistiken einer Session.
 type StatisticsDTO struct {
 	AvgVelocityMPS    float64 `json:"avgVelocityMPS"`
@@ -51,8 +123,177 @@ type StatisticsDTO struct {
 	MaxVelocityMPS    float64 `json:"maxVelocityMPS"`
 	ExtremeSpread     float64 `json:"extremeSpread"`
 	AvgEnergyJoules   float64 `json:"avgEnergyJoules"`
+	AvgVelocityFPS    float64 `json:"avgVelocityFPS"`
+	AvgEnergyFtLbf    float64 `json:"avgEnergyFtLbf"`
 	ValidShotCount    int     `json:"validShotCount"`
-	TotalShotCount    int     `json:"totalShotCount"`
+
+	// Mittlere Energiedichte; nil = Kaliber des Projectiles unbekannt
+	AvgEnergyDensityJCM2 *float64 `json:"avgEnergyDensityJCM2,omitempty"`
+	TotalShotCount       int      `json:"totalShotCount"`
+
+	// StandardDeviation ist die Streuung dieser Schüsse (durch n),
+	// SampleStandardDeviation schätzt die der Waffe (durch n-1)
+	SampleStandardDeviation float64         `json:"sampleStandardDeviation"`
+	CoefficientOfVariation  float64         `json:"coefficientOfVariation"` // Stichproben-SD / Mittelwert in %
+	MedianVelocityMPS       float64         `json:"medianVelocityMPS"`
+	Percentiles             []PercentileDTO `json:"percentiles"`
+	MeanAbsoluteDeviation   float64         `json:"meanAbsoluteDeviation"` // Mittel von |x - Mittelwert|
+
+	// 95-%-Konfidenzintervalle (ab 2 gültigen Schüssen)
+	MeanCI              *IntervalDTO `json:"meanCI,omitempty"`
+	StandardDeviationCI *IntervalDTO `json:"standardDeviationCI,omitempty"`
+
+	// Vorschläge zum Markieren als ungültig (Entscheidung beim Benutzer)
+	OutlierSuggestions []OutlierDTO `json:"outlierSuggestions"`
+
+	EnergyCheck *EnergyCheckDTO `json:"energyCheck,omitempty"` // nil = keine Energiegrenze
+
+	// Statistik je Schussserie; leer = Session nicht unterteilt
+	Strings []StringStatisticsDTO `json:"strings,omitempty"`
+}
+
+// StringStatisticsDTO ist die Statistik der gültigen Schüsse einer Serie.
+type StringStatisticsDTO struct {
+	SessionID               string   `json:"sessionId,omitempty"` // nur bei Auswertung eines Profils
+	Number                  int      `json:"number"`              // 1-basiert
+	Name                    string   `json:"name"`
+	FirstShot               int      `json:"firstShot"` // 1-basiert
+	ShotCount               int      `json:"shotCount"`
+	ValidShotCount          int      `json:"validShotCount"`
+	AvgVelocityMPS          float64  `json:"avgVelocityMPS"`
+	SampleStandardDeviation float64  `json:"sampleStandardDeviation"`
+	MinVelocityMPS          float64  `json:"minVelocityMPS"`
+	MaxVelocityMPS          float64  `json:"maxVelocityMPS"`
+	ExtremeSpread           float64  `json:"extremeSpread"`
+	AvgEnergyJoules         float64  `json:"avgEnergyJoules"`
+	Fill                    *FillDTO `json:"fill,omitempty"`
+}
+
+// PercentileDTO ist ein Perzentil der Geschwindigkeit (lineare Interpolation).
+type PercentileDTO struct {
+	Percent     int     `json:"percent"`
+	VelocityMPS float64 `json:"velocityMPS"`
+}
+
+// IntervalDTO ist ein Konfidenzintervall.
+type IntervalDTO struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+}
+
+// OutlierDTO schlägt einen Schuss als Ausreißer vor.
+type OutlierDTO struct {
+	ShotIndex    int      `json:"shotIndex"` // Index in shots (wie MarkShotInvalid)
+	VelocityMPS  float64  `json:"velocityMPS"`
+	DeviationMPS float64  `json:"deviationMPS"` // Abstand zum Median
+	Methods      []string `json:"methods"`      // OutlierMethodGrubbs, OutlierMethodMAD
+}
+
+// EnergyCheckDTO ist die Prüfung einer Session gegen die Energiegrenze
+// ihrer Sportgeräte-Kategorie (nur gültige Schüsse).
+type EnergyCheckDTO struct {
+	Label       string  `json:"label"` // z.B. "DE F-Zeichen"
+	Category    string  `json:"category"`
+	LimitJoules float64 `json:"limitJoules"`
+	WarnJoules  float64 `json:"warnJoules"` // ab hier "near"
+
+	// "ok", "near" oder "exceeded"; leer = noch keine gültigen Schüsse
+	Verdict          string   `json:"verdict"`
+	ValidShotCount   int      `json:"validShotCount"`
+	ExceededShots    int      `json:"exceededShots"`
+	NearShots        int      `json:"nearShots"`
+	MaxShotJoules    float64  `json:"maxShotJoules"`
+	MeanJoules       float64  `json:"meanJoules"`
+	UpperBoundJoules *float64 `json:"upperBoundJoules,omitempty"` // obere 95-%-Grenze der mittleren Energie (ab 2 Schüssen)
+}
+
+// LotStatisticsDTO fasst alle gültigen Schüsse der Sessions einer Charge zusammen.
+// LotID "" steht für Sessions ohne Charge.
+type LotStatisticsDTO struct {
+	LotID              string   `json:"lotId"`
+	LotNumber          string   `json:"lotNumber"`
+	AverageWeightGrams *float64 `json:"averageWeightGrams,omitempty"` // gemessen, aus dem jüngsten Snapshot
+	SessionCount       int      `json:"sessionCount"`
+	ValidShotCount     int      `json:"validShotCount"`
+	AvgVelocityMPS     float64  `json:"avgVelocityMPS"`
+	StandardDeviation  float64  `json:"standardDeviation"`
+	MinVelocityMPS     float64  `json:"minVelocityMPS"`
+	MaxVelocityMPS     float64  `json:"maxVelocityMPS"`
+	ExtremeSpread      float64  `json:"extremeSpread"`
+	AvgEnergyJoules    float64  `json:"avgEnergyJoules"`
+	FirstSessionAt     string   `json:"firstSessionAt"` // ISO 8601
+	LastSessionAt      string   `json:"lastSessionAt"`  // ISO 8601
+}
+
+// SessionComparisonDTO stellt Sessions nebeneinander. Die erste Session ist
+// die Referenz; jede weitere wird gegen sie getestet.
+type SessionComparisonDTO struct {
+	Sessions []ComparedSessionDTO `json:"sessions"`
+	Tests    []SessionTestDTO     `json:"tests"` // eine pro Session nach der Referenz
+
+	// Warnings gelten für den ganzen Vergleich (CompareWarningMultiple)
+	Warnings []string `json:"warnings"`
+}
+
+// ComparedSessionDTO ist eine Spalte des Vergleichs.
+type ComparedSessionDTO struct {
+	SessionID      string        `json:"sessionId"`
+	CreatedAt      string        `json:"createdAt"` // ISO 8601
+	ProfileName    string        `json:"profileName"`
+	ProjectileName string        `json:"projectileName"`
+	LotNumber      string        `json:"lotNumber,omitempty"`
+	Statistics     StatisticsDTO `json:"statistics"`
+}
+
+// SessionTestDTO vergleicht eine Session mit der Referenz. Die Tests sind
+// nil, wenn die Daten sie nicht tragen (siehe Warnings).
+type SessionTestDTO struct {
+	ReferenceID string `json:"referenceId"`
+	SessionID   string `json:"sessionId"`
+
+	// MeanDifferenceMPS ist Session minus Referenz
+	MeanDifferenceMPS float64 `json:"meanDifferenceMPS"`
+
+	Means     *MeanTestDTO     `json:"means,omitempty"`
+	Variances *VarianceTestDTO `json:"variances,omitempty"`
+	Effect    *EffectSizeDTO   `json:"effect,omitempty"`
+
+	// Warnings: CompareWarningTooFewShots, CompareWarningSmallSample,
+	// CompareWarningZeroVariance
+	Warnings []string `json:"warnings"`
+}
+
+// MeanTestDTO ist Welchs t-Test auf gleiche Mittelwerte.
+type MeanTestDTO struct {
+	T            float64     `json:"t"`
+	DF           float64     `json:"df"` // Welch-Satterthwaite, gebrochen
+	P            float64     `json:"p"`  // zweiseitig
+	Significant  bool        `json:"significant"`
+	DifferenceCI IntervalDTO `json:"differenceCI"` // 95 % für MeanDifferenceMPS
+}
+
+// VarianceTestDTO prüft, ob sich die Streuung unterscheidet.
+type VarianceTestDTO struct {
+	// F = Varianz Session / Varianz Referenz (Stichproben-Varianzen)
+	F   float64 `json:"f"`
+	DF1 int     `json:"df1"`
+	DF2 int     `json:"df2"`
+	FP  float64 `json:"fP"` // zweiseitig
+
+	// Levene-Test mit Median (Brown-Forsythe), robust gegen Ausreißer und
+	// nicht normalverteilte Schüsse; nil, wenn alle Abweichungen gleich sind
+	Levene  *float64 `json:"levene,omitempty"`
+	LeveneP *float64 `json:"leveneP,omitempty"`
+
+	// Significant folgt Levene, ohne Levene dem F-Test
+	Significant bool `json:"significant"`
+}
+
+// EffectSizeDTO ist die standardisierte Mittelwertdifferenz.
+type EffectSizeDTO struct {
+	CohensD   float64 `json:"cohensD"`   // Differenz / gepoolte Stichproben-SD
+	HedgesG   float64 `json:"hedgesG"`   // für kleine Stichproben korrigiert
+	Magnitude string  `json:"magnitude"` // EffectNegligible ... EffectLarge, nach |g|
 }
 
 // SessionToDTO konvertiert Domain-Session zu vollständigem DTO (mit Shots).
@@ -66,15 +307,57 @@ func SessionToDTO(s *entities.Session) SessionDTO {
 		CreatedAt:          s.CreatedAt.Format(time.RFC3339),
 	}
 
-	// Konvertiere Temperature (optional)
+	// Konvertiere Umgebungsbedingungen (optional)
 	if s.Temperature != nil {
 		temp := s.Temperature.Celsius()
 		dto.TemperatureCelsius = &temp
 	}
+	if s.Pressure != nil {
+		pressure := s.Pressure.HectoPascals()
+		dto.PressureHPa = &pressure
+	}
+	if s.Humidity != nil {
+		humidity := s.Humidity.Percent()
+		dto.HumidityPercent = &humidity
+	}
+	if s.Altitude != nil {
+		altitude := s.Altitude.Meters()
+		dto.AltitudeMeters = &altitude
+	}
+	atmosphere := s.Atmosphere()
+	if density, ok := atmosphere.AirDensity(); ok {
+		dto.AirDensityKgM3 = &density
+	}
+	if densityAltitude, ok := atmosphere.DensityAltitude(); ok {
+		meters := densityAltitude.Meters()
+		dto.DensityAltitudeMeters = &meters
+	}
+
+	if s.Downrange != nil {
+		dto.Downrange = &DownrangeDTO{
+			NearSessionID:  s.Downrange.NearSessionID,
+			DistanceMeters: s.Downrange.DistanceMeters,
+		}
+	}
 
 	// Konvertiere alle Shots
-	for _, shot := range s.Shots {
-		dto.Shots = append(dto.Shots, shotToDTO(shot, s.ProjectileSnapshot.Weight))
+	for i, shot := range s.Shots {
+		shotDTO := shotToDTO(shot, s.ProjectileSnapshot)
+		shotDTO.StringNumber = s.StringOf(i) + 1
+		dto.Shots = append(dto.Shots, shotDTO)
+	}
+
+	dto.Strings = make([]ShotStringDTO, 0, len(s.Strings))
+	for i, str := range s.Strings {
+		first, end := s.StringBounds(i)
+		dto.Strings = append(dto.Strings, ShotStringDTO{
+			Number:    i + 1,
+			Name:      str.Name,
+			FirstShot: first + 1,
+			ShotCount: end - first,
+			StartedAt: str.StartedAt.Format(time.RFC3339),
+			Fill:      fillToDTO(str.Fill, end-first),
+		})
 	}
 
 	return dto
@@ -84,13 +367,19 @@ func SessionToDTO(s *entities.Session) SessionDTO {
 func SessionToMetaDTO(s *entities.Session) SessionMetaDTO {
 	dto := SessionMetaDTO{
 		ID:             s.ID,
+		ProfileID:      s.ProfileSnapshot.ID,
 		ProfileName:    s.ProfileSnapshot.Name,
+		ProjectileID:   s.ProjectileSnapshot.ID,
 		ProjectileName: s.ProjectileSnapshot.Name,
 		ShotCount:      s.ShotCount(),
 		ValidShotCount: s.ValidShotCount(),
 		CreatedAt:      s.CreatedAt.Format(time.RFC3339),
 		Note:           s.Note,
 	}
+	if lot := s.ProjectileSnapshot.Lot; lot != nil {
+		dto.LotID = lot.ID
+		dto.LotNumber = lot.Number
+	}
 
 	// Berechne Durchschnittswerte wenn Shots vorhanden
 	if s.ValidShotCount() > 0 {
@@ -105,11 +394,36 @@ func SessionToMetaDTO(s *entities.Session) SessionMetaDTO {
 	return dto
 }
 
+// sessionIndexEntryToMetaDTO konvertiert einen Index-Eintrag (ohne die Session zu laden).
+func sessionIndexEntryToMetaDTO(e persistence.SessionIndexEntry) SessionMetaDTO {
+	return SessionMetaDTO{
+		ID:              e.ID,
+		ProfileID:       e.ProfileID,
+		ProfileName:     e.ProfileName,
+		ProjectileID:    e.ProjectileID,
+		ProjectileName:  e.ProjectileName,
+		LotID:           e.LotID,
+		LotNumber:       e.LotNumber,
+		ShotCount:       e.ShotCount,
+		ValidShotCount:  e.ValidShotCount,
+		CreatedAt:       e.CreatedAt.Format(time.RFC3339),
+		Note:            e.Note,
+		AvgVelocityMPS:  e.AvgVelocityMPS,
+		AvgEnergyJoules: e.AvgEnergyJoules,
+	}
+}
+
 // GetStatistics berechnet Statistiken aus Session.
 func GetStatistics(s *entities.Session) (StatisticsDTO, error) {
 	stats := StatisticsDTO{
-		ValidShotCount: s.ValidShotCount(),
-		TotalShotCount: s.ShotCount(),
+		ValidShotCount:     s.ValidShotCount(),
+		TotalShotCount:     s.ShotCount(),
+		Percentiles:        []PercentileDTO{},
+		OutlierSuggestions: []OutlierDTO{},
+	}
+
+	for i := range s.Strings {
+		stats.Strings = append(stats.Strings, stringStatistics(s, i))
 	}
 
 	// Keine gültigen Shots -> Return empty stats
@@ -120,12 +434,17 @@ func GetStatistics(s *entities.Session) (StatisticsDTO, error) {
 	// Berechne alle Statistiken
 	if avg, err := s.CalculateAverageVelocity(); err == nil {
 		stats.AvgVelocityMPS = avg.MetersPerSecond()
+		stats.AvgVelocityFPS = avg.FeetPerSecond()
 	}
 
 	if sd, err := s.CalculateStandardDeviation(); err == nil {
 		stats.StandardDeviation = sd
 	}
 
+	if sd, err := s.CalculateSampleStandardDeviation(); err == nil {
+		stats.SampleStandardDeviation = sd
+	}
+
 	if min, err := s.MinVelocity(); err == nil {
 		stats.MinVelocityMPS = min.MetersPerSecond()
 	}
@@ -140,11 +459,44 @@ func GetStatistics(s *entities.Session) (StatisticsDTO, error) {
 
 	if avgE, err := s.CalculateAverageEnergy(); err == nil {
 		stats.AvgEnergyJoules = avgE.Joules()
+		stats.AvgEnergyFtLbf = avgE.FootPounds()
+		if density, ok := s.ProjectileSnapshot.EnergyDensity(avgE); ok {
+			jcm2 := density.JoulesPerSquareCentimeter()
+			stats.AvgEnergyDensityJCM2 = &jcm2
+		}
 	}
 
+	// Median, Perzentile, Konfidenzintervalle, Ausreißer (session_statistics.go)
+	addExtendedStatistics(s, &stats)
+
 	return stats, nil
 }
 
+// stringStatistics berechnet die Statistik der Serie i wie die einer Session.
+// Eine Session ohne Serien ist Serie 0 ohne Namen und Füllung.
+func stringStatistics(s *entities.Session, i int) StringStatisticsDTO {
+	first, end := s.StringBounds(i)
+	sub, _ := GetStatistics(s.SubSession(i))
+	str := &entities.ShotString{}
+	if len(s.Strings) > 0 {
+		str = s.Strings[i]
+	}
+	return StringStatisticsDTO{
+		Number:                  i + 1,
+		Name:                    str.Name,
+		FirstShot:               first + 1,
+		ShotCount:               end - first,
+		ValidShotCount:          sub.ValidShotCount,
+		AvgVelocityMPS:          sub.AvgVelocityMPS,
+		SampleStandardDeviation: sub.SampleStandardDeviation,
+		MinVelocityMPS:          sub.MinVelocityMPS,
+		MaxVelocityMPS:          sub.MaxVelocityMPS,
+		ExtremeSpread:           sub.ExtremeSpread,
+		AvgEnergyJoules:         sub.AvgEnergyJoules,
+		Fill:                    fillToDTO(str.Fill, end-first),
+	}
+}
+
 // Helper: Konvertiert ProfileSnapshot (ist bereits ProfileCopy)
 func profileSnapshotToDTO(snapshot *entities.Profile) ProfileDTO {
 	if snapshot == nil {
@@ -180,6 +532,8 @@ func profileSnapshotToDTO(snapshot *entities.Profile) ProfileDTO {
 		dto.DefaultAmmoID = snapshot.DefaultAmmoID
 	}
 
+	setPowerPlantDTO(&dto, snapshot)
+
 	return dto
 }
 
@@ -189,20 +543,57 @@ func projectileSnapshotToDTO(snapshot *entities.Projectile) ProjectileDTO {
 		return ProjectileDTO{}
 	}
 
-	return ProjectileDTO{
-		ID:          snapshot.ID,
-		Name:        snapshot.Name,
-		WeightGrams: snapshot.Weight.Grams(),
-		BC:          snapshot.BC,
-	}
+	return ProjectileToDTO(snapshot)
 }
 
 // Helper: Konvertiert Shot zu DTO mit Energy-Berechnung
-func shotToDTO(shot *entities.Shot, projectileWeight valueobjects.Mass) ShotDTO {
-	return ShotDTO{
+func shotToDTO(shot *entities.Shot, projectile *entities.Projectile) ShotDTO {
+	energy := shot.CalculateEnergy(projectile.Weight)
+	dto := ShotDTO{
 		VelocityMPS:  shot.Velocity.MetersPerSecond(),
-		EnergyJoules: shot.CalculateEnergy(projectileWeight).Joules(),
+		EnergyJoules: energy.Joules(),
+		VelocityFPS:  shot.Velocity.FeetPerSecond(),
+		EnergyFtLbf:  energy.FootPounds(),
 		Timestamp:    shot.Timestamp.Format(time.RFC3339),
 		Valid:        shot.Valid,
 	}
+	if density, ok := projectile.EnergyDensity(energy); ok {
+		jcm2 := density.JoulesPerSquareCentimeter()
+		dto.EnergyDensityJCM2 = &jcm2
+	}
+	return dto
+}
+
+// DownrangeDTO verknüpft eine Session mit ihrer nahen Messung (BC-Bestimmung).
+type DownrangeDTO struct {
+	NearSessionID  string  `json:"nearSessionId"`
+	DistanceMeters float64 `json:"distanceMeters"`
+}
+
+// BCEstimateRequestDTO wählt Modell und Auswertung einer BC-Bestimmung.
+type BCEstimateRequestDTO struct {
+	DragModel string `json:"dragModel"` // "" = Modell des Projectiles
+	Paired    bool   `json:"paired"`    // Schuss i nah = Schuss i fern (zwei Chronographen)
+}
+
+// BCEstimateDTO ist ein aus nah/fern gemessener BC mit 95-%-Konfidenzintervall.
+// BC, Grenzen und CurrentBC sind in BCUnit angegeben und gelten für DragModel.
+type BCEstimateDTO struct {
+	NearSessionID   string   `json:"nearSessionId"`
+	FarSessionID    string   `json:"farSessionId"`
+	ProjectileID    string   `json:"projectileId"`
+	ProjectileName  string   `json:"projectileName"`
+	DistanceMeters  float64  `json:"distanceMeters"`
+	DragModel       string   `json:"dragModel"`
+	BCUnit          string   `json:"bcUnit"`
+	BC              float64  `json:"bc"`
+	BCLower         *float64 `json:"bcLower,omitempty"` // nur mit mindestens 2 Schüssen bzw. Paaren
+	BCUpper         *float64 `json:"bcUpper,omitempty"`
+	CurrentBC       float64  `json:"currentBC"` // aus dem Snapshot der nahen Session
+	Paired          bool     `json:"paired"`
+	PairCount       int      `json:"pairCount"` // nur paarweise
+	NearShotCount   int      `json:"nearShotCount"`
+	FarShotCount    int      `json:"farShotCount"`
+	NearVelocityMPS float64  `json:"nearVelocityMPS"` // Mittelwerte der verwendeten Schüsse
+	FarVelocityMPS  float64  `json:"farVelocityMPS"`
 }
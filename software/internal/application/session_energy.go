@@ -0,0 +1,98 @@
+package application
+
+import (
+	"math"
+	"metric-neo/internal/domain/entities"
+)
+
+// SetEnergyLimits setzt die Energiegrenzen, gegen die Sessions geprüft
+// werden (aus der Config, siehe Config.EnergyLimits). nil = keine Prüfung.
+func (s *SessionService) SetEnergyLimits(limits []entities.EnergyLimit) {
+	s.energyMu.Lock()
+	defer s.energyMu.Unlock()
+	s.energyLimits = limits
+}
+
+// energyLimitFor sucht die Grenze für die Kategorie des Sportgeräts.
+func (s *SessionService) energyLimitFor(category entities.ProfileCategory) *entities.EnergyLimit {
+	s.energyMu.RLock()
+	defer s.energyMu.RUnlock()
+	return entities.EnergyLimitFor(s.energyLimits, category)
+}
+
+// toDTO konvertiert eine Session samt Prüfung gegen die Energiegrenze.
+func (s *SessionService) toDTO(session *entities.Session) SessionDTO {
+	dto := SessionToDTO(session)
+	limit := s.energyLimitFor(session.ProfileSnapshot.Category)
+	if limit == nil {
+		return dto
+	}
+
+	check, verdicts := checkEnergy(session, *limit)
+	dto.EnergyCheck = &check
+	for i := range dto.Shots {
+		dto.Shots[i].EnergyVerdict = string(verdicts[i])
+	}
+	return dto
+}
+
+// checkEnergy prüft alle Schüsse einer Session gegen limit.
+//
+// Jeder Schuss bekommt ein Urteil (auch ungültige, zur Anzeige). Für die
+// Session zählen nur gültige Schüsse:
+//   - exceeded: mindestens ein Schuss über der Grenze
+//   - near: ein Schuss ab EnergyWarnRatio, oder die obere Grenze des
+//     95-%-Konfidenzintervalls der mittleren Energie erreicht EnergyWarnRatio
+//     (die Waffe kann bei weiteren Schüssen über die Grenze kommen)
+//   - ok: sonst
+//
+// Ohne gültige Schüsse bleibt das Urteil der Session leer.
+func checkEnergy(session *entities.Session, limit entities.EnergyLimit) (EnergyCheckDTO, []entities.EnergyVerdict) {
+	check := EnergyCheckDTO{
+		Label:       limit.Label,
+		Category:    string(limit.Category),
+		LimitJoules: limit.Max.Joules(),
+		WarnJoules:  limit.WarnThreshold().Joules(),
+	}
+
+	weight := session.ProjectileSnapshot.Weight
+	verdicts := make([]entities.EnergyVerdict, len(session.Shots))
+	var energies []float64
+	verdict := entities.EnergyWithinLimit
+	for i, shot := range session.Shots {
+		energy := shot.CalculateEnergy(weight)
+		verdicts[i] = limit.Check(energy)
+		if !shot.Valid {
+			continue
+		}
+
+		energies = append(energies, energy.Joules())
+		check.MaxShotJoules = math.Max(check.MaxShotJoules, energy.Joules())
+		switch verdicts[i] {
+		case entities.EnergyOverLimit:
+			check.ExceededShots++
+		case entities.EnergyNearLimit:
+			check.NearShots++
+		}
+		if verdicts[i].Severity() > verdict.Severity() {
+			verdict = verdicts[i]
+		}
+	}
+
+	check.ValidShotCount = len(energies)
+	if len(energies) == 0 {
+		return check, verdicts
+	}
+
+	mean, sd := meanAndSampleSD(energies)
+	check.MeanJoules = mean
+	if len(energies) >= 2 {
+		upper := mean + studentT95(len(energies)-1)*sd/math.Sqrt(float64(len(energies)))
+		check.UpperBoundJoules = &upper
+		if upper >= check.WarnJoules && verdict == entities.EnergyWithinLimit {
+			verdict = entities.EnergyNearLimit
+		}
+	}
+	check.Verdict = string(verdict)
+	return check, verdicts
+}
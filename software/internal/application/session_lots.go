@@ -0,0 +1,117 @@
+package application
+
+import (
+	"math"
+	"metric-neo/internal/domain/entities"
+	"metric-neo/internal/infrastructure/persistence"
+	"time"
+)
+
+// CompareLots vergleicht die Chargen eines Projectiles über alle Sessions.
+//
+// Liefert pro Charge (in der Reihenfolge ihrer ersten Session) Mittelwert,
+// Standardabweichung und Spreizung aller gültigen Schüsse. Weicht eine
+// Charge bei gleichem Sportgerät deutlich ab, liegt es an der Munition,
+// nicht an der Waffe. Sessions ohne Charge bilden eine eigene Gruppe.
+func (s *SessionService) CompareLots(projectileID string) Result[[]LotStatisticsDTO] {
+	if projectileID == "" {
+		return FailWithMessage[[]LotStatisticsDTO]("Projectile-ID darf nicht leer sein")
+	}
+
+	entries, _, err := s.sessionRepo.Query(persistence.SessionQuery{ProjectileID: projectileID, OldestFirst: true})
+	if err != nil {
+		return Fail[[]LotStatisticsDTO](err)
+	}
+
+	groups := map[string]*lotAccumulator{}
+	var order []string
+	for _, entry := range entries {
+		session, err := s.sessionRepo.Load(entry.ID)
+		if err != nil {
+			return Fail[[]LotStatisticsDTO](err)
+		}
+
+		acc, ok := groups[entry.LotID]
+		if !ok {
+			acc = &lotAccumulator{first: session.CreatedAt}
+			groups[entry.LotID] = acc
+			order = append(order, entry.LotID)
+		}
+		acc.add(session)
+	}
+
+	stats := make([]LotStatisticsDTO, 0, len(order))
+	for _, lotID := range order {
+		stats = append(stats, groups[lotID].toDTO(lotID))
+	}
+	return OK(stats)
+}
+
+// lotAccumulator sammelt die gültigen Schüsse der Sessions einer Charge.
+type lotAccumulator struct {
+	lot         *entities.ProjectileLot // aus dem jüngsten Snapshot
+	sessions    int
+	velocities  []float64
+	energySum   float64
+	first, last time.Time
+}
+
+// add übernimmt eine Session. Energie kommt aus dem Gewicht ihres eigenen
+// Snapshots - die Charge kann zwischendurch neu gewogen worden sein.
+func (a *lotAccumulator) add(session *entities.Session) {
+	a.sessions++
+	a.last = session.CreatedAt
+	if session.ProjectileSnapshot.Lot != nil {
+		a.lot = session.ProjectileSnapshot.Lot
+	}
+	for _, shot := range session.Shots {
+		if !shot.Valid {
+			continue
+		}
+		a.velocities = append(a.velocities, shot.Velocity.MetersPerSecond())
+		a.energySum += shot.CalculateEnergy(session.ProjectileSnapshot.Weight).Joules()
+	}
+}
+
+func (a *lotAccumulator) toDTO(lotID string) LotStatisticsDTO {
+	dto := LotStatisticsDTO{
+		LotID:          lotID,
+		SessionCount:   a.sessions,
+		ValidShotCount: len(a.velocities),
+		FirstSessionAt: a.first.Format(time.RFC3339),
+		LastSessionAt:  a.last.Format(time.RFC3339),
+	}
+	if a.lot != nil {
+		dto.LotNumber = a.lot.Number
+		if a.lot.AverageWeight != nil {
+			grams := a.lot.AverageWeight.Grams()
+			dto.AverageWeightGrams = &grams
+		}
+	}
+
+	n := float64(len(a.velocities))
+	if n == 0 {
+		return dto
+	}
+
+	sum := 0.0
+	dto.MinVelocityMPS, dto.MaxVelocityMPS = a.velocities[0], a.velocities[0]
+	for _, v := range a.velocities {
+		sum += v
+		dto.MinVelocityMPS = math.Min(dto.MinVelocityMPS, v)
+		dto.MaxVelocityMPS = math.Max(dto.MaxVelocityMPS, v)
+	}
+	dto.AvgVelocityMPS = sum / n
+	dto.ExtremeSpread = dto.MaxVelocityMPS - dto.MinVelocityMPS
+	dto.AvgEnergyJoules = a.energySum / n
+
+	// Standardabweichung wie Session.CalculateStandardDeviation (durch n)
+	if len(a.velocities) >= 2 {
+		variance := 0.0
+		for _, v := range a.velocities {
+			variance += (v - dto.AvgVelocityMPS) * (v - dto.AvgVelocityMPS)
+		}
+		dto.StandardDeviation = math.Sqrt(variance / n)
+	}
+	return dto
+}
@@ -2,10 +2,13 @@ package application
 
 import (
 	"fmt"
+	"io"
 	"metric-neo/internal/domain/entities"
 	"metric-neo/internal/domain/valueobjects"
 	"metric-neo/internal/infrastructure/persistence"
-	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 )
 
 // SessionService orchestriert Session-Use-Cases.
@@ -16,25 +19,38 @@ import (
 // - Verwaltet Shots (RecordShot fügt hinzu und speichert)
 // - Berechnet Statistiken
 type SessionService struct {
-	sessionRepo    *persistence.SessionRepository
-	profileRepo    *persistence.ProfileRepository
-	projectileRepo *persistence.ProjectileRepository
+	// mu serialisiert Lesen-Ändern-Speichern einer Session: Die Live-Aufnahme
+	// (CaptureService) und das Frontend schreiben gleichzeitig.
+	mu sync.Mutex
+
+	sessionRepo    SessionRepository
+	profileRepo    ProfileRepository
+	projectileRepo ProjectileRepository
+
+	// energyLimits sind die Energiegrenzen je Kategorie (siehe session_energy.go)
+	energyMu     sync.RWMutex
+	energyLimits []entities.EnergyLimit
 }
 
-// NewSessionService erstellt einen neuen SessionService.
+// NewSessionService erstellt einen neuen SessionService auf dem JSON-Backend.
 // dataDir ist das Root-Verzeichnis, Repositories nutzen:
 // - sessions/ (direkt im Root)
 // - inventory/profiles/ (Stammdaten)
 // - inventory/projectiles/ (Stammdaten)
 func NewSessionService(dataDir string) *SessionService {
+	return NewSessionServiceWith(NewJSONRepositories(dataDir))
+}
+
+// NewSessionServiceWith erstellt einen SessionService auf beliebigen Repositories.
+func NewSessionServiceWith(repos *Repositories) *SessionService {
 	return &SessionService{
-		sessionRepo:    persistence.NewSessionRepository(filepath.Join(dataDir, "sessions")),
-		profileRepo:    persistence.NewProfileRepository(filepath.Join(dataDir, "inventory", "profiles")),
-		projectileRepo: persistence.NewProjectileRepository(filepath.Join(dataDir, "inventory", "projectiles")),
+		sessionRepo:    repos.Sessions,
+		profileRepo:    repos.Profiles,
+		projectileRepo: repos.Projectiles,
 	}
 }
 
-// CreateSession erstellt eine neue Session mit Snapshots.
+// CreateSession erstellt eine neue Session mit Snapshots (ohne Charge).
 //
 // WICHTIG: Snapshot Pattern!
 // ProfileSnapshot und ProjectileSnapshot werden beim Erstellen "eingefroren".
@@ -44,6 +60,20 @@ func (s *SessionService) CreateSession(
 	projectileID string,
 	temperatureCelsius *float64,
 	note string,
+) Result[SessionDTO] {
+	return s.CreateSessionWithLot(profileID, projectileID, "", ConditionsDTO{TemperatureCelsius: temperatureCelsius}, note)
+}
+
+// CreateSessionWithLot erstellt eine Session mit der gewählten Charge des Projectiles.
+// lotID ist die ID oder die Losnummer einer Charge, "" = ohne Charge.
+// Die Charge wird im ProjectileSnapshot eingefroren, die Umgebungsbedingungen
+// werden wie bei NewTemperature auf plausible Bereiche geprüft.
+func (s *SessionService) CreateSessionWithLot(
+	profileID string,
+	projectileID string,
+	lotID string,
+	conditions ConditionsDTO,
+	note string,
 ) Result[SessionDTO] {
 	// Validierung
 	if profileID == "" {
@@ -66,16 +96,15 @@ func (s *SessionService) CreateSession(
 	}
 
 	// Erstelle Session (mit Snapshot Pattern!)
-	// GO-KONZEPT: Die NewSession-Funktion macht Deep Copies von Profile und Projectile
-	session := entities.NewSession(profile, projectile)
+	// GO-KONZEPT: NewSessionWithLot macht Deep Copies von Profile und Projectile
+	session, err := entities.NewSessionWithLot(profile, projectile, lotID)
+	if err != nil {
+		return FailWithMessage[SessionDTO](fmt.Sprintf("Charge nicht gefunden: %s", lotID))
+	}
 
 	// Setze optionale Felder
-	if temperatureCelsius != nil {
-		temp, err := valueobjects.NewTemperature(*temperatureCelsius)
-		if err != nil {
-			return Fail[SessionDTO](err)
-		}
-		session.SetTemperature(temp)
+	if err := applyConditions(session, conditions); err != nil {
+		return Fail[SessionDTO](err)
 	}
 
 	if note != "" {
@@ -87,7 +116,40 @@ func (s *SessionService) CreateSession(
 		return Fail[SessionDTO](err)
 	}
 
-	return OK(SessionToDTO(session))
+	return OK(s.toDTO(session))
+}
+
+// applyConditions validiert die Umgebungsbedingungen und setzt sie an der Session.
+func applyConditions(session *entities.Session, conditions ConditionsDTO) error {
+	if conditions.TemperatureCelsius != nil {
+		temp, err := valueobjects.NewTemperature(*conditions.TemperatureCelsius)
+		if err != nil {
+			return err
+		}
+		session.SetTemperature(temp)
+	}
+	if conditions.PressureHPa != nil {
+		pressure, err := valueobjects.NewPressure(*conditions.PressureHPa)
+		if err != nil {
+			return err
+		}
+		session.SetPressure(pressure)
+	}
+	if conditions.HumidityPercent != nil {
+		humidity, err := valueobjects.NewHumidity(*conditions.HumidityPercent)
+		if err != nil {
+			return err
+		}
+		session.SetHumidity(humidity)
+	}
+	if conditions.AltitudeMeters != nil {
+		altitude, err := valueobjects.NewAltitude(*conditions.AltitudeMeters)
+		if err != nil {
+			return err
+		}
+		session.SetAltitude(altitude)
+	}
+	return nil
 }
 
 // RecordShot fügt einen neuen Schuss zu einer Session hinzu.
@@ -101,6 +163,9 @@ func (s *SessionService) RecordShot(
 	sessionID string,
 	velocityMPS float64,
 ) Result[SessionDTO] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if sessionID == "" {
 		return FailWithMessage[SessionDTO]("Session-ID darf nicht leer sein")
 	}
@@ -127,7 +192,7 @@ func (s *SessionService) RecordShot(
 		return Fail[SessionDTO](err)
 	}
 
-	return OK(SessionToDTO(session))
+	return OK(s.toDTO(session))
 }
 
 // MarkShotInvalid markiert einen Schuss als ungültig (Fehlmessung).
@@ -135,6 +200,9 @@ func (s *SessionService) RecordShot(
 // GO-KONZEPT: Index-basierter Zugriff
 // shotIndex ist 0-basiert (wie in JavaScript Arrays)
 func (s *SessionService) MarkShotInvalid(sessionID string, shotIndex int) Result[SessionDTO] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if sessionID == "" {
 		return FailWithMessage[SessionDTO]("Session-ID darf nicht leer sein")
 	}
@@ -157,7 +225,7 @@ func (s *SessionService) MarkShotInvalid(sessionID string, shotIndex int) Result
 		return Fail[SessionDTO](err)
 	}
 
-	return OK(SessionToDTO(session))
+	return OK(s.toDTO(session))
 }
 
 // GetStatistics berechnet Statistiken für eine Session.
@@ -171,15 +239,27 @@ func (s *SessionService) GetStatistics(sessionID string) Result[StatisticsDTO] {
 		return FailWithMessage[StatisticsDTO]("Session nicht gefunden")
 	}
 
-	// Berechne Statistiken (aus session_dto.go)
-	stats, err := GetStatistics(session)
+	stats, err := s.statistics(session)
 	if err != nil {
 		return Fail[StatisticsDTO](err)
 	}
-
 	return OK(stats)
 }
 
+// statistics berechnet die Statistik einer Session (aus session_dto.go)
+// samt Prüfung gegen die Energiegrenze ihrer Kategorie.
+func (s *SessionService) statistics(session *entities.Session) (StatisticsDTO, error) {
+	stats, err := GetStatistics(session)
+	if err != nil {
+		return StatisticsDTO{}, err
+	}
+	if limit := s.energyLimitFor(session.ProfileSnapshot.Category); limit != nil {
+		check, _ := checkEnergy(session, *limit)
+		stats.EnergyCheck = &check
+	}
+	return stats, nil
+}
+
 // LoadSession lädt eine Session als vollständiges DTO (mit allen Shots).
 func (s *SessionService) LoadSession(id string) Result[SessionDTO] {
 	if id == "" {
@@ -191,34 +271,110 @@ func (s *SessionService) LoadSession(id string) Result[SessionDTO] {
 		return FailWithMessage[SessionDTO](fmt.Sprintf("Session nicht gefunden: %s", id))
 	}
 
-	return OK(SessionToDTO(session))
+	return OK(s.toDTO(session))
 }
 
-// ListSessions gibt alle Sessions als Metadata-DTOs zurück (OHNE Shots).
+// ListSessions gibt alle Sessions als Metadata-DTOs zurück (OHNE Shots),
+// neueste zuerst.
 //
-// PERFORMANCE: Lädt nur Metadata, nicht alle Shots!
+// PERFORMANCE: Liest nur den Session-Index, nicht die Session-Dateien!
 // Für Details: LoadSession() verwenden.
 func (s *SessionService) ListSessions() Result[[]SessionMetaDTO] {
-	ids, err := s.sessionRepo.List()
+	page := s.QuerySessions(SessionQueryDTO{})
+	if !page.Success {
+		return Result[[]SessionMetaDTO]{Success: false, Error: page.Error}
+	}
+	return OK(page.Data.Sessions)
+}
+
+// QuerySessions filtert, sortiert und blättert die Session-Liste über den Index.
+func (s *SessionService) QuerySessions(q SessionQueryDTO) Result[SessionPageDTO] {
+	if q.Offset < 0 || q.Limit < 0 {
+		return FailWithMessage[SessionPageDTO]("Offset und Limit dürfen nicht negativ sein")
+	}
+
+	query := persistence.SessionQuery{
+		ProfileID:    q.ProfileID,
+		ProjectileID: q.ProjectileID,
+		LotID:        q.LotID,
+		Offset:       q.Offset,
+		Limit:        q.Limit,
+	}
+	switch q.Sort {
+	case "", "newest":
+	case "oldest":
+		query.OldestFirst = true
+	default:
+		return FailWithMessage[SessionPageDTO](fmt.Sprintf("Unbekannte Sortierung: %s (newest oder oldest)", q.Sort))
+	}
+
+	var err error
+	if query.From, err = parseQueryTime(q.From, false); err != nil {
+		return FailWithMessage[SessionPageDTO](fmt.Sprintf("Ungültiges Startdatum: %s", q.From))
+	}
+	if query.To, err = parseQueryTime(q.To, true); err != nil {
+		return FailWithMessage[SessionPageDTO](fmt.Sprintf("Ungültiges Enddatum: %s", q.To))
+	}
+
+	entries, total, err := s.sessionRepo.Query(query)
 	if err != nil {
-		return Fail[[]SessionMetaDTO](err)
+		return Fail[SessionPageDTO](err)
 	}
 
-	// Lade alle Sessions und konvertiere zu Meta-DTOs
-	dtos := make([]SessionMetaDTO, 0, len(ids))
-	for _, id := range ids {
-		session, err := s.sessionRepo.Load(id)
-		if err != nil {
-			continue // Skip fehlerhafte
+	page := SessionPageDTO{
+		Sessions: make([]SessionMetaDTO, 0, len(entries)),
+		Total:    total,
+		Offset:   q.Offset,
+		Limit:    q.Limit,
+		Warnings: []string{},
+	}
+	if err := s.sessionRepo.IndexWarning(); err != nil {
+		page.Warnings = append(page.Warnings, fmt.Sprintf("Session-Index konnte nicht gespeichert werden: %v", err))
+	}
+	for _, entry := range entries {
+		page.Sessions = append(page.Sessions, sessionIndexEntryToMetaDTO(entry))
+	}
+	return OK(page)
+}
+
+// parseQueryTime liest ein Datum (YYYY-MM-DD, lokale Zeit) oder einen RFC3339-Zeitstempel.
+// endOfDay macht ein reines Datum zur exklusiven Obergrenze (Beginn des Folgetags).
+func parseQueryTime(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		if endOfDay {
+			// Der Zeitstempel selbst gehört noch dazu
+			return t.Add(time.Nanosecond), nil
 		}
-		dtos = append(dtos, SessionToMetaDTO(session))
+		return t, nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		return day.AddDate(0, 0, 1), nil
 	}
+	return day, nil
+}
 
-	return OK(dtos)
+// RebuildSessionIndex baut den Session-Index aus allen Session-Dateien neu auf.
+// Nötig nur nach manuellen Eingriffen; geänderte Dateien erkennt der Index selbst.
+func (s *SessionService) RebuildSessionIndex() Result[int] {
+	count, err := s.sessionRepo.RebuildIndex()
+	if err != nil {
+		return Fail[int](err)
+	}
+	return OK(count)
 }
 
 // DeleteSession löscht eine Session.
 func (s *SessionService) DeleteSession(id string) Result[bool] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if id == "" {
 		return FailWithMessage[bool]("ID darf nicht leer sein")
 	}
@@ -230,8 +386,28 @@ func (s *SessionService) DeleteSession(id string) Result[bool] {
 	return OK(true)
 }
 
+// OpenWireLog öffnet das Chrono-Rohdaten-Log einer Session zum Anhängen.
+// Die Datei liegt neben der Session-JSON und wird mit ihr gelöscht.
+func (s *SessionService) OpenWireLog(sessionID string) (io.WriteCloser, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("Session-ID darf nicht leer sein")
+	}
+	if _, err := s.sessionRepo.Load(sessionID); err != nil {
+		return nil, err
+	}
+	return s.sessionRepo.OpenWireLog(sessionID)
+}
+
+// WireLogPath gibt den Pfad des Rohdaten-Logs zurück (existiert evtl. nicht).
+func (s *SessionService) WireLogPath(sessionID string) string {
+	return s.sessionRepo.WireLogPath(sessionID)
+}
+
 // UpdateNote aktualisiert die Notiz einer Session.
 func (s *SessionService) UpdateNote(sessionID string, note string) Result[SessionDTO] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if sessionID == "" {
 		return FailWithMessage[SessionDTO]("Session-ID darf nicht leer sein")
 	}
@@ -247,5 +423,91 @@ func (s *SessionService) UpdateNote(sessionID string, note string) Result[Sessio
 		return Fail[SessionDTO](err)
 	}
 
-	return OK(SessionToDTO(session))
+	return OK(s.toDTO(session))
+}
+
+// ExportCSV schreibt die angegebenen Sessions als CSV nach w.
+// Leere ids = alle Sessions.
+func (s *SessionService) ExportCSV(w io.Writer, ids []string, opts CSVOptionsDTO) error {
+	if len(ids) == 0 {
+		all, err := s.sessionRepo.List()
+		if err != nil {
+			return err
+		}
+		ids = all
+	}
+
+	sessions := make([]*entities.Session, 0, len(ids))
+	for _, id := range ids {
+		session, err := s.sessionRepo.Load(id)
+		if err != nil {
+			return fmt.Errorf("Session nicht gefunden: %s", id)
+		}
+		sessions = append(sessions, session)
+	}
+
+	s.energyMu.RLock()
+	limits := s.energyLimits
+	s.energyMu.RUnlock()
+	return WriteSessionsCSVWithLimits(w, sessions, opts, limits)
+}
+
+// ImportCSV liest Sessions aus einer CSV-Datei und speichert sie.
+//
+// ALLES ODER NICHTS: Erst werden alle Zeilen geprüft, dann gespeichert.
+// Existiert eine Session-ID bereits, wird nichts importiert. Scheitert das
+// Speichern einer Session, werden die zuvor gespeicherten wieder gelöscht,
+// damit ein erneuter Import nicht an "Session existiert bereits" scheitert.
+func (s *SessionService) ImportCSV(r io.Reader, opts CSVOptionsDTO) Result[CSVImportResultDTO] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions, err := ReadSessionsCSV(r, opts, s)
+	if err != nil {
+		return Fail[CSVImportResultDTO](err)
+	}
+
+	for _, session := range sessions {
+		if _, err := s.sessionRepo.Load(session.ID); err == nil {
+			return FailWithMessage[CSVImportResultDTO](fmt.Sprintf("Session existiert bereits: %s", session.ID))
+		}
+	}
+
+	result := CSVImportResultDTO{Sessions: make([]SessionMetaDTO, 0, len(sessions))}
+	for i, session := range sessions {
+		if err := s.sessionRepo.Save(session); err != nil {
+			return Fail[CSVImportResultDTO](s.rollbackImport(sessions[:i], err))
+		}
+		result.Sessions = append(result.Sessions, SessionToMetaDTO(session))
+		result.ShotCount += session.ShotCount()
+	}
+
+	return OK(result)
+}
+
+// rollbackImport löscht die bereits importierten Sessions nach einem
+// Speicherfehler. Lässt sich eine nicht löschen, nennt der Fehler die IDs,
+// die übrig geblieben sind.
+func (s *SessionService) rollbackImport(saved []*entities.Session, cause error) error {
+	var left []string
+	for _, session := range saved {
+		if err := s.sessionRepo.Delete(session.ID); err != nil {
+			left = append(left, session.ID)
+		}
+	}
+	if len(left) > 0 {
+		return fmt.Errorf("Import abgebrochen: %w; bereits gespeicherte Sessions konnten nicht entfernt werden: %s",
+			cause, strings.Join(left, ", "))
+	}
+	return fmt.Errorf("Import abgebrochen, nichts importiert: %w", cause)
+}
+
+// LookupProfile implementiert SnapshotLookup für den CSV-Import.
+func (s *SessionService) LookupProfile(id string) (*entities.Profile, error) {
+	return s.profileRepo.Load(id)
+}
+
+// LookupProjectile implementiert SnapshotLookup für den CSV-Import.
+func (s *SessionService) LookupProjectile(id string) (*entities.Projectile, error) {
+	return s.projectileRepo.Load(id)
 }
@@ -1,7 +1,15 @@
 package application
 
 import (
+	"bytes"
+	"math"
+	"metric-neo/internal/domain/ballistics"
+	"metric-neo/internal/domain/entities"
+	"metric-neo/internal/domain/valueobjects"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestSessionService_CreateSession(t *testing.T) {
@@ -52,6 +60,40 @@ func TestSessionService_CreateSession(t *testing.T) {
 	t.Logf("✓ Session created with snapshots")
 }
 
+func TestSessionService_CreateSession_Conditions(t *testing.T) {
+	dir := t.TempDir()
+	profile := NewProfileService(dir).CreateProfile("Test Profile", "air_rifle", 420.0, 500.0, 50.0)
+	projectile := NewProjectileService(dir).CreateProjectile("JSB Exact", 0.547, 0.024)
+	sessionService := NewSessionService(dir)
+
+	temp, pressure, humidity := 15.0, 1013.25, 0.0
+	result := sessionService.CreateSessionWithLot(profile.Data.ID, projectile.Data.ID, "",
+		ConditionsDTO{TemperatureCelsius: &temp, PressureHPa: &pressure, HumidityPercent: &humidity}, "")
+	if !result.Success {
+		t.Fatalf("CreateSessionWithLot failed: %s", result.Error)
+	}
+	if result.Data.AirDensityKgM3 == nil || math.Abs(*result.Data.AirDensityKgM3-1.225) > 0.0005 {
+		t.Errorf("air density = %v, want 1.225", result.Data.AirDensityKgM3)
+	}
+	if result.Data.DensityAltitudeMeters == nil || math.Abs(*result.Data.DensityAltitudeMeters) > 5 {
+		t.Errorf("density altitude = %v, want 0", result.Data.DensityAltitudeMeters)
+	}
+
+	// Ohne Druck und Höhe keine abgeleiteten Werte
+	plain := sessionService.CreateSession(profile.Data.ID, projectile.Data.ID, &temp, "")
+	if plain.Data.AirDensityKgM3 != nil || plain.Data.DensityAltitudeMeters != nil {
+		t.Error("air density without pressure or altitude should be empty")
+	}
+
+	// Werte außerhalb der Bereiche werden abgelehnt (z.B. Druck in kPa)
+	kPa, belowSea := 101.3, -1000.0
+	for _, conditions := range []ConditionsDTO{{PressureHPa: &kPa}, {HumidityPercent: &kPa}, {AltitudeMeters: &belowSea}} {
+		if r := sessionService.CreateSessionWithLot(profile.Data.ID, projectile.Data.ID, "", conditions, ""); r.Success {
+			t.Errorf("CreateSessionWithLot(%+v) should fail", conditions)
+		}
+	}
+}
+
 func TestSessionService_SnapshotIsolation(t *testing.T) {
 	dir := t.TempDir()
 
@@ -206,6 +248,56 @@ func TestSessionService_GetStatistics(t *testing.T) {
 	t.Logf("  Avg Energy: %.2f J", stats.AvgEnergyJoules)
 }
 
+func TestSessionService_GetStatistics_Extended(t *testing.T) {
+	dir := t.TempDir()
+	profile := NewProfileService(dir).CreateProfile("Test", "air_rifle", 420.0, 500.0, 50.0)
+	projectile := NewProjectileService(dir).CreateProjectile("Test", 0.547, 0.024)
+	sessionService := NewSessionService(dir)
+	sessionID := sessionService.CreateSession(profile.Data.ID, projectile.Data.ID, nil, "").Data.ID
+
+	// Sechs ruhige Schüsse und ein Versager (Schuss 5)
+	for _, v := range []float64{175.0, 175.4, 174.8, 175.2, 168.0, 174.9, 175.1} {
+		sessionService.RecordShot(sessionID, v)
+	}
+
+	stats := sessionService.GetStatistics(sessionID).Data
+	if stats.MedianVelocityMPS != 175.0 {
+		t.Errorf("median = %.2f, want 175.0", stats.MedianVelocityMPS)
+	}
+	if stats.SampleStandardDeviation <= stats.StandardDeviation {
+		t.Errorf("sample SD %.3f should exceed population SD %.3f", stats.SampleStandardDeviation, stats.StandardDeviation)
+	}
+	if want := stats.SampleStandardDeviation / stats.AvgVelocityMPS * 100; math.Abs(stats.CoefficientOfVariation-want) > 1e-9 {
+		t.Errorf("CV = %.4f %%, want %.4f %%", stats.CoefficientOfVariation, want)
+	}
+	if len(stats.Percentiles) != 4 || stats.Percentiles[0].Percent != 10 {
+		t.Errorf("unexpected percentiles: %+v", stats.Percentiles)
+	}
+	if ci := stats.MeanCI; ci == nil || ci.Lower >= stats.AvgVelocityMPS || ci.Upper <= stats.AvgVelocityMPS {
+		t.Errorf("mean CI %+v does not contain the mean %.2f", ci, stats.AvgVelocityMPS)
+	}
+	if ci := stats.StandardDeviationCI; ci == nil || ci.Lower >= stats.SampleStandardDeviation || ci.Upper <= stats.SampleStandardDeviation {
+		t.Errorf("SD CI %+v does not contain the sample SD %.3f", ci, stats.SampleStandardDeviation)
+	}
+
+	if len(stats.OutlierSuggestions) != 1 {
+		t.Fatalf("expected one outlier suggestion, got %+v", stats.OutlierSuggestions)
+	}
+	outlier := stats.OutlierSuggestions[0]
+	if outlier.ShotIndex != 4 || outlier.DeviationMPS != -7.0 || len(outlier.Methods) != 2 {
+		t.Errorf("unexpected suggestion: %+v, want shot index 4 by grubbs and mad", outlier)
+	}
+
+	// Nur ein Vorschlag: erst MarkShotInvalid nimmt den Schuss heraus
+	if sessionService.GetStatistics(sessionID).Data.ValidShotCount != 7 {
+		t.Error("suggestions must not invalidate shots")
+	}
+	sessionService.MarkShotInvalid(sessionID, outlier.ShotIndex)
+	if again := sessionService.GetStatistics(sessionID).Data; len(again.OutlierSuggestions) != 0 {
+		t.Errorf("no outliers expected after marking shot 5 invalid, got %+v", again.OutlierSuggestions)
+	}
+}
+
 func TestSessionService_ListSessions(t *testing.T) {
 	dir := t.TempDir()
 
@@ -244,6 +336,46 @@ func TestSessionService_ListSessions(t *testing.T) {
 	t.Logf("✓ Listed %d sessions (metadata only)", len(listResult.Data))
 }
 
+func TestSessionService_QuerySessions(t *testing.T) {
+	dir := t.TempDir()
+
+	profileService := NewProfileService(dir)
+	projectileService := NewProjectileService(dir)
+	sessionService := NewSessionService(dir)
+
+	steyr := profileService.CreateProfile("Steyr", "air_rifle", 420.0, 500.0, 50.0)
+	walther := profileService.CreateProfile("Walther", "air_rifle", 420.0, 500.0, 50.0)
+	projectile := projectileService.CreateProjectile("JSB", 0.547, 0.024)
+
+	sessionService.CreateSession(steyr.Data.ID, projectile.Data.ID, nil, "1")
+	sessionService.CreateSession(walther.Data.ID, projectile.Data.ID, nil, "2")
+	sessionService.CreateSession(steyr.Data.ID, projectile.Data.ID, nil, "3")
+
+	result := sessionService.QuerySessions(SessionQueryDTO{ProfileID: steyr.Data.ID, Limit: 1})
+	if !result.Success {
+		t.Fatalf("QuerySessions failed: %s", result.Error)
+	}
+	page := result.Data
+	if page.Total != 2 || len(page.Sessions) != 1 || page.Sessions[0].ProfileID != steyr.Data.ID {
+		t.Errorf("page = %+v, want 1 of 2 Steyr sessions", page)
+	}
+
+	// Heute als ganzer Tag (YYYY-MM-DD, inklusive)
+	today := time.Now().Format("2006-01-02")
+	if result := sessionService.QuerySessions(SessionQueryDTO{From: today, To: today}); result.Data.Total != 3 {
+		t.Errorf("today: total = %d, want 3", result.Data.Total)
+	}
+	if result := sessionService.QuerySessions(SessionQueryDTO{To: "2000-01-01"}); result.Data.Total != 0 {
+		t.Errorf("before 2000: total = %d, want 0", result.Data.Total)
+	}
+
+	for _, query := range []SessionQueryDTO{{Sort: "random"}, {From: "yesterday"}, {Limit: -1}} {
+		if result := sessionService.QuerySessions(query); result.Success {
+			t.Errorf("query %+v should fail", query)
+		}
+	}
+}
+
 func TestSessionService_MarkShotInvalid(t *testing.T) {
 	dir := t.TempDir()
 
@@ -293,3 +425,419 @@ func TestSessionService_MarkShotInvalid(t *testing.T) {
 
 	t.Log("✓ Invalid shot excluded from statistics")
 }
+
+func TestSessionService_CompareLots(t *testing.T) {
+	dir := t.TempDir()
+
+	profileService := NewProfileService(dir)
+	projectileService := NewProjectileService(dir)
+	sessionService := NewSessionService(dir)
+
+	profile := profileService.CreateProfile("Steyr", "air_rifle", 420.0, 500.0, 50.0)
+	projectile := projectileService.CreateProjectile("JSB", 0.547, 0.024)
+	weight := 0.552
+	lotA := projectileService.AddLot(projectile.Data.ID, ProjectileLotDTO{Number: "A", AverageWeightGrams: &weight, CountOnHand: 500}).Data.Lots[0]
+	projectileService.AddLot(projectile.Data.ID, ProjectileLotDTO{Number: "B", CountOnHand: 500})
+
+	record := func(lotID string, velocities ...float64) SessionDTO {
+		t.Helper()
+		result := sessionService.CreateSessionWithLot(profile.Data.ID, projectile.Data.ID, lotID, ConditionsDTO{}, "")
+		if !result.Success {
+			t.Fatalf("CreateSessionWithLot(%q) failed: %s", lotID, result.Error)
+		}
+		for _, v := range velocities {
+			sessionService.RecordShot(result.Data.ID, v)
+		}
+		return result.Data
+	}
+
+	session := record(lotA.ID, 175.0, 176.0)
+	record(lotA.ID, 177.0)
+	record("B", 170.0, 172.0) // Losnummer statt ID
+	record("", 180.0)
+
+	// Die Charge ist im Snapshot eingefroren, ihr Gewicht gilt für die Energie
+	if lot := session.ProjectileSnapshot.Lot; lot == nil || lot.Number != "A" {
+		t.Fatalf("lot not in snapshot: %+v", session.ProjectileSnapshot)
+	}
+	if session.ProjectileSnapshot.WeightGrams != 0.552 {
+		t.Errorf("snapshot weight = %.3f, want lot weight 0.552", session.ProjectileSnapshot.WeightGrams)
+	}
+	if r := sessionService.CreateSessionWithLot(profile.Data.ID, projectile.Data.ID, "unknown", ConditionsDTO{}, ""); r.Success {
+		t.Error("unknown lot should fail")
+	}
+
+	if page := sessionService.QuerySessions(SessionQueryDTO{LotID: lotA.ID}); page.Data.Total != 2 || page.Data.Sessions[0].LotNumber != "A" {
+		t.Errorf("lot filter: %+v", page.Data)
+	}
+
+	result := sessionService.CompareLots(projectile.Data.ID)
+	if !result.Success {
+		t.Fatalf("CompareLots failed: %s", result.Error)
+	}
+	if len(result.Data) != 3 {
+		t.Fatalf("groups = %d, want 3 (A, B, no lot)", len(result.Data))
+	}
+	a, b, none := result.Data[0], result.Data[1], result.Data[2]
+	if a.LotNumber != "A" || a.SessionCount != 2 || a.ValidShotCount != 3 || a.AvgVelocityMPS != 176.0 || a.ExtremeSpread != 2.0 {
+		t.Errorf("lot A: %+v", a)
+	}
+	if a.AverageWeightGrams == nil || *a.AverageWeightGrams != 0.552 {
+		t.Errorf("lot A weight: %v", a.AverageWeightGrams)
+	}
+	if b.LotNumber != "B" || b.AvgVelocityMPS != 171.0 || b.StandardDeviation != 1.0 {
+		t.Errorf("lot B: %+v", b)
+	}
+	if none.LotID != "" || none.ValidShotCount != 1 {
+		t.Errorf("sessions without lot: %+v", none)
+	}
+}
+
+func TestSessionService_CompareSessions(t *testing.T) {
+	dir := t.TempDir()
+	f := newAnalyticsFixture(t, dir)
+	projectile := NewProjectileService(dir).CreateProjectile("JSB", 0.547, 0.024).Data
+
+	reference := f.record(projectile.ID, nil, 100.0, 101.0, 102.0)
+	faster := f.record(projectile.ID, nil, 104.0, 105.0, 106.0)
+	wider := f.record(projectile.ID, nil, 99.0, 101.0, 103.0)
+	constant := f.record(projectile.ID, nil, 101.0, 101.0)
+	single := f.record(projectile.ID, nil, 101.0)
+
+	result := f.sessions.CompareSessions(reference, faster, wider, constant, single)
+	if !result.Success {
+		t.Fatalf("CompareSessions failed: %s", result.Error)
+	}
+	c := result.Data
+	if len(c.Sessions) != 5 || len(c.Tests) != 4 || c.Sessions[1].Statistics.AvgVelocityMPS != 105.0 {
+		t.Fatalf("comparison: %+v", c)
+	}
+	if !slices.Contains(c.Warnings, CompareWarningMultiple) {
+		t.Errorf("warnings = %v, want %s", c.Warnings, CompareWarningMultiple)
+	}
+
+	// Gleiche Varianz 1, n = 3: t = 4/√(2/3), df = 4
+	test := c.Tests[0]
+	if test.ReferenceID != reference || test.SessionID != faster || test.MeanDifferenceMPS != 4.0 {
+		t.Errorf("test: %+v", test)
+	}
+	if m := test.Means; m == nil || math.Abs(m.T-4.899) > 1e-3 || math.Abs(m.DF-4) > 1e-9 ||
+		math.Abs(m.P-0.00805) > 1e-4 || !m.Significant || math.Abs(m.DifferenceCI.Lower-(4-2.2666)) > 1e-3 {
+		t.Errorf("welch: %+v", test.Means)
+	}
+	if e := test.Effect; e == nil || math.Abs(e.CohensD-4) > 1e-9 || math.Abs(e.HedgesG-3.2) > 1e-9 || e.Magnitude != EffectLarge {
+		t.Errorf("effect: %+v", test.Effect)
+	}
+	if v := test.Variances; v == nil || v.F != 1 || v.FP != 1 || v.Levene == nil || *v.Levene != 0 || v.Significant {
+		t.Errorf("variances: %+v", test.Variances)
+	}
+	if !slices.Contains(test.Warnings, CompareWarningSmallSample) {
+		t.Errorf("warnings = %v, want %s", test.Warnings, CompareWarningSmallSample)
+	}
+
+	// Gleicher Mittelwert, vierfache Varianz
+	test = c.Tests[1]
+	if test.Means == nil || test.Means.T != 0 || test.Means.Significant || test.Effect.Magnitude != EffectNegligible || test.Variances.F != 4 {
+		t.Errorf("wider: %+v %+v", test.Means, test.Variances)
+	}
+
+	// Ohne Streuung kein Varianztest, der t-Test bleibt
+	test = c.Tests[2]
+	if test.Means == nil || test.Variances != nil || !slices.Contains(test.Warnings, CompareWarningZeroVariance) {
+		t.Errorf("constant: %+v", test)
+	}
+
+	test = c.Tests[3]
+	if test.Means != nil || test.Effect != nil || !slices.Contains(test.Warnings, CompareWarningTooFewShots) {
+		t.Errorf("single shot: %+v", test)
+	}
+
+	for _, ids := range [][]string{{reference}, {reference, reference}, {reference, "unknown"}, {reference, ""}} {
+		if r := f.sessions.CompareSessions(ids...); r.Success {
+			t.Errorf("CompareSessions(%v) should fail", ids)
+		}
+	}
+}
+
+func TestSessionService_ShotStrings(t *testing.T) {
+	dir := t.TempDir()
+	f := newAnalyticsFixture(t, dir)
+	projectile := NewProjectileService(dir).CreateProjectile("JSB", 0.547, 0.024).Data
+	id := f.record(projectile.ID, nil, 270.0, 276.0, 280.0, 281.0, 280.0, 272.0)
+
+	result := f.sessions.StartString(id, "Fill 2", nil)
+	if !result.Success {
+		t.Fatalf("StartString failed: %s", result.Error)
+	}
+	if r := f.sessions.StartString(id, "Fill 3", nil); r.Success {
+		t.Error("StartString without shots in the current string should fail")
+	}
+	for _, v := range []float64{272.0, 278.0, 281.0, 280.0, 281.0, 274.0, 265.0} {
+		f.sessions.RecordShot(id, v)
+	}
+	f.sessions.MarkShotInvalid(id, 0)
+
+	session := f.sessions.LoadSession(id).Data
+	if len(session.Strings) != 2 || session.Strings[1].FirstShot != 7 || session.Strings[1].ShotCount != 7 || session.Shots[6].StringNumber != 2 {
+		t.Fatalf("strings = %+v", session.Strings)
+	}
+	stats := f.sessions.GetStatistics(id).Data
+	if len(stats.Strings) != 2 || stats.Strings[0].ValidShotCount != 5 || stats.Strings[1].Name != "Fill 2" || stats.Strings[1].MaxVelocityMPS != 281.0 {
+		t.Errorf("string statistics = %+v", stats.Strings)
+	}
+	if r := f.sessions.RenameString(id, 3, "x"); r.Success {
+		t.Error("RenameString of a missing string should fail")
+	}
+
+	// Schuss 3-5 liegen in beiden Füllungen bei 280.5 m/s
+	fill := f.sessions.AnalyzeShotsPerFill(id, ShotsPerFillRequestDTO{})
+	if !fill.Success {
+		t.Fatalf("AnalyzeShotsPerFill failed: %s", fill.Error)
+	}
+	if c := fill.Data.Curve; len(c) != 7 || c[0].Count != 1 || c[0].MeanVelocityMPS != 272.0 || c[6].MeanVelocityMPS != 265.0 {
+		t.Errorf("curve = %+v", fill.Data.Curve)
+	}
+	spot := fill.Data.SweetSpot
+	if fill.Data.ToleranceMPS != DefaultSweetSpotToleranceMPS || spot == nil || spot.FirstShot != 3 || spot.LastShot != 5 ||
+		spot.ShotCount != 3 || spot.MeanVelocityMPS != 280.5 || spot.ExtremeSpread != 1.0 || spot.SpreadMPS != 0 || spot.CompleteStrings != 2 {
+		t.Errorf("sweet spot = %+v", spot)
+	}
+
+	// Gleich lange Bereiche: der schnellere gewinnt
+	curve := []FillCurvePointDTO{
+		{ShotNumber: 1, MeanVelocityMPS: 270}, {ShotNumber: 2, MeanVelocityMPS: 272},
+		{ShotNumber: 3, MeanVelocityMPS: 280}, {ShotNumber: 4, MeanVelocityMPS: 282},
+	}
+	if first, last, ok := findSweetSpot(curve, 2); !ok || first != 2 || last != 3 {
+		t.Errorf("findSweetSpot() = %d, %d, want 2, 3", first, last)
+	}
+
+	// Fehlende Schussnummern unterbrechen den Bereich: 4-5 statt 1-5 über die Lücke
+	curve = []FillCurvePointDTO{
+		{ShotNumber: 1, MeanVelocityMPS: 280}, {ShotNumber: 2, MeanVelocityMPS: 280},
+		{ShotNumber: 4, MeanVelocityMPS: 280}, {ShotNumber: 5, MeanVelocityMPS: 281}, {ShotNumber: 6, MeanVelocityMPS: 270},
+	}
+	if first, last, ok := findSweetSpot(curve, 2); !ok || first != 2 || last != 3 {
+		t.Errorf("findSweetSpot() across gap = %d, %d, want 2, 3", first, last)
+	}
+
+	if r := f.sessions.AnalyzeShotsPerFill(id, ShotsPerFillRequestDTO{ToleranceMPS: -1}); r.Success {
+		t.Error("negative tolerance should fail")
+	}
+	if r := f.sessions.AnalyzeShotsPerFill("unknown", ShotsPerFillRequestDTO{}); r.Success {
+		t.Error("unknown session should fail")
+	}
+}
+
+func TestSessionService_CalculateTrajectory(t *testing.T) {
+	dir := t.TempDir()
+	profile := NewProfileService(dir).CreateProfile("Test Profile", "air_rifle", 420.0, 500.0, 50.0)
+	projectile := NewProjectileService(dir).CreateProjectile("JSB Exact", 0.547, 0.024)
+	sessionService := NewSessionService(dir)
+
+	session := sessionService.CreateSession(profile.Data.ID, projectile.Data.ID, nil, "")
+	if r := sessionService.CalculateTrajectory(session.Data.ID, TrajectoryRequestDTO{}); r.Success {
+		t.Error("CalculateTrajectory without shots should fail")
+	}
+
+	sessionService.RecordShot(session.Data.ID, 174.0)
+	sessionService.RecordShot(session.Data.ID, 176.0)
+
+	result := sessionService.CalculateTrajectory(session.Data.ID, TrajectoryRequestDTO{MaxRangeMeters: 50, StepMeters: 5})
+	if !result.Success {
+		t.Fatalf("CalculateTrajectory failed: %s", result.Error)
+	}
+	trajectory := result.Data
+	if trajectory.DragModel != "G1" || trajectory.ZeroRangeMeters != 25 || trajectory.MuzzleVelocityMPS != 175.0 {
+		t.Errorf("defaults not applied: %+v", trajectory)
+	}
+	if len(trajectory.Points) != 11 || trajectory.Points[0].PathMM != -50 {
+		t.Fatalf("unexpected table: %+v", trajectory.Points)
+	}
+	if zero := trajectory.Points[5]; math.Abs(zero.PathMM) > 0.01 {
+		t.Errorf("path at 25 m = %.3f mm, want 0", zero.PathMM)
+	}
+
+}
+
+func TestSessionService_CalculateTrajectory_UsesSnapshotDragModel(t *testing.T) {
+	dir := t.TempDir()
+	profile := NewProfileService(dir).CreateProfile("Test Profile", "air_rifle", 420.0, 500.0, 50.0)
+	projectiles := NewProjectileService(dir)
+	sessionService := NewSessionService(dir)
+
+	// 16.9 kg/m² GA = 0.024 lb/in² GA
+	projectile := projectiles.CreateProjectileWithDrag("JSB Exact", 0.547, DragDTO{BC: 16.9, DragModel: "GA", BCUnit: "kg/m2"})
+	if !projectile.Success {
+		t.Fatalf("CreateProjectileWithDrag failed: %s", projectile.Error)
+	}
+	session := sessionService.CreateSession(profile.Data.ID, projectile.Data.ID, nil, "")
+	sessionService.RecordShot(session.Data.ID, 175.0)
+
+	before := sessionService.CalculateTrajectory(session.Data.ID, TrajectoryRequestDTO{})
+	if !before.Success {
+		t.Fatalf("CalculateTrajectory failed: %s", before.Error)
+	}
+	if before.Data.DragModel != "GA" || before.Data.BC != 16.9 || before.Data.BCUnit != "kg/m2" {
+		t.Errorf("trajectory = %s %.4f %s, want GA 16.9 kg/m2", before.Data.DragModel, before.Data.BC, before.Data.BCUnit)
+	}
+
+	// Stammdaten ändern: Die Session rechnet weiter mit ihrem Snapshot
+	projectiles.UpdateProjectileWithDrag(projectile.Data.ID, "JSB Exact", 0.547, DragDTO{BC: 0.5, DragModel: "G7"})
+	after := sessionService.CalculateTrajectory(session.Data.ID, TrajectoryRequestDTO{})
+	last := len(after.Data.Points) - 1
+	if after.Data.DragModel != "GA" || after.Data.Points[last].PathMM != before.Data.Points[last].PathMM {
+		t.Errorf("snapshot changed with master data: %s, %.2f mm vs %.2f mm",
+			after.Data.DragModel, after.Data.Points[last].PathMM, before.Data.Points[last].PathMM)
+	}
+}
+
+func TestSessionService_EstimateBC(t *testing.T) {
+	dir := t.TempDir()
+	profile := NewProfileService(dir).CreateProfile("Test Profile", "air_rifle", 420.0, 500.0, 50.0)
+	projectiles := NewProjectileService(dir)
+	sessionService := NewSessionService(dir)
+
+	projectile := projectiles.CreateProjectileWithDrag("JSB Exact", 0.547, DragDTO{BC: 0.030, DragModel: "GA"})
+	other := projectiles.CreateProjectile("H&N Baracuda", 0.691, 0.030)
+	near := sessionService.CreateSession(profile.Data.ID, projectile.Data.ID, nil, "")
+	far := sessionService.CreateSession(profile.Data.ID, projectile.Data.ID, nil, "")
+	foreign := sessionService.CreateSession(profile.Data.ID, other.Data.ID, nil, "")
+
+	// Fern-Geschwindigkeiten mit dem echten BC 0.025 GA auf 20 m rechnen
+	for _, v := range []float64{278.0, 280.0, 282.0, 281.0} {
+		trajectory, err := ballistics.Solve(ballistics.Input{
+			MuzzleVelocity: valueobjects.Velocity(v),
+			BC:             0.025,
+			DragModel:      ballistics.GA,
+			MaxRangeMeters: 20,
+			StepMeters:     20,
+		})
+		if err != nil {
+			t.Fatalf("Solve() failed: %v", err)
+		}
+		sessionService.RecordShot(near.Data.ID, v)
+		sessionService.RecordShot(far.Data.ID, trajectory.Points[1].Velocity.MetersPerSecond())
+	}
+
+	if r := sessionService.EstimateBC(far.Data.ID, BCEstimateRequestDTO{}); r.Success {
+		t.Error("EstimateBC without downrange link should fail")
+	}
+	if r := sessionService.SetDownrange(far.Data.ID, foreign.Data.ID, 20); r.Success {
+		t.Error("SetDownrange with another projectile should fail")
+	}
+	linked := sessionService.SetDownrange(far.Data.ID, near.Data.ID, 20)
+	if !linked.Success || linked.Data.Downrange == nil || linked.Data.Downrange.NearSessionID != near.Data.ID {
+		t.Fatalf("SetDownrange failed: %s", linked.Error)
+	}
+
+	paired := sessionService.EstimateBC(far.Data.ID, BCEstimateRequestDTO{Paired: true})
+	if !paired.Success {
+		t.Fatalf("EstimateBC (paired) failed: %s", paired.Error)
+	}
+	if paired.Data.DragModel != "GA" || paired.Data.PairCount != 4 || math.Abs(paired.Data.BC-0.025) > 1e-5 {
+		t.Errorf("paired estimate = %s %.6f (%d pairs), want GA 0.025 (4 pairs)", paired.Data.DragModel, paired.Data.BC, paired.Data.PairCount)
+	}
+
+	// Über die Mittelwerte: gleicher BC, Intervall aus der Streuung der Schüsse
+	means := sessionService.EstimateBC(far.Data.ID, BCEstimateRequestDTO{})
+	if !means.Success {
+		t.Fatalf("EstimateBC (means) failed: %s", means.Error)
+	}
+	estimate := means.Data
+	if math.Abs(estimate.BC-0.025) > 0.0002 {
+		t.Errorf("estimate from means = %.6f, want ~0.025", estimate.BC)
+	}
+	if estimate.BCLower == nil || estimate.BCUpper == nil || *estimate.BCLower >= estimate.BC || *estimate.BCUpper <= estimate.BC {
+		t.Errorf("confidence bounds missing or not around the estimate: %v %.6f %v", estimate.BCLower, estimate.BC, estimate.BCUpper)
+	}
+
+	// Für ein anderes Modell bestimmt: nicht übernehmen
+	g1 := sessionService.EstimateBC(far.Data.ID, BCEstimateRequestDTO{DragModel: "G1"})
+	if !g1.Success {
+		t.Fatalf("EstimateBC (G1) failed: %s", g1.Error)
+	}
+	if r := projectiles.ApplyBCEstimate(g1.Data); r.Success {
+		t.Error("ApplyBCEstimate with another drag model should fail")
+	}
+
+	applied := projectiles.ApplyBCEstimate(paired.Data)
+	if !applied.Success || applied.Data.BC != 0.025 {
+		t.Errorf("ApplyBCEstimate: BC = %.4f (%s), want 0.025", applied.Data.BC, applied.Error)
+	}
+
+	// Ungleich viele Schüsse: keine Paare
+	sessionService.RecordShot(far.Data.ID, 250.0)
+	if r := sessionService.EstimateBC(far.Data.ID, BCEstimateRequestDTO{Paired: true}); r.Success {
+		t.Error("paired EstimateBC with different shot counts should fail")
+	}
+}
+
+func TestSessionService_EnergyLimits(t *testing.T) {
+	dir := t.TempDir()
+	rifle := NewProfileService(dir).CreateProfile("Test Profile", "air_rifle", 420.0, 500.0, 50.0)
+	bow := NewProfileService(dir).CreateProfile("Recurve", "bow", 700.0, 0, 0)
+	projectile := NewProjectileService(dir).CreateProjectile("JSB Exact", 0.547, 0.024)
+	sessionService := NewSessionService(dir)
+
+	limits, err := entities.ResolveEnergyLimits("DE", nil)
+	if err != nil {
+		t.Fatalf("ResolveEnergyLimits failed: %v", err)
+	}
+	sessionService.SetEnergyLimits(limits)
+
+	session := sessionService.CreateSession(rifle.Data.ID, projectile.Data.ID, nil, "")
+	if session.Data.EnergyCheck == nil || session.Data.EnergyCheck.Verdict != "" {
+		t.Fatalf("new session should have a check without verdict: %+v", session.Data.EnergyCheck)
+	}
+
+	// 0.547 g: 7.5 J bei 165.6 m/s, 95 % (7.125 J) bei 161.4 m/s
+	for _, v := range []float64{150.0, 152.0, 151.0} {
+		sessionService.RecordShot(session.Data.ID, v)
+	}
+	result := sessionService.LoadSession(session.Data.ID)
+	if check := result.Data.EnergyCheck; check.Verdict != "ok" || check.LimitJoules != 7.5 || check.UpperBoundJoules == nil {
+		t.Errorf("well below the limit: %+v, want ok with upper bound", check)
+	}
+
+	// Ein Schuss nahe der Grenze
+	near := sessionService.RecordShot(session.Data.ID, 163.0)
+	if near.Data.EnergyCheck.Verdict != "near" || near.Data.EnergyCheck.NearShots != 1 || near.Data.Shots[3].EnergyVerdict != "near" {
+		t.Errorf("shot at 7.27 J: session %s, shot %s, want near", near.Data.EnergyCheck.Verdict, near.Data.Shots[3].EnergyVerdict)
+	}
+
+	// Ein Schuss über der Grenze entscheidet die Session
+	sessionService.RecordShot(session.Data.ID, 170.0)
+	stats := sessionService.GetStatistics(session.Data.ID)
+	if !stats.Success || stats.Data.EnergyCheck == nil {
+		t.Fatalf("GetStatistics failed or without energy check: %s", stats.Error)
+	}
+	if check := stats.Data.EnergyCheck; check.Verdict != "exceeded" || check.ExceededShots != 1 || check.MaxShotJoules < 7.9 {
+		t.Errorf("shot at 7.90 J: %+v, want exceeded", check)
+	}
+
+	// Ungültige Schüsse zählen nicht
+	sessionService.MarkShotInvalid(session.Data.ID, 4)
+	if check := sessionService.LoadSession(session.Data.ID).Data.EnergyCheck; check.Verdict != "near" || check.ExceededShots != 0 {
+		t.Errorf("after invalidating the 7.90 J shot: %+v, want near", check)
+	}
+
+	// Der CSV-Export enthält Grenze und Urteile
+	var buf bytes.Buffer
+	if err := sessionService.ExportCSV(&buf, []string{session.Data.ID}, CSVOptionsDTO{}); err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !strings.HasSuffix(lines[0], ",energy_limit_j,energy_verdict,session_energy_verdict") {
+		t.Errorf("header missing energy columns: %s", lines[0])
+	}
+	if !strings.HasSuffix(lines[5], ",false,7.50,exceeded,near") {
+		t.Errorf("unexpected row for the invalid 7.90 J shot: %s", lines[5])
+	}
+
+	// Bogen: keine Grenze, keine Prüfung
+	bowSession := sessionService.CreateSession(bow.Data.ID, projectile.Data.ID, nil, "")
+	if bowSession.Data.EnergyCheck != nil {
+		t.Errorf("bow session should not be checked: %+v", bowSession.Data.EnergyCheck)
+	}
+}
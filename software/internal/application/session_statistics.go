@@ -0,0 +1,169 @@
+package application
+
+import (
+	"math"
+	"metric-neo/internal/domain/entities"
+)
+
+// Erweiterte Statistik und Ausreißer-Erkennung der gültigen Schüsse.
+//
+// Die Ausreißer sind nur Vorschläge: Markiert werden sie vom Benutzer
+// (MarkShotInvalid). Ein langsamer Schuss kann ein Versager sein - oder
+// das Verhalten der Waffe, das die Statistik zeigen soll.
+
+const (
+	// OutlierMethodGrubbs: Grubbs-Test (α = 0.05, zweiseitig), wiederholt,
+	// solange er einen Ausreißer findet und mindestens 3 Werte bleiben
+	OutlierMethodGrubbs = "grubbs"
+
+	// OutlierMethodMAD: modifizierter z-Wert 0.6745·(x - Median)/MAD über 3.5
+	// (Iglewicz & Hoaglin) - robust, auch wenn mehrere Ausreißer sich
+	// gegenseitig verdecken
+	OutlierMethodMAD = "mad"
+)
+
+const (
+	grubbsAlpha       = 0.05
+	madOutlierZ       = 3.5
+	madNormalConstant = 0.6745 // Φ⁻¹(0.75): MAD einer Normalverteilung = 0.6745·σ
+)
+
+// statisticsPercentiles sind die Perzentile in StatisticsDTO.Percentiles.
+var statisticsPercentiles = []int{10, 25, 75, 90}
+
+// validVelocity ist ein gültiger Schuss mit seinem Index in session.Shots.
+type validVelocity struct {
+	index int
+	mps   float64
+}
+
+// addExtendedStatistics ergänzt stats um Verteilung, Konfidenzintervalle
+// und Ausreißer-Vorschläge.
+func addExtendedStatistics(session *entities.Session, stats *StatisticsDTO) {
+	var shots []validVelocity
+	var values []float64
+	for i, shot := range session.Shots {
+		if shot.Valid {
+			shots = append(shots, validVelocity{index: i, mps: shot.Velocity.MetersPerSecond()})
+			values = append(values, shot.Velocity.MetersPerSecond())
+		}
+	}
+	stats.OutlierSuggestions = []OutlierDTO{}
+	if len(values) == 0 {
+		return
+	}
+
+	mean, sd := meanAndSampleSD(values)
+	if mean > 0 {
+		stats.CoefficientOfVariation = sd / mean * 100
+	}
+	stats.MedianVelocityMPS = median(values)
+	stats.MeanAbsoluteDeviation = meanAbsoluteDeviation(values, mean)
+	for _, p := range statisticsPercentiles {
+		stats.Percentiles = append(stats.Percentiles, PercentileDTO{
+			Percent:     p,
+			VelocityMPS: percentile(values, float64(p)),
+		})
+	}
+
+	n := len(values)
+	if n >= 2 {
+		margin := studentT95(n-1) * sd / math.Sqrt(float64(n))
+		stats.MeanCI = &IntervalDTO{Lower: mean - margin, Upper: mean + margin}
+
+		// (n-1)·s²/σ² ist Chi-Quadrat-verteilt mit n-1 Freiheitsgraden
+		df := float64(n - 1)
+		stats.StandardDeviationCI = &IntervalDTO{
+			Lower: sd * math.Sqrt(df/chiSquareQuantile(0.975, n-1)),
+			Upper: sd * math.Sqrt(df/chiSquareQuantile(0.025, n-1)),
+		}
+	}
+
+	stats.OutlierSuggestions = suggestOutliers(session, shots, stats.MedianVelocityMPS)
+}
+
+// suggestOutliers kombiniert beide Verfahren (ein Eintrag je Schuss).
+func suggestOutliers(session *entities.Session, shots []validVelocity, med float64) []OutlierDTO {
+	methods := map[int][]string{}
+	for _, index := range grubbsOutliers(shots) {
+		methods[index] = append(methods[index], OutlierMethodGrubbs)
+	}
+	for _, index := range madOutliers(shots, med) {
+		methods[index] = append(methods[index], OutlierMethodMAD)
+	}
+
+	suggestions := []OutlierDTO{}
+	for i, shot := range session.Shots {
+		if len(methods[i]) == 0 {
+			continue
+		}
+		suggestions = append(suggestions, OutlierDTO{
+			ShotIndex:    i,
+			VelocityMPS:  shot.Velocity.MetersPerSecond(),
+			DeviationMPS: shot.Velocity.MetersPerSecond() - med,
+			Methods:      methods[i],
+		})
+	}
+	return suggestions
+}
+
+// grubbsOutliers gibt die Indizes der Ausreißer nach Grubbs zurück.
+//
+// G = max|x - x̄| / s wird mit dem kritischen Wert
+// (n-1)/√n · √(t² / (n-2+t²)) verglichen, t = t(1 - α/(2n), n-2).
+func grubbsOutliers(shots []validVelocity) []int {
+	remaining := append([]validVelocity(nil), shots...)
+	var outliers []int
+	for len(remaining) >= 3 {
+		values := make([]float64, len(remaining))
+		for i, shot := range remaining {
+			values[i] = shot.mps
+		}
+		mean, sd := meanAndSampleSD(values)
+		if sd == 0 {
+			break
+		}
+
+		worst := 0
+		for i, v := range values {
+			if math.Abs(v-mean) > math.Abs(values[worst]-mean) {
+				worst = i
+			}
+		}
+
+		n := float64(len(values))
+		t := studentTQuantile(1-grubbsAlpha/(2*n), len(values)-2)
+		critical := (n - 1) / math.Sqrt(n) * math.Sqrt(t*t/(n-2+t*t))
+		if math.Abs(values[worst]-mean)/sd <= critical {
+			break
+		}
+
+		outliers = append(outliers, remaining[worst].index)
+		remaining = append(remaining[:worst], remaining[worst+1:]...)
+	}
+	return outliers
+}
+
+// madOutliers gibt die Indizes der Ausreißer nach dem modifizierten z-Wert
+// zurück. Ab 3 Schüssen; bei MAD = 0 (mehr als die Hälfte gleich) keine.
+func madOutliers(shots []validVelocity, med float64) []int {
+	if len(shots) < 3 {
+		return nil
+	}
+	values := make([]float64, len(shots))
+	for i, shot := range shots {
+		values[i] = shot.mps
+	}
+	mad := medianAbsoluteDeviation(values, med)
+	if mad == 0 {
+		return nil
+	}
+
+	var outliers []int
+	for _, shot := range shots {
+		if math.Abs(madNormalConstant*(shot.mps-med)/mad) > madOutlierZ {
+			outliers = append(outliers, shot.index)
+		}
+	}
+	return outliers
+}
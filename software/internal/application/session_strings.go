@@ -0,0 +1,368 @@
+package application
+
+import (
+	"fmt"
+	"math"
+	"metric-neo/internal/domain/entities"
+)
+
+// Schussserien innerhalb einer Session und Schüsse pro Füllung.
+//
+// Eine ungeregelte Pressluftwaffe schießt pro Füllung nicht gleich schnell:
+// Bei vollem Druck hemmt der Druck das Ventil, die Geschwindigkeit steigt
+// erst an, bleibt eine Weile oben und fällt dann ab. Der flache Teil der
+// Kurve ist der nutzbare Bereich ("Sweet Spot").
+//
+// Mit Start- und Enddruck je Serie wird daraus das Füllfenster: bis zu
+// welchem Druck füllen, bei welchem Druck nachfüllen. Eine geregelte PCP
+// hat ein flaches Plateau, solange der Tankdruck über dem Regler liegt.
+
+// DefaultSweetSpotToleranceMPS ist die Standard-Spreizung im Sweet Spot
+// (3 m/s ≈ 10 fps, eine verbreitete Faustregel für Luftgewehre).
+const DefaultSweetSpotToleranceMPS = 3.0
+
+// StartString beginnt eine neue Schussserie; folgende Schüsse - auch aus
+// einer laufenden Live-Aufnahme - gehören zu ihr. fill ist der Tankdruck
+// beim Start (nil = nicht erfasst); den Enddruck setzt SetStringFill.
+func (s *SessionService) StartString(sessionID, name string, fill *FillDTO) Result[SessionDTO] {
+	event, err := fillFromDTO(fill)
+	if err != nil {
+		return Fail[SessionDTO](err)
+	}
+	return s.updateStrings(sessionID, func(session *entities.Session) error {
+		str, err := session.StartString(name)
+		if err != nil {
+			return err
+		}
+		str.Fill = event
+		return nil
+	})
+}
+
+// SetStringFill setzt Start- und Enddruck der Serie number (1-basiert);
+// nil entfernt die Füllung. Eine Session ohne Serien ist Serie 1.
+func (s *SessionService) SetStringFill(sessionID string, number int, fill *FillDTO) Result[SessionDTO] {
+	event, err := fillFromDTO(fill)
+	if err != nil {
+		return Fail[SessionDTO](err)
+	}
+	return s.updateStrings(sessionID, func(session *entities.Session) error {
+		return session.SetStringFill(number-1, event)
+	})
+}
+
+// RenameString benennt die Serie number (1-basiert) um.
+func (s *SessionService) RenameString(sessionID string, number int, name string) Result[SessionDTO] {
+	return s.updateStrings(sessionID, func(session *entities.Session) error {
+		return session.RenameString(number-1, name)
+	})
+}
+
+// updateStrings lädt die Session, ändert sie und speichert sie. Der Lock
+// schützt vor einem gleichzeitig gespeicherten Schuss der Live-Aufnahme.
+func (s *SessionService) updateStrings(sessionID string, change func(*entities.Session) error) Result[SessionDTO] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sessionID == "" {
+		return FailWithMessage[SessionDTO]("Session-ID darf nicht leer sein")
+	}
+	session, err := s.sessionRepo.Load(sessionID)
+	if err != nil {
+		return FailWithMessage[SessionDTO]("Session nicht gefunden")
+	}
+	if err := change(session); err != nil {
+		return Fail[SessionDTO](err)
+	}
+	if err := s.sessionRepo.Save(session); err != nil {
+		return Fail[SessionDTO](err)
+	}
+	return OK(s.toDTO(session))
+}
+
+// AnalyzeShotsPerFill legt die Serien einer Session übereinander: Für jede
+// Schussnummer ab Serienbeginn die mittlere Geschwindigkeit aller Serien,
+// daraus der längste Bereich, dessen Spreizung die Toleranz nicht übersteigt.
+//
+// Eine Session ohne Serien gilt als eine Füllung.
+func (s *SessionService) AnalyzeShotsPerFill(sessionID string, request ShotsPerFillRequestDTO) Result[ShotsPerFillDTO] {
+	if sessionID == "" {
+		return FailWithMessage[ShotsPerFillDTO]("Session-ID darf nicht leer sein")
+	}
+	tolerance, err := sweetSpotTolerance(request)
+	if err != nil {
+		return Fail[ShotsPerFillDTO](err)
+	}
+
+	session, err := s.sessionRepo.Load(sessionID)
+	if err != nil {
+		return FailWithMessage[ShotsPerFillDTO]("Session nicht gefunden")
+	}
+
+	result := newShotsPerFill(session.ProfileSnapshot, tolerance)
+	result.SessionID = session.ID
+	result.SessionCount = 1
+	strs := addFillStrings(nil, session, &result)
+	analyzeShotsPerFill(strs, &result)
+	return OK(result)
+}
+
+// sweetSpotTolerance prüft die Toleranz (0 = Standard).
+func sweetSpotTolerance(request ShotsPerFillRequestDTO) (float64, error) {
+	if request.ToleranceMPS < 0 {
+		return 0, fmt.Errorf("Toleranz darf nicht negativ sein")
+	}
+	if request.ToleranceMPS == 0 {
+		return DefaultSweetSpotToleranceMPS, nil
+	}
+	return request.ToleranceMPS, nil
+}
+
+// Warnungen der Auswertung (ShotsPerFillDTO.Warnings).
+const (
+	// FillWarningNotPCP: das Profil hat Feder- oder CO2-Antrieb, Füllung
+	// und Fülldruck sind dort ohne Bedeutung
+	FillWarningNotPCP = "not_pcp"
+	// FillWarningNoFillPressures: keine Serie mit Start- und Enddruck,
+	// daher kein Füllfenster
+	FillWarningNoFillPressures = "no_fill_pressures"
+	// FillWarningSingleString: nur eine Füllung - der Sweet Spot beruht
+	// auf Einzelschüssen statt auf Mittelwerten
+	FillWarningSingleString = "single_string"
+	// FillWarningBelowRegulator: der Nachfülldruck liegt unter dem
+	// Regler-Druck; der Regler arbeitet dort nicht mehr
+	FillWarningBelowRegulator = "below_regulator_setpoint"
+)
+
+// fillString ist eine Serie mit ihrer Füllung (nil = nicht erfasst).
+type fillString struct {
+	shots []*entities.Shot
+	fill  *entities.FillEvent
+}
+
+// dropPerShot gibt den Druckverlust pro Schuss zurück (ok = false ohne
+// Start- und Enddruck).
+func (f fillString) dropPerShot() (float64, bool) {
+	if f.fill == nil {
+		return 0, false
+	}
+	return f.fill.DropPerShot(len(f.shots))
+}
+
+// pressureBefore schätzt den Tankdruck vor Schuss n (1-basiert): linear
+// zwischen Start- und Enddruck. Für eine ungeregelte PCP eine Näherung,
+// der Luftverbrauch sinkt mit dem Druck leicht.
+func (f fillString) pressureBefore(n int) (float64, bool) {
+	drop, ok := f.dropPerShot()
+	if !ok {
+		return 0, false
+	}
+	return f.fill.StartPressure.Bar() - float64(n-1)*drop, true
+}
+
+// newShotsPerFill legt das Ergebnis mit Antrieb und Regler aus profile an.
+func newShotsPerFill(profile *entities.Profile, tolerance float64) ShotsPerFillDTO {
+	result := ShotsPerFillDTO{
+		ToleranceMPS: tolerance,
+		Strings:      []StringStatisticsDTO{},
+		Warnings:     []string{},
+	}
+	if profile != nil {
+		dto := ProfileToDTO(profile)
+		result.ProfileID, result.ProfileName = dto.ID, dto.Name
+		result.PowerPlant, result.RegulatorSetpointBar = dto.PowerPlant, dto.RegulatorSetpointBar
+	}
+	return result
+}
+
+// addFillStrings hängt die Serien der Session an strs an und ihre
+// Statistik an result.Strings. Für eine einzelne Session nur, wenn sie
+// unterteilt ist; sonst wiederholte die Tabelle die Session-Statistik.
+func addFillStrings(strs []fillString, session *entities.Session, result *ShotsPerFillDTO) []fillString {
+	profileWide := result.SessionID == ""
+	for i := 0; i < session.StringCount(); i++ {
+		str := fillString{shots: session.StringShots(i)}
+		if len(session.Strings) > 0 {
+			str.fill = session.Strings[i].Fill
+		}
+		if len(session.Strings) > 0 || profileWide {
+			stats := stringStatistics(session, i)
+			if profileWide {
+				stats.SessionID = session.ID
+			}
+			result.Strings = append(result.Strings, stats)
+		}
+		strs = append(strs, str)
+	}
+	return strs
+}
+
+// analyzeShotsPerFill füllt Kurve, Sweet Spot, Füllfenster und Warnungen.
+func analyzeShotsPerFill(strs []fillString, result *ShotsPerFillDTO) {
+	shots := make([][]*entities.Shot, len(strs))
+	for i, str := range strs {
+		shots[i] = str.shots
+	}
+
+	result.Curve = fillCurve(shots)
+	addCurvePressures(strs, result.Curve)
+	if first, last, ok := findSweetSpot(result.Curve, result.ToleranceMPS); ok {
+		result.SweetSpot = sweetSpot(shots, result.Curve[first:last+1])
+		result.FillWindow = fillWindow(strs, result.SweetSpot)
+	}
+	result.Warnings = fillWarnings(len(strs), *result)
+}
+
+// fillCurve mittelt die gültigen Schüsse gleicher Schussnummer aller Serien.
+// Schussnummern ohne gültigen Schuss fehlen in der Kurve.
+func fillCurve(strings [][]*entities.Shot) []FillCurvePointDTO {
+	longest := 0
+	for _, shots := range strings {
+		longest = max(longest, len(shots))
+	}
+
+	curve := make([]FillCurvePointDTO, 0, longest)
+	for n := 0; n < longest; n++ {
+		point := FillCurvePointDTO{ShotNumber: n + 1, MinVelocityMPS: math.Inf(1), MaxVelocityMPS: math.Inf(-1)}
+		sum := 0.0
+		for _, shots := range strings {
+			if n >= len(shots) || !shots[n].Valid {
+				continue
+			}
+			v := shots[n].Velocity.MetersPerSecond()
+			sum += v
+			point.Count++
+			point.MinVelocityMPS = math.Min(point.MinVelocityMPS, v)
+			point.MaxVelocityMPS = math.Max(point.MaxVelocityMPS, v)
+		}
+		if point.Count == 0 {
+			continue
+		}
+		point.MeanVelocityMPS = sum / float64(point.Count)
+		curve = append(curve, point)
+	}
+	return curve
+}
+
+// addCurvePressures mittelt den geschätzten Druck vor jedem Schuss über
+// die Serien mit Start- und Enddruck, die so weit reichen.
+func addCurvePressures(strs []fillString, curve []FillCurvePointDTO) {
+	for i := range curve {
+		n := curve[i].ShotNumber
+		var pressures []float64
+		for _, str := range strs {
+			if p, ok := str.pressureBefore(n); ok && n <= len(str.shots) {
+				pressures = append(pressures, p)
+			}
+		}
+		if len(pressures) > 0 {
+			mean, _ := meanAndSampleSD(pressures)
+			curve[i].PressureBar = &mean
+		}
+	}
+}
+
+// findSweetSpot sucht den längsten zusammenhängenden Bereich [first, last]
+// der Kurve mit max - min ≤ tolerance. Bei gleicher Länge gewinnt der
+// schnellere Bereich (Plateau statt Anstieg).
+//
+// Zusammenhängend heißt ohne Lücke in den Schussnummern: Fehlt eine Nummer
+// in der Kurve (kein gültiger Schuss in keiner Serie), ist ihre
+// Geschwindigkeit unbekannt und der Bereich endet davor.
+func findSweetSpot(curve []FillCurvePointDTO, tolerance float64) (first, last int, ok bool) {
+	bestMean := math.Inf(-1)
+	for i := range curve {
+		lo, hi, sum := math.Inf(1), math.Inf(-1), 0.0
+		for j := i; j < len(curve); j++ {
+			if j > i && curve[j].ShotNumber != curve[j-1].ShotNumber+1 {
+				break
+			}
+			v := curve[j].MeanVelocityMPS
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+			if hi-lo > tolerance {
+				break
+			}
+			sum += v
+			mean := sum / float64(j-i+1)
+			longer := j-i > last-first
+			if !ok || longer || (j-i == last-first && mean > bestMean) {
+				first, last, bestMean, ok = i, j, mean, true
+			}
+		}
+	}
+	return first, last, ok
+}
+
+// sweetSpot beschreibt den Bereich window der Kurve über die Einzelschüsse.
+func sweetSpot(strings [][]*entities.Shot, window []FillCurvePointDTO) *SweetSpotDTO {
+	firstShot, lastShot := window[0].ShotNumber, window[len(window)-1].ShotNumber
+	spot := &SweetSpotDTO{
+		FirstShot: firstShot,
+		LastShot:  lastShot,
+		ShotCount: lastShot - firstShot + 1,
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, point := range window {
+		lo, hi = math.Min(lo, point.MeanVelocityMPS), math.Max(hi, point.MeanVelocityMPS)
+	}
+	spot.SpreadMPS = hi - lo
+
+	var values []float64
+	for _, shots := range strings {
+		if len(shots) >= lastShot {
+			spot.CompleteStrings++
+		}
+		for n := firstShot - 1; n < min(lastShot, len(shots)); n++ {
+			if shots[n].Valid {
+				values = append(values, shots[n].Velocity.MetersPerSecond())
+			}
+		}
+	}
+	spot.MeanVelocityMPS, spot.SampleStandardDeviation = meanAndSampleSD(values)
+	spot.ExtremeSpread = percentile(values, 100) - percentile(values, 0)
+	return spot
+}
+
+// fillWindow rechnet den Sweet Spot in Tankdruck um: Druck vor dessen
+// erstem und nach dessen letztem Schuss, gemittelt über die Serien mit
+// Start- und Enddruck, die bis LastShot reichen. nil ohne solche Serien.
+func fillWindow(strs []fillString, spot *SweetSpotDTO) *FillWindowDTO {
+	var fills, refills, drops []float64
+	for _, str := range strs {
+		drop, ok := str.dropPerShot()
+		if !ok || len(str.shots) < spot.LastShot {
+			continue
+		}
+		fill, _ := str.pressureBefore(spot.FirstShot)
+		refill, _ := str.pressureBefore(spot.LastShot + 1)
+		fills, refills, drops = append(fills, fill), append(refills, refill), append(drops, drop)
+	}
+	if len(drops) == 0 {
+		return nil
+	}
+	window := &FillWindowDTO{Strings: len(drops)}
+	window.FillPressureBar, _ = meanAndSampleSD(fills)
+	window.RefillPressureBar, _ = meanAndSampleSD(refills)
+	window.PressurePerShotBar, _ = meanAndSampleSD(drops)
+	return window
+}
+
+// fillWarnings sammelt die Hinweise zur Aussagekraft der Auswertung.
+func fillWarnings(stringCount int, result ShotsPerFillDTO) []string {
+	warnings := []string{}
+	plant := entities.PowerPlant(result.PowerPlant)
+	if plant != "" && plant != entities.PowerPlantPCP {
+		warnings = append(warnings, FillWarningNotPCP)
+	} else if result.SweetSpot != nil && result.FillWindow == nil {
+		warnings = append(warnings, FillWarningNoFillPressures)
+	}
+	if result.SweetSpot != nil && stringCount < 2 {
+		warnings = append(warnings, FillWarningSingleString)
+	}
+	if result.FillWindow != nil && result.RegulatorSetpointBar != nil &&
+		result.FillWindow.RefillPressureBar < *result.RegulatorSetpointBar {
+		warnings = append(warnings, FillWarningBelowRegulator)
+	}
+	return warnings
+}
@@ -0,0 +1,102 @@
+package application
+
+import (
+	"fmt"
+	"metric-neo/internal/domain/ballistics"
+)
+
+// CalculateTrajectory berechnet die Flugbahntabelle einer Session.
+//
+// Eingaben aus der Session: mittlere Geschwindigkeit der gültigen Schüsse,
+// Gewicht, BC und Widerstandsmodell aus dem ProjectileSnapshot, Visierhöhe
+// aus dem ProfileSnapshot und die Umgebungsbedingungen (fehlende Werte aus
+// der Standardatmosphäre). Der Request wählt Fleckschussentfernung,
+// Tabellenbereich und Seitenwind.
+func (s *SessionService) CalculateTrajectory(sessionID string, request TrajectoryRequestDTO) Result[TrajectoryDTO] {
+	if sessionID == "" {
+		return FailWithMessage[TrajectoryDTO]("Session-ID darf nicht leer sein")
+	}
+
+	session, err := s.sessionRepo.Load(sessionID)
+	if err != nil {
+		return FailWithMessage[TrajectoryDTO](fmt.Sprintf("Session nicht gefunden: %s", sessionID))
+	}
+
+	velocity, err := session.CalculateAverageVelocity()
+	if err != nil {
+		return FailWithMessage[TrajectoryDTO]("Session hat keine gültigen Schüsse")
+	}
+	projectile := session.ProjectileSnapshot
+	if projectile.BC <= 0 {
+		return FailWithMessage[TrajectoryDTO](fmt.Sprintf("Projectile %s hat keinen BC", projectile.Name))
+	}
+
+	// Snapshots vor Schema 2 ohne Modell meinten G1
+	dragModel, err := ballistics.ParseDragModel(string(projectile.DragModel))
+	if err != nil {
+		return Fail[TrajectoryDTO](err)
+	}
+	if request.ZeroRangeMeters == 0 {
+		request.ZeroRangeMeters = defaultZeroRangeMeters
+	}
+	if request.MaxRangeMeters == 0 {
+		request.MaxRangeMeters = defaultMaxRangeMeters
+	}
+	if request.StepMeters == 0 {
+		request.StepMeters = defaultStepMeters
+	}
+
+	trajectory, err := ballistics.Solve(ballistics.Input{
+		MuzzleVelocity:  velocity,
+		Weight:          projectile.Weight,
+		BC:              projectile.BCImperial(),
+		DragModel:       dragModel,
+		DragTable:       projectile.DragTable,
+		SightHeight:     session.ProfileSnapshot.SightHeight,
+		ZeroRangeMeters: request.ZeroRangeMeters,
+		Atmosphere:      session.Atmosphere(),
+		CrosswindMPS:    request.CrosswindMPS,
+		MaxRangeMeters:  request.MaxRangeMeters,
+		StepMeters:      request.StepMeters,
+	})
+	if err != nil {
+		return Fail[TrajectoryDTO](err)
+	}
+
+	return OK(trajectoryToDTO(session.ID, projectile.BC, projectile.BCUnit, trajectory))
+}
+
+// trajectoryToDTO konvertiert das Ergebnis des Solvers.
+// bc und unit sind die Angabe des Snapshots (der Solver rechnet in lb/in²).
+func trajectoryToDTO(sessionID string, bc float64, unit ballistics.BCUnit, t *ballistics.Trajectory) TrajectoryDTO {
+	if unit == "" {
+		unit = ballistics.BCUnitImperial
+	}
+	dto := TrajectoryDTO{
+		SessionID:         sessionID,
+		DragModel:         t.Input.DragModel.String(),
+		BC:                bc,
+		BCUnit:            string(unit),
+		MuzzleVelocityMPS: t.Input.MuzzleVelocity.MetersPerSecond(),
+		WeightGrams:       t.Input.Weight.Grams(),
+		SightHeightMM:     t.Input.SightHeight.Millimeters(),
+		ZeroRangeMeters:   t.Input.ZeroRangeMeters,
+		CrosswindMPS:      t.Input.CrosswindMPS,
+		AirDensityKgM3:    t.AirDensity,
+		SpeedOfSoundMPS:   t.SpeedOfSound,
+		ZeroAngleMRAD:     t.ZeroAngleMRAD,
+		Points:            make([]TrajectoryPointDTO, 0, len(t.Points)),
+	}
+	for _, p := range t.Points {
+		dto.Points = append(dto.Points, TrajectoryPointDTO{
+			RangeMeters:         p.RangeMeters,
+			PathMM:              p.PathMM,
+			DropMM:              p.DropMM,
+			WindDriftMM:         p.WindDriftMM,
+			TimeOfFlightSeconds: p.TimeOfFlightSeconds,
+			VelocityMPS:         p.Velocity.MetersPerSecond(),
+			EnergyJoules:        p.Energy.Joules(),
+		})
+	}
+	return dto
+}
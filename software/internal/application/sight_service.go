@@ -4,20 +4,21 @@ import (
 	"fmt"
 	"metric-neo/internal/domain/entities"
 	"metric-neo/internal/domain/valueobjects"
-	"metric-neo/internal/infrastructure/persistence"
-	"path/filepath"
 )
 
 // SightService orchestriert SightingSystem-Use-Cases.
 type SightService struct {
-	repo *persistence.SightRepository
+	repo SightRepository
 }
 
-// NewSightService erstellt einen neuen SightService.
+// NewSightService erstellt einen neuen SightService auf dem JSON-Backend.
 func NewSightService(dataDir string) *SightService {
-	return &SightService{
-		repo: persistence.NewSightRepository(filepath.Join(dataDir, "inventory", "sights")),
-	}
+	return NewSightServiceWith(NewJSONRepositories(dataDir))
+}
+
+// NewSightServiceWith erstellt einen SightService auf beliebigen Repositories.
+func NewSightServiceWith(repos *Repositories) *SightService {
+	return &SightService{repo: repos.Sights}
 }
 
 // CreateSight erstellt eine neue Optik.
@@ -0,0 +1,232 @@
+package application
+
+import (
+	"math"
+	"sort"
+)
+
+// meanAndSampleSD berechnet Mittelwert und Stichproben-Standardabweichung
+// (durch n-1). Bei weniger als zwei Werten ist die Standardabweichung 0.
+func meanAndSampleSD(values []float64) (mean, sd float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	if len(values) < 2 {
+		return mean, 0
+	}
+
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)-1))
+}
+
+// studentT95 ist das 97.5-%-Quantil der t-Verteilung (zweiseitiges
+// 95-%-Intervall) für df Freiheitsgrade.
+//
+// Bis 30 Freiheitsgrade aus der Tabelle, darüber die Näherung
+// 1.96 + 2.4/df (Fehler < 0.003).
+func studentT95(df int) float64 {
+	table := [...]float64{
+		12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+		2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+		2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+	}
+	if df < 1 {
+		return math.Inf(1)
+	}
+	if df <= len(table) {
+		return table[df-1]
+	}
+	return 1.96 + 2.4/float64(df)
+}
+
+// median ist der Median von values (nicht leer). values wird nicht verändert.
+func median(values []float64) float64 {
+	return percentile(values, 50)
+}
+
+// percentile ist das p-Perzentil (0-100) mit linearer Interpolation
+// zwischen den Rängen (wie PERCENTILE.INC in Excel/LibreOffice).
+func percentile(values []float64, p float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	if lower >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lower] + (rank-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
+
+// meanAbsoluteDeviation ist die mittlere absolute Abweichung vom Mittelwert.
+func meanAbsoluteDeviation(values []float64, mean float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += math.Abs(v - mean)
+	}
+	return sum / float64(len(values))
+}
+
+// medianAbsoluteDeviation ist der Median der absoluten Abweichungen vom Median.
+func medianAbsoluteDeviation(values []float64, med float64) float64 {
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = math.Abs(v - med)
+	}
+	return median(deviations)
+}
+
+// studentTQuantile ist das p-Quantil der t-Verteilung mit df Freiheitsgraden
+// (p > 0.5). Für die festen 95 % reicht studentT95; Grubbs braucht Quantile
+// wie 1 - 0.05/(2n).
+//
+// GO-KONZEPT: Numerische Umkehrung
+// Die Verteilungsfunktion ist monoton, daher Intervallhalbierung statt
+// einer Näherungsformel - exakt auf 1e-9, auch bei df = 1.
+func studentTQuantile(p float64, df int) float64 {
+	cdf := func(t float64) float64 {
+		return 1 - 0.5*studentTTwoSidedP(t, float64(df))
+	}
+	return bisect(cdf, p, 0, 1e6)
+}
+
+// studentTTwoSidedP ist die zweiseitige Überschreitungswahrscheinlichkeit
+// P(|T| ≥ |t|) der t-Verteilung. df darf gebrochen sein (Welch).
+func studentTTwoSidedP(t, df float64) float64 {
+	return regularizedBeta(df/(df+t*t), df/2, 0.5)
+}
+
+// fDistributionCDF ist die Verteilungsfunktion der F-Verteilung mit d1 und
+// d2 Freiheitsgraden.
+func fDistributionCDF(x, d1, d2 float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return regularizedBeta(d1*x/(d1*x+d2), d1/2, d2/2)
+}
+
+// chiSquareQuantile ist das p-Quantil der Chi-Quadrat-Verteilung mit df
+// Freiheitsgraden.
+func chiSquareQuantile(p float64, df int) float64 {
+	k := float64(df)
+	cdf := func(x float64) float64 {
+		return regularizedGammaP(k/2, x/2)
+	}
+	return bisect(cdf, p, 0, k+50*math.Sqrt(2*k)+100)
+}
+
+// bisect sucht x in [lo, hi] mit cdf(x) = p (cdf monoton steigend).
+func bisect(cdf func(float64) float64, p, lo, hi float64) float64 {
+	for i := 0; i < 200 && hi-lo > 1e-9*math.Max(1, lo); i++ {
+		mid := (lo + hi) / 2
+		if cdf(mid) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// regularizedBeta ist die regularisierte unvollständige Betafunktion I_x(a, b)
+// (Kettenbruch nach Lentz, Numerical Recipes 6.4).
+func regularizedBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	// Der Kettenbruch konvergiert schnell für x < (a+1)/(a+b+2)
+	if x > (a+1)/(a+b+2) {
+		return 1 - regularizedBeta(1-x, b, a)
+	}
+
+	lgA, _ := math.Lgamma(a)
+	lgB, _ := math.Lgamma(b)
+	lgAB, _ := math.Lgamma(a + b)
+	front := math.Exp(lgAB-lgA-lgB+a*math.Log(x)+b*math.Log(1-x)) / a
+
+	const tiny = 1e-300
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	f := d
+	for m := 1; m <= 300; m++ {
+		fm := float64(m)
+		for _, numerator := range [2]float64{
+			fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm)),
+			-(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1)),
+		} {
+			d = 1 + numerator*d
+			if math.Abs(d) < tiny {
+				d = tiny
+			}
+			c = 1 + numerator/c
+			if math.Abs(c) < tiny {
+				c = tiny
+			}
+			d = 1 / d
+			f *= c * d
+		}
+		if math.Abs(c*d-1) < 1e-12 {
+			break
+		}
+	}
+	return front * f
+}
+
+// regularizedGammaP ist die regularisierte untere unvollständige
+// Gammafunktion P(a, x) (Reihe für x < a+1, sonst Kettenbruch).
+func regularizedGammaP(a, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	lgA, _ := math.Lgamma(a)
+	front := math.Exp(-x + a*math.Log(x) - lgA)
+
+	if x < a+1 {
+		sum, term := 1/a, 1/a
+		for n := 1; n <= 500; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if term < sum*1e-15 {
+				break
+			}
+		}
+		return front * sum
+	}
+
+	// Q(a, x) als Kettenbruch (Lentz)
+	const tiny = 1e-300
+	b := x + 1 - a
+	c, d := 1/tiny, 1/b
+	h := d
+	for n := 1; n <= 500; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+		if math.Abs(d*c-1) < 1e-15 {
+			break
+		}
+	}
+	return 1 - front*h
+}
@@ -0,0 +1,55 @@
+package application
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuantiles(t *testing.T) {
+	// Tabellenwerte
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"t 0.975 df 1", studentTQuantile(0.975, 1), 12.706},
+		{"t 0.975 df 10", studentTQuantile(0.975, 10), 2.228},
+		{"t 0.995 df 4", studentTQuantile(0.995, 4), 4.604},
+		{"chi2 0.025 df 2", chiSquareQuantile(0.025, 2), 0.0506},
+		{"chi2 0.975 df 2", chiSquareQuantile(0.975, 2), 7.378},
+		{"chi2 0.975 df 9", chiSquareQuantile(0.975, 9), 19.023},
+		{"chi2 0.025 df 29", chiSquareQuantile(0.025, 29), 16.047},
+		{"t p 2.228 df 10", studentTTwoSidedP(2.228, 10), 0.05},
+		{"t p 2.0 df 24.5", studentTTwoSidedP(2.0, 24.5), 0.0567},
+		{"F 3.326 (5, 10)", fDistributionCDF(3.326, 5, 10), 0.95},
+		{"F 4.965 (1, 10)", fDistributionCDF(4.965, 1, 10), 0.95},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want)/tt.want > 1e-3 {
+			t.Errorf("%s = %.4f, want %.4f", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// studentT95 (Tabelle) und studentTQuantile müssen übereinstimmen
+	for df := 1; df <= 40; df++ {
+		if diff := math.Abs(studentT95(df) - studentTQuantile(0.975, df)); diff > 0.003 {
+			t.Errorf("df %d: studentT95 = %.4f, studentTQuantile = %.4f", df, studentT95(df), studentTQuantile(0.975, df))
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := []float64{180, 170, 175, 172}
+	if got := median(values); got != 173.5 {
+		t.Errorf("median = %.2f, want 173.5", got)
+	}
+	if got := percentile(values, 25); math.Abs(got-171.5) > 1e-9 {
+		t.Errorf("P25 = %.2f, want 171.5", got)
+	}
+	if got := percentile(values, 100); got != 180 {
+		t.Errorf("P100 = %.2f, want 180", got)
+	}
+	if values[0] != 180 {
+		t.Error("percentile must not sort the input")
+	}
+}
@@ -0,0 +1,162 @@
+package application
+
+import (
+	"fmt"
+	"metric-neo/internal/infrastructure/persistence"
+	"metric-neo/internal/infrastructure/persistence/sqlite"
+)
+
+// StorageInfoDTO beschreibt die Speicherung des Datenverzeichnisses.
+type StorageInfoDTO struct {
+	Backend    string `json:"backend"` // "json" oder "sqlite"
+	SQLitePath string `json:"sqlitePath"`
+}
+
+// StorageConversionDTO ist das Ergebnis einer Konvertierung zwischen den Backends.
+type StorageConversionDTO struct {
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Profiles    int    `json:"profiles"`
+	Projectiles int    `json:"projectiles"`
+	Sights      int    `json:"sights"`
+	Sessions    int    `json:"sessions"`
+	// BackupDir enthält frühere Daten im Ziel-Backend (leer, wenn es keine gab)
+	BackupDir string `json:"backupDir"`
+}
+
+// GetStorageInfo liest das aktive Backend aus dem Manifest.
+func GetStorageInfo(dataDir string) (StorageInfoDTO, error) {
+	backend, err := persistence.ReadStorageBackend(dataDir)
+	if err != nil {
+		return StorageInfoDTO{}, err
+	}
+	return StorageInfoDTO{Backend: string(backend), SQLitePath: persistence.SQLitePath(dataDir)}, nil
+}
+
+// ConvertStorage kopiert alle Daten vom aktiven Backend nach to und
+// schaltet das Datenverzeichnis danach auf to um.
+//
+// Das Ziel wird eine exakte Kopie: Daten, die dort schon lagen (z.B. von
+// einer früheren Konvertierung), werden vorher nach backups/ verschoben.
+// Scheitert ein Schritt, bleibt das alte Backend aktiv und unverändert.
+// Rohdaten-Logs (sessions/<id>.wire.log) sind in beiden Backends Dateien
+// und werden nicht kopiert.
+func ConvertStorage(dataDir string, to persistence.StorageBackend) (StorageConversionDTO, error) {
+	from, err := persistence.ReadStorageBackend(dataDir)
+	if err != nil {
+		return StorageConversionDTO{}, err
+	}
+	if to, err = persistence.ParseStorageBackend(string(to)); err != nil {
+		return StorageConversionDTO{}, err
+	}
+	result := StorageConversionDTO{From: string(from), To: string(to)}
+	if from == to {
+		return result, fmt.Errorf("data directory already uses the %s backend", to)
+	}
+
+	source, err := OpenRepositories(dataDir, from)
+	if err != nil {
+		return result, err
+	}
+	defer source.Close()
+
+	label := "storage-" + string(to)
+	switch to {
+	case persistence.BackendSQLite:
+		result.BackupDir, err = sqlite.MoveAside(dataDir, label)
+	case persistence.BackendJSON:
+		result.BackupDir, err = persistence.MoveDocumentsAside(dataDir, label)
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to back up existing %s data: %w", to, err)
+	}
+
+	target, err := OpenRepositories(dataDir, to)
+	if err != nil {
+		return result, err
+	}
+	defer target.Close()
+
+	if err := copyRepositories(source, target, &result); err != nil {
+		return result, err
+	}
+	if err := persistence.WriteStorageBackend(dataDir, to); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// copyRepositories kopiert alle Entities; Stammdaten vor Sessions.
+func copyRepositories(source, target *Repositories, result *StorageConversionDTO) error {
+	profileIDs, err := source.Profiles.List()
+	if err != nil {
+		return err
+	}
+	for _, id := range profileIDs {
+		profile, err := source.Profiles.Load(id)
+		if err != nil {
+			return err
+		}
+		if err := target.Profiles.Save(profile); err != nil {
+			return err
+		}
+		result.Profiles++
+	}
+
+	projectiles, err := source.Projectiles.List()
+	if err != nil {
+		return err
+	}
+	for _, projectile := range projectiles {
+		if err := target.Projectiles.Save(projectile); err != nil {
+			return err
+		}
+		result.Projectiles++
+	}
+
+	sights, err := source.Sights.List()
+	if err != nil {
+		return err
+	}
+	for _, sight := range sights {
+		if err := target.Sights.Save(sight); err != nil {
+			return err
+		}
+		result.Sights++
+	}
+
+	sessionIDs, err := source.Sessions.List()
+	if err != nil {
+		return err
+	}
+	for _, id := range sessionIDs {
+		session, err := source.Sessions.Load(id)
+		if err != nil {
+			return err
+		}
+		if err := target.Sessions.Save(session); err != nil {
+			return err
+		}
+		result.Sessions++
+	}
+	return nil
+}
+
+// QuerySQLite führt eine lesende SQL-Abfrage auf der Datenbank des
+// Datenverzeichnisses aus (nur Backend sqlite).
+func QuerySQLite(dataDir, query string) ([]string, [][]any, error) {
+	backend, err := persistence.ReadStorageBackend(dataDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if backend != persistence.BackendSQLite {
+		return nil, nil, fmt.Errorf("data directory uses the %s backend - convert it with \"storage convert --to sqlite\" first", backend)
+	}
+
+	db, err := sqlite.Open(dataDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer db.Close()
+	return db.Query(query)
+}
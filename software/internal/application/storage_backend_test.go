@@ -0,0 +1,63 @@
+package application
+
+import (
+	"metric-neo/internal/infrastructure/persistence"
+	"testing"
+)
+
+func TestConvertStorage_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	profile := NewProfileService(dir).CreateProfile("Test Profile", "air_rifle", 420.0, 500.0, 50.0)
+	projectile := NewProjectileService(dir).CreateProjectile("JSB Exact", 0.547, 0.024)
+	sessions := NewSessionService(dir)
+	first := sessions.CreateSession(profile.Data.ID, projectile.Data.ID, nil, "first")
+	second := sessions.CreateSession(profile.Data.ID, projectile.Data.ID, nil, "second")
+	sessions.RecordShot(first.Data.ID, 175.0)
+
+	result, err := ConvertStorage(dir, persistence.BackendSQLite)
+	if err != nil {
+		t.Fatalf("ConvertStorage(sqlite) failed: %v", err)
+	}
+	if result.Profiles != 1 || result.Projectiles != 1 || result.Sessions != 2 {
+		t.Errorf("unexpected conversion counts: %+v", result)
+	}
+	if info, _ := GetStorageInfo(dir); info.Backend != "sqlite" {
+		t.Fatalf("backend = %q, want sqlite", info.Backend)
+	}
+
+	// In SQLite weiterarbeiten: eine Session löschen
+	repos, err := OpenDataDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessionService := NewSessionServiceWith(repos)
+	if r := sessionService.DeleteSession(second.Data.ID); !r.Success {
+		t.Fatalf("DeleteSession failed: %s", r.Error)
+	}
+	repos.Close()
+
+	_, rows, err := QuerySQLite(dir, "SELECT velocity_mps FROM shots")
+	if err != nil || len(rows) != 1 {
+		t.Fatalf("QuerySQLite: %v, %d rows", err, len(rows))
+	}
+
+	// Zurück nach JSON: Die gelöschte Session darf nicht wieder auftauchen
+	if _, err := ConvertStorage(dir, persistence.BackendJSON); err != nil {
+		t.Fatalf("ConvertStorage(json) failed: %v", err)
+	}
+	list := NewSessionService(dir).ListSessions()
+	if !list.Success || len(list.Data) != 1 || list.Data[0].ID != first.Data.ID {
+		t.Fatalf("sessions after round trip: %+v", list.Data)
+	}
+	if list.Data[0].ShotCount != 1 {
+		t.Errorf("shot count = %d, want 1", list.Data[0].ShotCount)
+	}
+
+	if _, err := ConvertStorage(dir, persistence.BackendJSON); err == nil {
+		t.Error("converting to the active backend should fail")
+	}
+	if _, _, err := QuerySQLite(dir, "SELECT 1"); err == nil {
+		t.Error("QuerySQLite should require the sqlite backend")
+	}
+}
@@ -0,0 +1,83 @@
+package application
+
+import (
+	"metric-neo/internal/infrastructure/persistence"
+)
+
+// CorruptFileDTO beschreibt eine unlesbare Datei, die beim Start beiseitegelegt wurde.
+type CorruptFileDTO struct {
+	Path          string `json:"path"`
+	QuarantinedAs string `json:"quarantinedAs"`
+	Reason        string `json:"reason"`
+}
+
+// StorageRecoveryDTO ist das Ergebnis der Start-Prüfung des Datenverzeichnisses.
+type StorageRecoveryDTO struct {
+	RemovedTempFiles []string         `json:"removedTempFiles"`
+	CorruptFiles     []CorruptFileDTO `json:"corruptFiles"`
+	// Migration ist gesetzt, wenn das Datenverzeichnis beim Start aktualisiert wurde
+	Migration *DataMigrationDTO `json:"migration,omitempty"`
+}
+
+// DataMigrationDTO beschreibt eine Migration des Datenverzeichnisses auf ein neues Schema.
+type DataMigrationDTO struct {
+	FromVersion int    `json:"fromVersion"`
+	ToVersion   int    `json:"toVersion"`
+	Documents   int    `json:"documents"`
+	BackupDir   string `json:"backupDir"`
+}
+
+// RecoverStorage prüft alle Repository-Verzeichnisse nach einem Absturz.
+//
+// Übrig gebliebene Temp-Dateien werden gelöscht, unlesbare JSON-Dateien
+// umbenannt (<id>.json.corrupt) und gemeldet, damit sie nicht stillschweigend
+// aus den Listen verschwinden. Wird beim Start von App und CLI aufgerufen.
+func RecoverStorage(dataDir string) (StorageRecoveryDTO, error) {
+	var report persistence.RecoveryReport
+	for _, kind := range persistence.DocumentKinds() {
+		dirReport, err := persistence.NewFileStore(persistence.DocumentDir(dataDir, kind)).Recover()
+		report.Merge(dirReport)
+		if err != nil {
+			return toStorageRecoveryDTO(report), err
+		}
+	}
+	return toStorageRecoveryDTO(report), nil
+}
+
+// MigrateDataDir bringt das Datenverzeichnis auf das aktuelle Schema.
+//
+// Läuft beim Start von App und CLI nach RecoverStorage und vor dem Erstellen
+// der Services. Die Originale landen vorher in backups/. Daten einer neueren
+// Programmversion werden nicht angefasst: Der Fehler enthält dann
+// persistence.ErrNewerSchema und die Services dürfen nicht starten.
+// Gibt nil zurück, wenn nichts zu migrieren war.
+func MigrateDataDir(dataDir string) (*DataMigrationDTO, error) {
+	report, err := persistence.Migrate(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	if !report.Migrated() {
+		return nil, nil
+	}
+	return &DataMigrationDTO{
+		FromVersion: report.FromVersion,
+		ToVersion:   report.ToVersion,
+		Documents:   report.Documents,
+		BackupDir:   report.BackupDir,
+	}, nil
+}
+
+func toStorageRecoveryDTO(report persistence.RecoveryReport) StorageRecoveryDTO {
+	dto := StorageRecoveryDTO{
+		RemovedTempFiles: append([]string{}, report.RemovedTempFiles...),
+		CorruptFiles:     make([]CorruptFileDTO, 0, len(report.CorruptFiles)),
+	}
+	for _, f := range report.CorruptFiles {
+		dto.CorruptFiles = append(dto.CorruptFiles, CorruptFileDTO{
+			Path:          f.Path,
+			QuarantinedAs: f.QuarantinedAs,
+			Reason:        f.Reason,
+		})
+	}
+	return dto
+}
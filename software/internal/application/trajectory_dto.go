@@ -0,0 +1,47 @@
+package application
+
+// TrajectoryRequestDTO sind die Einstellungen einer Flugbahnberechnung.
+// Geschwindigkeit, Projektil (samt Widerstandsmodell), Visierhöhe und
+// Atmosphäre kommen aus der Session.
+type TrajectoryRequestDTO struct {
+	ZeroRangeMeters float64 `json:"zeroRangeMeters"` // 0 = 25 m
+	MaxRangeMeters  float64 `json:"maxRangeMeters"`  // 0 = 100 m
+	StepMeters      float64 `json:"stepMeters"`      // 0 = 10 m
+	CrosswindMPS    float64 `json:"crosswindMPS"`    // positiv = von links
+}
+
+// Standardwerte für leere Felder im TrajectoryRequestDTO
+const (
+	defaultZeroRangeMeters = 25.0
+	defaultMaxRangeMeters  = 100.0
+	defaultStepMeters      = 10.0
+)
+
+// TrajectoryDTO ist eine Flugbahntabelle mit den verwendeten Eingaben.
+type TrajectoryDTO struct {
+	SessionID         string  `json:"sessionId"`
+	DragModel         string  `json:"dragModel"`
+	BC                float64 `json:"bc"`
+	BCUnit            string  `json:"bcUnit"`
+	MuzzleVelocityMPS float64 `json:"muzzleVelocityMPS"` // Mittelwert der gültigen Schüsse
+	WeightGrams       float64 `json:"weightGrams"`
+	SightHeightMM     float64 `json:"sightHeightMM"`
+	ZeroRangeMeters   float64 `json:"zeroRangeMeters"`
+	CrosswindMPS      float64 `json:"crosswindMPS"`
+	AirDensityKgM3    float64 `json:"airDensityKgM3"`
+	SpeedOfSoundMPS   float64 `json:"speedOfSoundMPS"`
+	ZeroAngleMRAD     float64 `json:"zeroAngleMRAD"`
+
+	Points []TrajectoryPointDTO `json:"points"`
+}
+
+// TrajectoryPointDTO ist eine Zeile der Flugbahntabelle.
+type TrajectoryPointDTO struct {
+	RangeMeters         float64 `json:"rangeMeters"`
+	PathMM              float64 `json:"pathMM"`      // relativ zur Visierlinie, negativ = darunter
+	DropMM              float64 `json:"dropMM"`      // relativ zur Laufachse
+	WindDriftMM         float64 `json:"windDriftMM"` // positiv = nach rechts
+	TimeOfFlightSeconds float64 `json:"timeOfFlightSeconds"`
+	VelocityMPS         float64 `json:"velocityMPS"`
+	EnergyJoules        float64 `json:"energyJoules"`
+}
@@ -0,0 +1,179 @@
+package application
+
+import (
+	"fmt"
+	"metric-neo/internal/domain/valueobjects"
+)
+
+// Einheitensysteme für Anzeige und CSV.
+//
+// Gespeichert wird immer in SI (domain-model.md 5.4); das Einheitensystem
+// betrifft nur, was Frontend, CLI und Export zeigen.
+const (
+	// UnitSystemMetric: m/s, J, g, mm, J/cm² (°C)
+	UnitSystemMetric = "metric"
+	// UnitSystemImperial: fps, ft·lbf, gr, in, ft·lbf/in² (°F)
+	UnitSystemImperial = "imperial"
+	// UnitSystemMixed: fps und gr (wie Chrono und Diabolo-Dose),
+	// Energie in J und Längen in mm (wie das Gesetz) - nur Anzeige, nicht CSV
+	UnitSystemMixed = "mixed"
+)
+
+// UnitsDTO beschreibt die Anzeige-Einheiten eines Einheitensystems.
+//
+// Jede Größe hat ein Label und einen Faktor: Anzeigewert = SI-Wert · Faktor.
+// Das Frontend rechnet damit die SI-Felder der DTOs um, ohne die
+// Umrechnungen der Value Objects nachzubauen.
+type UnitsDTO struct {
+	System        string   `json:"system"`
+	Velocity      UnitDTO  `json:"velocity"`      // SI: m/s
+	Energy        UnitDTO  `json:"energy"`        // SI: J
+	Mass          UnitDTO  `json:"mass"`          // SI: g
+	Length        UnitDTO  `json:"length"`        // SI: mm
+	EnergyDensity UnitDTO  `json:"energyDensity"` // SI: J/cm²
+	FillPressure  UnitDTO  `json:"fillPressure"`  // Basis: bar
+	Systems       []string `json:"systems"`       // alle wählbaren Systeme
+}
+
+// UnitDTO ist Label und Faktor einer Anzeige-Einheit.
+type UnitDTO struct {
+	Label    string  `json:"label"`
+	Factor   float64 `json:"factor"`   // Anzeigewert = SI-Wert · Factor
+	Decimals int     `json:"decimals"` // übliche Nachkommastellen
+}
+
+// UnitSystems listet die wählbaren Einheitensysteme.
+func UnitSystems() []string {
+	return []string{UnitSystemMetric, UnitSystemImperial, UnitSystemMixed}
+}
+
+// ParseUnitSystem prüft ein Einheitensystem. "" = metric.
+func ParseUnitSystem(system string) (string, error) {
+	switch system {
+	case "":
+		return UnitSystemMetric, nil
+	case UnitSystemMetric, UnitSystemImperial, UnitSystemMixed:
+		return system, nil
+	}
+	return "", fmt.Errorf("Unbekanntes Einheitensystem: %s (metric, imperial, mixed)", system)
+}
+
+// Presenter rechnet SI-Werte der Services in die Anzeige-Einheiten um.
+//
+// GO-KONZEPT: Value Type mit Methoden
+// Presenter hat nur das Einheitensystem als Zustand und wird als Wert
+// weitergegeben (wie ein Value Object). Die Umrechnung selbst steckt in
+// den Value Objects (Velocity.FeetPerSecond, Mass.Grains, ...).
+type Presenter struct {
+	System string
+}
+
+// NewPresenter erstellt einen Presenter für ein Einheitensystem ("" = metric).
+func NewPresenter(system string) (Presenter, error) {
+	system, err := ParseUnitSystem(system)
+	if err != nil {
+		return Presenter{}, err
+	}
+	return Presenter{System: system}, nil
+}
+
+func (p Presenter) imperialVelocity() bool {
+	return p.System == UnitSystemImperial || p.System == UnitSystemMixed
+}
+
+func (p Presenter) imperialEnergy() bool {
+	return p.System == UnitSystemImperial
+}
+
+// Velocity rechnet m/s in die Anzeige-Einheit um.
+func (p Presenter) Velocity(mps float64) float64 {
+	if p.imperialVelocity() {
+		return valueobjects.Velocity(mps).FeetPerSecond()
+	}
+	return mps
+}
+
+// Energy rechnet J in die Anzeige-Einheit um.
+func (p Presenter) Energy(joules float64) float64 {
+	if p.imperialEnergy() {
+		return valueobjects.Energy(joules).FootPounds()
+	}
+	return joules
+}
+
+// Mass rechnet g in die Anzeige-Einheit um.
+func (p Presenter) Mass(grams float64) float64 {
+	if p.imperialVelocity() {
+		return valueobjects.Mass(grams).Grains()
+	}
+	return grams
+}
+
+// Length rechnet mm in die Anzeige-Einheit um.
+func (p Presenter) Length(mm float64) float64 {
+	if p.imperialEnergy() {
+		return valueobjects.Length(mm).Inches()
+	}
+	return mm
+}
+
+// EnergyDensity rechnet J/cm² in die Anzeige-Einheit um.
+func (p Presenter) EnergyDensity(joulesPerCM2 float64) float64 {
+	if p.imperialEnergy() {
+		return valueobjects.EnergyDensity(joulesPerCM2).FootPoundsPerSquareInch()
+	}
+	return joulesPerCM2
+}
+
+// FillPressure rechnet den Tankdruck (bar) in die Anzeige-Einheit um.
+// psi nur im imperialen System; europäische Manometer zeigen bar.
+func (p Presenter) FillPressure(bar float64) float64 {
+	if p.imperialEnergy() {
+		return valueobjects.FillPressure(bar).PSI()
+	}
+	return bar
+}
+
+// Units beschreibt die Einheiten des Presenters (Faktoren aus den
+// Umrechnungen von 1 SI-Einheit).
+func (p Presenter) Units() UnitsDTO {
+	units := UnitsDTO{
+		System:        p.System,
+		Velocity:      UnitDTO{Label: "m/s", Factor: p.Velocity(1), Decimals: 1},
+		Energy:        UnitDTO{Label: "J", Factor: p.Energy(1), Decimals: 2},
+		Mass:          UnitDTO{Label: "g", Factor: p.Mass(1), Decimals: 3},
+		Length:        UnitDTO{Label: "mm", Factor: p.Length(1), Decimals: 2},
+		EnergyDensity: UnitDTO{Label: "J/cm²", Factor: p.EnergyDensity(1), Decimals: 1},
+		FillPressure:  UnitDTO{Label: "bar", Factor: p.FillPressure(1), Decimals: 0},
+		Systems:       UnitSystems(),
+	}
+	if p.imperialVelocity() {
+		units.Velocity.Label, units.Velocity.Decimals = "fps", 0
+		units.Mass.Label, units.Mass.Decimals = "gr", 2
+	}
+	if p.imperialEnergy() {
+		units.Energy.Label = "ft·lbf"
+		units.Length.Label, units.Length.Decimals = "in", 3
+		units.EnergyDensity.Label = "ft·lbf/in²"
+		units.FillPressure.Label = "psi"
+	}
+	return units
+}
+
+// FormatEnergy formatiert J mit Einheit (z.B. "5.53 ft·lbf").
+func (p Presenter) FormatEnergy(joules float64) string {
+	unit := p.Units().Energy
+	return fmt.Sprintf("%.*f %s", unit.Decimals, p.Energy(joules), unit.Label)
+}
+
+// FormatMass formatiert g mit Einheit (z.B. "8.44 gr").
+func (p Presenter) FormatMass(grams float64) string {
+	unit := p.Units().Mass
+	return fmt.Sprintf("%.*f %s", unit.Decimals, p.Mass(grams), unit.Label)
+}
+
+// FormatLength formatiert mm mit Einheit (z.B. "0.177 in").
+func (p Presenter) FormatLength(mm float64) string {
+	unit := p.Units().Length
+	return fmt.Sprintf("%.*f %s", unit.Decimals, p.Length(mm), unit.Label)
+}
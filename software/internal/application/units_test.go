@@ -0,0 +1,64 @@
+package application
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPresenter(t *testing.T) {
+	tests := []struct {
+		system   string
+		velocity float64 // 100 m/s
+		energy   float64 // 10 J
+		mass     float64 // 1 g
+		length   float64 // 25.4 mm
+		labels   [4]string
+	}{
+		{UnitSystemMetric, 100, 10, 1, 25.4, [4]string{"m/s", "J", "g", "mm"}},
+		{UnitSystemImperial, 328.084, 7.3756, 15.4324, 1, [4]string{"fps", "ft·lbf", "gr", "in"}},
+		{UnitSystemMixed, 328.084, 10, 15.4324, 25.4, [4]string{"fps", "J", "gr", "mm"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.system, func(t *testing.T) {
+			p, err := NewPresenter(tt.system)
+			if err != nil {
+				t.Fatalf("NewPresenter() failed: %v", err)
+			}
+			got := []float64{p.Velocity(100), p.Energy(10), p.Mass(1), p.Length(25.4)}
+			want := []float64{tt.velocity, tt.energy, tt.mass, tt.length}
+			for i := range got {
+				if math.Abs(got[i]-want[i]) > 1e-3 {
+					t.Errorf("value %d = %.4f, want %.4f", i, got[i], want[i])
+				}
+			}
+
+			units := p.Units()
+			labels := [4]string{units.Velocity.Label, units.Energy.Label, units.Mass.Label, units.Length.Label}
+			if labels != tt.labels {
+				t.Errorf("labels = %v, want %v", labels, tt.labels)
+			}
+			// Faktor und Umrechnung müssen übereinstimmen (Frontend rechnet mit dem Faktor)
+			if math.Abs(units.Velocity.Factor*100-got[0]) > 1e-9 {
+				t.Errorf("velocity factor %.6f does not match Velocity()", units.Velocity.Factor)
+			}
+		})
+	}
+
+	// Tankdruck: psi nur imperial, gemischt bleibt bar (europäische Manometer)
+	imperial, _ := NewPresenter(UnitSystemImperial)
+	mixed, _ := NewPresenter(UnitSystemMixed)
+	if psi := imperial.FillPressure(200); math.Abs(psi-2900.75) > 0.01 || imperial.Units().FillPressure.Label != "psi" {
+		t.Errorf("FillPressure(200 bar) = %.2f %s, want 2900.75 psi", psi, imperial.Units().FillPressure.Label)
+	}
+	if mixed.FillPressure(200) != 200 || mixed.Units().FillPressure.Label != "bar" {
+		t.Errorf("mixed FillPressure(200 bar) = %.2f, want 200 bar", mixed.FillPressure(200))
+	}
+
+	if p, err := NewPresenter(""); err != nil || p.System != UnitSystemMetric {
+		t.Errorf("NewPresenter(\"\") = %v, %v, want metric", p, err)
+	}
+	if _, err := NewPresenter("nautical"); err == nil {
+		t.Error("NewPresenter(nautical) should fail")
+	}
+}
@@ -0,0 +1,243 @@
+package cli
+
+import (
+	"fmt"
+	"metric-neo/internal/application"
+	"strings"
+)
+
+const analyticsUsage = `Usage: metric-neo analytics <command> [flags]
+
+Commands:
+  trend <profile-id>             Velocity trend of a profile across sessions with drop detection
+                                 (--projectile, --baseline, --normalize-projectile, --temp-coeff, --units)
+  spc <profile-id>               X-bar/R and I-MR control charts with Western Electric rule violations
+                                 (--projectile, --subgroup, --baseline, --all, --units)
+  fill <profile-id>              Shots per fill across all sessions: sweet spot and fill window of a PCP
+                                 (--projectile, --tolerance, --units)
+`
+
+func (c *CLI) runAnalytics(args []string) error {
+	if len(args) == 0 {
+		return c.unknownSubcommand("analytics", args, analyticsUsage)
+	}
+
+	switch args[0] {
+	case "trend":
+		return c.analyticsTrend(args[1:])
+	case "spc":
+		return c.analyticsSPC(args[1:])
+	case "fill":
+		return c.analyticsFill(args[1:])
+	default:
+		return c.unknownSubcommand("analytics", args, analyticsUsage)
+	}
+}
+
+func (c *CLI) analyticsTrend(args []string) error {
+	fs, common := c.newFlagSet("analytics trend")
+	var request application.TrendRequestDTO
+	fs.StringVar(&request.ProjectileID, "projectile", "", "only sessions with this projectile")
+	fs.IntVar(&request.BaselineSessions, "baseline", application.DefaultTrendBaselineSessions, "number of first sessions that define the reference")
+	fs.BoolVar(&request.NormalizeProjectile, "normalize-projectile", false, "scale every projectile to the one of the first session")
+	tempCoefficient := fs.Float64("temp-coeff", 0, "velocity change in % per °C; corrects sessions to 15 °C (0 = off)")
+	units := addUnitsFlag(fs)
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<profile-id>"); err != nil {
+		return err
+	}
+	if *tempCoefficient != 0 {
+		request.TemperatureCoefficient = tempCoefficient
+	}
+	presenter, err := c.presenter(*units)
+	if err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	trend, err := unwrap(svc.analytics.AnalyzePerformanceTrend(rest[0], request))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, trend)
+	}
+	return c.printTrend(trend, presenter)
+}
+
+// printTrend gibt die Zeitreihe und das Ergebnis der Drop-Erkennung aus.
+func (c *CLI) printTrend(trend application.PerformanceTrendDTO, presenter application.Presenter) error {
+	v := presenter.Units().Velocity.Label
+	velocity := presenter.Velocity
+
+	t := newTable(c.stdout, "SESSION", "DATE", "PROJECTILE", "SHOTS", "AVG "+strings.ToUpper(v), "SD", "ES", "ADJUSTED", "CUSUM-", "ALERTS")
+	for _, p := range trend.Points {
+		id := p.SessionID
+		if p.Baseline {
+			id += " *"
+		}
+		t.row(id, p.CreatedAt[:10], p.ProjectileName, p.ValidShotCount,
+			velocity(p.AvgVelocityMPS), velocity(p.StandardDeviation), velocity(p.ExtremeSpread),
+			velocity(p.AdjustedVelocityMPS), p.CUSUMLow, strings.Join(p.Alerts, ","))
+	}
+	if err := t.flush(); err != nil {
+		return err
+	}
+	fmt.Fprint(c.stdout, "* baseline session\n\n")
+
+	var baseline, slope any
+	if trend.Status != application.TrendInsufficient {
+		baseline = fmt.Sprintf("%.2f %s (SD %.2f, between sessions %.2f)",
+			velocity(trend.BaselineMeanMPS), v, velocity(trend.BaselineSDMPS), velocity(trend.BetweenSessionMPS))
+	}
+	if trend.SlopeMPSPerDay != nil {
+		slope = fmt.Sprintf("%.3f %s/day", velocity(*trend.SlopeMPSPerDay), v)
+	}
+	return printFields(c.stdout,
+		"Profile", trend.ProfileName,
+		"Status", trend.Status,
+		"Baseline", baseline,
+		"Trend", slope,
+		"Drop started", trend.DropStartSessionID,
+		"Drop detected", trend.DropDetectedSessionID,
+		"Skipped", fmt.Sprintf("%d sessions without valid shots", trend.SkippedSessions),
+	)
+}
+
+func (c *CLI) analyticsSPC(args []string) error {
+	fs, common := c.newFlagSet("analytics spc")
+	var request application.ControlChartRequestDTO
+	fs.StringVar(&request.ProjectileID, "projectile", "", "only sessions with this projectile")
+	fs.IntVar(&request.SubgroupSize, "subgroup", application.DefaultSubgroupSize, "consecutive shots per subgroup of the X-bar/R chart (2-10)")
+	fs.IntVar(&request.BaselineSessions, "baseline", application.DefaultControlBaselineSessions, "number of first sessions that define the control limits")
+	all := fs.Bool("all", false, "list every point, not only rule violations")
+	units := addUnitsFlag(fs)
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<profile-id>"); err != nil {
+		return err
+	}
+	presenter, err := c.presenter(*units)
+	if err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	charts, err := unwrap(svc.controlCharts.ControlCharts(rest[0], request))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, charts)
+	}
+	return c.printControlCharts(charts, presenter, *all)
+}
+
+// printControlCharts gibt Grenzen und auffällige Punkte der vier Karten aus.
+func (c *CLI) printControlCharts(charts application.ControlChartsDTO, presenter application.Presenter, all bool) error {
+	v := presenter.Units().Velocity.Label
+	velocity := presenter.Velocity
+
+	if err := printFields(c.stdout,
+		"Profile", charts.ProfileName,
+		"Sessions", fmt.Sprintf("%d (baseline: first %d)", charts.SessionCount, charts.BaselineSessions),
+		"Subgroups", fmt.Sprintf("%d shots, %d in baseline, %d shots left over", charts.SubgroupSize, charts.BaselineSubgroups, charts.DiscardedShots),
+		"In control", charts.InControl,
+	); err != nil {
+		return err
+	}
+	fmt.Fprintln(c.stdout)
+
+	t := newTable(c.stdout, "CHART", "LCL "+strings.ToUpper(v), "CENTER", "UCL", "VIOLATIONS")
+	chartList := []application.ControlChartDTO{charts.XBar, charts.Range, charts.Individuals, charts.MovingRange}
+	for _, chart := range chartList {
+		t.row(chartNames[chart.Kind], velocity(chart.LCL), velocity(chart.CenterLine), velocity(chart.UCL), chart.ViolationCount)
+	}
+	if err := t.flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.stdout)
+	t = newTable(c.stdout, "CHART", "SESSION", "DATE", "SHOT", strings.ToUpper(v), "RULES")
+	for _, chart := range chartList {
+		for _, p := range chart.Points {
+			if !all && (len(p.Rules) == 0 || p.Baseline) {
+				continue
+			}
+			id := p.SessionID
+			if p.Baseline {
+				id += " *"
+			}
+			rules := make([]string, len(p.Rules))
+			for i, rule := range p.Rules {
+				rules[i] = fmt.Sprint(rule)
+			}
+			t.row(chartNames[chart.Kind], id, p.CreatedAt[:10], fmt.Sprintf("#%d", p.ShotNumber), velocity(p.Value), strings.Join(rules, ","))
+		}
+	}
+	if err := t.flush(); err != nil {
+		return err
+	}
+	if all {
+		fmt.Fprintln(c.stdout, "* baseline session")
+	}
+	return nil
+}
+
+// chartNames sind die Anzeigenamen der Regelkarten.
+var chartNames = map[string]string{
+	application.ChartXBar:        "X-bar",
+	application.ChartRange:       "R",
+	application.ChartIndividuals: "I",
+	application.ChartMovingRange: "MR",
+}
+
+func (c *CLI) analyticsFill(args []string) error {
+	fs, common := c.newFlagSet("analytics fill")
+	var request application.ShotsPerFillRequestDTO
+	fs.StringVar(&request.ProjectileID, "projectile", "", "only sessions with this projectile")
+	fs.Float64Var(&request.ToleranceMPS, "tolerance", application.DefaultSweetSpotToleranceMPS, "allowed velocity spread of the sweet spot in m/s")
+	units := addUnitsFlag(fs)
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<profile-id>"); err != nil {
+		return err
+	}
+	presenter, err := c.presenter(*units)
+	if err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	fill, err := unwrap(svc.analytics.AnalyzeShotsPerFill(rest[0], request))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, fill)
+	}
+	fmt.Fprintf(c.stdout, "%s: %d sessions, %d strings\n\n", fill.ProfileName, fill.SessionCount, len(fill.Strings))
+	return c.printShotsPerFill(fill, presenter)
+}
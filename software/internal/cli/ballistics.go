@@ -0,0 +1,278 @@
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"metric-neo/internal/application"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// dragFlags sind BC, Widerstandsmodell, Einheit und eigene Kurve als Flags
+// (für projectile add und projectile set-drag).
+type dragFlags struct {
+	bc        *float64
+	model     *string
+	unit      *string
+	tablePath *string
+}
+
+func addDragFlags(fs *flag.FlagSet) *dragFlags {
+	return &dragFlags{
+		bc:        fs.Float64("bc", 0, "ballistic coefficient (0 = unknown)"),
+		model:     fs.String("drag", "G1", "drag model the BC refers to: G1, G7, GA or CUSTOM"),
+		unit:      fs.String("bc-unit", "lb/in2", "unit of the BC: lb/in2 or kg/m2"),
+		tablePath: fs.String("drag-table", "", "file with the own drag curve for CUSTOM (one \"mach,cd\" pair per line)"),
+	}
+}
+
+// apply überträgt die gesetzten Flags auf drag. all=true übernimmt alle Flags (add).
+// Ein anderes Modell als CUSTOM verwirft eine vorhandene eigene Kurve.
+func (f *dragFlags) apply(fs *flag.FlagSet, drag *application.DragDTO, all bool) error {
+	set := map[string]bool{}
+	fs.Visit(func(fl *flag.Flag) { set[fl.Name] = true })
+
+	if all || set["bc"] {
+		drag.BC = *f.bc
+	}
+	if all || set["drag"] {
+		drag.DragModel = *f.model
+		if !strings.EqualFold(*f.model, "CUSTOM") {
+			drag.DragTable = nil
+		}
+	}
+	if all || set["bc-unit"] {
+		drag.BCUnit = *f.unit
+	}
+	if *f.tablePath != "" {
+		table, err := readDragTable(*f.tablePath)
+		if err != nil {
+			return err
+		}
+		drag.DragTable = table
+	}
+	return nil
+}
+
+// readDragTable liest eine eigene Widerstandskurve: je Zeile Mach und Cd,
+// getrennt durch Komma, Semikolon oder Leerzeichen. Leerzeilen, Zeilen mit
+// # und eine Kopfzeile (z.B. "mach,cd") werden übersprungen.
+func readDragTable(path string) ([]application.DragPointDTO, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var table []application.DragPointDTO
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ';' || r == ' ' || r == '\t' })
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected \"mach,cd\", got %q", path, line, text)
+		}
+		mach, errMach := strconv.ParseFloat(fields[0], 64)
+		cd, errCd := strconv.ParseFloat(fields[1], 64)
+		if errMach != nil || errCd != nil {
+			if len(table) == 0 {
+				continue // Kopfzeile
+			}
+			return nil, fmt.Errorf("%s:%d: invalid number in %q", path, line, text)
+		}
+		table = append(table, application.DragPointDTO{Mach: mach, Cd: cd})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// projectileSetDrag ändert BC, Widerstandsmodell und Einheit eines Projectiles.
+// Sessions behalten ihren Snapshot.
+func (c *CLI) projectileSetDrag(args []string) error {
+	fs, common := c.newFlagSet("inventory projectile set-drag")
+	flags := addDragFlags(fs)
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<projectile-id>"); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	p, err := unwrap(svc.projectiles.LoadProjectile(rest[0]))
+	if err != nil {
+		return err
+	}
+	drag := application.DragDTO{BC: p.BC, DragModel: p.DragModel, BCUnit: p.BCUnit, DragTable: p.DragTable}
+	if err := flags.apply(fs, &drag, false); err != nil {
+		return err
+	}
+	p, err = unwrap(svc.projectiles.UpdateProjectileWithDrag(p.ID, p.Name, p.WeightGrams, drag))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, p)
+	}
+	fmt.Fprintf(c.stdout, "%s: BC %.4f %s (%s)\n", p.Name, p.BC, p.BCUnit, p.DragModel)
+	return nil
+}
+
+// sessionTrajectory berechnet die Flugbahntabelle einer Session.
+func (c *CLI) sessionTrajectory(args []string) error {
+	fs, common := c.newFlagSet("session trajectory")
+	var request application.TrajectoryRequestDTO
+	fs.Float64Var(&request.ZeroRangeMeters, "zero", 25, "zero range in m")
+	fs.Float64Var(&request.MaxRangeMeters, "max", 100, "last range of the table in m")
+	fs.Float64Var(&request.StepMeters, "step", 10, "range step in m")
+	fs.Float64Var(&request.CrosswindMPS, "wind", 0, "crosswind in m/s, positive from the left")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<session-id>"); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	trajectory, err := unwrap(svc.sessions.CalculateTrajectory(rest[0], request))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, trajectory)
+	}
+
+	fmt.Fprintf(c.stdout, "%.2f m/s, BC %.4f %s (%s), sight height %.0f mm, zero %.0f m, air density %.4f kg/m³\n\n",
+		trajectory.MuzzleVelocityMPS, trajectory.BC, trajectory.BCUnit, trajectory.DragModel, trajectory.SightHeightMM,
+		trajectory.ZeroRangeMeters, trajectory.AirDensityKgM3)
+
+	t := newTable(c.stdout, "RANGE M", "PATH MM", "DROP MM", "WIND MM", "TIME S", "M/S", "J")
+	for _, p := range trajectory.Points {
+		t.row(fmt.Sprintf("%.0f", p.RangeMeters), fmt.Sprintf("%.1f", p.PathMM), fmt.Sprintf("%.1f", p.DropMM),
+			fmt.Sprintf("%.1f", p.WindDriftMM), fmt.Sprintf("%.3f", p.TimeOfFlightSeconds), p.VelocityMPS, p.EnergyJoules)
+	}
+	return t.flush()
+}
+
+// sessionDownrange verknüpft eine Session mit ihrer nahen Messung.
+func (c *CLI) sessionDownrange(args []string) error {
+	fs, common := c.newFlagSet("session downrange")
+	distance := fs.Float64("distance", 0, "distance between the near and the far measurement in m")
+	clearLink := fs.Bool("clear", false, "remove the link")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *clearLink {
+		err = c.requireArgs(fs, rest, 1, "<session-id>")
+	} else {
+		err = c.requireArgs(fs, rest, 2, "<session-id> <near-session-id>")
+	}
+	if err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	nearID := ""
+	if !*clearLink {
+		nearID = rest[1]
+	}
+	session, err := unwrap(svc.sessions.SetDownrange(rest[0], nearID, *distance))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, session)
+	}
+	if session.Downrange == nil {
+		fmt.Fprintf(c.stdout, "%s: downrange link removed\n", session.ID)
+		return nil
+	}
+	fmt.Fprintf(c.stdout, "%s: %s\n", session.ID, formatDownrange(session.Downrange))
+	return nil
+}
+
+// sessionEstimateBC bestimmt den BC aus einer fernen Session und übernimmt
+// ihn mit --apply in das Projectile.
+func (c *CLI) sessionEstimateBC(args []string) error {
+	fs, common := c.newFlagSet("session estimate-bc")
+	var request application.BCEstimateRequestDTO
+	fs.StringVar(&request.DragModel, "drag", "", "drag model to fit: G1, G7, GA or CUSTOM (default: the projectile's)")
+	fs.BoolVar(&request.Paired, "paired", false, "pair shot i of both sessions (two chronographs)")
+	apply := fs.Bool("apply", false, "write the estimated BC to the projectile")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<session-id>"); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	estimate, err := unwrap(svc.sessions.EstimateBC(rest[0], request))
+	if err != nil {
+		return err
+	}
+	if *apply {
+		if _, err := unwrap(svc.projectiles.ApplyBCEstimate(estimate)); err != nil {
+			return err
+		}
+	}
+
+	if common.json {
+		return printJSON(c.stdout, estimate)
+	}
+
+	interval := ""
+	if estimate.BCLower != nil && estimate.BCUpper != nil {
+		interval = fmt.Sprintf("%.4f - %.4f", *estimate.BCLower, *estimate.BCUpper)
+	}
+	shots := fmt.Sprintf("%d near, %d far", estimate.NearShotCount, estimate.FarShotCount)
+	if estimate.Paired {
+		shots = fmt.Sprintf("%d pairs", estimate.PairCount)
+	}
+	if err := printFields(c.stdout,
+		"Projectile", estimate.ProjectileName,
+		"Distance m", estimate.DistanceMeters,
+		"Shots", shots,
+		"Near m/s", estimate.NearVelocityMPS,
+		"Far m/s", estimate.FarVelocityMPS,
+		"BC", fmt.Sprintf("%.4f %s (%s)", estimate.BC, estimate.BCUnit, estimate.DragModel),
+		"95% interval", interval,
+		"Current BC", fmt.Sprintf("%.4f %s", estimate.CurrentBC, estimate.BCUnit),
+	); err != nil {
+		return err
+	}
+	if *apply {
+		fmt.Fprintf(c.stdout, "\nBC of %s updated\n", estimate.ProjectileName)
+	}
+	return nil
+}
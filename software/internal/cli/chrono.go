@@ -0,0 +1,308 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"metric-neo/internal/application"
+	"metric-neo/internal/infrastructure/chrono"
+	"metric-neo/internal/infrastructure/chrono/chronosim"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+)
+
+const chronoUsage = `Usage: metric-neo chrono <command> [flags]
+
+Commands:
+  drivers         List the supported chronograph protocols
+  ports           List serial ports (--probe listens for a chronograph)
+  replay          Play back a recorded wire log (--verify checks the parser)
+  simulate        Run a virtual chronograph on a pseudo terminal (Linux)
+`
+
+func (c *CLI) runChrono(args []string) error {
+	if len(args) == 0 {
+		return c.unknownSubcommand("chrono", args, chronoUsage)
+	}
+
+	switch args[0] {
+	case "drivers":
+		return c.chronoDrivers(args[1:])
+	case "ports":
+		return c.chronoPorts(args[1:])
+	case "replay":
+		return c.chronoReplay(args[1:])
+	case "simulate":
+		return c.chronoSimulate(args[1:])
+	default:
+		return c.unknownSubcommand("chrono", args, chronoUsage)
+	}
+}
+
+func (c *CLI) chronoDrivers(args []string) error {
+	fs, common := c.newFlagSet("chrono drivers")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	drivers := application.ListChronoDrivers()
+	if common.json {
+		return printJSON(c.stdout, drivers)
+	}
+
+	t := newTable(c.stdout, "NAME", "UNIT", "BAUD", "MODE", "DESCRIPTION")
+	for _, d := range drivers {
+		t.row(d.Name, d.Unit, d.BaudRate, d.Mode, d.Description)
+	}
+	return t.flush()
+}
+
+// chronoPorts listet die seriellen Ports und prüft sie optional auf einen Chrono.
+func (c *CLI) chronoPorts(args []string) error {
+	fs, common := c.newFlagSet("chrono ports")
+	probe := fs.Bool("probe", false, "open each port and listen for chronograph frames")
+	driverName := fs.String("driver", "", "protocol for --probe (default: configured driver)")
+	baudRate := fs.Int("baud", 0, "baud rate for --probe (default: driver default)")
+	timeout := fs.Duration("timeout", application.DefaultProbeTimeout, "listen time per port for --probe")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	if *probe && *driverName == "" {
+		if cfg, err := c.loadConfig(); err == nil && cfg != nil {
+			*driverName = cfg.ChronoDriver
+		}
+	}
+
+	ports, err := application.DiscoverChronoPorts(*probe, *driverName, *baudRate, *timeout)
+	if err != nil {
+		return err
+	}
+	if common.json {
+		return printJSON(c.stdout, ports)
+	}
+
+	headers := []string{"PORT", "USB", "VID:PID", "SERIAL", "PRODUCT"}
+	if *probe {
+		headers = append(headers, "STATUS")
+	}
+	t := newTable(c.stdout, headers...)
+	for _, p := range ports {
+		usb, id := "no", ""
+		if p.IsUSB {
+			usb, id = "yes", p.VID+":"+p.PID
+		}
+		cells := []any{p.Name, usb, id, p.SerialNumber, p.Product}
+		if *probe {
+			cells = append(cells, p.Status)
+		}
+		t.row(cells...)
+	}
+	return t.flush()
+}
+
+// replayShot ist eine Zeile der JSON-Ausgabe von "chrono replay" (NDJSON).
+type replayShot struct {
+	Shot        int     `json:"shot"`
+	VelocityMPS float64 `json:"velocityMPS"`
+}
+
+// wireLogMismatchDTO ist ein abweichender Frame in der JSON-Ausgabe von --verify.
+type wireLogMismatchDTO struct {
+	Line     int    `json:"line"`
+	Frame    string `json:"frame"`
+	Recorded string `json:"recorded"`
+	Got      string `json:"got"`
+}
+
+// chronoReplay spielt ein Wire-Log ab oder prüft es gegen den aktuellen Parser.
+//
+// Ohne --verify werden die Messwerte wie bei "session capture" ausgegeben,
+// mit --verify jeder Frame, den der Parser heute anders liest (Exit-Code 1).
+func (c *CLI) chronoReplay(args []string) error {
+	fs, common := c.newFlagSet("chrono replay")
+	driverName := fs.String("driver", "", "protocol to parse the frames with (default: driver recorded in the log)")
+	speed := fs.Float64("speed", 1, "playback speed: 1 = original pace, 10 = ten times faster, 0 = no delays")
+	verify := fs.Bool("verify", false, "compare every frame with the recorded result instead of playing back")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, positional, 1, "<wire-log>"); err != nil {
+		return err
+	}
+	path := positional[0]
+
+	header, err := readWireLogHeader(path)
+	if err != nil {
+		return err
+	}
+	if *driverName == "" {
+		*driverName = header.Driver
+	}
+	driver, err := chrono.LookupDriver(*driverName)
+	if err != nil {
+		return err
+	}
+
+	if *verify {
+		return c.chronoVerify(path, driver, common)
+	}
+
+	replay := chrono.NewReplayChrono(driver, *speed)
+	if err := replay.Connect(path, 0); err != nil {
+		return err
+	}
+	defer replay.Disconnect()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	velocities := make(chan float32, 16)
+	errs := make(chan error, 16)
+	replay.StartAutoRead(velocities, errs)
+
+	enc := json.NewEncoder(c.stdout)
+	shots := 0
+	printShot := func(velocity float32) error {
+		shots++
+		if common.json {
+			return enc.Encode(replayShot{Shot: shots, VelocityMPS: float64(velocity)})
+		}
+		_, err := fmt.Fprintf(c.stdout, "#%d\t%.2f m/s\n", shots, velocity)
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case velocity := <-velocities:
+			if err := printShot(velocity); err != nil {
+				return err
+			}
+		case err := <-errs:
+			if !errors.Is(err, chrono.ErrDisconnected) {
+				fmt.Fprintf(c.stderr, "chrono: %v\n", err)
+				continue
+			}
+			// Ende des Logs: alle Messwerte davor liegen bereits im Channel
+			for len(velocities) > 0 {
+				if err := printShot(<-velocities); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+}
+
+func (c *CLI) chronoVerify(path string, driver chrono.Driver, common *commonFlags) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	log, err := chrono.NewWireLogReader(file)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	checked, mismatches, err := chrono.VerifyWireLog(log, driver)
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		dtos := make([]wireLogMismatchDTO, 0, len(mismatches))
+		for _, m := range mismatches {
+			dtos = append(dtos, wireLogMismatchDTO{Line: m.Entry.Line, Frame: string(m.Entry.Frame), Recorded: m.Entry.Result(), Got: m.Got})
+		}
+		if err := printJSON(c.stdout, map[string]any{"checked": checked, "mismatches": dtos}); err != nil {
+			return err
+		}
+	} else if len(mismatches) > 0 {
+		t := newTable(c.stdout, "LINE", "FRAME", "RECORDED", "NOW")
+		for _, m := range mismatches {
+			t.row(m.Entry.Line, strconv.Quote(string(m.Entry.Frame)), m.Entry.Result(), m.Got)
+		}
+		if err := t.flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%d of %d frames parse differently with driver %s", len(mismatches), checked, driver.Name)
+	}
+	if !common.json {
+		fmt.Fprintf(c.stderr, "%d frames OK (driver %s)\n", checked, driver.Name)
+	}
+	return nil
+}
+
+// readWireLogHeader liest nur den Kopf eines Wire-Logs (Treiber, Port).
+func readWireLogHeader(path string) (chrono.WireLogHeader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return chrono.WireLogHeader{}, err
+	}
+	defer file.Close()
+
+	log, err := chrono.NewWireLogReader(file)
+	if err != nil {
+		return chrono.WireLogHeader{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return log.Header(), nil
+}
+
+// chronoSimulate startet einen virtuellen Chronographen für die Entwicklung.
+//
+// Der ausgegebene Pfad wird in den Einstellungen (oder mit --port) als
+// Port eingetragen; danach sendet der Simulator Schüsse im Takt von --interval.
+func (c *CLI) chronoSimulate(args []string) error {
+	fs, _ := c.newFlagSet("chrono simulate")
+	driverName := fs.String("driver", "lmbr", "protocol to emit")
+	interval := fs.Duration("interval", 2*time.Second, "time between shots")
+	mean := fs.Float64("mean", 175, "mean velocity in m/s")
+	sd := fs.Float64("sd", 1.5, "standard deviation in m/s")
+	count := fs.Int("count", 0, "stop after N shots (0 = until interrupted)")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	encoding, err := chronosim.EncodingFor(*driverName)
+	if err != nil {
+		return err
+	}
+	sim, err := chronosim.New(chronosim.Options{Encoding: encoding})
+	if err != nil {
+		return err
+	}
+	defer sim.Close()
+
+	fmt.Fprintln(c.stdout, sim.Path())
+	fmt.Fprintf(c.stderr, "simulating %s chronograph on %s - press Ctrl+C to stop\n", *driverName, sim.Path())
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	for sent := 0; *count <= 0 || sent < *count; sent++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		shots, velocities := chronosim.NormalShots(time.Now().UnixNano(), *mean, *sd, 1)
+		if err := sim.Play(shots...); err != nil {
+			return err
+		}
+		fmt.Fprintf(c.stderr, "#%d\t%.2f m/s\n", sent+1, velocities[0])
+	}
+	return nil
+}
@@ -0,0 +1,400 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"metric-neo/internal/application"
+	"metric-neo/internal/infrastructure/chrono"
+	"strings"
+)
+
+// CLI ist der Headless-Modus von Metric Neo.
+//
+// GO-KONZEPT: Gleiche Services, anderer Adapter
+// Die CLI ist (wie app.go für Wails) nur ein dünner Adapter über den
+// Application-Services. Sie arbeitet auf demselben Daten-Verzeichnis
+// wie die GUI (LoadConfig) oder auf einem per --data-dir übergebenen.
+//
+// Aufbau: metric-neo <gruppe> <befehl> [flags] [argumente]
+//
+//	metric-neo session list --json
+//	metric-neo session capture --profile X --projectile Y --port /dev/ttyUSB0
+//	metric-neo inventory projectile add --name "JSB Exact" --weight-g 0.547
+type CLI struct {
+	stdout io.Writer
+	stderr io.Writer
+
+	// newChrono erzeugt den Chronographen für "session capture".
+	// Tests ersetzen ihn durch einen MockChrono.
+	newChrono func(driver chrono.Driver) chrono.ChronoService
+
+	// loadConfig lädt die App-Konfiguration (Default: application.LoadConfig)
+	loadConfig func() (*application.Config, error)
+
+	// opened sind die Repositories dieses Aufrufs; Run schließt sie am Ende
+	// (SQLite schreibt dabei sein WAL zurück in die Datenbank).
+	opened []*application.Repositories
+}
+
+// errUsage signalisiert einen Bedienfehler (Exit-Code 2, Usage wurde bereits ausgegeben).
+var errUsage = errors.New("usage error")
+
+// commandGroups sind die Top-Level-Befehle der CLI.
+var commandGroups = []string{"session", "inventory", "analytics", "chrono", "storage", "help"}
+
+// New erstellt eine CLI mit Standard-Abhängigkeiten.
+func New(stdout, stderr io.Writer) *CLI {
+	return &CLI{
+		stdout:     stdout,
+		stderr:     stderr,
+		newChrono:  func(driver chrono.Driver) chrono.ChronoService { return chrono.NewSerialChronoWithDriver(driver) },
+		loadConfig: application.LoadConfig,
+	}
+}
+
+// IsCommand prüft, ob arg ein CLI-Befehl ist.
+//
+// main.go entscheidet damit, ob die CLI oder das Wails-Fenster startet.
+func IsCommand(arg string) bool {
+	switch arg {
+	case "-h", "--help", "-help":
+		return true
+	}
+	for _, group := range commandGroups {
+		if arg == group {
+			return true
+		}
+	}
+	return false
+}
+
+// Run führt die CLI mit den Standard-Abhängigkeiten aus und gibt den Exit-Code zurück.
+func Run(args []string, stdout, stderr io.Writer) int {
+	return New(stdout, stderr).Run(args)
+}
+
+// Run führt einen CLI-Aufruf aus und gibt den Exit-Code zurück.
+//
+// Exit-Codes: 0 = OK, 1 = Fehler, 2 = falsche Bedienung
+func (c *CLI) Run(args []string) int {
+	err := c.dispatch(args)
+	c.closeRepositories()
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	default:
+		fmt.Fprintf(c.stderr, "error: %v\n", err)
+		return 1
+	}
+}
+
+func (c *CLI) dispatch(args []string) error {
+	if len(args) == 0 {
+		c.printUsage(c.stderr)
+		return errUsage
+	}
+
+	switch args[0] {
+	case "session":
+		return c.runSession(args[1:])
+	case "inventory":
+		return c.runInventory(args[1:])
+	case "analytics":
+		return c.runAnalytics(args[1:])
+	case "chrono":
+		return c.runChrono(args[1:])
+	case "storage":
+		return c.runStorage(args[1:])
+	case "help", "-h", "--help", "-help":
+		c.printUsage(c.stdout)
+		return nil
+	default:
+		fmt.Fprintf(c.stderr, "unknown command: %s\n\n", args[0])
+		c.printUsage(c.stderr)
+		return errUsage
+	}
+}
+
+func (c *CLI) printUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage: metric-neo <command> <subcommand> [flags] [args]
+
+Without arguments metric-neo starts the desktop application.
+
+Sessions:
+  session list                     List all sessions
+  session show <id>                Show a session with all shots
+  session stats <id>               Show session statistics
+  session create                   Create a session (--profile, --projectile)
+  session record <id> <v>...       Record shots manually (m/s)
+  session capture                  Record shots from the chronograph
+  session string <id> [name]       Start a new shot string (e.g. per fill), also while capturing
+  session set-fill <id> <n>        Record start and end tank pressure of shot string n (PCP)
+  session fill <id>                Shots per fill: velocity curve across strings, sweet spot, fill window
+  session export <id>...           Export sessions as JSON or CSV (--format csv)
+  session import <file.csv>        Import sessions from CSV
+  session delete <id>              Delete a session
+  session lots <projectile-id>     Compare the statistics of all lots of a projectile
+  session compare <id> <id>...     Compare sessions with significance tests
+  session trajectory <id>          Trajectory table (drop, wind drift, velocity, energy)
+
+Inventory:
+  inventory profile    list|show|add|delete|set-power-plant
+  inventory projectile list|show|add|delete|set-drag|lots|lot-add|lot-update|lot-delete
+  inventory sight      list|show|add|delete
+
+Analytics:
+  analytics trend <profile-id>     Velocity trend across sessions, detects performance drops
+  analytics spc <profile-id>       X-bar/R and I-MR control charts over the shot history
+  analytics fill <profile-id>      Shots per fill and fill window across all sessions of a PCP
+
+Chronograph:
+  chrono drivers                   List supported chronograph protocols
+  chrono ports                     List serial ports and detect chronographs
+  chrono replay <wire-log>         Play back raw chrono data (--verify checks the parser)
+  chrono simulate                  Run a virtual chronograph (Linux, for development)
+
+Storage:
+  storage info                     Show the storage backend of the data directory
+  storage convert --to <backend>   Copy all data to json or sqlite and switch to it
+  storage query <sql>              Run a read-only SQL query (sqlite backend)
+
+Common flags:
+  --data-dir <dir>   Use this data directory instead of the configured one
+  --json             Print machine readable JSON instead of tables
+`)
+}
+
+// unknownSubcommand meldet einen unbekannten Unterbefehl einer Gruppe.
+func (c *CLI) unknownSubcommand(group string, args []string, usage string) error {
+	if len(args) > 0 {
+		fmt.Fprintf(c.stderr, "unknown %s command: %s\n\n", group, args[0])
+	}
+	fmt.Fprint(c.stderr, usage)
+	return errUsage
+}
+
+// commonFlags sind die Flags, die jeder Befehl versteht.
+type commonFlags struct {
+	dataDir string
+	json    bool
+}
+
+// newFlagSet erstellt ein FlagSet mit den gemeinsamen Flags.
+//
+// GO-KONZEPT: flag.ContinueOnError
+// Wir wollen Fehler selbst behandeln statt os.Exit() aus dem flag-Package.
+func (c *CLI) newFlagSet(name string) (*flag.FlagSet, *commonFlags) {
+	fs := flag.NewFlagSet("metric-neo "+name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+
+	common := &commonFlags{}
+	fs.StringVar(&common.dataDir, "data-dir", "", "data directory (default: configured directory)")
+	fs.BoolVar(&common.json, "json", false, "print JSON instead of tables")
+	return fs, common
+}
+
+// parseArgs parst Flags und Positionsargumente in beliebiger Reihenfolge.
+//
+// Das flag-Package stoppt beim ersten Nicht-Flag. Für Aufrufe wie
+// "session show <id> --json" parsen wir deshalb wiederholt weiter.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, errUsage
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		if rest[0] == "--" {
+			return append(positional, rest[1:]...), nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// requireArgs prüft die Anzahl der Positionsargumente.
+func (c *CLI) requireArgs(fs *flag.FlagSet, args []string, min int, names string) error {
+	if len(args) < min {
+		fmt.Fprintf(c.stderr, "missing argument: %s\n", names)
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+// resolveDataDir bestimmt das Daten-Verzeichnis.
+//
+// Priorität: --data-dir > config.json der Desktop-App
+func (c *CLI) resolveDataDir(common *commonFlags) (string, error) {
+	if common.dataDir != "" {
+		return common.dataDir, nil
+	}
+
+	cfg, err := c.loadConfig()
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg == nil {
+		return "", fmt.Errorf("setup not completed - start the desktop app once or pass --data-dir")
+	}
+	return cfg.DataDir, nil
+}
+
+// services bündelt die Application-Services für einen CLI-Aufruf.
+type services struct {
+	profiles      *application.ProfileService
+	projectiles   *application.ProjectileService
+	sights        *application.SightService
+	sessions      *application.SessionService
+	analytics     *application.AnalyticsService
+	controlCharts *application.ControlChartService
+}
+
+// openServices erstellt die Services auf dem aufgelösten Daten-Verzeichnis.
+func (c *CLI) openServices(common *commonFlags) (*services, error) {
+	dataDir, err := c.prepareDataDir(common)
+	if err != nil {
+		return nil, err
+	}
+
+	repos, err := application.OpenDataDir(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	c.opened = append(c.opened, repos)
+
+	svc := &services{
+		profiles:      application.NewProfileServiceWith(repos),
+		projectiles:   application.NewProjectileServiceWith(repos),
+		sights:        application.NewSightServiceWith(repos),
+		sessions:      application.NewSessionServiceWith(repos),
+		analytics:     application.NewAnalyticsServiceWith(repos),
+		controlCharts: application.NewControlChartServiceWith(repos),
+	}
+
+	// Energiegrenzen wie in der Desktop-App (ungültige Regeln nur melden)
+	if cfg, err := c.loadConfig(); err == nil && cfg != nil {
+		limits, err := cfg.ResolveEnergyLimits()
+		if err != nil {
+			fmt.Fprintf(c.stderr, "warning: ignoring energy limits in config: %v\n", err)
+		}
+		svc.sessions.SetEnergyLimits(limits)
+	}
+	return svc, nil
+}
+
+// addJurisdictionFlag fügt --jurisdiction für die Prüfung der Energiegrenzen hinzu.
+func addJurisdictionFlag(fs *flag.FlagSet) *string {
+	return fs.String("jurisdiction", "", "check shot energy against the limits of a jurisdiction (DE, UK; default: configured)")
+}
+
+// applyJurisdiction prüft mit den Grenzen eines Rechtsraums statt dem
+// konfigurierten. Eigene Regeln aus der Config gelten weiter.
+func (c *CLI) applyJurisdiction(svc *services, jurisdiction string) error {
+	if jurisdiction == "" {
+		return nil
+	}
+
+	override := application.Config{EnergyJurisdiction: jurisdiction}
+	if cfg, err := c.loadConfig(); err == nil && cfg != nil {
+		override.EnergyLimits = cfg.EnergyLimits
+	}
+	limits, err := override.ResolveEnergyLimits()
+	if err != nil {
+		return err
+	}
+	svc.sessions.SetEnergyLimits(limits)
+	return nil
+}
+
+// addUnitsFlag fügt --units für die Anzeige-Einheiten hinzu.
+func addUnitsFlag(fs *flag.FlagSet) *string {
+	return fs.String("units", "", "display units: metric, imperial or mixed (default: configured)")
+}
+
+// presenter bestimmt die Anzeige-Einheiten: --units, sonst die Config,
+// sonst metric. Ein ungültiger Wert in der Config wird nur gemeldet.
+func (c *CLI) presenter(units string) (application.Presenter, error) {
+	if units != "" {
+		return application.NewPresenter(units)
+	}
+	if cfg, err := c.loadConfig(); err == nil && cfg != nil {
+		presenter, err := application.NewPresenter(cfg.UnitSystem)
+		if err == nil {
+			return presenter, nil
+		}
+		fmt.Fprintf(c.stderr, "warning: ignoring unit system in config: %v\n", err)
+	}
+	return application.NewPresenter(application.UnitSystemMetric)
+}
+
+// prepareDataDir löst das Daten-Verzeichnis auf und bringt es auf den
+// aktuellen Stand, bevor ein Backend geöffnet wird.
+func (c *CLI) prepareDataDir(common *commonFlags) (string, error) {
+	dataDir, err := c.resolveDataDir(common)
+	if err != nil {
+		return "", err
+	}
+
+	// Wie beim Start der Desktop-App: Reste eines Absturzes aufräumen und melden
+	recovery, err := application.RecoverStorage(dataDir)
+	if err != nil {
+		return "", fmt.Errorf("storage recovery failed: %w", err)
+	}
+	for _, f := range recovery.CorruptFiles {
+		fmt.Fprintf(c.stderr, "warning: unreadable data file moved to %s (%s)\n", f.QuarantinedAs, f.Reason)
+	}
+	migration, err := application.MigrateDataDir(dataDir)
+	if err != nil {
+		return "", fmt.Errorf("data migration failed: %w", err)
+	}
+	if migration != nil {
+		fmt.Fprintf(c.stderr, "migrated %d data files from schema %d to %d (backup: %s)\n",
+			migration.Documents, migration.FromVersion, migration.ToVersion, migration.BackupDir)
+	}
+
+	return dataDir, nil
+}
+
+// closeRepositories schließt alle Repositories des Aufrufs.
+func (c *CLI) closeRepositories() {
+	for _, repos := range c.opened {
+		if err := repos.Close(); err != nil {
+			fmt.Fprintf(c.stderr, "warning: failed to close storage: %v\n", err)
+		}
+	}
+	c.opened = nil
+}
+
+// unwrap wandelt ein Result in (Data, error) um.
+//
+// GO-KONZEPT: Generics
+// Die Services liefern Wails-kompatible Results, die CLI arbeitet mit error.
+func unwrap[T any](result application.Result[T]) (T, error) {
+	if !result.Success {
+		return result.Data, errors.New(result.Error)
+	}
+	return result.Data, nil
+}
+
+// joinUsage baut einen Usage-Text für eine Befehlsgruppe.
+func joinUsage(group string, commands ...string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Usage: metric-neo %s <command> [flags]\n\nCommands:\n", group)
+	for _, cmd := range commands {
+		fmt.Fprintf(&b, "  %s\n", cmd)
+	}
+	return b.String()
+}
@@ -0,0 +1,742 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"metric-neo/internal/application"
+	"metric-neo/internal/infrastructure/chrono"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestCLI erstellt eine CLI mit gepufferter Ausgabe und ohne Desktop-Config.
+func newTestCLI() (*CLI, *bytes.Buffer, *bytes.Buffer) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	c := New(stdout, stderr)
+	c.loadConfig = func() (*application.Config, error) { return nil, nil }
+	c.newChrono = func(chrono.Driver) chrono.ChronoService { return chrono.NewMockChrono() }
+	return c, stdout, stderr
+}
+
+// run führt einen Befehl aus und gibt stdout zurück (Test schlägt bei Exit != 0 fehl).
+func run(t *testing.T, args ...string) string {
+	t.Helper()
+	c, stdout, stderr := newTestCLI()
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("%v: exit code %d, stderr: %s", args, code, stderr.String())
+	}
+	return stdout.String()
+}
+
+func TestCLI_InventoryAndSessionWorkflow(t *testing.T) {
+	dir := t.TempDir()
+
+	profileID := strings.TrimSpace(run(t, "inventory", "profile", "add",
+		"--data-dir", dir, "--name", "Steyr", "--barrel-mm", "420", "--trigger-g", "500", "--sight-height-mm", "50"))
+	projectileID := strings.TrimSpace(run(t, "inventory", "projectile", "add",
+		"--data-dir", dir, "--name", "JSB Exact", "--weight-g", "0.547", "--bc", "0.024"))
+
+	sessionID := strings.TrimSpace(run(t, "session", "create",
+		"--data-dir", dir, "--profile", profileID, "--projectile", projectileID, "--temp", "21.5"))
+	if sessionID == "" {
+		t.Fatal("session create did not print an ID")
+	}
+
+	run(t, "session", "record", sessionID, "175.0", "176.0", "--data-dir", dir)
+
+	// --json nach dem Positionsargument muss funktionieren
+	var session application.SessionDTO
+	out := run(t, "session", "show", sessionID, "--data-dir", dir, "--json")
+	if err := json.Unmarshal([]byte(out), &session); err != nil {
+		t.Fatalf("session show --json is not valid JSON: %v\n%s", err, out)
+	}
+	if len(session.Shots) != 2 {
+		t.Errorf("shots = %d, want 2", len(session.Shots))
+	}
+	if session.TemperatureCelsius == nil || *session.TemperatureCelsius != 21.5 {
+		t.Error("temperature not stored")
+	}
+
+	var metas []application.SessionMetaDTO
+	if err := json.Unmarshal([]byte(run(t, "session", "list", "--data-dir", dir, "--json")), &metas); err != nil {
+		t.Fatalf("session list --json: %v", err)
+	}
+	if len(metas) != 1 || metas[0].AvgVelocityMPS != 175.5 {
+		t.Errorf("unexpected session list: %+v", metas)
+	}
+
+	table := run(t, "session", "list", "--data-dir", dir)
+	if !strings.Contains(table, "JSB Exact") || !strings.Contains(table, "175.50") {
+		t.Errorf("table output missing data:\n%s", table)
+	}
+}
+
+func TestCLI_Capture(t *testing.T) {
+	dir := t.TempDir()
+
+	profileID := strings.TrimSpace(run(t, "inventory", "profile", "add",
+		"--data-dir", dir, "--name", "Steyr", "--trigger-g", "500"))
+	projectileID := strings.TrimSpace(run(t, "inventory", "projectile", "add",
+		"--data-dir", dir, "--name", "JSB Exact", "--weight-g", "0.547"))
+
+	// MockChrono liefert einen Messwert pro Sekunde
+	out := run(t, "session", "capture", "--data-dir", dir, "--json",
+		"--profile", profileID, "--projectile", projectileID, "--port", "/dev/mock", "--count", "1")
+
+	// NDJSON: ein Schuss pro Zeile. Ein Schuss, der beim Beenden noch
+	// gelesen wurde, ist gespeichert und erscheint ebenfalls.
+	dec := json.NewDecoder(strings.NewReader(out))
+	shots := 0
+	for dec.More() {
+		var shot application.ShotDTO
+		if err := dec.Decode(&shot); err != nil {
+			t.Fatalf("capture output is not a JSON shot: %v\n%s", err, out)
+		}
+		if shot.VelocityMPS < 170 || shot.VelocityMPS > 180 {
+			t.Errorf("velocity = %.2f, want ~175", shot.VelocityMPS)
+		}
+		shots++
+	}
+	if shots == 0 {
+		t.Fatalf("capture printed no shot:\n%s", out)
+	}
+}
+
+func TestCLI_Errors(t *testing.T) {
+	c, _, stderr := newTestCLI()
+	if code := c.Run([]string{"session", "list"}); code != 1 {
+		t.Errorf("missing data dir: exit code %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "--data-dir") {
+		t.Errorf("error should mention --data-dir, got: %s", stderr.String())
+	}
+
+	c, _, _ = newTestCLI()
+	if code := c.Run([]string{"session", "frobnicate"}); code != 2 {
+		t.Errorf("unknown command: exit code %d, want 2", code)
+	}
+
+	c, _, _ = newTestCLI()
+	if code := c.Run([]string{"session", "show", "--data-dir", t.TempDir(), "does-not-exist"}); code != 1 {
+		t.Errorf("unknown session: exit code %d, want 1", code)
+	}
+}
+
+func TestCLI_SessionListQuery(t *testing.T) {
+	dir := t.TempDir()
+
+	steyr := strings.TrimSpace(run(t, "inventory", "profile", "add", "--data-dir", dir, "--name", "Steyr", "--trigger-g", "500"))
+	walther := strings.TrimSpace(run(t, "inventory", "profile", "add", "--data-dir", dir, "--name", "Walther", "--trigger-g", "500"))
+	projectile := strings.TrimSpace(run(t, "inventory", "projectile", "add", "--data-dir", dir, "--name", "JSB", "--weight-g", "0.547"))
+	for _, profile := range []string{steyr, walther, steyr} {
+		run(t, "session", "create", "--data-dir", dir, "--profile", profile, "--projectile", projectile)
+	}
+
+	var metas []application.SessionMetaDTO
+	json.Unmarshal([]byte(run(t, "session", "list", "--data-dir", dir, "--json", "--profile", steyr)), &metas)
+	if len(metas) != 2 || metas[0].ProfileName != "Steyr" {
+		t.Errorf("--profile: %+v", metas)
+	}
+
+	c, stdout, stderr := newTestCLI()
+	if code := c.Run([]string{"session", "list", "--data-dir", dir, "--limit", "1"}); code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr.String())
+	}
+	if lines := strings.Count(stdout.String(), "\n"); lines != 2 {
+		t.Errorf("--limit 1 printed %d lines:\n%s", lines, stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "showing 1-1 of 3") {
+		t.Errorf("stderr should show the page, got: %s", stderr.String())
+	}
+
+	// Index löschen: reindex baut ihn aus den Dateien neu auf
+	os.Remove(filepath.Join(dir, "sessions", ".index.json"))
+	if out := run(t, "session", "reindex", "--data-dir", dir); strings.TrimSpace(out) != "indexed 3 sessions" {
+		t.Errorf("reindex printed %q", out)
+	}
+}
+
+func TestIsCommand(t *testing.T) {
+	for _, arg := range []string{"session", "inventory", "chrono", "help", "--help"} {
+		if !IsCommand(arg) {
+			t.Errorf("IsCommand(%q) = false", arg)
+		}
+	}
+	if IsCommand("-devtools") {
+		t.Error("unknown flags must start the desktop app")
+	}
+}
+
+func TestCLI_CSVExportImport(t *testing.T) {
+	dir := t.TempDir()
+
+	profileID := strings.TrimSpace(run(t, "inventory", "profile", "add",
+		"--data-dir", dir, "--name", "Steyr", "--trigger-g", "500"))
+	projectileID := strings.TrimSpace(run(t, "inventory", "projectile", "add",
+		"--data-dir", dir, "--name", "JSB Exact", "--weight-g", "0.547"))
+	sessionID := strings.TrimSpace(run(t, "session", "create",
+		"--data-dir", dir, "--profile", profileID, "--projectile", projectileID))
+	run(t, "session", "record", sessionID, "175.0", "176.0", "--data-dir", dir)
+
+	file := filepath.Join(t.TempDir(), "sessions.csv")
+	run(t, "session", "export", "--all", "--data-dir", dir, "--format", "csv", "--decimal", ",", "--out", file)
+
+	// Import in ein neues Datenverzeichnis
+	target := t.TempDir()
+	out := run(t, "session", "import", file, "--data-dir", target)
+	if strings.TrimSpace(out) != sessionID {
+		t.Errorf("import printed %q, want %s", out, sessionID)
+	}
+
+	var session application.SessionDTO
+	if err := json.Unmarshal([]byte(run(t, "session", "show", sessionID, "--data-dir", target, "--json")), &session); err != nil {
+		t.Fatalf("session show --json: %v", err)
+	}
+	if len(session.Shots) != 2 || session.Shots[1].VelocityMPS != 176.0 {
+		t.Errorf("unexpected imported shots: %+v", session.Shots)
+	}
+}
+
+func TestCLI_ChronoDrivers(t *testing.T) {
+	out := run(t, "chrono", "drivers")
+	for _, name := range []string{"lmbr", "generic-fps", "tagged", "stx-etx"} {
+		if !strings.Contains(out, name) {
+			t.Errorf("driver %s missing:\n%s", name, out)
+		}
+	}
+
+	c, _, _ := newTestCLI()
+	code := c.Run([]string{"session", "capture", "--data-dir", t.TempDir(), "--port", "/dev/mock", "--driver", "nope"})
+	if code != 1 {
+		t.Errorf("unknown driver: exit code %d, want 1", code)
+	}
+}
+
+func TestCLI_ChronoReplay(t *testing.T) {
+	log := filepath.Join("..", "infrastructure", "chrono", "testdata", "lmbr.wire.log")
+
+	out := run(t, "chrono", "replay", "--speed", "0", "--json", log)
+	var shots []replayShot
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var shot replayShot
+		if err := json.Unmarshal([]byte(line), &shot); err != nil {
+			t.Fatalf("replay output is not NDJSON: %v\n%s", err, out)
+		}
+		shots = append(shots, shot)
+	}
+	if len(shots) != 4 || shots[0].VelocityMPS < 175.22 || shots[0].VelocityMPS > 175.24 {
+		t.Errorf("replayed shots = %+v, want 4 starting with 175.23", shots)
+	}
+
+	if out := run(t, "chrono", "replay", "--verify", log); out != "" {
+		t.Errorf("verify with recorded driver: unexpected mismatches:\n%s", out)
+	}
+
+	// Ein anderer Parser liest die Rohdaten anders: Exit-Code 1 mit Liste
+	c, stdout, _ := newTestCLI()
+	if code := c.Run([]string{"chrono", "replay", "--verify", "--driver", "generic-fps", log}); code != 1 {
+		t.Errorf("verify mismatches: exit code %d, want 1", code)
+	}
+	if !strings.Contains(stdout.String(), `"175,23"`) {
+		t.Errorf("mismatch table should list the frame:\n%s", stdout.String())
+	}
+}
+
+func TestCLI_ReportsCorruptFilesOnStartup(t *testing.T) {
+	dir := t.TempDir()
+	run(t, "inventory", "profile", "add", "--data-dir", dir, "--name", "Steyr", "--trigger-g", "500")
+
+	// Abgeschnittene Session (Stromausfall vor dem atomaren Schreiben)
+	sessions := filepath.Join(dir, "sessions")
+	os.MkdirAll(sessions, 0755)
+	os.WriteFile(filepath.Join(sessions, "broken.json"), []byte(`{"id": "broken", "shots": [`), 0644)
+
+	c, stdout, stderr := newTestCLI()
+	if code := c.Run([]string{"session", "list", "--data-dir", dir}); code != 0 {
+		t.Fatalf("session list: exit code %d, stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "broken.json.corrupt") {
+		t.Errorf("stderr should report the quarantined file, got: %s", stderr.String())
+	}
+	if strings.Contains(stdout.String(), "broken") {
+		t.Errorf("corrupt session must not be listed:\n%s", stdout.String())
+	}
+}
+
+func TestCLI_RefusesDataFromNewerVersion(t *testing.T) {
+	dir := t.TempDir()
+	run(t, "inventory", "profile", "add", "--data-dir", dir, "--name", "Steyr", "--trigger-g", "500")
+
+	// Eine spätere Version hat das Verzeichnis migriert
+	os.WriteFile(filepath.Join(dir, "manifest.json"), []byte(`{"schema_version": 99}`), 0644)
+
+	c, _, stderr := newTestCLI()
+	if code := c.Run([]string{"inventory", "profile", "list", "--data-dir", dir}); code == 0 {
+		t.Fatal("expected a non-zero exit code for data from a newer version")
+	}
+	if !strings.Contains(stderr.String(), "newer version") {
+		t.Errorf("stderr should explain the refusal, got: %s", stderr.String())
+	}
+}
+
+func TestCLI_StorageConvertAndQuery(t *testing.T) {
+	dir := t.TempDir()
+
+	run(t, "inventory", "projectile", "add", "--data-dir", dir, "--name", "JSB Exact", "--weight-g", "0.547", "--bc", "0.024")
+
+	// Abfragen gehen nur auf SQLite
+	c, _, stderr := newTestCLI()
+	if code := c.Run([]string{"storage", "query", "SELECT 1", "--data-dir", dir}); code != 1 {
+		t.Errorf("query on json backend: exit code %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "storage convert --to sqlite") {
+		t.Errorf("expected a hint to convert, got: %s", stderr.String())
+	}
+
+	out := run(t, "storage", "convert", "--to", "sqlite", "--data-dir", dir)
+	if !strings.Contains(out, "converted json to sqlite: 0 profiles, 1 projectiles") {
+		t.Errorf("unexpected convert output: %s", out)
+	}
+
+	// Normale Befehle arbeiten jetzt auf der Datenbank
+	run(t, "inventory", "projectile", "add", "--data-dir", dir, "--name", "H&N Baracuda", "--weight-g", "0.69", "--bc", "0.031")
+
+	var rows []map[string]any
+	out = run(t, "storage", "query", "SELECT name FROM projectiles ORDER BY name", "--data-dir", dir, "--json")
+	if err := json.Unmarshal([]byte(out), &rows); err != nil {
+		t.Fatalf("storage query --json: %v\n%s", err, out)
+	}
+	if len(rows) != 2 || rows[0]["name"] != "H&N Baracuda" {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+
+	if out := run(t, "storage", "info", "--data-dir", dir); !strings.Contains(out, "sqlite") {
+		t.Errorf("storage info does not show the backend:\n%s", out)
+	}
+}
+
+func TestCLI_ProjectileLots(t *testing.T) {
+	dir := t.TempDir()
+
+	profileID := strings.TrimSpace(run(t, "inventory", "profile", "add",
+		"--data-dir", dir, "--name", "Steyr", "--barrel-mm", "420", "--trigger-g", "500", "--sight-height-mm", "50"))
+	projectileID := strings.TrimSpace(run(t, "inventory", "projectile", "add",
+		"--data-dir", dir, "--name", "JSB Exact", "--weight-g", "0.547"))
+
+	lotID := strings.TrimSpace(run(t, "inventory", "projectile", "lot-add", projectileID,
+		"--data-dir", dir, "--number", "LOT-A", "--purchased", "2024-03-01", "--count", "500"))
+	run(t, "inventory", "projectile", "lot-add", projectileID, "--data-dir", dir, "--number", "LOT-B")
+	run(t, "inventory", "projectile", "lot-update", projectileID, "LOT-A", "--data-dir", dir, "--avg-weight-g", "0.551")
+
+	var lots []application.ProjectileLotDTO
+	if err := json.Unmarshal([]byte(run(t, "inventory", "projectile", "lots", projectileID, "--data-dir", dir, "--json")), &lots); err != nil {
+		t.Fatalf("lots --json: %v", err)
+	}
+	if len(lots) != 2 || lots[0].ID != lotID || lots[0].CountOnHand != 500 || lots[0].AverageWeightGrams == nil {
+		t.Fatalf("lot-update must keep unset fields: %+v", lots)
+	}
+
+	for lot, velocity := range map[string]string{"LOT-A": "175.0", "LOT-B": "171.0"} {
+		sessionID := strings.TrimSpace(run(t, "session", "create", "--data-dir", dir,
+			"--profile", profileID, "--projectile", projectileID, "--lot", lot))
+		run(t, "session", "record", sessionID, velocity, "--data-dir", dir)
+	}
+
+	out := run(t, "session", "lots", projectileID, "--data-dir", dir)
+	if !strings.Contains(out, "LOT-A") || !strings.Contains(out, "175.00") || !strings.Contains(out, "171.00") {
+		t.Errorf("lot comparison incomplete:\n%s", out)
+	}
+
+	// CSV-Export trägt die Charge mit
+	csv := run(t, "session", "export", "--all", "--format", "csv", "--data-dir", dir)
+	if !strings.Contains(csv, "projectile_lot") || !strings.Contains(csv, "LOT-B") {
+		t.Errorf("CSV export without lot:\n%s", csv)
+	}
+}
+
+func TestCLI_SessionTrajectory(t *testing.T) {
+	dir := t.TempDir()
+
+	profileID := strings.TrimSpace(run(t, "inventory", "profile", "add",
+		"--data-dir", dir, "--name", "Steyr", "--barrel-mm", "420", "--trigger-g", "500", "--sight-height-mm", "50"))
+	projectileID := strings.TrimSpace(run(t, "inventory", "projectile", "add",
+		"--data-dir", dir, "--name", "JSB Exact", "--weight-g", "0.547", "--bc", "0.024"))
+	sessionID := strings.TrimSpace(run(t, "session", "create", "--data-dir", dir,
+		"--profile", profileID, "--projectile", projectileID, "--altitude", "500"))
+	run(t, "session", "record", sessionID, "175.0", "--data-dir", dir)
+
+	var trajectory application.TrajectoryDTO
+	out := run(t, "session", "trajectory", sessionID, "--data-dir", dir, "--max", "50", "--step", "5", "--wind", "2", "--json")
+	if err := json.Unmarshal([]byte(out), &trajectory); err != nil {
+		t.Fatalf("trajectory --json: %v", err)
+	}
+	if len(trajectory.Points) != 11 || trajectory.Points[10].WindDriftMM <= 0 {
+		t.Errorf("unexpected trajectory: %+v", trajectory.Points)
+	}
+	if trajectory.AirDensityKgM3 >= 1.225 {
+		t.Errorf("air density at 500 m = %.4f, want below sea level", trajectory.AirDensityKgM3)
+	}
+
+	// Das Modell kommt aus dem Projectile-Snapshot, nicht aus der Anfrage
+	table := run(t, "session", "trajectory", sessionID, "--data-dir", dir)
+	if !strings.Contains(table, "RANGE M") || !strings.Contains(table, "lb/in2 (G1)") {
+		t.Errorf("unexpected table:\n%s", table)
+	}
+}
+
+func TestCLI_ProjectileDragModel(t *testing.T) {
+	dir := t.TempDir()
+
+	curve := filepath.Join(dir, "curve.csv")
+	os.WriteFile(curve, []byte("mach,cd\n# Doppler-Radar\n0.3,0.25\n0.5;0.26\n0.8 0.31\n"), 0644)
+
+	projectileID := strings.TrimSpace(run(t, "inventory", "projectile", "add", "--data-dir", dir,
+		"--name", "Own", "--weight-g", "0.547", "--bc", "1", "--drag", "custom", "--drag-table", curve))
+	show := run(t, "inventory", "projectile", "show", projectileID, "--data-dir", dir)
+	if !strings.Contains(show, "CUSTOM") || !strings.Contains(show, "0.2600") {
+		t.Errorf("unexpected show:\n%s", show)
+	}
+
+	// Wechsel auf GA verwirft die eigene Kurve; 0.5 ist kein plausibler GA-BC
+	c, _, _ := newTestCLI()
+	if code := c.Run([]string{"inventory", "projectile", "set-drag", projectileID, "--data-dir", dir, "--drag", "GA", "--bc", "0.5"}); code != 1 {
+		t.Errorf("set-drag with GA BC 0.5: exit code %d, want 1", code)
+	}
+	out := run(t, "inventory", "projectile", "set-drag", projectileID, "--data-dir", dir, "--drag", "GA", "--bc", "16.9", "--bc-unit", "kg/m2")
+	if !strings.Contains(out, "BC 16.9000 kg/m2 (GA)") {
+		t.Errorf("unexpected set-drag output: %s", out)
+	}
+
+	var p application.ProjectileDTO
+	json.Unmarshal([]byte(run(t, "inventory", "projectile", "show", projectileID, "--data-dir", dir, "--json")), &p)
+	if p.DragModel != "GA" || len(p.DragTable) != 0 || p.Name != "Own" {
+		t.Errorf("projectile after set-drag = %+v", p)
+	}
+}
+
+func TestCLI_SessionEstimateBC(t *testing.T) {
+	dir := t.TempDir()
+
+	profileID := strings.TrimSpace(run(t, "inventory", "profile", "add",
+		"--data-dir", dir, "--name", "Steyr", "--barrel-mm", "420", "--trigger-g", "500"))
+	projectileID := strings.TrimSpace(run(t, "inventory", "projectile", "add",
+		"--data-dir", dir, "--name", "JSB Exact", "--weight-g", "0.547", "--bc", "0.030", "--drag", "GA"))
+	nearID := strings.TrimSpace(run(t, "session", "create", "--data-dir", dir, "--profile", profileID, "--projectile", projectileID))
+	farID := strings.TrimSpace(run(t, "session", "create", "--data-dir", dir, "--profile", profileID, "--projectile", projectileID))
+	run(t, "session", "record", nearID, "280.0", "281.0", "279.0", "--data-dir", dir)
+	run(t, "session", "record", farID, "262.0", "263.5", "261.0", "--data-dir", dir)
+
+	out := run(t, "session", "downrange", farID, nearID, "--distance", "20", "--data-dir", dir)
+	if !strings.Contains(out, "20.0 m behind session "+nearID) {
+		t.Errorf("unexpected downrange output: %s", out)
+	}
+
+	var estimate application.BCEstimateDTO
+	out = run(t, "session", "estimate-bc", farID, "--paired", "--json", "--data-dir", dir)
+	if err := json.Unmarshal([]byte(out), &estimate); err != nil {
+		t.Fatalf("estimate-bc --json: %v", err)
+	}
+	if estimate.DragModel != "GA" || estimate.PairCount != 3 || estimate.BC <= 0 || estimate.BCLower == nil {
+		t.Errorf("unexpected estimate: %+v", estimate)
+	}
+
+	out = run(t, "session", "estimate-bc", farID, "--apply", "--data-dir", dir)
+	if !strings.Contains(out, "95% interval") || !strings.Contains(out, "BC of JSB Exact updated") {
+		t.Errorf("unexpected estimate-bc output:\n%s", out)
+	}
+	var p application.ProjectileDTO
+	json.Unmarshal([]byte(run(t, "inventory", "projectile", "show", projectileID, "--data-dir", dir, "--json")), &p)
+	if p.BC == 0.030 {
+		t.Error("estimate-bc --apply did not update the BC")
+	}
+}
+
+func TestCLI_SessionEnergyLimit(t *testing.T) {
+	dir := t.TempDir()
+
+	profileID := strings.TrimSpace(run(t, "inventory", "profile", "add",
+		"--data-dir", dir, "--name", "Steyr", "--category", "air_rifle", "--barrel-mm", "420", "--trigger-g", "500"))
+	projectileID := strings.TrimSpace(run(t, "inventory", "projectile", "add",
+		"--data-dir", dir, "--name", "JSB Exact", "--weight-g", "0.547", "--bc", "0.024"))
+	sessionID := strings.TrimSpace(run(t, "session", "create", "--data-dir", dir, "--profile", profileID, "--projectile", projectileID))
+	// 0.547 g: 170 m/s = 7.90 J
+	run(t, "session", "record", sessionID, "150.0", "151.0", "170.0", "--data-dir", dir)
+
+	// Ohne Konfiguration keine Prüfung
+	var stats application.StatisticsDTO
+	json.Unmarshal([]byte(run(t, "session", "stats", sessionID, "--json", "--data-dir", dir)), &stats)
+	if stats.EnergyCheck != nil {
+		t.Errorf("no limits configured, got %+v", stats.EnergyCheck)
+	}
+
+	out := run(t, "session", "stats", sessionID, "--jurisdiction", "DE", "--data-dir", dir)
+	if !strings.Contains(out, "7.50 J DE F-Zeichen: exceeded (1 over") {
+		t.Errorf("unexpected energy limit line:\n%s", out)
+	}
+
+	out = run(t, "session", "export", sessionID, "--format", "csv", "--jurisdiction", "DE", "--data-dir", dir)
+	if !strings.Contains(out, ",7.50,exceeded,exceeded") {
+		t.Errorf("csv export without energy verdict:\n%s", out)
+	}
+
+	c, _, stderr := newTestCLI()
+	if code := c.Run([]string{"session", "stats", sessionID, "--jurisdiction", "XX", "--data-dir", dir}); code == 0 {
+		t.Error("unknown jurisdiction should fail")
+	} else if !strings.Contains(stderr.String(), "unknown jurisdiction") {
+		t.Errorf("unexpected error: %s", stderr.String())
+	}
+}
+
+func TestCLI_SessionUnits(t *testing.T) {
+	dir := t.TempDir()
+
+	profileID := strings.TrimSpace(run(t, "inventory", "profile", "add",
+		"--data-dir", dir, "--name", "Steyr", "--barrel-mm", "420", "--trigger-g", "500"))
+	projectileID := strings.TrimSpace(run(t, "inventory", "projectile", "add",
+		"--data-dir", dir, "--name", "JSB Exact", "--weight-g", "0.547", "--caliber-mm", "4.5"))
+	sessionID := strings.TrimSpace(run(t, "session", "create", "--data-dir", dir, "--profile", profileID, "--projectile", projectileID))
+	// 0.547 g: 170 m/s = 7.90 J, auf 0.159 cm² = 49.70 J/cm²
+	run(t, "session", "record", sessionID, "170.0", "--data-dir", dir)
+
+	out := run(t, "session", "show", sessionID, "--data-dir", dir)
+	for _, want := range []string{"JSB Exact (0.547 g, 4.50 mm)", "J/CM²", "49.70", "Average energy density J/cm²:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("metric output missing %q:\n%s", want, out)
+		}
+	}
+
+	out = run(t, "session", "show", sessionID, "--units", "imperial", "--data-dir", dir)
+	for _, want := range []string{"JSB Exact (8.44 gr, 0.177 in)", "FPS", "557.74", "5.83", "236.49", "Average fps:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("imperial output missing %q:\n%s", want, out)
+		}
+	}
+
+	// mixed: fps und gr, aber J und mm
+	out = run(t, "session", "stats", sessionID, "--units", "mixed", "--data-dir", dir)
+	for _, want := range []string{"Average fps:", "Average energy J:", "7.90"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("mixed output missing %q:\n%s", want, out)
+		}
+	}
+
+	c, _, stderr := newTestCLI()
+	if code := c.Run([]string{"session", "stats", sessionID, "--units", "nautical", "--data-dir", dir}); code == 0 {
+		t.Error("unknown unit system should fail")
+	} else if !strings.Contains(stderr.String(), "nautical") {
+		t.Errorf("unexpected error: %s", stderr.String())
+	}
+}
+
+func TestCLI_AnalyticsTrend(t *testing.T) {
+	dir := t.TempDir()
+
+	profileID := strings.TrimSpace(run(t, "inventory", "profile", "add",
+		"--data-dir", dir, "--name", "Steyr", "--barrel-mm", "420", "--trigger-g", "500"))
+	projectileID := strings.TrimSpace(run(t, "inventory", "projectile", "add",
+		"--data-dir", dir, "--name", "JSB Exact", "--weight-g", "0.547"))
+	var last string
+	for _, shots := range [][]string{
+		{"279", "280", "281"}, {"279.5", "280.5", "281.5"}, {"278.5", "279.5", "280.5"}, // Baseline
+		{"276", "277", "278"},
+	} {
+		last = strings.TrimSpace(run(t, "session", "create", "--data-dir", dir, "--profile", profileID, "--projectile", projectileID))
+		run(t, append([]string{"session", "record", last, "--data-dir", dir}, shots...)...)
+	}
+
+	out := run(t, "analytics", "trend", profileID, "--data-dir", dir)
+	for _, want := range []string{"AVG M/S", "* baseline session", "Status:", "drop", "280.00 m/s (SD 1.00", "Drop detected:  " + last} {
+		if !strings.Contains(out, want) {
+			t.Errorf("trend output missing %q:\n%s", want, out)
+		}
+	}
+
+	out = run(t, "analytics", "trend", profileID, "--baseline", "5", "--data-dir", dir)
+	if !strings.Contains(out, "insufficient") {
+		t.Errorf("5 baseline sessions of 4 should be insufficient:\n%s", out)
+	}
+}
+
+func TestCLI_AnalyticsSPC(t *testing.T) {
+	dir := t.TempDir()
+
+	profileID := strings.TrimSpace(run(t, "inventory", "profile", "add",
+		"--data-dir", dir, "--name", "Steyr", "--barrel-mm", "420", "--trigger-g", "500"))
+	projectileID := strings.TrimSpace(run(t, "inventory", "projectile", "add",
+		"--data-dir", dir, "--name", "JSB Exact", "--weight-g", "0.547"))
+	var last string
+	for _, shots := range [][]string{
+		{"279", "281", "280", "279.5", "280.5"}, {"280.5", "279.5", "280", "281", "279"}, // Baseline
+		{"283", "283.5", "282.5", "283", "283"},
+	} {
+		last = strings.TrimSpace(run(t, "session", "create", "--data-dir", dir, "--profile", profileID, "--projectile", projectileID))
+		run(t, append([]string{"session", "record", last, "--data-dir", dir}, shots...)...)
+	}
+
+	out := run(t, "analytics", "spc", profileID, "--baseline", "2", "--data-dir", dir)
+	for _, want := range []string{"In control:  no", "X-bar  278.85   280.00  281.15", "X-bar  " + last, "#1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("spc output missing %q:\n%s", want, out)
+		}
+	}
+
+	c, _, stderr := newTestCLI()
+	if code := c.Run([]string{"analytics", "spc", profileID, "--subgroup", "12", "--data-dir", dir}); code == 0 {
+		t.Error("subgroup size 12 should fail")
+	} else if !strings.Contains(stderr.String(), "12") {
+		t.Errorf("unexpected error: %s", stderr.String())
+	}
+}
+
+func TestCLI_SessionOutliers(t *testing.T) {
+	dir := t.TempDir()
+
+	profileID := strings.TrimSpace(run(t, "inventory", "profile", "add",
+		"--data-dir", dir, "--name", "Steyr", "--barrel-mm", "420", "--trigger-g", "500"))
+	projectileID := strings.TrimSpace(run(t, "inventory", "projectile", "add",
+		"--data-dir", dir, "--name", "JSB Exact", "--weight-g", "0.547", "--bc", "0.024"))
+	sessionID := strings.TrimSpace(run(t, "session", "create", "--data-dir", dir, "--profile", profileID, "--projectile", projectileID))
+	run(t, "session", "record", sessionID, "175.0", "175.4", "174.8", "175.2", "168.0", "174.9", "175.1", "--data-dir", dir)
+
+	out := run(t, "session", "stats", sessionID, "--data-dir", dir)
+	for _, want := range []string{"Median m/s:", "175.00", "P10 ", "Mean 95% CI m/s:", "#5 168.00 m/s (grubbs, mad)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("stats output missing %q:\n%s", want, out)
+		}
+	}
+
+	out = run(t, "session", "invalidate", sessionID, "5", "--data-dir", dir)
+	if !strings.Contains(out, "6 of 7 shot(s) valid") {
+		t.Errorf("unexpected invalidate output: %s", out)
+	}
+	var stats application.StatisticsDTO
+	json.Unmarshal([]byte(run(t, "session", "stats", sessionID, "--json", "--data-dir", dir)), &stats)
+	if stats.ValidShotCount != 6 || len(stats.OutlierSuggestions) != 0 {
+		t.Errorf("after invalidate: %d valid, suggestions %+v", stats.ValidShotCount, stats.OutlierSuggestions)
+	}
+}
+
+func TestCLI_SessionCompare(t *testing.T) {
+	dir := t.TempDir()
+
+	profileID := strings.TrimSpace(run(t, "inventory", "profile", "add",
+		"--data-dir", dir, "--name", "Steyr", "--barrel-mm", "420", "--trigger-g", "500"))
+	projectileID := strings.TrimSpace(run(t, "inventory", "projectile", "add",
+		"--data-dir", dir, "--name", "JSB Exact", "--weight-g", "0.547"))
+	var ids []string
+	for _, shots := range [][]string{{"100", "101", "102"}, {"104", "105", "106"}} {
+		id := strings.TrimSpace(run(t, "session", "create", "--data-dir", dir, "--profile", profileID, "--projectile", projectileID))
+		run(t, append([]string{"session", "record", id, "--data-dir", dir}, shots...)...)
+		ids = append(ids, id)
+	}
+
+	out := run(t, "session", "compare", ids[0], ids[1], "--data-dir", dir)
+	for _, want := range []string{ids[0] + " *", "4.00", "1.73 - 6.27", "0.008 !", "3.20", "large", "small_sample"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("compare output missing %q:\n%s", want, out)
+		}
+	}
+
+	c, _, stderr := newTestCLI()
+	if code := c.Run([]string{"session", "compare", ids[0], "--data-dir", dir}); code == 0 {
+		t.Error("a single session should fail")
+	} else if !strings.Contains(stderr.String(), "missing argument") {
+		t.Errorf("unexpected error: %s", stderr.String())
+	}
+}
+
+func TestCLI_SessionStringsAndFill(t *testing.T) {
+	dir := t.TempDir()
+
+	profileID := strings.TrimSpace(run(t, "inventory", "profile", "add",
+		"--data-dir", dir, "--name", "Steyr", "--barrel-mm", "420", "--trigger-g", "500"))
+	projectileID := strings.TrimSpace(run(t, "inventory", "projectile", "add",
+		"--data-dir", dir, "--name", "JSB Exact", "--weight-g", "0.547"))
+	id := strings.TrimSpace(run(t, "session", "create", "--data-dir", dir, "--profile", profileID, "--projectile", projectileID))
+	run(t, "session", "record", id, "--data-dir", dir, "270", "280", "281")
+
+	if out := run(t, "session", "string", id, "Fill", "2", "--data-dir", dir); !strings.Contains(out, "string #2 Fill 2 starts with shot #4") {
+		t.Errorf("unexpected string output: %s", out)
+	}
+	run(t, "session", "record", id, "--data-dir", dir, "272", "281", "280")
+	run(t, "session", "rename-string", id, "1", "Fill 1", "--data-dir", dir)
+
+	out := run(t, "session", "stats", id, "--data-dir", dir)
+	for _, want := range []string{"#1 Fill 1", "#2 Fill 2", "277.67"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("stats output missing %q:\n%s", want, out)
+		}
+	}
+
+	out = run(t, "session", "fill", id, "--data-dir", dir)
+	for _, want := range []string{"271.00", "280.50", "sweet spot: shots 2-3 (2 per fill"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("fill output missing %q:\n%s", want, out)
+		}
+	}
+
+	c, _, stderr := newTestCLI()
+	if code := c.Run([]string{"session", "string", id, "--data-dir", dir}); code != 0 {
+		t.Fatalf("second string failed: %s", stderr.String())
+	}
+	if code := c.Run([]string{"session", "string", id, "--data-dir", dir}); code == 0 {
+		t.Error("a string without shots in the current one should fail")
+	}
+}
+
+func TestCLI_FillPressure(t *testing.T) {
+	dir := t.TempDir()
+
+	profileID := strings.TrimSpace(run(t, "inventory", "profile", "add", "--data-dir", dir, "--name", "FX Impact",
+		"--barrel-mm", "600", "--trigger-g", "300", "--power-plant", "pcp", "--regulator-bar", "100"))
+	if out := run(t, "inventory", "profile", "show", profileID, "--data-dir", dir); !strings.Contains(out, "pcp") {
+		t.Errorf("profile show missing power plant:\n%s", out)
+	}
+	projectileID := strings.TrimSpace(run(t, "inventory", "projectile", "add",
+		"--data-dir", dir, "--name", "JSB Exact", "--weight-g", "0.547"))
+	id := strings.TrimSpace(run(t, "session", "create", "--data-dir", dir, "--profile", profileID, "--projectile", projectileID))
+
+	// Zwei Füllungen von 200 auf 170 bar: 5 bar pro Schuss
+	for _, fill := range []struct {
+		number     string
+		velocities []string
+	}{
+		{"1", []string{"270", "278", "280", "281", "280", "272"}},
+		{"2", []string{"272", "279", "281", "280", "281", "270"}},
+	} {
+		run(t, "session", "string", id, "Fill", fill.number, "--fill-bar", "200", "--data-dir", dir)
+		run(t, append([]string{"session", "record", id, "--data-dir", dir}, fill.velocities...)...)
+		out := run(t, "session", "set-fill", id, fill.number, "--end-bar", "170", "--data-dir", dir)
+		if !strings.Contains(out, "5.00 bar per shot") {
+			t.Errorf("unexpected set-fill output: %s", out)
+		}
+	}
+
+	out := run(t, "session", "fill", id, "--data-dir", dir)
+	for _, want := range []string{"sweet spot: shots 2-5", "fill to 195 bar, refill at 175 bar (2 strings)", "200.00"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("fill output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Warnings") {
+		t.Errorf("unexpected warnings:\n%s", out)
+	}
+
+	out = run(t, "analytics", "fill", profileID, "--units", "imperial", "--data-dir", dir)
+	for _, want := range []string{"1 sessions, 2 strings", "fill to 2828 psi"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("analytics fill output missing %q:\n%s", want, out)
+		}
+	}
+
+	c, _, _ := newTestCLI()
+	if code := c.Run([]string{"session", "set-fill", id, "1", "--data-dir", dir}); code == 0 {
+		t.Error("set-fill without pressures should fail")
+	}
+	if code := c.Run([]string{"session", "set-fill", id, "1", "--start-bar", "150", "--end-bar", "170", "--data-dir", dir}); code == 0 {
+		t.Error("an end pressure above the start pressure should fail")
+	}
+}
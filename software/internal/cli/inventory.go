@@ -0,0 +1,564 @@
+package cli
+
+import (
+	"fmt"
+	"metric-neo/internal/application"
+)
+
+const inventoryUsage = `Usage: metric-neo inventory <profile|projectile|sight> <command> [flags]
+
+Commands:
+  list            List all entries
+  show <id>       Show one entry
+  add             Create an entry (see --help of the command)
+  delete <id>     Delete an entry
+
+Profile power plant (air guns):
+  set-power-plant <profile-id> [<type>] Set spring, co2 or pcp (--regulator-bar; no type = unknown)
+
+Projectile drag model:
+  set-drag <projectile-id>              Change BC and drag model (--bc, --drag, --bc-unit, --drag-table)
+  set-caliber <projectile-id> [<mm>]    Set the caliber for the energy density (no value = unknown)
+
+Projectile lots:
+  lots <projectile-id>                  List the lots of a projectile
+  lot-add <projectile-id>               Add a lot (--number, --purchased, --avg-weight-g, --count)
+  lot-update <projectile-id> <lot>      Change a lot (only the given flags)
+  lot-delete <projectile-id> <lot>      Delete a lot (sessions keep their snapshot)
+`
+
+func (c *CLI) runInventory(args []string) error {
+	if len(args) < 2 {
+		return c.unknownSubcommand("inventory", nil, inventoryUsage)
+	}
+
+	switch args[0] {
+	case "profile", "profiles":
+		return c.runProfile(args[1:])
+	case "projectile", "projectiles":
+		return c.runProjectile(args[1:])
+	case "sight", "sights":
+		return c.runSight(args[1:])
+	default:
+		return c.unknownSubcommand("inventory", args, inventoryUsage)
+	}
+}
+
+// ==================== PROFILE ====================
+
+func (c *CLI) runProfile(args []string) error {
+	switch args[0] {
+	case "list", "ls":
+		return c.profileList(args[1:])
+	case "show":
+		return c.profileShow(args[1:])
+	case "add":
+		return c.profileAdd(args[1:])
+	case "delete", "rm":
+		return c.profileDelete(args[1:])
+	case "set-power-plant":
+		return c.profileSetPowerPlant(args[1:])
+	default:
+		return c.unknownSubcommand("inventory profile", args, inventoryUsage)
+	}
+}
+
+func (c *CLI) profileList(args []string) error {
+	fs, common := c.newFlagSet("inventory profile list")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	profiles, err := unwrap(svc.profiles.ListProfiles())
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, profiles)
+	}
+
+	t := newTable(c.stdout, "ID", "NAME", "CATEGORY", "BARREL MM", "SIGHT HEIGHT MM", "OPTIC")
+	for _, p := range profiles {
+		optic := ""
+		if p.Optic != nil {
+			optic = p.Optic.ModelName
+		}
+		t.row(p.ID, p.Name, p.Category, p.BarrelLengthMM, p.SightHeightMM, optic)
+	}
+	return t.flush()
+}
+
+func (c *CLI) profileShow(args []string) error {
+	fs, common := c.newFlagSet("inventory profile show")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<profile-id>"); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	p, err := unwrap(svc.profiles.LoadProfile(rest[0]))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, p)
+	}
+
+	optic := ""
+	if p.Optic != nil {
+		optic = fmt.Sprintf("%s (%s)", p.Optic.ModelName, p.Optic.Type)
+	}
+	return printFields(c.stdout,
+		"ID", p.ID,
+		"Name", p.Name,
+		"Category", p.Category,
+		"Barrel length mm", p.BarrelLengthMM,
+		"Trigger weight g", p.TriggerWeightG,
+		"Sight height mm", p.SightHeightMM,
+		"Twist rate mm", p.TwistRateMM,
+		"Power plant", p.PowerPlant,
+		"Regulator bar", p.RegulatorSetpointBar,
+		"Optic", optic,
+		"Total weight g", p.TotalWeightG,
+	)
+}
+
+func (c *CLI) profileAdd(args []string) error {
+	fs, common := c.newFlagSet("inventory profile add")
+	name := fs.String("name", "", "profile name (required)")
+	category := fs.String("category", "air_rifle", "air_rifle, air_pistol, bow or firearm")
+	barrelMM := fs.Float64("barrel-mm", 0, "barrel length in mm")
+	triggerG := fs.Float64("trigger-g", 0, "trigger weight in g (required)")
+	sightHeightMM := fs.Float64("sight-height-mm", 0, "sight height in mm")
+	powerPlant := fs.String("power-plant", "", "spring, co2 or pcp (air guns only)")
+	var regulator optionalFloat
+	fs.Var(&regulator, "regulator-bar", "regulator setpoint in bar of a regulated pcp")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	p, err := unwrap(svc.profiles.CreateProfile(*name, *category, *barrelMM, *triggerG, *sightHeightMM))
+	if err != nil {
+		return err
+	}
+	if *powerPlant != "" || regulator.set {
+		id := p.ID
+		if p, err = unwrap(svc.profiles.SetPowerPlant(id, *powerPlant, regulator.ptr())); err != nil {
+			return fmt.Errorf("profile %s created without power plant: %w", id, err)
+		}
+	}
+
+	if common.json {
+		return printJSON(c.stdout, p)
+	}
+	fmt.Fprintln(c.stdout, p.ID)
+	return nil
+}
+
+func (c *CLI) profileSetPowerPlant(args []string) error {
+	fs, common := c.newFlagSet("inventory profile set-power-plant")
+	var regulator optionalFloat
+	fs.Var(&regulator, "regulator-bar", "regulator setpoint in bar (pcp only; default: unregulated)")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<profile-id> [spring|co2|pcp]"); err != nil {
+		return err
+	}
+
+	powerPlant := ""
+	if len(rest) > 1 {
+		powerPlant = rest[1]
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	p, err := unwrap(svc.profiles.SetPowerPlant(rest[0], powerPlant, regulator.ptr()))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, p)
+	}
+	fmt.Fprintln(c.stdout, p.ID)
+	return nil
+}
+
+func (c *CLI) profileDelete(args []string) error {
+	fs, common := c.newFlagSet("inventory profile delete")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<profile-id>"); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	if _, err := unwrap(svc.profiles.DeleteProfile(rest[0])); err != nil {
+		return err
+	}
+	if !common.json {
+		fmt.Fprintf(c.stdout, "deleted profile %s\n", rest[0])
+	}
+	return nil
+}
+
+// ==================== PROJECTILE ====================
+
+func (c *CLI) runProjectile(args []string) error {
+	switch args[0] {
+	case "list", "ls":
+		return c.projectileList(args[1:])
+	case "show":
+		return c.projectileShow(args[1:])
+	case "add":
+		return c.projectileAdd(args[1:])
+	case "delete", "rm":
+		return c.projectileDelete(args[1:])
+	case "set-drag":
+		return c.projectileSetDrag(args[1:])
+	case "set-caliber":
+		return c.projectileSetCaliber(args[1:])
+	case "lots":
+		return c.projectileLots(args[1:])
+	case "lot-add":
+		return c.projectileLotAdd(args[1:])
+	case "lot-update":
+		return c.projectileLotUpdate(args[1:])
+	case "lot-delete", "lot-rm":
+		return c.projectileLotDelete(args[1:])
+	default:
+		return c.unknownSubcommand("inventory projectile", args, inventoryUsage)
+	}
+}
+
+func (c *CLI) projectileList(args []string) error {
+	fs, common := c.newFlagSet("inventory projectile list")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	projectiles, err := unwrap(svc.projectiles.ListProjectiles())
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, projectiles)
+	}
+
+	t := newTable(c.stdout, "ID", "NAME", "WEIGHT G", "BC", "DRAG")
+	for _, p := range projectiles {
+		t.row(p.ID, p.Name, fmt.Sprintf("%.3f", p.WeightGrams), fmt.Sprintf("%.3f", p.BC), p.DragModel)
+	}
+	return t.flush()
+}
+
+func (c *CLI) projectileShow(args []string) error {
+	fs, common := c.newFlagSet("inventory projectile show")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<projectile-id>"); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	p, err := unwrap(svc.projectiles.LoadProjectile(rest[0]))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, p)
+	}
+	if err := printFields(c.stdout,
+		"ID", p.ID,
+		"Name", p.Name,
+		"Weight g", fmt.Sprintf("%.3f", p.WeightGrams),
+		"BC", fmt.Sprintf("%.4f %s", p.BC, p.BCUnit),
+		"Drag model", p.DragModel,
+		"Caliber mm", p.CaliberMM,
+	); err != nil {
+		return err
+	}
+	if len(p.DragTable) > 0 {
+		fmt.Fprintln(c.stdout)
+		t := newTable(c.stdout, "MACH", "CD")
+		for _, point := range p.DragTable {
+			t.row(fmt.Sprintf("%.3f", point.Mach), fmt.Sprintf("%.4f", point.Cd))
+		}
+		if err := t.flush(); err != nil {
+			return err
+		}
+	}
+	if len(p.Lots) == 0 {
+		return nil
+	}
+	fmt.Fprintln(c.stdout)
+	return c.printLots(p.Lots)
+}
+
+func (c *CLI) projectileAdd(args []string) error {
+	fs, common := c.newFlagSet("inventory projectile add")
+	name := fs.String("name", "", "projectile name (required)")
+	weightG := fs.Float64("weight-g", 0, "weight in g (required)")
+	var caliber optionalFloat
+	fs.Var(&caliber, "caliber-mm", "caliber in mm, for the energy density (optional)")
+	flags := addDragFlags(fs)
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	var drag application.DragDTO
+	if err := flags.apply(fs, &drag, true); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	p, err := unwrap(svc.projectiles.CreateProjectileWithDrag(*name, *weightG, drag))
+	if err != nil {
+		return err
+	}
+	if caliber.set {
+		if p, err = unwrap(svc.projectiles.SetCaliber(p.ID, caliber.ptr())); err != nil {
+			return err
+		}
+	}
+
+	if common.json {
+		return printJSON(c.stdout, p)
+	}
+	fmt.Fprintln(c.stdout, p.ID)
+	return nil
+}
+
+// projectileSetCaliber setzt oder löscht das Kaliber eines Projectiles.
+func (c *CLI) projectileSetCaliber(args []string) error {
+	fs, common := c.newFlagSet("inventory projectile set-caliber")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<projectile-id> [<caliber-mm>]"); err != nil {
+		return err
+	}
+
+	var caliber optionalFloat
+	if len(rest) > 1 {
+		if err := caliber.Set(rest[1]); err != nil {
+			return fmt.Errorf("invalid caliber %q: %w", rest[1], err)
+		}
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	p, err := unwrap(svc.projectiles.SetCaliber(rest[0], caliber.ptr()))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, p)
+	}
+	fmt.Fprintln(c.stdout, p.ID)
+	return nil
+}
+
+func (c *CLI) projectileDelete(args []string) error {
+	fs, common := c.newFlagSet("inventory projectile delete")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<projectile-id>"); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	if _, err := unwrap(svc.projectiles.DeleteProjectile(rest[0])); err != nil {
+		return err
+	}
+	if !common.json {
+		fmt.Fprintf(c.stdout, "deleted projectile %s\n", rest[0])
+	}
+	return nil
+}
+
+// ==================== SIGHT ====================
+
+func (c *CLI) runSight(args []string) error {
+	switch args[0] {
+	case "list", "ls":
+		return c.sightList(args[1:])
+	case "show":
+		return c.sightShow(args[1:])
+	case "add":
+		return c.sightAdd(args[1:])
+	case "delete", "rm":
+		return c.sightDelete(args[1:])
+	default:
+		return c.unknownSubcommand("inventory sight", args, inventoryUsage)
+	}
+}
+
+func (c *CLI) sightList(args []string) error {
+	fs, common := c.newFlagSet("inventory sight list")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	sights, err := unwrap(svc.sights.ListSights())
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, sights)
+	}
+
+	t := newTable(c.stdout, "ID", "MODEL", "TYPE", "WEIGHT G", "MAGNIFICATION")
+	for _, s := range sights {
+		t.row(s.ID, s.ModelName, s.Type, s.WeightG, fmt.Sprintf("%.1f-%.1fx", s.MinMagnification, s.MaxMagnification))
+	}
+	return t.flush()
+}
+
+func (c *CLI) sightShow(args []string) error {
+	fs, common := c.newFlagSet("inventory sight show")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<sight-id>"); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	s, err := unwrap(svc.sights.LoadSight(rest[0]))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, s)
+	}
+	return printFields(c.stdout,
+		"ID", s.ID,
+		"Model", s.ModelName,
+		"Type", s.Type,
+		"Weight g", s.WeightG,
+		"Magnification", fmt.Sprintf("%.1f-%.1fx", s.MinMagnification, s.MaxMagnification),
+	)
+}
+
+func (c *CLI) sightAdd(args []string) error {
+	fs, common := c.newFlagSet("inventory sight add")
+	typeName := fs.String("type", "scope", "scope, red_dot, diopter or open_sights")
+	model := fs.String("model", "", "model name (required)")
+	weightG := fs.Float64("weight-g", 0, "weight in g (required)")
+	minMag := fs.Float64("min-mag", 1, "minimum magnification")
+	maxMag := fs.Float64("max-mag", 1, "maximum magnification")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	s, err := unwrap(svc.sights.CreateSight(*typeName, *model, *weightG, *minMag, *maxMag))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, s)
+	}
+	fmt.Fprintln(c.stdout, s.ID)
+	return nil
+}
+
+func (c *CLI) sightDelete(args []string) error {
+	fs, common := c.newFlagSet("inventory sight delete")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<sight-id>"); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	if _, err := unwrap(svc.sights.DeleteSight(rest[0])); err != nil {
+		return err
+	}
+	if !common.json {
+		fmt.Fprintf(c.stdout, "deleted sight %s\n", rest[0])
+	}
+	return nil
+}
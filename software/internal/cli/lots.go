@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"metric-neo/internal/application"
+)
+
+// ==================== PROJECTILE LOTS ====================
+
+func (c *CLI) projectileLots(args []string) error {
+	fs, common := c.newFlagSet("inventory projectile lots")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<projectile-id>"); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	p, err := unwrap(svc.projectiles.LoadProjectile(rest[0]))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, p.Lots)
+	}
+	return c.printLots(p.Lots)
+}
+
+func (c *CLI) printLots(lots []application.ProjectileLotDTO) error {
+	t := newTable(c.stdout, "LOT ID", "NUMBER", "PURCHASED", "AVG WEIGHT G", "ON HAND")
+	for _, lot := range lots {
+		var weight any
+		if lot.AverageWeightGrams != nil {
+			weight = fmt.Sprintf("%.3f", *lot.AverageWeightGrams)
+		}
+		t.row(lot.ID, lot.Number, lot.PurchaseDate, weight, lot.CountOnHand)
+	}
+	return t.flush()
+}
+
+// lotFlags sind die Felder einer Charge als Flags (für lot-add und lot-update).
+type lotFlags struct {
+	number      *string
+	purchased   *string
+	avgWeightG  *float64
+	countOnHand *int
+}
+
+func addLotFlags(fs *flag.FlagSet) *lotFlags {
+	return &lotFlags{
+		number:      fs.String("number", "", "lot number as printed on the tin (required for lot-add)"),
+		purchased:   fs.String("purchased", "", "purchase date (YYYY-MM-DD)"),
+		avgWeightG:  fs.Float64("avg-weight-g", 0, "measured average weight in g (0 = not measured)"),
+		countOnHand: fs.Int("count", 0, "count on hand"),
+	}
+}
+
+// apply überträgt die gesetzten Flags auf lot. all=true übernimmt alle Flags (lot-add).
+func (f *lotFlags) apply(fs *flag.FlagSet, lot *application.ProjectileLotDTO, all bool) {
+	set := map[string]bool{}
+	fs.Visit(func(fl *flag.Flag) { set[fl.Name] = true })
+
+	if all || set["number"] {
+		lot.Number = *f.number
+	}
+	if all || set["purchased"] {
+		lot.PurchaseDate = *f.purchased
+	}
+	if all || set["avg-weight-g"] {
+		lot.AverageWeightGrams = nil
+		if *f.avgWeightG != 0 {
+			weight := *f.avgWeightG
+			lot.AverageWeightGrams = &weight
+		}
+	}
+	if all || set["count"] {
+		lot.CountOnHand = *f.countOnHand
+	}
+}
+
+func (c *CLI) projectileLotAdd(args []string) error {
+	fs, common := c.newFlagSet("inventory projectile lot-add")
+	flags := addLotFlags(fs)
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<projectile-id>"); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	var lot application.ProjectileLotDTO
+	flags.apply(fs, &lot, true)
+	p, err := unwrap(svc.projectiles.AddLot(rest[0], lot))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, p)
+	}
+	// Die neue Charge ist die letzte in der Liste
+	fmt.Fprintln(c.stdout, p.Lots[len(p.Lots)-1].ID)
+	return nil
+}
+
+func (c *CLI) projectileLotUpdate(args []string) error {
+	fs, common := c.newFlagSet("inventory projectile lot-update")
+	flags := addLotFlags(fs)
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 2, "<projectile-id> <lot-id|number>"); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	lot, err := c.findLot(svc, rest[0], rest[1])
+	if err != nil {
+		return err
+	}
+	flags.apply(fs, &lot, false)
+	p, err := unwrap(svc.projectiles.UpdateLot(rest[0], lot))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, p)
+	}
+	fmt.Fprintf(c.stdout, "updated lot %s\n", lot.Number)
+	return nil
+}
+
+func (c *CLI) projectileLotDelete(args []string) error {
+	fs, common := c.newFlagSet("inventory projectile lot-delete")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 2, "<projectile-id> <lot-id|number>"); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	lot, err := c.findLot(svc, rest[0], rest[1])
+	if err != nil {
+		return err
+	}
+	if _, err := unwrap(svc.projectiles.DeleteLot(rest[0], lot.ID)); err != nil {
+		return err
+	}
+	if !common.json {
+		fmt.Fprintf(c.stdout, "deleted lot %s\n", lot.Number)
+	}
+	return nil
+}
+
+// findLot sucht eine Charge anhand ID oder Losnummer.
+func (c *CLI) findLot(svc *services, projectileID, idOrNumber string) (application.ProjectileLotDTO, error) {
+	p, err := unwrap(svc.projectiles.LoadProjectile(projectileID))
+	if err != nil {
+		return application.ProjectileLotDTO{}, err
+	}
+	for _, lot := range p.Lots {
+		if lot.ID == idOrNumber || lot.Number == idOrNumber {
+			return lot, nil
+		}
+	}
+	return application.ProjectileLotDTO{}, fmt.Errorf("lot not found: %s", idOrNumber)
+}
+
+// ==================== LOT COMPARISON ====================
+
+func (c *CLI) sessionLots(args []string) error {
+	fs, common := c.newFlagSet("session lots")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<projectile-id>"); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	stats, err := unwrap(svc.sessions.CompareLots(rest[0]))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, stats)
+	}
+
+	t := newTable(c.stdout, "LOT", "SESSIONS", "SHOTS", "AVG M/S", "SD", "ES", "AVG J", "AVG WEIGHT G")
+	for _, s := range stats {
+		lot := s.LotNumber
+		if s.LotID == "" {
+			lot = "(no lot)"
+		}
+		t.row(lot, s.SessionCount, s.ValidShotCount, s.AvgVelocityMPS, s.StandardDeviation, s.ExtremeSpread, s.AvgEnergyJoules, s.AverageWeightGrams)
+	}
+	return t.flush()
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// printJSON gibt v als eingerücktes JSON aus (für --json / Pipes).
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// table ist ein kleiner Helper für menschenlesbare Tabellen.
+//
+// GO-KONZEPT: text/tabwriter
+// Spalten werden mit \t getrennt, tabwriter richtet sie beim Flush aus.
+type table struct {
+	tw *tabwriter.Writer
+}
+
+func newTable(w io.Writer, headers ...string) *table {
+	t := &table{tw: tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)}
+	t.row(toAny(headers)...)
+	return t
+}
+
+func (t *table) row(values ...any) {
+	cells := make([]string, len(values))
+	for i, v := range values {
+		cells[i] = formatCell(v)
+	}
+	fmt.Fprintln(t.tw, strings.Join(cells, "\t"))
+}
+
+func (t *table) flush() error {
+	return t.tw.Flush()
+}
+
+// formatCell formatiert einen Tabellenwert.
+// Floats werden auf 2 Nachkommastellen gerundet, nil-Pointer als "-".
+func formatCell(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "-"
+	case float64:
+		return fmt.Sprintf("%.2f", val)
+	case *float64:
+		if val == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%.2f", *val)
+	case *string:
+		if val == nil {
+			return "-"
+		}
+		return *val
+	case bool:
+		if val {
+			return "yes"
+		}
+		return "no"
+	case string:
+		if val == "" {
+			return "-"
+		}
+		return val
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+func toAny(values []string) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+// printFields gibt Schlüssel/Wert-Paare als zweispaltige Liste aus.
+func printFields(w io.Writer, pairs ...any) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		fmt.Fprintf(tw, "%s:\t%s\n", pairs[i], formatCell(pairs[i+1]))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,1019 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"metric-neo/internal/application"
+	"metric-neo/internal/infrastructure/chrono"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+)
+
+const sessionUsage = `Usage: metric-neo session <command> [flags]
+
+Commands:
+  list                           List sessions, newest first (--profile, --projectile, --lot, --from, --to, --limit)
+  show <id>                      Show a session with all shots (--jurisdiction: energy limit check, --units)
+  stats <id>                     Show session statistics (--jurisdiction: energy limit check, --units)
+  create                         Create a session (--temp, --pressure, --humidity, --altitude)
+  record <id> <velocity>...      Record shots manually (m/s)
+  invalidate <id> <shot>...      Mark shots invalid (numbers as in show, e.g. suggested outliers)
+  capture                        Record shots from the chronograph (--string, --fill-bar: start a new shot string first)
+  string <id> [name]             Start a new shot string, e.g. per fill (--fill-bar; also while capturing)
+  rename-string <id> <n> <name>  Rename shot string n
+  set-fill <id> <n>              Record the fill pressure of shot string n (--start-bar, --end-bar, --clear)
+  fill <id>                      Velocity per shot number across strings, sweet spot and fill window (--tolerance, --units)
+  export <id>... | --all         Export sessions as JSON or CSV (--format csv)
+  import <file.csv>              Import sessions from CSV
+  delete <id>                    Delete a session
+  reindex                        Rebuild the session index (sessions/.index.json)
+  lots <projectile-id>           Compare the statistics of all lots of a projectile
+  compare <id> <id>...           Compare sessions with the first one: Welch t-test, F/Levene test, effect size (--units)
+  trajectory <id>                Trajectory table from the session velocity (--zero, --max, --step, --wind)
+  downrange <id> <near-id>       Mark a session as measured --distance m behind another one (--clear)
+  estimate-bc <id>               Estimate the BC from a downrange session (--drag, --paired, --apply)
+`
+
+func (c *CLI) runSession(args []string) error {
+	if len(args) == 0 {
+		return c.unknownSubcommand("session", args, sessionUsage)
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		return c.sessionList(args[1:])
+	case "show":
+		return c.sessionShow(args[1:])
+	case "stats":
+		return c.sessionStats(args[1:])
+	case "create":
+		return c.sessionCreate(args[1:])
+	case "record":
+		return c.sessionRecord(args[1:])
+	case "invalidate":
+		return c.sessionInvalidate(args[1:])
+	case "capture":
+		return c.sessionCapture(args[1:])
+	case "string":
+		return c.sessionString(args[1:])
+	case "rename-string":
+		return c.sessionRenameString(args[1:])
+	case "set-fill":
+		return c.sessionSetFill(args[1:])
+	case "fill":
+		return c.sessionFill(args[1:])
+	case "export":
+		return c.sessionExport(args[1:])
+	case "import":
+		return c.sessionImport(args[1:])
+	case "delete", "rm":
+		return c.sessionDelete(args[1:])
+	case "reindex":
+		return c.sessionReindex(args[1:])
+	case "lots":
+		return c.sessionLots(args[1:])
+	case "compare":
+		return c.sessionCompare(args[1:])
+	case "trajectory":
+		return c.sessionTrajectory(args[1:])
+	case "downrange":
+		return c.sessionDownrange(args[1:])
+	case "estimate-bc":
+		return c.sessionEstimateBC(args[1:])
+	default:
+		return c.unknownSubcommand("session", args, sessionUsage)
+	}
+}
+
+func (c *CLI) sessionList(args []string) error {
+	fs, common := c.newFlagSet("session list")
+	var query application.SessionQueryDTO
+	fs.StringVar(&query.ProfileID, "profile", "", "only sessions with this profile ID")
+	fs.StringVar(&query.ProjectileID, "projectile", "", "only sessions with this projectile ID")
+	fs.StringVar(&query.LotID, "lot", "", "only sessions with this lot ID")
+	fs.StringVar(&query.From, "from", "", "only sessions on or after this date (YYYY-MM-DD)")
+	fs.StringVar(&query.To, "to", "", "only sessions on or before this date (YYYY-MM-DD)")
+	fs.StringVar(&query.Sort, "sort", "newest", "sort order: newest or oldest")
+	fs.IntVar(&query.Limit, "limit", 0, "maximum number of sessions (0 = all)")
+	fs.IntVar(&query.Offset, "offset", 0, "skip this many sessions")
+	units := addUnitsFlag(fs)
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	presenter, err := c.presenter(*units)
+	if err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	page, err := unwrap(svc.sessions.QuerySessions(query))
+	if err != nil {
+		return err
+	}
+	for _, warning := range page.Warnings {
+		fmt.Fprintf(c.stderr, "warning: %s\n", warning)
+	}
+
+	if common.json {
+		return printJSON(c.stdout, page.Sessions)
+	}
+
+	labels := presenter.Units()
+	t := newTable(c.stdout, "ID", "CREATED", "PROFILE", "PROJECTILE", "LOT", "SHOTS", "VALID",
+		"AVG "+strings.ToUpper(labels.Velocity.Label), "AVG "+strings.ToUpper(labels.Energy.Label), "NOTE")
+	for _, s := range page.Sessions {
+		t.row(s.ID, s.CreatedAt, s.ProfileName, s.ProjectileName, s.LotNumber, s.ShotCount, s.ValidShotCount,
+			presenter.Velocity(s.AvgVelocityMPS), presenter.Energy(s.AvgEnergyJoules), s.Note)
+	}
+	if err := t.flush(); err != nil {
+		return err
+	}
+	if len(page.Sessions) < page.Total {
+		fmt.Fprintf(c.stderr, "showing %d-%d of %d sessions\n", page.Offset+1, page.Offset+len(page.Sessions), page.Total)
+	}
+	return nil
+}
+
+func (c *CLI) sessionReindex(args []string) error {
+	fs, common := c.newFlagSet("session reindex")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	count, err := unwrap(svc.sessions.RebuildSessionIndex())
+	if err != nil {
+		return err
+	}
+	if common.json {
+		return printJSON(c.stdout, map[string]int{"sessions": count})
+	}
+	fmt.Fprintf(c.stdout, "indexed %d sessions\n", count)
+	return nil
+}
+
+func (c *CLI) sessionShow(args []string) error {
+	fs, common := c.newFlagSet("session show")
+	jurisdiction := addJurisdictionFlag(fs)
+	units := addUnitsFlag(fs)
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<session-id>"); err != nil {
+		return err
+	}
+	presenter, err := c.presenter(*units)
+	if err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+	if err := c.applyJurisdiction(svc, *jurisdiction); err != nil {
+		return err
+	}
+
+	session, err := unwrap(svc.sessions.LoadSession(rest[0]))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, session)
+	}
+
+	if err := printFields(c.stdout,
+		"ID", session.ID,
+		"Created", session.CreatedAt,
+		"Profile", session.ProfileSnapshot.Name,
+		"Projectile", formatProjectile(presenter, session.ProjectileSnapshot),
+		"Temperature °C", session.TemperatureCelsius,
+		"Pressure hPa", session.PressureHPa,
+		"Humidity %", session.HumidityPercent,
+		"Altitude m", session.AltitudeMeters,
+		"Air density kg/m³", formatDensity(session.AirDensityKgM3),
+		"Density altitude m", session.DensityAltitudeMeters,
+		"Downrange", formatDownrange(session.Downrange),
+		"Note", session.Note,
+	); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.stdout)
+	labels := presenter.Units()
+	t := newTable(c.stdout, "#", "TIME", strings.ToUpper(labels.Velocity.Label), strings.ToUpper(labels.Energy.Label),
+		strings.ToUpper(labels.EnergyDensity.Label), "LIMIT", "VALID")
+	for i, shot := range session.Shots {
+		var density *float64
+		if shot.EnergyDensityJCM2 != nil {
+			value := presenter.EnergyDensity(*shot.EnergyDensityJCM2)
+			density = &value
+		}
+		t.row(i+1, shot.Timestamp, presenter.Velocity(shot.VelocityMPS), presenter.Energy(shot.EnergyJoules),
+			density, shot.EnergyVerdict, shot.Valid)
+	}
+	if err := t.flush(); err != nil {
+		return err
+	}
+
+	stats, err := unwrap(svc.sessions.GetStatistics(session.ID))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.stdout)
+	return c.printStatistics(stats, presenter)
+}
+
+func (c *CLI) sessionStats(args []string) error {
+	fs, common := c.newFlagSet("session stats")
+	jurisdiction := addJurisdictionFlag(fs)
+	units := addUnitsFlag(fs)
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<session-id>"); err != nil {
+		return err
+	}
+	presenter, err := c.presenter(*units)
+	if err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+	if err := c.applyJurisdiction(svc, *jurisdiction); err != nil {
+		return err
+	}
+
+	stats, err := unwrap(svc.sessions.GetStatistics(rest[0]))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, stats)
+	}
+	return c.printStatistics(stats, presenter)
+}
+
+// printStatistics gibt die Statistik in den Einheiten des Presenters aus.
+func (c *CLI) printStatistics(stats application.StatisticsDTO, presenter application.Presenter) error {
+	units := presenter.Units()
+	v, e := units.Velocity.Label, units.Energy.Label
+	velocity := presenter.Velocity
+	var density *float64
+	if stats.AvgEnergyDensityJCM2 != nil {
+		value := presenter.EnergyDensity(*stats.AvgEnergyDensityJCM2)
+		density = &value
+	}
+	if err := printFields(c.stdout,
+		"Shots (valid/total)", fmt.Sprintf("%d/%d", stats.ValidShotCount, stats.TotalShotCount),
+		"Average "+v, velocity(stats.AvgVelocityMPS),
+		"Min "+v, velocity(stats.MinVelocityMPS),
+		"Max "+v, velocity(stats.MaxVelocityMPS),
+		"Extreme spread "+v, velocity(stats.ExtremeSpread),
+		"Standard deviation "+v, velocity(stats.StandardDeviation),
+		"Sample SD "+v+" (n-1)", velocity(stats.SampleStandardDeviation),
+		"Coefficient of variation %", stats.CoefficientOfVariation,
+		"Median "+v, velocity(stats.MedianVelocityMPS),
+		"Percentiles "+v, formatPercentiles(stats.Percentiles, presenter),
+		"Mean absolute deviation "+v, velocity(stats.MeanAbsoluteDeviation),
+		"Mean 95% CI "+v, formatInterval(stats.MeanCI, presenter),
+		"SD 95% CI "+v, formatInterval(stats.StandardDeviationCI, presenter),
+		"Average energy "+e, presenter.Energy(stats.AvgEnergyJoules),
+		"Average energy density "+units.EnergyDensity.Label, density,
+		"Energy limit", formatEnergyCheck(stats.EnergyCheck, presenter),
+		"Suggested outliers", formatOutliers(stats.OutlierSuggestions, presenter),
+	); err != nil {
+		return err
+	}
+	if len(stats.Strings) == 0 {
+		return nil
+	}
+	fmt.Fprintln(c.stdout)
+	return c.printStringStatistics(stats.Strings, presenter)
+}
+
+func (c *CLI) sessionCompare(args []string) error {
+	fs, common := c.newFlagSet("session compare")
+	units := addUnitsFlag(fs)
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 2, "<reference-id> <session-id>..."); err != nil {
+		return err
+	}
+	presenter, err := c.presenter(*units)
+	if err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	comparison, err := unwrap(svc.sessions.CompareSessions(rest...))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, comparison)
+	}
+	return c.printComparison(comparison, presenter)
+}
+
+// printComparison gibt die Sessions nebeneinander und die Tests gegen die
+// Referenz aus.
+func (c *CLI) printComparison(comparison application.SessionComparisonDTO, presenter application.Presenter) error {
+	v := presenter.Units().Velocity.Label
+	velocity := presenter.Velocity
+
+	t := newTable(c.stdout, "SESSION", "DATE", "PROJECTILE", "SHOTS", "AVG "+strings.ToUpper(v), "SD", "ES", "CV %")
+	for i, s := range comparison.Sessions {
+		id := s.SessionID
+		if i == 0 {
+			id += " *"
+		}
+		projectile := s.ProjectileName
+		if s.LotNumber != "" {
+			projectile += " · " + s.LotNumber
+		}
+		stats := s.Statistics
+		t.row(id, s.CreatedAt[:10], projectile, stats.ValidShotCount, velocity(stats.AvgVelocityMPS),
+			velocity(stats.SampleStandardDeviation), velocity(stats.ExtremeSpread), stats.CoefficientOfVariation)
+	}
+	if err := t.flush(); err != nil {
+		return err
+	}
+	fmt.Fprint(c.stdout, "* reference session, SD with n-1\n\n")
+
+	t = newTable(c.stdout, "SESSION", "DIFF "+strings.ToUpper(v), "95% CI", "P MEAN", "F", "P F", "P LEVENE", "HEDGES G", "EFFECT", "WARNINGS")
+	for _, test := range comparison.Tests {
+		var ci, pMean, f, pF, pLevene, g, effect any
+		if m := test.Means; m != nil {
+			ci = formatInterval(&m.DifferenceCI, presenter)
+			pMean = formatP(m.P, m.Significant)
+		}
+		if vt := test.Variances; vt != nil {
+			f, pF = vt.F, formatP(vt.FP, vt.LeveneP == nil && vt.Significant)
+			if vt.LeveneP != nil {
+				pLevene = formatP(*vt.LeveneP, vt.Significant)
+			}
+		}
+		if e := test.Effect; e != nil {
+			g, effect = e.HedgesG, e.Magnitude
+		}
+		t.row(test.SessionID, velocity(test.MeanDifferenceMPS), ci, pMean, f, pF, pLevene, g, effect, strings.Join(test.Warnings, ","))
+	}
+	if err := t.flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.stdout, "! significant at p < %.2f\n", application.ComparisonAlpha)
+	if len(comparison.Warnings) > 0 {
+		fmt.Fprintf(c.stdout, "Warnings: %s\n", strings.Join(comparison.Warnings, ", "))
+	}
+	return nil
+}
+
+// formatP formatiert einen p-Wert, signifikante mit "!".
+func formatP(p float64, significant bool) string {
+	s := fmt.Sprintf("%.3f", p)
+	if p < 0.001 {
+		s = "<0.001"
+	}
+	if significant {
+		s += " !"
+	}
+	return s
+}
+
+func formatPercentiles(percentiles []application.PercentileDTO, presenter application.Presenter) string {
+	parts := make([]string, 0, len(percentiles))
+	for _, p := range percentiles {
+		parts = append(parts, fmt.Sprintf("P%d %.2f", p.Percent, presenter.Velocity(p.VelocityMPS)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatInterval(interval *application.IntervalDTO, presenter application.Presenter) string {
+	if interval == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.2f - %.2f", presenter.Velocity(interval.Lower), presenter.Velocity(interval.Upper))
+}
+
+// formatOutliers listet die Vorschläge mit Schussnummer (1-basiert wie in session show).
+func formatOutliers(outliers []application.OutlierDTO, presenter application.Presenter) string {
+	parts := make([]string, 0, len(outliers))
+	for _, o := range outliers {
+		parts = append(parts, fmt.Sprintf("#%d %.2f %s (%s)", o.ShotIndex+1, presenter.Velocity(o.VelocityMPS),
+			presenter.Units().Velocity.Label, strings.Join(o.Methods, ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// formatProjectile zeigt Name, Gewicht und (falls bekannt) Kaliber.
+func formatProjectile(presenter application.Presenter, p application.ProjectileDTO) string {
+	details := presenter.FormatMass(p.WeightGrams)
+	if p.CaliberMM != nil {
+		details += ", " + presenter.FormatLength(*p.CaliberMM)
+	}
+	return fmt.Sprintf("%s (%s)", p.Name, details)
+}
+
+// formatEnergyCheck fasst die Prüfung gegen die Energiegrenze zusammen.
+func formatEnergyCheck(check *application.EnergyCheckDTO, presenter application.Presenter) string {
+	if check == nil {
+		return ""
+	}
+	limit := presenter.FormatEnergy(check.LimitJoules)
+	if check.Label != "" {
+		limit += " " + check.Label
+	}
+	if check.Verdict == "" {
+		return limit + ": no valid shots"
+	}
+
+	summary := fmt.Sprintf("%s: %s (%d over, %d near, max %s", limit, check.Verdict,
+		check.ExceededShots, check.NearShots, presenter.FormatEnergy(check.MaxShotJoules))
+	if check.UpperBoundJoules != nil {
+		summary += ", mean upper 95% " + presenter.FormatEnergy(*check.UpperBoundJoules)
+	}
+	return summary + ")"
+}
+
+func (c *CLI) sessionCreate(args []string) error {
+	fs, common := c.newFlagSet("session create")
+	profileID := fs.String("profile", "", "profile ID (required)")
+	projectileID := fs.String("projectile", "", "projectile ID (required)")
+	lotID := fs.String("lot", "", "lot ID or number of the projectile")
+	note := fs.String("note", "", "session note")
+	conditions := addConditionFlags(fs, "")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	session, err := unwrap(svc.sessions.CreateSessionWithLot(*profileID, *projectileID, *lotID, conditions.dto(), *note))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, session)
+	}
+	fmt.Fprintln(c.stdout, session.ID)
+	return nil
+}
+
+func (c *CLI) sessionRecord(args []string) error {
+	fs, common := c.newFlagSet("session record")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 2, "<session-id> <velocity-m/s>..."); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	var session application.SessionDTO
+	for _, raw := range rest[1:] {
+		velocity, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid velocity %q: %w", raw, err)
+		}
+		session, err = unwrap(svc.sessions.RecordShot(rest[0], velocity))
+		if err != nil {
+			return err
+		}
+	}
+
+	if common.json {
+		return printJSON(c.stdout, session)
+	}
+	fmt.Fprintf(c.stdout, "recorded %d shot(s), session has %d shot(s)\n", len(rest)-1, len(session.Shots))
+	return nil
+}
+
+// sessionInvalidate markiert Schüsse als ungültig (Nummern wie in session show,
+// z.B. die Ausreißer-Vorschläge aus session stats).
+func (c *CLI) sessionInvalidate(args []string) error {
+	fs, common := c.newFlagSet("session invalidate")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 2, "<session-id> <shot-number>..."); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	var session application.SessionDTO
+	for _, raw := range rest[1:] {
+		number, err := strconv.Atoi(raw)
+		if err != nil || number < 1 {
+			return fmt.Errorf("invalid shot number %q (1 = first shot)", raw)
+		}
+		session, err = unwrap(svc.sessions.MarkShotInvalid(rest[0], number-1))
+		if err != nil {
+			return err
+		}
+	}
+
+	if common.json {
+		return printJSON(c.stdout, session)
+	}
+	fmt.Fprintf(c.stdout, "marked %d shot(s) invalid, %d of %d shot(s) valid\n",
+		len(rest)-1, countValid(session.Shots), len(session.Shots))
+	return nil
+}
+
+func countValid(shots []application.ShotDTO) int {
+	valid := 0
+	for _, shot := range shots {
+		if shot.Valid {
+			valid++
+		}
+	}
+	return valid
+}
+
+// sessionCapture liest Messwerte vom Chronographen und zeichnet sie auf.
+//
+// Entweder wird eine neue Session erstellt (--profile/--projectile)
+// oder an eine bestehende angehängt (--session).
+// Läuft bis --count Schüsse erfasst wurden oder bis Ctrl+C.
+func (c *CLI) sessionCapture(args []string) error {
+	fs, common := c.newFlagSet("session capture")
+	sessionID := fs.String("session", "", "append to an existing session instead of creating one")
+	profileID := fs.String("profile", "", "profile ID for a new session")
+	projectileID := fs.String("projectile", "", "projectile ID for a new session")
+	lotID := fs.String("lot", "", "lot ID or number for a new session")
+	note := fs.String("note", "", "note for a new session")
+	port := fs.String("port", "", "serial port (default: configured chrono port)")
+	baudRate := fs.Int("baud", 0, "baud rate (default: configured baud rate, then driver default)")
+	driverName := fs.String("driver", "", "chrono protocol, see 'metric-neo chrono drivers' (default: configured driver)")
+	count := fs.Int("count", 0, "stop after N recorded shots (0 = until interrupted)")
+	stringName := fs.String("string", "", "start a new shot string with this name first, e.g. after refilling")
+	var fillBar optionalFloat
+	fs.Var(&fillBar, "fill-bar", "tank pressure in bar of the new shot string (PCP)")
+	wireLog := fs.Bool("wire-log", false, "also record the raw chrono data (sessions/<id>.wire.log, see 'chrono replay')")
+	conditions := addConditionFlags(fs, " for a new session")
+	units := addUnitsFlag(fs)
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	presenter, err := c.presenter(*units)
+	if err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	// Port/BaudRate/Treiber: Flags > Desktop-Konfiguration > Treiber-Default
+	serialNumber := ""
+	if *port == "" || *baudRate <= 0 || *driverName == "" {
+		if cfg, err := c.loadConfig(); err == nil && cfg != nil {
+			if *port == "" {
+				// Nach Umstecken ggf. unter neuem Namen: per Seriennummer wiederfinden
+				*port = chrono.ResolvePort(cfg.ChronoPort, cfg.ChronoSerialNumber)
+				serialNumber = cfg.ChronoSerialNumber
+			}
+			if *baudRate <= 0 {
+				*baudRate = cfg.ChronoBaudRate
+			}
+			if *driverName == "" {
+				*driverName = cfg.ChronoDriver
+			}
+		}
+	}
+	if *port == "" {
+		return fmt.Errorf("no chrono port configured - pass --port")
+	}
+	driver, err := chrono.LookupDriver(*driverName)
+	if err != nil {
+		return err
+	}
+	if *baudRate <= 0 {
+		*baudRate = driver.BaudRate
+	}
+
+	if *sessionID == "" {
+		session, err := unwrap(svc.sessions.CreateSessionWithLot(*profileID, *projectileID, *lotID, conditions.dto(), *note))
+		if err != nil {
+			return err
+		}
+		*sessionID = session.ID
+	} else if _, err := unwrap(svc.sessions.LoadSession(*sessionID)); err != nil {
+		return err
+	}
+	if *stringName != "" || fillBar.set {
+		if _, err := unwrap(svc.sessions.StartString(*sessionID, *stringName, fillDTO(fillBar, optionalFloat{}))); err != nil {
+			return err
+		}
+	}
+
+	// Der Supervisor verbindet nach einem Kabelwackler selbst neu
+	states := make(chan chrono.StateChange, 16)
+	opts := chrono.SupervisorOptions{
+		Port:         *port,
+		SerialNumber: serialNumber,
+		BaudRate:     *baudRate,
+		NewChrono:    c.newChrono,
+		OnState: func(change chrono.StateChange) {
+			select {
+			case states <- change:
+			default: // Ausgabe ist nur Information, Supervisor nicht blockieren
+			}
+		},
+	}
+	if *wireLog {
+		file, err := svc.sessions.OpenWireLog(*sessionID)
+		if err != nil {
+			return err
+		}
+		// Wird von supervisor.Stop() geschlossen (Disarm)
+		opts.WireLog = chrono.NewWireLog(file)
+	}
+	supervisor := chrono.NewSupervisor(driver, opts)
+
+	// Gleiche Aufnahmeschleife wie die Desktop-App: jeder Messwert wird
+	// sofort gespeichert, die CLI gibt nur die Events aus
+	events := make(chan captureEvent, 64)
+	capture := application.NewCaptureService(svc.sessions, func(name string, data interface{}) {
+		events <- captureEvent{name: name, data: data}
+	})
+	if _, err := unwrap(capture.Arm(*sessionID, true, supervisor)); err != nil {
+		if opts.WireLog != nil {
+			opts.WireLog.Close()
+		}
+		return err
+	}
+	disarmed := false
+	disarm := func() {
+		if !disarmed {
+			disarmed = true
+			// Disarm speichert noch gepufferte Schüsse und sendet dafür Events;
+			// events wird erst danach geschlossen
+			go func() {
+				capture.Disarm()
+				close(events)
+			}()
+		}
+	}
+	defer func() {
+		disarm()
+		for range events {
+		}
+	}()
+
+	// Falscher Port o.ä.: sofort abbrechen statt endlos neu zu versuchen
+	if err := awaitFirstConnect(states); err != nil {
+		return err
+	}
+
+	// GO-KONZEPT: signal.NotifyContext
+	// Ctrl+C beendet die Aufnahme sauber (Port wird per defer geschlossen).
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if !common.json {
+		fmt.Fprintf(c.stderr, "capturing into session %s on %s (%s, %d baud) - press Ctrl+C to stop\n", *sessionID, *port, driver.Name, *baudRate)
+	}
+
+	// JSON-Modus: ein ShotDTO pro Zeile (NDJSON), damit andere Tools streamen können
+	enc := json.NewEncoder(c.stdout)
+	recorded := 0
+	handle := func(event captureEvent) error {
+		switch event.name {
+		case application.CaptureErrorEvent:
+			fmt.Fprintf(c.stderr, "chrono: %s\n", event.data.(application.CaptureErrorDTO).Error)
+		case application.ShotRecordedEvent:
+			session := event.data.(application.ShotRecordedDTO).Session
+			recorded++
+			shot := session.Shots[len(session.Shots)-1]
+			if common.json {
+				return enc.Encode(shot)
+			}
+			// Neue Serie (auch aus "session string" in einem anderen Terminal)
+			if n := len(session.Strings); n > 0 && session.Strings[n-1].FirstShot == len(session.Shots) {
+				fmt.Fprintf(c.stdout, "-- string %s\n", formatString(n, session.Strings[n-1].Name))
+			}
+			labels := presenter.Units()
+			fmt.Fprintf(c.stdout, "#%d\t%.2f %s\t%.2f %s\n", len(session.Shots),
+				presenter.Velocity(shot.VelocityMPS), labels.Velocity.Label, presenter.Energy(shot.EnergyJoules), labels.Energy.Label)
+		}
+		return nil
+	}
+
+capture:
+	for *count <= 0 || recorded < *count {
+		select {
+		case <-ctx.Done():
+			break capture
+		case change := <-states:
+			switch change.State {
+			case chrono.StateDisconnected:
+				fmt.Fprintf(c.stderr, "chrono: disconnected, retrying in %s\n", change.RetryIn)
+			case chrono.StateConnected:
+				fmt.Fprintf(c.stderr, "chrono: connected on %s\n", change.Port)
+			case chrono.StateDegraded:
+				fmt.Fprintf(c.stderr, "chrono: connection degraded (repeated invalid frames)\n")
+			}
+		case event := <-events:
+			if err := handle(event); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Schüsse, die beim Beenden noch unterwegs waren, sind gespeichert - auch ausgeben
+	disarm()
+	for event := range events {
+		if err := handle(event); err != nil {
+			return err
+		}
+	}
+
+	if common.json {
+		return nil
+	}
+
+	fmt.Fprintf(c.stdout, "\nrecorded %d shot(s) into session %s\n\n", recorded, *sessionID)
+	stats, err := unwrap(svc.sessions.GetStatistics(*sessionID))
+	if err != nil {
+		return err
+	}
+	return c.printStatistics(stats, presenter)
+}
+
+// captureEvent ist ein Event des CaptureService für die CLI-Ausgabe.
+type captureEvent struct {
+	name string
+	data interface{}
+}
+
+// awaitFirstConnect wartet auf das Ergebnis des ersten Verbindungsversuchs.
+func awaitFirstConnect(states <-chan chrono.StateChange) error {
+	for change := range states {
+		switch change.State {
+		case chrono.StateConnected:
+			return nil
+		case chrono.StateDisconnected:
+			return change.Err
+		}
+	}
+	return nil
+}
+
+func (c *CLI) sessionExport(args []string) error {
+	fs, common := c.newFlagSet("session export")
+	all := fs.Bool("all", false, "export all sessions")
+	out := fs.String("out", "", "write to file instead of stdout")
+	format := fs.String("format", "json", "json or csv")
+	units := fs.String("units", application.UnitSystemMetric, "csv units: metric or imperial")
+	decimal := fs.String("decimal", ".", "csv decimal separator: . or , (comma uses ; as field separator)")
+	jurisdiction := addJurisdictionFlag(fs)
+	ids, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if !*all {
+		if err := c.requireArgs(fs, ids, 1, "<session-id>... or --all"); err != nil {
+			return err
+		}
+	}
+	if *format != "json" && *format != "csv" {
+		return fmt.Errorf("%w: unknown format %q (json or csv)", errUsage, *format)
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+	if err := c.applyJurisdiction(svc, *jurisdiction); err != nil {
+		return err
+	}
+
+	if *all {
+		metas, err := unwrap(svc.sessions.ListSessions())
+		if err != nil {
+			return err
+		}
+		ids = ids[:0]
+		for _, meta := range metas {
+			ids = append(ids, meta.ID)
+		}
+	}
+
+	w := c.stdout
+	var file *os.File
+	if *out != "" {
+		file, err = os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", *out, err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if *format == "csv" {
+		opts := application.CSVOptionsDTO{UnitSystem: *units, DecimalSeparator: *decimal}
+		err = svc.sessions.ExportCSV(w, ids, opts)
+	} else {
+		err = c.writeSessionsJSON(w, svc, ids)
+	}
+	if err != nil {
+		return err
+	}
+
+	if file != nil {
+		return file.Close()
+	}
+	return nil
+}
+
+// writeSessionsJSON schreibt die Sessions als JSON-Array von SessionDTOs.
+func (c *CLI) writeSessionsJSON(w io.Writer, svc *services, ids []string) error {
+	sessions := make([]application.SessionDTO, 0, len(ids))
+	for _, id := range ids {
+		session, err := unwrap(svc.sessions.LoadSession(id))
+		if err != nil {
+			return err
+		}
+		sessions = append(sessions, session)
+	}
+	return printJSON(w, sessions)
+}
+
+func (c *CLI) sessionImport(args []string) error {
+	fs, common := c.newFlagSet("session import")
+	decimal := fs.String("decimal", "", "decimal separator: . or , (default: detect from field separator)")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<file.csv>"); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(rest[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Einheiten werden aus den Spaltennamen erkannt
+	result, err := unwrap(svc.sessions.ImportCSV(file, application.CSVOptionsDTO{DecimalSeparator: *decimal}))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, result)
+	}
+	for _, meta := range result.Sessions {
+		fmt.Fprintln(c.stdout, meta.ID)
+	}
+	fmt.Fprintf(c.stderr, "imported %d session(s) with %d shot(s)\n", len(result.Sessions), result.ShotCount)
+	return nil
+}
+
+func (c *CLI) sessionDelete(args []string) error {
+	fs, common := c.newFlagSet("session delete")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<session-id>"); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	if _, err := unwrap(svc.sessions.DeleteSession(rest[0])); err != nil {
+		return err
+	}
+	if !common.json {
+		fmt.Fprintf(c.stdout, "deleted session %s\n", rest[0])
+	}
+	return nil
+}
+
+// formatDensity zeigt die Luftdichte mit 4 Nachkommastellen (1.2250 statt 1.23).
+func formatDensity(density *float64) string {
+	if density == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.4f", *density)
+}
+
+func formatDownrange(downrange *application.DownrangeDTO) string {
+	if downrange == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.1f m behind session %s", downrange.DistanceMeters, downrange.NearSessionID)
+}
+
+// conditionFlags sind die Umgebungsbedingungen für eine neue Session.
+type conditionFlags struct {
+	temperature, pressure, humidity, altitude optionalFloat
+}
+
+func addConditionFlags(fs *flag.FlagSet, suffix string) *conditionFlags {
+	c := &conditionFlags{}
+	fs.Var(&c.temperature, "temp", "ambient temperature in °C"+suffix)
+	fs.Var(&c.pressure, "pressure", "station pressure in hPa (not sea-level QNH)"+suffix)
+	fs.Var(&c.humidity, "humidity", "relative humidity in %"+suffix)
+	fs.Var(&c.altitude, "altitude", "altitude of the range in m"+suffix)
+	return c
+}
+
+func (c *conditionFlags) dto() application.ConditionsDTO {
+	return application.ConditionsDTO{
+		TemperatureCelsius: c.temperature.ptr(),
+		PressureHPa:        c.pressure.ptr(),
+		HumidityPercent:    c.humidity.ptr(),
+		AltitudeMeters:     c.altitude.ptr(),
+	}
+}
+
+// optionalFloat ist ein flag.Value für optionale Zahlen (z.B. Temperatur).
+//
+// GO-KONZEPT: Optional über Pointer
+// Wie in den Services: nicht gesetzt = nil.
+type optionalFloat struct {
+	value float64
+	set   bool
+}
+
+func (o *optionalFloat) String() string {
+	if o == nil || !o.set {
+		return ""
+	}
+	return strconv.FormatFloat(o.value, 'f', -1, 64)
+}
+
+func (o *optionalFloat) Set(raw string) error {
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return err
+	}
+	o.value = v
+	o.set = true
+	return nil
+}
+
+func (o *optionalFloat) ptr() *float64 {
+	if !o.set {
+		return nil
+	}
+	v := o.value
+	return &v
+}
@@ -0,0 +1,287 @@
+package cli
+
+import (
+	"fmt"
+	"metric-neo/internal/application"
+	"strconv"
+	"strings"
+)
+
+// sessionString beginnt eine neue Schussserie. Läuft in einem zweiten
+// Terminal auch während "session capture": die Aufnahme speichert jeden
+// Schuss einzeln und hängt ihn an die neue Serie an.
+func (c *CLI) sessionString(args []string) error {
+	fs, common := c.newFlagSet("session string")
+	var fillBar optionalFloat
+	fs.Var(&fillBar, "fill-bar", "tank pressure in bar after refilling (PCP)")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<session-id> [name]"); err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	session, err := unwrap(svc.sessions.StartString(rest[0], strings.Join(rest[1:], " "), fillDTO(fillBar, optionalFloat{})))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, session)
+	}
+	current := session.Strings[len(session.Strings)-1]
+	fmt.Fprintf(c.stdout, "%s: string %s starts with shot #%d\n", session.ID, formatString(current.Number, current.Name), current.FirstShot)
+	return nil
+}
+
+func (c *CLI) sessionRenameString(args []string) error {
+	fs, common := c.newFlagSet("session rename-string")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 3, "<session-id> <string-number> <name>"); err != nil {
+		return err
+	}
+	number, err := strconv.Atoi(rest[1])
+	if err != nil {
+		return fmt.Errorf("invalid string number %q", rest[1])
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	session, err := unwrap(svc.sessions.RenameString(rest[0], number, strings.Join(rest[2:], " ")))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, session)
+	}
+	fmt.Fprintf(c.stdout, "%s: string #%d renamed to %q\n", session.ID, number, session.Strings[number-1].Name)
+	return nil
+}
+
+// sessionSetFill erfasst Start- und Enddruck einer Serie, typischerweise
+// den Enddruck nach dem letzten Schuss.
+func (c *CLI) sessionSetFill(args []string) error {
+	fs, common := c.newFlagSet("session set-fill")
+	var startBar, endBar optionalFloat
+	fs.Var(&startBar, "start-bar", "tank pressure in bar before the first shot (default: keep)")
+	fs.Var(&endBar, "end-bar", "tank pressure in bar after the last shot")
+	remove := fs.Bool("clear", false, "remove the fill pressures of the string")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 2, "<session-id> <string-number>"); err != nil {
+		return err
+	}
+	number, err := strconv.Atoi(rest[1])
+	if err != nil {
+		return fmt.Errorf("invalid string number %q", rest[1])
+	}
+	if *remove == (startBar.set || endBar.set) {
+		return fmt.Errorf("use --start-bar and/or --end-bar, or --clear")
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	var fill *application.FillDTO
+	if !*remove {
+		start := startBar.ptr()
+		if start == nil {
+			// Nur der Enddruck: Startdruck der Serie behalten
+			session, err := unwrap(svc.sessions.LoadSession(rest[0]))
+			if err != nil {
+				return err
+			}
+			if number < 1 || number > len(session.Strings) || session.Strings[number-1].Fill == nil {
+				return fmt.Errorf("string #%d has no start pressure yet, use --start-bar", number)
+			}
+			start = &session.Strings[number-1].Fill.StartPressureBar
+		}
+		fill = &application.FillDTO{StartPressureBar: *start, EndPressureBar: endBar.ptr()}
+	}
+
+	session, err := unwrap(svc.sessions.SetStringFill(rest[0], number, fill))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, session)
+	}
+	if fill == nil {
+		fmt.Fprintf(c.stdout, "%s: fill of string #%d removed\n", session.ID, number)
+		return nil
+	}
+	fmt.Fprintf(c.stdout, "%s: string #%d filled to %.0f bar", session.ID, number, fill.StartPressureBar)
+	if dto := session.Strings[number-1].Fill; dto.EndPressureBar != nil {
+		fmt.Fprintf(c.stdout, ", %.0f bar after %d shots", *dto.EndPressureBar, session.Strings[number-1].ShotCount)
+		if dto.DropPerShotBar != nil {
+			fmt.Fprintf(c.stdout, " (%.2f bar per shot)", *dto.DropPerShotBar)
+		}
+	}
+	fmt.Fprintln(c.stdout)
+	return nil
+}
+
+// fillDTO baut die Füllung aus den Druck-Flags (nil ohne Startdruck).
+func fillDTO(startBar, endBar optionalFloat) *application.FillDTO {
+	if !startBar.set {
+		return nil
+	}
+	return &application.FillDTO{StartPressureBar: startBar.value, EndPressureBar: endBar.ptr()}
+}
+
+func (c *CLI) sessionFill(args []string) error {
+	fs, common := c.newFlagSet("session fill")
+	var request application.ShotsPerFillRequestDTO
+	fs.Float64Var(&request.ToleranceMPS, "tolerance", application.DefaultSweetSpotToleranceMPS, "allowed velocity spread of the sweet spot in m/s")
+	units := addUnitsFlag(fs)
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, rest, 1, "<session-id>"); err != nil {
+		return err
+	}
+	presenter, err := c.presenter(*units)
+	if err != nil {
+		return err
+	}
+
+	svc, err := c.openServices(common)
+	if err != nil {
+		return err
+	}
+
+	fill, err := unwrap(svc.sessions.AnalyzeShotsPerFill(rest[0], request))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, fill)
+	}
+	return c.printShotsPerFill(fill, presenter)
+}
+
+// printShotsPerFill gibt die Kurve mit markiertem Sweet Spot aus, mit
+// erfassten Fülldrücken auch das Füllfenster.
+func (c *CLI) printShotsPerFill(fill application.ShotsPerFillDTO, presenter application.Presenter) error {
+	units := presenter.Units()
+	v := units.Velocity.Label
+	velocity := presenter.Velocity
+
+	if len(fill.Strings) > 0 {
+		if err := c.printStringStatistics(fill.Strings, presenter); err != nil {
+			return err
+		}
+		fmt.Fprintln(c.stdout)
+	}
+
+	spot := fill.SweetSpot
+	t := newTable(c.stdout, "SHOT", "MEAN "+strings.ToUpper(v), "MIN", "MAX", "STRINGS", strings.ToUpper(units.FillPressure.Label), "SPOT")
+	for _, point := range fill.Curve {
+		mark := ""
+		if spot != nil && point.ShotNumber >= spot.FirstShot && point.ShotNumber <= spot.LastShot {
+			mark = "*"
+		}
+		t.row(point.ShotNumber, velocity(point.MeanVelocityMPS), velocity(point.MinVelocityMPS),
+			velocity(point.MaxVelocityMPS), point.Count, fillPressure(presenter, point.PressureBar), mark)
+	}
+	if err := t.flush(); err != nil {
+		return err
+	}
+
+	if spot == nil {
+		fmt.Fprintln(c.stdout, "\nno valid shots - no sweet spot")
+		return nil
+	}
+	fmt.Fprintf(c.stdout, "\n* sweet spot: shots %d-%d (%d per fill, curve within %.2f %s)\n",
+		spot.FirstShot, spot.LastShot, spot.ShotCount, velocity(fill.ToleranceMPS), v)
+
+	var window, perShot, regulator any
+	if w := fill.FillWindow; w != nil {
+		p := units.FillPressure
+		window = fmt.Sprintf("fill to %.*f %s, refill at %.*f %s (%d strings)",
+			p.Decimals, presenter.FillPressure(w.FillPressureBar), p.Label,
+			p.Decimals, presenter.FillPressure(w.RefillPressureBar), p.Label, w.Strings)
+		perShot = fmt.Sprintf("%.2f %s", presenter.FillPressure(w.PressurePerShotBar), p.Label)
+	}
+	if fill.RegulatorSetpointBar != nil {
+		regulator = fmt.Sprintf("%.0f %s", presenter.FillPressure(*fill.RegulatorSetpointBar), units.FillPressure.Label)
+	}
+	err := printFields(c.stdout,
+		"Average "+v, velocity(spot.MeanVelocityMPS),
+		"Curve spread "+v, velocity(spot.SpreadMPS),
+		"Extreme spread "+v, velocity(spot.ExtremeSpread),
+		"Sample SD "+v+" (n-1)", velocity(spot.SampleStandardDeviation),
+		"Complete strings", spot.CompleteStrings,
+		"Fill window", window,
+		"Pressure per shot", perShot,
+		"Regulator", regulator,
+	)
+	if err != nil {
+		return err
+	}
+	if len(fill.Warnings) > 0 {
+		fmt.Fprintf(c.stdout, "Warnings: %s\n", strings.Join(fill.Warnings, ", "))
+	}
+	return nil
+}
+
+// fillPressure rechnet einen optionalen Druck in die Anzeige-Einheit um.
+func fillPressure(presenter application.Presenter, bar *float64) *float64 {
+	if bar == nil {
+		return nil
+	}
+	value := presenter.FillPressure(*bar)
+	return &value
+}
+
+// printStringStatistics gibt die Statistik je Schussserie als Tabelle aus.
+func (c *CLI) printStringStatistics(strs []application.StringStatisticsDTO, presenter application.Presenter) error {
+	units := presenter.Units()
+	velocity := presenter.Velocity
+	pressure := strings.ToUpper(units.FillPressure.Label)
+	t := newTable(c.stdout, "STRING", "FIRST", "SHOTS", "AVG "+strings.ToUpper(units.Velocity.Label), "SD", "ES",
+		"MIN", "MAX", strings.ToUpper(units.Energy.Label), "START "+pressure, "END "+pressure)
+	for _, s := range strs {
+		name := formatString(s.Number, s.Name)
+		if s.SessionID != "" {
+			name = s.SessionID + " " + name
+		}
+		var start, end *float64
+		if s.Fill != nil {
+			start = fillPressure(presenter, &s.Fill.StartPressureBar)
+			end = fillPressure(presenter, s.Fill.EndPressureBar)
+		}
+		t.row(name, s.FirstShot, fmt.Sprintf("%d/%d", s.ValidShotCount, s.ShotCount),
+			velocity(s.AvgVelocityMPS), velocity(s.SampleStandardDeviation), velocity(s.ExtremeSpread),
+			velocity(s.MinVelocityMPS), velocity(s.MaxVelocityMPS), presenter.Energy(s.AvgEnergyJoules), start, end)
+	}
+	return t.flush()
+}
+
+// formatString zeigt Nummer und Namen einer Serie ("#2 Fill 2").
+func formatString(number int, name string) string {
+	if name == "" {
+		return fmt.Sprintf("#%d", number)
+	}
+	return fmt.Sprintf("#%d %s", number, name)
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"fmt"
+	"metric-neo/internal/application"
+	"metric-neo/internal/infrastructure/persistence"
+	"strings"
+)
+
+const storageUsage = `Usage: metric-neo storage <command> [flags]
+
+Commands:
+  info                           Show the storage backend of the data directory
+  convert --to json|sqlite       Copy all data to the other backend and switch to it
+  query <sql>                    Run a read-only SQL query (sqlite backend only)
+`
+
+func (c *CLI) runStorage(args []string) error {
+	if len(args) == 0 {
+		return c.unknownSubcommand("storage", args, storageUsage)
+	}
+
+	switch args[0] {
+	case "info":
+		return c.storageInfo(args[1:])
+	case "convert":
+		return c.storageConvert(args[1:])
+	case "query", "sql":
+		return c.storageQuery(args[1:])
+	default:
+		return c.unknownSubcommand("storage", args, storageUsage)
+	}
+}
+
+func (c *CLI) storageInfo(args []string) error {
+	fs, common := c.newFlagSet("storage info")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	dataDir, err := c.resolveDataDir(common)
+	if err != nil {
+		return err
+	}
+	info, err := application.GetStorageInfo(dataDir)
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, info)
+	}
+	return printFields(c.stdout,
+		"Data directory", dataDir,
+		"Backend", info.Backend,
+		"Database", info.SQLitePath,
+	)
+}
+
+func (c *CLI) storageConvert(args []string) error {
+	fs, common := c.newFlagSet("storage convert")
+	to := fs.String("to", "", "target backend: json or sqlite")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if *to == "" {
+		fmt.Fprintln(c.stderr, "missing flag: --to")
+		fs.Usage()
+		return errUsage
+	}
+
+	dataDir, err := c.prepareDataDir(common)
+	if err != nil {
+		return err
+	}
+	result, err := application.ConvertStorage(dataDir, persistence.StorageBackend(strings.ToLower(*to)))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(c.stdout, result)
+	}
+	fmt.Fprintf(c.stdout, "converted %s to %s: %d profiles, %d projectiles, %d sights, %d sessions\n",
+		result.From, result.To, result.Profiles, result.Projectiles, result.Sights, result.Sessions)
+	if result.BackupDir != "" {
+		fmt.Fprintf(c.stderr, "previous %s data moved to %s\n", result.To, result.BackupDir)
+	}
+	return nil
+}
+
+func (c *CLI) storageQuery(args []string) error {
+	fs, common := c.newFlagSet("storage query")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.requireArgs(fs, positional, 1, "<sql>"); err != nil {
+		return err
+	}
+
+	dataDir, err := c.resolveDataDir(common)
+	if err != nil {
+		return err
+	}
+	columns, rows, err := application.QuerySQLite(dataDir, strings.Join(positional, " "))
+	if err != nil {
+		return err
+	}
+
+	if common.json {
+		// Eine Zeile = ein Objekt Spalte -> Wert
+		records := make([]map[string]any, len(rows))
+		for i, row := range rows {
+			record := make(map[string]any, len(columns))
+			for j, column := range columns {
+				record[column] = row[j]
+			}
+			records[i] = record
+		}
+		return printJSON(c.stdout, records)
+	}
+
+	t := newTable(c.stdout, columns...)
+	for _, row := range rows {
+		t.row(row...)
+	}
+	return t.flush()
+}
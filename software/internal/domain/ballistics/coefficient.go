@@ -0,0 +1,116 @@
+package ballistics
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BCUnit ist die Einheit, in der ein BC angegeben ist.
+//
+// Ein BC ist eine Querschnittsbelastung (Masse / Fläche). Hersteller geben
+// ihn fast immer in lb/in² an, ohne die Einheit zu nennen; europäische
+// Datenblätter nutzen teils kg/m² (Faktor 703.07).
+type BCUnit string
+
+const (
+	// BCUnitImperial ist lb/in², die übliche Herstellerangabe.
+	BCUnitImperial BCUnit = "lb/in2"
+
+	// BCUnitMetric ist kg/m².
+	BCUnitMetric BCUnit = "kg/m2"
+)
+
+// BCUnits listet alle unterstützten Einheiten.
+var BCUnits = []BCUnit{BCUnitImperial, BCUnitMetric}
+
+// ParseBCUnit liest eine Einheit; "" ergibt lb/in².
+// "lb/in²" und "kg/m²" werden wie "lb/in2" und "kg/m2" akzeptiert.
+func ParseBCUnit(name string) (BCUnit, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	normalized = strings.ReplaceAll(normalized, "²", "2")
+	switch BCUnit(normalized) {
+	case "":
+		return BCUnitImperial, nil
+	case BCUnitImperial, BCUnitMetric:
+		return BCUnit(normalized), nil
+	}
+	return "", fmt.Errorf("unknown BC unit: %s (supported: lb/in2, kg/m2)", name)
+}
+
+// ToImperial rechnet einen BC in dieser Einheit nach lb/in² um.
+// Unbekannte Einheiten (auch "") gelten als lb/in².
+func (u BCUnit) ToImperial(bc float64) float64 {
+	if u == BCUnitMetric {
+		return bc / bcToSI
+	}
+	return bc
+}
+
+// FromImperial rechnet einen BC in lb/in² in diese Einheit um.
+func (u BCUnit) FromImperial(bc float64) float64 {
+	if u == BCUnitMetric {
+		return bc * bcToSI
+	}
+	return bc
+}
+
+// String implementiert fmt.Stringer.
+func (u BCUnit) String() string {
+	return string(u)
+}
+
+// bcRange ist der plausible Bereich eines BC in lb/in².
+type bcRange struct {
+	min, max float64
+}
+
+// bcRanges sind die plausiblen BCs je Modell (in lb/in²).
+//
+// Die Grenzen fangen vor allem Einheitenfehler ab: Ein kg/m²-Wert, der als
+// lb/in² eingegeben wird, ist 703-mal zu groß. Realistische Werte:
+//   - G1: Diabolos ab ~0.01, .50 BMG ~1.05
+//   - G7: lange Boattail-Geschosse 0.1-0.5
+//   - GA: Diabolos 0.01-0.06, schwere Diabolos bis ~0.1
+//   - Custom: hängt von der eigenen Kurve ab, daher weit
+var bcRanges = map[DragModel]bcRange{
+	G1:     {min: 0.005, max: 1.2},
+	G7:     {min: 0.005, max: 1.0},
+	GA:     {min: 0.005, max: 0.2},
+	Custom: {min: 0.001, max: 2.0},
+}
+
+// ValidateBC prüft einen BC gegen sein Modell.
+//
+// bc 0 bedeutet "unbekannt" und ist immer erlaubt (die Flugbahn braucht ihn,
+// die Energie nicht). Custom verlangt eine gültige Kurve, die übrigen
+// Modelle keine.
+func ValidateBC(bc float64, model DragModel, unit BCUnit, table DragTable) error {
+	if !model.Valid() {
+		return fmt.Errorf("unknown drag model: %s", model)
+	}
+	if _, err := ParseBCUnit(string(unit)); err != nil {
+		return err
+	}
+	if model == Custom {
+		if err := table.Validate(); err != nil {
+			return err
+		}
+	} else if len(table) > 0 {
+		return fmt.Errorf("drag table is only allowed for drag model %s", Custom)
+	}
+
+	if bc < 0 {
+		return fmt.Errorf("ballistic coefficient cannot be negative, got: %.4f", bc)
+	}
+	if bc == 0 {
+		return nil
+	}
+
+	limits := bcRanges[model]
+	imperial := unit.ToImperial(bc)
+	if imperial < limits.min || imperial > limits.max {
+		return fmt.Errorf("%s ballistic coefficient must be between %.4g and %.4g %s, got: %.4f",
+			model, unit.FromImperial(limits.min), unit.FromImperial(limits.max), unit, bc)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package ballistics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBCUnit(t *testing.T) {
+	if unit, err := ParseBCUnit("kg/m²"); err != nil || unit != BCUnitMetric {
+		t.Errorf("ParseBCUnit(kg/m²) = %v, %v", unit, err)
+	}
+	if unit, _ := ParseBCUnit(""); unit != BCUnitImperial {
+		t.Errorf("ParseBCUnit(\"\") = %v, want lb/in2", unit)
+	}
+	if _, err := ParseBCUnit("g/cm2"); err == nil {
+		t.Error("ParseBCUnit(g/cm2) expected error")
+	}
+
+	// 0.024 lb/in² = 16.87 kg/m²
+	metric := BCUnitMetric.FromImperial(0.024)
+	if math.Abs(metric-16.874) > 0.001 {
+		t.Errorf("0.024 lb/in² = %.3f kg/m², want 16.874", metric)
+	}
+	if back := BCUnitMetric.ToImperial(metric); math.Abs(back-0.024) > 1e-12 {
+		t.Errorf("roundtrip = %v, want 0.024", back)
+	}
+}
+
+func TestValidateBC(t *testing.T) {
+	table := DragTable{{Mach: 0.3, Cd: 0.25}, {Mach: 0.8, Cd: 0.3}}
+
+	tests := []struct {
+		name    string
+		bc      float64
+		model   DragModel
+		unit    BCUnit
+		table   DragTable
+		wantErr bool
+	}{
+		{"unknown BC", 0, GA, BCUnitImperial, nil, false},
+		{"pellet G1", 0.024, G1, BCUnitImperial, nil, false},
+		{"pellet GA metric", 16.9, GA, BCUnitMetric, nil, false},
+		{"rifle G7", 0.305, G7, BCUnitImperial, nil, false},
+		{"custom with table", 1.0, Custom, BCUnitImperial, table, false},
+		{"negative", -0.1, G1, BCUnitImperial, nil, true},
+		{"G1 too large", 1.5, G1, BCUnitImperial, nil, true},
+		{"GA too large", 0.3, GA, BCUnitImperial, nil, true},
+		{"kg/m² entered as lb/in²", 16.9, GA, BCUnitImperial, nil, true},
+		{"lb/in² entered as kg/m²", 0.024, G1, BCUnitMetric, nil, true},
+		{"unknown model", 0.024, "G9", BCUnitImperial, nil, true},
+		{"unknown unit", 0.024, G1, "g/cm2", nil, true},
+		{"custom without table", 1.0, Custom, BCUnitImperial, nil, true},
+		{"table on standard model", 0.024, G1, BCUnitImperial, table, true},
+		{"custom table descending", 1.0, Custom, BCUnitImperial, DragTable{{Mach: 0.8, Cd: 0.3}, {Mach: 0.3, Cd: 0.25}}, true},
+		{"custom table zero Cd", 1.0, Custom, BCUnitImperial, DragTable{{Mach: 0.3, Cd: 0}, {Mach: 0.8, Cd: 0.3}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBC(tt.bc, tt.model, tt.unit, tt.table)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBC() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package ballistics
+
+import (
+	"fmt"
+	"math"
+	"metric-neo/internal/domain/valueobjects"
+)
+
+// FitInput sind die Eingaben einer BC-Bestimmung aus zwei Geschwindigkeiten.
+//
+// Near ist die Geschwindigkeit am ersten Chronographen (meist an der
+// Mündung), Far die am zweiten, DistanceMeters weiter. Aus dem
+// Geschwindigkeitsverlust folgt der BC, mit dem der Solver dieselbe Far
+// ergibt - er gilt nur für das gewählte Modell.
+type FitInput struct {
+	Near           valueobjects.Velocity
+	Far            valueobjects.Velocity
+	DistanceMeters float64
+	DragModel      DragModel
+	DragTable      DragTable // nur bei DragModel Custom
+	Atmosphere     valueobjects.Atmosphere
+}
+
+// fitIterations reicht für eine relative Genauigkeit < 1e-6 über den ganzen
+// plausiblen Bereich eines Modells (Intervallhalbierung im Logarithmus).
+const fitIterations = 40
+
+// FitBC bestimmt den BC (lb/in²), mit dem das Projektil auf DistanceMeters
+// von Near auf Far abbremst.
+//
+// Die Geschwindigkeit in der Entfernung steigt streng mit dem BC, daher
+// genügt eine Intervallhalbierung über den plausiblen Bereich des Modells
+// (siehe ValidateBC). Liegt das Ergebnis außerhalb, ist meist die Distanz
+// oder eine der Messungen falsch - das ist ein Fehler, kein Randwert.
+func FitBC(in FitInput) (float64, error) {
+	if in.DistanceMeters <= 0 {
+		return 0, fmt.Errorf("chronograph distance must be positive, got: %.2f m", in.DistanceMeters)
+	}
+	if in.Far.MetersPerSecond() >= in.Near.MetersPerSecond() {
+		return 0, fmt.Errorf("far velocity (%.2f m/s) must be lower than near velocity (%.2f m/s)",
+			in.Far.MetersPerSecond(), in.Near.MetersPerSecond())
+	}
+	if !in.DragModel.Valid() {
+		return 0, fmt.Errorf("unknown drag model: %s", in.DragModel)
+	}
+
+	// Geschwindigkeit in der Entfernung (0 = nicht erreicht)
+	velocityAt := func(bc float64) (float64, error) {
+		trajectory, err := Solve(Input{
+			MuzzleVelocity: in.Near,
+			BC:             bc,
+			DragModel:      in.DragModel,
+			DragTable:      in.DragTable,
+			Atmosphere:     in.Atmosphere,
+			MaxRangeMeters: in.DistanceMeters,
+			StepMeters:     in.DistanceMeters,
+		})
+		if err != nil {
+			return 0, err
+		}
+		if len(trajectory.Points) < 2 {
+			return 0, nil
+		}
+		return trajectory.Points[1].Velocity.MetersPerSecond(), nil
+	}
+
+	far := in.Far.MetersPerSecond()
+	limits := bcRanges[in.DragModel]
+	low, high := math.Log(limits.min), math.Log(limits.max)
+
+	vLow, err := velocityAt(limits.min)
+	if err != nil {
+		return 0, err
+	}
+	if vLow > far {
+		return 0, fmt.Errorf("velocity loss too large for a %s BC: %.2f -> %.2f m/s over %.1f m needs BC < %.4g",
+			in.DragModel, in.Near.MetersPerSecond(), far, in.DistanceMeters, limits.min)
+	}
+	vHigh, err := velocityAt(limits.max)
+	if err != nil {
+		return 0, err
+	}
+	if vHigh < far {
+		return 0, fmt.Errorf("velocity loss too small for a %s BC: %.2f -> %.2f m/s over %.1f m needs BC > %.4g",
+			in.DragModel, in.Near.MetersPerSecond(), far, in.DistanceMeters, limits.max)
+	}
+
+	for i := 0; i < fitIterations; i++ {
+		mid := (low + high) / 2
+		v, err := velocityAt(math.Exp(mid))
+		if err != nil {
+			return 0, err
+		}
+		if v < far {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return math.Exp((low + high) / 2), nil
+}
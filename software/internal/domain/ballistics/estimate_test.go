@@ -0,0 +1,82 @@
+package ballistics
+
+import (
+	"math"
+	"metric-neo/internal/domain/valueobjects"
+	"testing"
+)
+
+// KRITISCHER TEST: FitBC muss den BC wiederfinden, mit dem Solve gerechnet hat
+func TestFitBC_RoundTrip(t *testing.T) {
+	tests := []struct {
+		model    DragModel
+		bc       float64
+		distance float64
+	}{
+		{GA, 0.024, 20},
+		{G1, 0.030, 10},
+		{G7, 0.250, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model.String(), func(t *testing.T) {
+			near := valueobjects.Velocity(280.0)
+			trajectory, err := Solve(Input{
+				MuzzleVelocity: near,
+				BC:             tt.bc,
+				DragModel:      tt.model,
+				MaxRangeMeters: tt.distance,
+				StepMeters:     tt.distance,
+			})
+			if err != nil {
+				t.Fatalf("Solve() failed: %v", err)
+			}
+
+			bc, err := FitBC(FitInput{
+				Near:           near,
+				Far:            trajectory.Points[1].Velocity,
+				DistanceMeters: tt.distance,
+				DragModel:      tt.model,
+			})
+			if err != nil {
+				t.Fatalf("FitBC() failed: %v", err)
+			}
+			if math.Abs(bc-tt.bc)/tt.bc > 1e-4 {
+				t.Errorf("FitBC() = %.6f, want %.6f", bc, tt.bc)
+			}
+		})
+	}
+}
+
+func TestFitBC_Invalid(t *testing.T) {
+	base := FitInput{
+		Near:           valueobjects.Velocity(280.0),
+		Far:            valueobjects.Velocity(260.0),
+		DistanceMeters: 20,
+		DragModel:      G1,
+	}
+
+	tests := []struct {
+		name   string
+		modify func(in *FitInput)
+	}{
+		{"zero distance", func(in *FitInput) { in.DistanceMeters = 0 }},
+		{"far not slower", func(in *FitInput) { in.Far = in.Near }},
+		{"unknown model", func(in *FitInput) { in.DragModel = "G5" }},
+		{"custom without table", func(in *FitInput) { in.DragModel = Custom }},
+		// 0.1 m/s auf 20 m schafft kein plausibler G1-BC
+		{"loss too small", func(in *FitInput) { in.Far = valueobjects.Velocity(279.9) }},
+		// 280 -> 30 m/s auf 20 m auch nicht
+		{"loss too large", func(in *FitInput) { in.Far = valueobjects.Velocity(30.0) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := base
+			tt.modify(&in)
+			if _, err := FitBC(in); err == nil {
+				t.Error("FitBC() should fail")
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+// Package ballistics berechnet die Flugbahn eines Projektils (Außenballistik).
+//
+// Das Modell ist ein Punktmassen-Modell: Das Projektil wird als Punkt ohne
+// Drall und Lage betrachtet, auf den nur Schwerkraft und Luftwiderstand
+// wirken. Der Luftwiderstand kommt aus der Referenzkurve des DragModel,
+// skaliert mit dem BC und der tatsächlichen Luftdichte. Für Luftgewehr und
+// Kleinkaliber auf übliche Distanzen ist das genauer als jede Messung von
+// Visierhöhe oder Entfernung; Coriolis- und Spin-Drift fehlen bewusst.
+//
+// DOMAIN MODEL: Siehe docs/specs/domain-model.md Abschnitt 5.5
+package ballistics
+
+import (
+	"fmt"
+	"math"
+	"metric-neo/internal/domain/valueobjects"
+)
+
+const (
+	gravity = 9.80665 // m/s²
+
+	// bcToSI rechnet einen BC in lb/in² (Herstellerangabe) in kg/m² um.
+	bcToSI = 703.0696
+
+	// timeStep ist der Integrationsschritt. 0.1 ms reicht für mm-Genauigkeit
+	// auf 1000 m und ist schnell genug für eine Tabelle pro Klick.
+	timeStep = 0.0001
+
+	// minVelocityMPS beendet die Rechnung, wenn das Projektil "steht".
+	minVelocityMPS = 10.0
+
+	// maxFlightTime begrenzt die Rechnung bei unsinnigen Eingaben.
+	maxFlightTime = 10.0 // s
+)
+
+// Input sind die Eingaben einer Flugbahnberechnung.
+//
+// Entfernungen sind in Metern, weil Tabellen nach Metern gerechnet werden.
+// Die Visierlinie ist horizontal (Schuss in der Ebene).
+type Input struct {
+	MuzzleVelocity valueobjects.Velocity
+	Weight         valueobjects.Mass // nur für die Energie
+	BC             float64           // bezogen auf DragModel, in lb/in²
+	DragModel      DragModel
+	DragTable      DragTable // nur bei DragModel Custom
+
+	// SightHeight ist der Abstand Laufseelenachse <-> Visierlinie.
+	SightHeight valueobjects.Length
+
+	// ZeroRangeMeters ist die Fleckschussentfernung (Visierlinie schneidet
+	// die Flugbahn). 0 = Laufachse parallel zur Visierlinie.
+	ZeroRangeMeters float64
+
+	Atmosphere valueobjects.Atmosphere
+
+	// CrosswindMPS ist der Seitenwind, positiv = von links (Abdrift nach rechts).
+	CrosswindMPS float64
+
+	MaxRangeMeters float64
+	StepMeters     float64
+}
+
+// Point ist eine Zeile der Flugbahntabelle.
+type Point struct {
+	RangeMeters float64
+
+	// PathMM ist die Höhe relativ zur Visierlinie (negativ = darunter).
+	PathMM float64
+
+	// DropMM ist der Fall relativ zur verlängerten Laufachse (immer <= 0).
+	DropMM float64
+
+	// WindDriftMM ist die seitliche Abdrift (positiv = nach rechts).
+	WindDriftMM float64
+
+	TimeOfFlightSeconds float64
+	Velocity            valueobjects.Velocity
+	Energy              valueobjects.Energy
+}
+
+// Trajectory ist das Ergebnis einer Berechnung.
+type Trajectory struct {
+	Input Input
+
+	// ZeroAngleMRAD ist der Winkel zwischen Laufachse und Visierlinie.
+	ZeroAngleMRAD float64
+
+	AirDensity   float64 // kg/m³, tatsächlich verwendet
+	SpeedOfSound float64 // m/s
+
+	Points []Point
+}
+
+// Validate prüft die Eingaben.
+func (in Input) Validate() error {
+	if in.MuzzleVelocity.MetersPerSecond() < minVelocityMPS {
+		return fmt.Errorf("muzzle velocity must be at least %.0f m/s, got: %.2f m/s", minVelocityMPS, in.MuzzleVelocity.MetersPerSecond())
+	}
+	if in.BC <= 0 {
+		return fmt.Errorf("ballistic coefficient must be positive, got: %.4f", in.BC)
+	}
+	if !in.DragModel.Valid() {
+		return fmt.Errorf("unknown drag model: %s", in.DragModel)
+	}
+	if in.DragModel == Custom {
+		if err := in.DragTable.Validate(); err != nil {
+			return err
+		}
+	}
+	if in.ZeroRangeMeters < 0 {
+		return fmt.Errorf("zero range cannot be negative, got: %.1f m", in.ZeroRangeMeters)
+	}
+	if in.StepMeters <= 0 {
+		return fmt.Errorf("range step must be positive, got: %.1f m", in.StepMeters)
+	}
+	if in.MaxRangeMeters < in.StepMeters {
+		return fmt.Errorf("max range (%.1f m) must be at least one step (%.1f m)", in.MaxRangeMeters, in.StepMeters)
+	}
+	if in.MaxRangeMeters/in.StepMeters > 10000 {
+		return fmt.Errorf("too many table rows: %.0f m in steps of %.1f m", in.MaxRangeMeters, in.StepMeters)
+	}
+	return nil
+}
+
+// Solve berechnet die Flugbahntabelle.
+//
+// Ablauf: Zuerst wird der Abgangswinkel gesucht, bei dem die Flugbahn die
+// Visierlinie in ZeroRangeMeters schneidet; mit diesem Winkel wird dann die
+// Tabelle bis MaxRangeMeters gerechnet. Erreicht das Projektil eine
+// Entfernung nicht (zu langsam), endet die Tabelle vorher.
+func Solve(in Input) (*Trajectory, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	density, ok := in.Atmosphere.AirDensity()
+	if !ok {
+		// Ohne Druck und Höhe: Meereshöhe annehmen
+		atmosphere := in.Atmosphere
+		seaLevel := valueobjects.Altitude(0)
+		atmosphere.Altitude = &seaLevel
+		density, _ = atmosphere.AirDensity()
+	}
+
+	s := solver{
+		in:           in,
+		density:      density,
+		speedOfSound: in.Atmosphere.SpeedOfSound(),
+		sightHeight:  in.SightHeight.Meters(),
+	}
+
+	angle, err := s.zeroAngle()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Trajectory{
+		Input:         in,
+		ZeroAngleMRAD: angle * 1000,
+		AirDensity:    density,
+		SpeedOfSound:  s.speedOfSound,
+		Points:        s.table(angle),
+	}, nil
+}
+
+// solver hält die umgerechneten Eingaben einer Berechnung.
+type solver struct {
+	in           Input
+	density      float64
+	speedOfSound float64
+	sightHeight  float64 // m
+}
+
+// state ist der Zustand des Projektils. Die Laufachse beginnt im Ursprung,
+// die Visierlinie liegt sightHeight darüber (y nach oben, z nach rechts).
+type state struct {
+	t, x, y, z, vx, vy, vz float64
+}
+
+// deceleration ist die Verzögerung durch Luftwiderstand je m/s Relativgeschwindigkeit.
+//
+// Formel: a = ρ · v² · Cd(M) · π / (8 · BC)   (BC in kg/m²)
+// Geteilt durch v ergibt den Faktor, der mit jeder Geschwindigkeitskomponente
+// multipliziert wird.
+func (s *solver) deceleration(speed float64) float64 {
+	mach := speed / s.speedOfSound
+	cd := s.in.DragModel.DragCoefficient(mach)
+	if s.in.DragModel == Custom {
+		cd = s.in.DragTable.DragCoefficient(mach)
+	}
+	return s.density * speed * cd * math.Pi / (8 * s.in.BC * bcToSI)
+}
+
+// step integriert einen Zeitschritt (Runge-Kutta 4. Ordnung).
+func (s *solver) step(st state, dt float64) state {
+	wind := s.in.CrosswindMPS
+
+	derive := func(vx, vy, vz float64) (ax, ay, az float64) {
+		// Widerstand wirkt gegen die Bewegung relativ zur Luft
+		rx, ry, rz := vx, vy, vz-wind
+		k := s.deceleration(math.Sqrt(rx*rx + ry*ry + rz*rz))
+		return -k * rx, -k*ry - gravity, -k * rz
+	}
+
+	ax1, ay1, az1 := derive(st.vx, st.vy, st.vz)
+	ax2, ay2, az2 := derive(st.vx+ax1*dt/2, st.vy+ay1*dt/2, st.vz+az1*dt/2)
+	ax3, ay3, az3 := derive(st.vx+ax2*dt/2, st.vy+ay2*dt/2, st.vz+az2*dt/2)
+	ax4, ay4, az4 := derive(st.vx+ax3*dt, st.vy+ay3*dt, st.vz+az3*dt)
+
+	next := st
+	next.t += dt
+	next.x += dt * (st.vx + dt/6*(ax1+ax2+ax3))
+	next.y += dt * (st.vy + dt/6*(ay1+ay2+ay3))
+	next.z += dt * (st.vz + dt/6*(az1+az2+az3))
+	next.vx += dt / 6 * (ax1 + 2*ax2 + 2*ax3 + ax4)
+	next.vy += dt / 6 * (ay1 + 2*ay2 + 2*ay3 + ay4)
+	next.vz += dt / 6 * (az1 + 2*az2 + 2*az3 + az4)
+	return next
+}
+
+// fly rechnet mit dem Abgangswinkel und ruft visit für jede Zielentfernung
+// (aufsteigend) mit dem interpolierten Zustand auf. Ergebnis false = die
+// Entfernung wurde nicht erreicht.
+func (s *solver) fly(angle float64, ranges []float64, visit func(i int, st state)) bool {
+	v0 := s.in.MuzzleVelocity.MetersPerSecond()
+	st := state{vx: v0 * math.Cos(angle), vy: v0 * math.Sin(angle)}
+
+	next := 0
+	for next < len(ranges) {
+		if st.t > maxFlightTime || math.Hypot(st.vx, st.vy) < minVelocityMPS || st.vx <= 0 {
+			return false
+		}
+
+		following := s.step(st, timeStep)
+		for next < len(ranges) && following.x >= ranges[next] {
+			// Linear zwischen den Schritten auf die exakte Entfernung
+			f := (ranges[next] - st.x) / (following.x - st.x)
+			visit(next, state{
+				t:  st.t + f*(following.t-st.t),
+				x:  ranges[next],
+				y:  st.y + f*(following.y-st.y),
+				z:  st.z + f*(following.z-st.z),
+				vx: st.vx + f*(following.vx-st.vx),
+				vy: st.vy + f*(following.vy-st.vy),
+				vz: st.vz + f*(following.vz-st.vz),
+			})
+			next++
+		}
+		st = following
+	}
+	return true
+}
+
+// zeroAngle sucht den Abgangswinkel für die Fleckschussentfernung (Sekantenverfahren).
+func (s *solver) zeroAngle() (float64, error) {
+	zero := s.in.ZeroRangeMeters
+	if zero == 0 {
+		return 0, nil
+	}
+
+	// Fehler in Metern an der Fleckschussentfernung
+	miss := func(angle float64) (float64, bool) {
+		var y float64
+		ok := s.fly(angle, []float64{zero}, func(_ int, st state) { y = st.y })
+		return y - s.sightHeight, ok
+	}
+
+	a0, a1 := 0.0, s.sightHeight/zero
+	e0, ok0 := miss(a0)
+	e1, ok1 := miss(a1)
+	if !ok0 || !ok1 {
+		return 0, fmt.Errorf("zero range %.0f m is out of reach", zero)
+	}
+	for i := 0; i < 30 && math.Abs(e1) > 1e-6; i++ {
+		if e1 == e0 {
+			break
+		}
+		a0, a1 = a1, a1-e1*(a1-a0)/(e1-e0)
+		e0 = e1
+		var ok bool
+		if e1, ok = miss(a1); !ok {
+			return 0, fmt.Errorf("zero range %.0f m is out of reach", zero)
+		}
+	}
+	if math.Abs(e1) > 1e-4 {
+		return 0, fmt.Errorf("could not zero at %.0f m", zero)
+	}
+	return a1, nil
+}
+
+// table rechnet die Tabellenzeilen (ab Mündung) mit dem Abgangswinkel.
+func (s *solver) table(angle float64) []Point {
+	ranges := []float64{0}
+	for r := s.in.StepMeters; r <= s.in.MaxRangeMeters+1e-9; r += s.in.StepMeters {
+		ranges = append(ranges, r)
+	}
+
+	points := make([]Point, 0, len(ranges))
+	s.fly(angle, ranges, func(_ int, st state) {
+		speed := math.Sqrt(st.vx*st.vx + st.vy*st.vy + st.vz*st.vz)
+		velocity := valueobjects.Velocity(speed)
+		points = append(points, Point{
+			RangeMeters:         st.x,
+			PathMM:              (st.y - s.sightHeight) * 1000,
+			DropMM:              (st.y - st.x*math.Tan(angle)) * 1000,
+			WindDriftMM:         st.z * 1000,
+			TimeOfFlightSeconds: st.t,
+			Velocity:            velocity,
+			Energy:              valueobjects.CalculateEnergy(s.in.Weight, velocity),
+		})
+	})
+	return points
+}
@@ -0,0 +1,199 @@
+package ballistics
+
+import (
+	"math"
+	"metric-neo/internal/domain/valueobjects"
+	"testing"
+)
+
+// airRifleInput ist ein typisches Luftgewehr: JSB Exact 0.547 g mit 175 m/s.
+func airRifleInput() Input {
+	return Input{
+		MuzzleVelocity:  valueobjects.Velocity(175.0),
+		Weight:          valueobjects.Mass(0.547),
+		BC:              0.024,
+		DragModel:       G1,
+		SightHeight:     valueobjects.Length(50.0),
+		ZeroRangeMeters: 25,
+		MaxRangeMeters:  50,
+		StepMeters:      5,
+	}
+}
+
+// KRITISCHER TEST: Ohne Luftwiderstand muss die Lösung der Wurfparabel entsprechen
+func TestSolve_MatchesVacuumWithoutDrag(t *testing.T) {
+	in := airRifleInput()
+	in.BC = 1e9 // praktisch kein Widerstand
+	in.ZeroRangeMeters = 0
+	in.MuzzleVelocity = valueobjects.Velocity(100.0)
+	in.MaxRangeMeters = 100
+	in.StepMeters = 50
+
+	trajectory, err := Solve(in)
+	if err != nil {
+		t.Fatalf("Solve() failed: %v", err)
+	}
+	last := trajectory.Points[len(trajectory.Points)-1]
+
+	// 100 m mit 100 m/s = 1 s Flugzeit, Fall = g·t²/2
+	if math.Abs(last.TimeOfFlightSeconds-1.0) > 1e-6 {
+		t.Errorf("time of flight = %.6f s, want 1.0", last.TimeOfFlightSeconds)
+	}
+	if math.Abs(last.DropMM-(-gravity/2*1000)) > 0.1 {
+		t.Errorf("drop = %.2f mm, want %.2f", last.DropMM, -gravity/2*1000)
+	}
+	if math.Abs(last.Velocity.MetersPerSecond()-math.Hypot(100, gravity)) > 1e-3 {
+		t.Errorf("velocity = %.4f m/s, want %.4f", last.Velocity.MetersPerSecond(), math.Hypot(100, gravity))
+	}
+}
+
+func TestSolve_ZeroRange(t *testing.T) {
+	trajectory, err := Solve(airRifleInput())
+	if err != nil {
+		t.Fatalf("Solve() failed: %v", err)
+	}
+	if len(trajectory.Points) != 11 {
+		t.Fatalf("got %d points, want 11 (0..50 m in 5 m steps)", len(trajectory.Points))
+	}
+
+	muzzle := trajectory.Points[0]
+	if muzzle.PathMM != -50 || muzzle.Energy.Joules() < 8.37 || muzzle.Energy.Joules() > 8.38 {
+		t.Errorf("muzzle point = %+v, want path -50 mm and 8.38 J", muzzle)
+	}
+
+	zero := trajectory.Points[5]
+	if zero.RangeMeters != 25 || math.Abs(zero.PathMM) > 0.01 {
+		t.Errorf("path at zero range = %.3f mm, want 0", zero.PathMM)
+	}
+
+	// Luftwiderstand: Geschwindigkeit und Energie nehmen ab, Flugzeit zu
+	for i := 1; i < len(trajectory.Points); i++ {
+		prev, p := trajectory.Points[i-1], trajectory.Points[i]
+		if p.Velocity >= prev.Velocity || p.TimeOfFlightSeconds <= prev.TimeOfFlightSeconds {
+			t.Errorf("point %d does not slow down: %+v after %+v", i, p, prev)
+		}
+	}
+	if v := trajectory.Points[10].Velocity.MetersPerSecond(); v < 120 || v > 140 {
+		t.Errorf("velocity at 50 m = %.1f m/s, want about 130", v)
+	}
+}
+
+// Abdrift nach der Didion-Formel: Wind · (Flugzeit - Entfernung / v0)
+func TestSolve_WindDriftFollowsLagTime(t *testing.T) {
+	in := airRifleInput()
+	in.CrosswindMPS = 2.0
+
+	trajectory, err := Solve(in)
+	if err != nil {
+		t.Fatalf("Solve() failed: %v", err)
+	}
+	for _, p := range trajectory.Points[1:] {
+		lag := p.TimeOfFlightSeconds - p.RangeMeters/in.MuzzleVelocity.MetersPerSecond()
+		want := in.CrosswindMPS * lag * 1000
+		if math.Abs(p.WindDriftMM-want) > 0.05*want {
+			t.Errorf("drift at %.0f m = %.1f mm, want about %.1f", p.RangeMeters, p.WindDriftMM, want)
+		}
+	}
+}
+
+func TestSolve_ThinAirCarriesFurther(t *testing.T) {
+	dense, _ := Solve(airRifleInput())
+
+	in := airRifleInput()
+	altitude := valueobjects.Altitude(2000)
+	in.Atmosphere = valueobjects.Atmosphere{Altitude: &altitude}
+	thin, err := Solve(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if thin.AirDensity >= dense.AirDensity {
+		t.Errorf("air density at 2000 m = %.3f, want below %.3f", thin.AirDensity, dense.AirDensity)
+	}
+	if thin.Points[10].Velocity <= dense.Points[10].Velocity {
+		t.Error("thinner air should leave more velocity at 50 m")
+	}
+}
+
+func TestSolve_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Input)
+	}{
+		{"zero BC", func(in *Input) { in.BC = 0 }},
+		{"unknown drag model", func(in *Input) { in.DragModel = "G9" }},
+		{"custom without table", func(in *Input) { in.DragModel = Custom }},
+		{"no step", func(in *Input) { in.StepMeters = 0 }},
+		{"max range below step", func(in *Input) { in.MaxRangeMeters = 2 }},
+		{"negative zero range", func(in *Input) { in.ZeroRangeMeters = -10 }},
+		{"zero out of reach", func(in *Input) { in.ZeroRangeMeters = 5000 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := airRifleInput()
+			tt.modify(&in)
+			if _, err := Solve(in); err == nil {
+				t.Error("Solve() expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestDragModel(t *testing.T) {
+	if model, err := ParseDragModel("g7"); err != nil || model != G7 {
+		t.Errorf("ParseDragModel(g7) = %v, %v", model, err)
+	}
+	if model, _ := ParseDragModel(""); model != G1 {
+		t.Errorf("ParseDragModel(\"\") = %v, want G1", model)
+	}
+	if _, err := ParseDragModel("G9"); err == nil {
+		t.Error("ParseDragModel(G9) expected error")
+	}
+
+	// Stützpunkt und Interpolation
+	if cd := G1.DragCoefficient(1.0); cd != 0.4805 {
+		t.Errorf("G1 Cd(1.0) = %v, want 0.4805", cd)
+	}
+	if cd := G7.DragCoefficient(0.7125); math.Abs(cd-0.12045) > 1e-9 {
+		t.Errorf("G7 Cd(0.7125) = %v, want 0.12045", cd)
+	}
+	// Außerhalb der Tabelle konstant
+	if cd := GA.DragCoefficient(3.0); cd != 0.5200 {
+		t.Errorf("GA Cd(3.0) = %v, want 0.52", cd)
+	}
+}
+
+// Jede eingebaute Referenzkurve muss eine gültige Tabelle sein
+func TestDragModels_TablesValid(t *testing.T) {
+	for _, model := range DragModels {
+		if model == Custom {
+			continue
+		}
+		if err := dragTables[model].Validate(); err != nil {
+			t.Errorf("%s table: %v", model, err)
+		}
+	}
+}
+
+// Eine eigene Kurve mit den G1-Werten muss exakt wie G1 rechnen
+func TestSolve_CustomTable(t *testing.T) {
+	in := airRifleInput()
+	want, err := Solve(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in.DragModel = Custom
+	in.DragTable = append(DragTable(nil), g1Table...)
+	got, err := Solve(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	last := len(want.Points) - 1
+	if got.Points[last].PathMM != want.Points[last].PathMM {
+		t.Errorf("custom path at %.0f m = %.3f mm, G1 = %.3f mm",
+			want.Points[last].RangeMeters, got.Points[last].PathMM, want.Points[last].PathMM)
+	}
+}
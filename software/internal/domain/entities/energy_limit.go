@@ -0,0 +1,152 @@
+package entities
+
+import (
+	"fmt"
+	"metric-neo/internal/domain/valueobjects"
+	"sort"
+	"strings"
+)
+
+// EnergyLimit ist eine gesetzliche (oder selbst gesetzte) Obergrenze der
+// Mündungsenergie für eine Sportgeräte-Kategorie.
+//
+// Beispiel: In Deutschland sind Druckluftwaffen mit F-Zeichen auf 7.5 J
+// begrenzt. Wer seine Waffe frei besitzen will, muss die Grenze mit jedem
+// Schuss einhalten - die Prüfung hier ist der Nachweis.
+//
+// DOMAIN MODEL: Siehe docs/specs/domain-model.md Abschnitt 5.7
+type EnergyLimit struct {
+	Category ProfileCategory
+	Max      valueobjects.Energy
+	Label    string // z.B. "DE F-Zeichen"
+}
+
+// EnergyWarnRatio ist der Anteil der Grenze, ab dem ein Wert als "nahe an
+// der Grenze" gilt (95 %: bei 7.5 J ab 7.125 J).
+const EnergyWarnRatio = 0.95
+
+// EnergyVerdict ist das Ergebnis einer Prüfung gegen eine EnergyLimit.
+type EnergyVerdict string
+
+const (
+	// EnergyWithinLimit: unter EnergyWarnRatio der Grenze
+	EnergyWithinLimit EnergyVerdict = "ok"
+
+	// EnergyNearLimit: ab EnergyWarnRatio, aber nicht darüber
+	EnergyNearLimit EnergyVerdict = "near"
+
+	// EnergyOverLimit: über der Grenze
+	EnergyOverLimit EnergyVerdict = "exceeded"
+)
+
+// Severity ordnet die Urteile (ok < near < exceeded).
+func (v EnergyVerdict) Severity() int {
+	switch v {
+	case EnergyNearLimit:
+		return 1
+	case EnergyOverLimit:
+		return 2
+	}
+	return 0
+}
+
+// Validate prüft Kategorie und Grenze.
+func (l EnergyLimit) Validate() error {
+	if !l.Category.IsValid() {
+		return fmt.Errorf("invalid profile category: %s", l.Category)
+	}
+	if l.Max.Joules() <= 0 {
+		return fmt.Errorf("energy limit must be positive, got: %.2f J", l.Max.Joules())
+	}
+	return nil
+}
+
+// WarnThreshold ist die Energie, ab der ein Wert nahe an der Grenze liegt.
+func (l EnergyLimit) WarnThreshold() valueobjects.Energy {
+	return valueobjects.Energy(l.Max.Joules() * EnergyWarnRatio)
+}
+
+// Check prüft eine Energie gegen die Grenze. Genau auf der Grenze ist erlaubt.
+func (l EnergyLimit) Check(energy valueobjects.Energy) EnergyVerdict {
+	switch {
+	case energy.Joules() > l.Max.Joules():
+		return EnergyOverLimit
+	case energy.Joules() >= l.WarnThreshold().Joules():
+		return EnergyNearLimit
+	}
+	return EnergyWithinLimit
+}
+
+// EnergyLimitPresets sind die Grenzen je Rechtsraum (Schlüssel: Länderkürzel).
+//
+// Nur frei erwerbbare Kategorien; Bogen und Feuerwaffe haben keine
+// Energiegrenze, die sich mit einem Chronographen nachweisen ließe.
+var EnergyLimitPresets = map[string][]EnergyLimit{
+	// WaffG Anlage 2: Druckluftwaffen mit F-Zeichen bis 7.5 J
+	"DE": {
+		{Category: CategoryAirRifle, Max: 7.5, Label: "DE F-Zeichen"},
+		{Category: CategoryAirPistol, Max: 7.5, Label: "DE F-Zeichen"},
+	},
+	// Firearms Act: 12 ft·lbf (Gewehr) bzw. 6 ft·lbf (Pistole) ohne Erlaubnis
+	"UK": {
+		{Category: CategoryAirRifle, Max: 16.27, Label: "UK 12 ft·lbf"},
+		{Category: CategoryAirPistol, Max: 8.13, Label: "UK 6 ft·lbf"},
+	},
+}
+
+// Jurisdictions listet die Schlüssel von EnergyLimitPresets (sortiert).
+func Jurisdictions() []string {
+	keys := make([]string, 0, len(EnergyLimitPresets))
+	for key := range EnergyLimitPresets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ResolveEnergyLimits kombiniert die Grenzen eines Rechtsraums mit eigenen
+// Regeln. Eine eigene Regel ersetzt die Grenze des Rechtsraums für ihre
+// Kategorie. jurisdiction "" = nur eigene Regeln.
+func ResolveEnergyLimits(jurisdiction string, custom []EnergyLimit) ([]EnergyLimit, error) {
+	var limits []EnergyLimit
+	if jurisdiction != "" {
+		preset, ok := EnergyLimitPresets[strings.ToUpper(jurisdiction)]
+		if !ok {
+			return nil, fmt.Errorf("unknown jurisdiction: %s (supported: %s)", jurisdiction, strings.Join(Jurisdictions(), ", "))
+		}
+		limits = append(limits, preset...)
+	}
+
+	seen := map[ProfileCategory]bool{}
+	for _, rule := range custom {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		if seen[rule.Category] {
+			return nil, fmt.Errorf("duplicate energy limit for category %s", rule.Category)
+		}
+		seen[rule.Category] = true
+
+		replaced := false
+		for i := range limits {
+			if limits[i].Category == rule.Category {
+				limits[i] = rule
+				replaced = true
+			}
+		}
+		if !replaced {
+			limits = append(limits, rule)
+		}
+	}
+	return limits, nil
+}
+
+// EnergyLimitFor sucht die Grenze einer Kategorie (nil = keine Grenze).
+func EnergyLimitFor(limits []EnergyLimit, category ProfileCategory) *EnergyLimit {
+	for i := range limits {
+		if limits[i].Category == category {
+			return &limits[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"metric-neo/internal/domain/valueobjects"
+	"testing"
+)
+
+func TestEnergyLimit_Check(t *testing.T) {
+	limit := EnergyLimit{Category: CategoryAirRifle, Max: 7.5}
+
+	tests := []struct {
+		joules float64
+		want   EnergyVerdict
+	}{
+		{7.0, EnergyWithinLimit},
+		{7.125, EnergyNearLimit}, // genau 95 %
+		{7.5, EnergyNearLimit},   // genau auf der Grenze ist erlaubt
+		{7.51, EnergyOverLimit},
+	}
+
+	for _, tt := range tests {
+		if got := limit.Check(valueobjects.Energy(tt.joules)); got != tt.want {
+			t.Errorf("Check(%.3f J) = %s, want %s", tt.joules, got, tt.want)
+		}
+	}
+}
+
+func TestResolveEnergyLimits(t *testing.T) {
+	// Eigene Regel ersetzt die Grenze des Rechtsraums für ihre Kategorie
+	limits, err := ResolveEnergyLimits("de", []EnergyLimit{
+		{Category: CategoryAirPistol, Max: 7.0, Label: "Verein"},
+		{Category: CategoryBow, Max: 100},
+	})
+	if err != nil {
+		t.Fatalf("ResolveEnergyLimits() failed: %v", err)
+	}
+	if len(limits) != 3 {
+		t.Fatalf("got %d limits, want 3", len(limits))
+	}
+	if rifle := EnergyLimitFor(limits, CategoryAirRifle); rifle == nil || rifle.Max != 7.5 {
+		t.Errorf("air rifle limit = %+v, want DE 7.5 J", rifle)
+	}
+	if pistol := EnergyLimitFor(limits, CategoryAirPistol); pistol == nil || pistol.Max != 7.0 || pistol.Label != "Verein" {
+		t.Errorf("air pistol limit = %+v, want the custom 7.0 J", pistol)
+	}
+	if EnergyLimitFor(limits, CategoryFirearm) != nil {
+		t.Error("firearm should have no limit")
+	}
+
+	invalid := []struct {
+		name         string
+		jurisdiction string
+		custom       []EnergyLimit
+	}{
+		{"unknown jurisdiction", "XX", nil},
+		{"invalid category", "", []EnergyLimit{{Category: "crossbow", Max: 10}}},
+		{"zero limit", "", []EnergyLimit{{Category: CategoryAirRifle}}},
+		{"duplicate category", "", []EnergyLimit{{Category: CategoryBow, Max: 10}, {Category: CategoryBow, Max: 20}}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ResolveEnergyLimits(tt.jurisdiction, tt.custom); err == nil {
+				t.Error("ResolveEnergyLimits() should fail")
+			}
+		})
+	}
+}
@@ -40,6 +40,29 @@ func (c ProfileCategory) String() string {
 	return string(c)
 }
 
+// PowerPlant ist der Antrieb einer Luftdruckwaffe.
+type PowerPlant string
+
+const (
+	PowerPlantSpring PowerPlant = "spring" // Feder/Gasdruckfeder
+	PowerPlantCO2    PowerPlant = "co2"
+	PowerPlantPCP    PowerPlant = "pcp" // Pressluft (pre-charged pneumatic)
+)
+
+// IsValid prüft, ob der PowerPlant gültig ist.
+func (p PowerPlant) IsValid() bool {
+	switch p {
+	case PowerPlantSpring, PowerPlantCO2, PowerPlantPCP:
+		return true
+	}
+	return false
+}
+
+// IsAirGun prüft, ob die Kategorie eine Luftdruckwaffe ist.
+func (c ProfileCategory) IsAirGun() bool {
+	return c == CategoryAirRifle || c == CategoryAirPistol
+}
+
 // Profile repräsentiert ein Sportgeräte-Profil (Hardware-Konfiguration).
 //
 // GO-KONZEPT: Optional Fields (Pointer vs. Value)
@@ -80,6 +103,13 @@ type Profile struct {
 	// DefaultAmmo ist eine REFERENZ zu einem Projectile
 	// Wird als ID gespeichert, nicht als ganzes Objekt!
 	DefaultAmmoID *string `json:"default_ammo_id,omitempty"`
+
+	// PowerPlant ist nur bei Luftdruckwaffen gesetzt ("" = unbekannt)
+	PowerPlant PowerPlant `json:"power_plant,omitempty"`
+
+	// RegulatorSetpoint ist der Ausgangsdruck des Reglers einer PCP
+	// (nil = ungeregelt). Unterhalb davon fällt die Geschwindigkeit ab.
+	RegulatorSetpoint *valueobjects.FillPressure `json:"regulator_setpoint,omitempty"`
 }
 
 // NewProfile erstellt ein neues Profile.
@@ -168,6 +198,39 @@ func (p *Profile) HasDefaultAmmo() bool {
 	return p.DefaultAmmoID != nil
 }
 
+// SetPowerPlant setzt den Antrieb und den Regler-Druck.
+//
+// Nur Luftdruckwaffen haben einen PowerPlant, nur PCP einen Regler.
+// plant "" entfernt beides.
+func (p *Profile) SetPowerPlant(plant PowerPlant, regulator *valueobjects.FillPressure) error {
+	if plant == "" {
+		if regulator != nil {
+			return fmt.Errorf("regulator setpoint requires power plant %s", PowerPlantPCP)
+		}
+		p.PowerPlant, p.RegulatorSetpoint = "", nil
+		return nil
+	}
+	if !plant.IsValid() {
+		return fmt.Errorf("invalid power plant: %s", plant)
+	}
+	if !p.Category.IsAirGun() {
+		return fmt.Errorf("power plant is only defined for air guns, not %s", p.Category)
+	}
+	if regulator != nil && plant != PowerPlantPCP {
+		return fmt.Errorf("regulator setpoint requires power plant %s", PowerPlantPCP)
+	}
+	if regulator != nil && regulator.Bar() <= 0 {
+		return fmt.Errorf("regulator setpoint must be positive")
+	}
+	p.PowerPlant, p.RegulatorSetpoint = plant, regulator
+	return nil
+}
+
+// IsPCP prüft, ob das Profil eine Pressluftwaffe ist.
+func (p *Profile) IsPCP() bool {
+	return p.PowerPlant == PowerPlantPCP
+}
+
 // TotalWeight berechnet das Gesamtgewicht (Waffe + Optik).
 //
 // GO-KONZEPT: Calculated Property mit Nil-Check
@@ -147,6 +147,35 @@ func TestProfile_SetTwistRate(t *testing.T) {
 	}
 }
 
+func TestProfile_SetPowerPlant(t *testing.T) {
+	barrel, _ := valueobjects.NewLength(450.0)
+	trigger, _ := valueobjects.NewMass(500.0)
+	sight, _ := valueobjects.NewLength(45.0)
+	regulator, _ := valueobjects.NewFillPressure(120)
+
+	profile, _ := NewProfile("FX Impact", CategoryAirRifle, barrel, trigger, sight)
+	if err := profile.SetPowerPlant(PowerPlantSpring, &regulator); err == nil {
+		t.Error("a regulator on a spring gun should fail")
+	}
+	if err := profile.SetPowerPlant("steam", nil); err == nil {
+		t.Error("an unknown power plant should fail")
+	}
+	if err := profile.SetPowerPlant(PowerPlantPCP, &regulator); err != nil {
+		t.Fatalf("SetPowerPlant() failed: %v", err)
+	}
+	if !profile.IsPCP() || profile.RegulatorSetpoint.Bar() != 120 {
+		t.Errorf("power plant = %s, regulator %v", profile.PowerPlant, profile.RegulatorSetpoint)
+	}
+	if err := profile.SetPowerPlant("", nil); err != nil || profile.PowerPlant != "" || profile.RegulatorSetpoint != nil {
+		t.Errorf("clearing the power plant failed: %v", err)
+	}
+
+	firearm, _ := NewProfile("Tikka T3x", CategoryFirearm, barrel, trigger, sight)
+	if err := firearm.SetPowerPlant(PowerPlantPCP, nil); err == nil {
+		t.Error("a power plant on a firearm should fail")
+	}
+}
+
 func TestProfile_TotalWeight(t *testing.T) {
 	barrel, _ := valueobjects.NewLength(450.0)
 	trigger, _ := valueobjects.NewMass(500.0)
@@ -2,6 +2,7 @@ package entities
 
 import (
 	"fmt"
+	"metric-neo/internal/domain/ballistics"
 	"metric-neo/internal/domain/valueobjects"
 
 	"github.com/google/uuid"
@@ -30,9 +31,31 @@ type Projectile struct {
 	// Velocity als Weight setzen.
 	Weight valueobjects.Mass `json:"weight"`
 
-	// BC (Ballistic Coefficient) - Optional für zukünftige Features
+	// BC (Ballistic Coefficient) in BCUnit, bezogen auf DragModel.
+	// 0 = unbekannt (Energie geht trotzdem, Flugbahn nicht).
 	// Primitive Typen (float64, int, string) können direkt genutzt werden
 	BC float64 `json:"bc"`
+
+	// DragModel ist die Referenzkurve, auf die sich BC bezieht.
+	// Ohne Modell ist ein BC nicht vergleichbar: derselbe Diabolo hat
+	// in G1 und GA verschiedene Werte.
+	DragModel ballistics.DragModel `json:"drag_model"`
+
+	// BCUnit ist die Einheit von BC (lb/in² oder kg/m²)
+	BCUnit ballistics.BCUnit `json:"bc_unit"`
+
+	// DragTable ist die eigene Widerstandskurve (nur bei DragModel CUSTOM)
+	DragTable ballistics.DragTable `json:"drag_table,omitempty"`
+
+	// Caliber ist der Durchmesser des Geschosses (nil = unbekannt).
+	// Nur für die Energiedichte (J/cm²) nötig.
+	Caliber *valueobjects.Length `json:"caliber,omitempty"`
+
+	// Lots sind die Chargen im Bestand (nur Stammdaten, siehe projectile_lot.go)
+	Lots []ProjectileLot `json:"lots,omitempty"`
+
+	// Lot ist die in einer Session verwendete Charge (nur im ProjectileSnapshot)
+	Lot *ProjectileLot `json:"lot,omitempty"`
 }
 
 // NewProjectile ist der Constructor für Projectile.
@@ -51,15 +74,18 @@ type Projectile struct {
 //     (z.B. UpdateBC()), müssen wir einen Pointer nutzen.
 //
 // GO-BEST-PRACTICE: Entities -> Pointer, Value Objects -> Value
+//
+// Der BC gilt als G1 in lb/in² (die übliche Herstellerangabe). Andere
+// Modelle und Einheiten setzt SetDrag.
 func NewProjectile(name string, weight valueobjects.Mass, bc float64) (*Projectile, error) {
 	// Validierung: Name darf nicht leer sein
 	if name == "" {
 		return nil, fmt.Errorf("projectile name cannot be empty")
 	}
 
-	// Validierung: BC muss >= 0 sein (physikalisch sinnvoll)
-	if bc < 0 {
-		return nil, fmt.Errorf("ballistic coefficient cannot be negative, got: %.3f", bc)
+	// Validierung: BC muss zum Modell passen (0 = unbekannt)
+	if err := ballistics.ValidateBC(bc, ballistics.G1, ballistics.BCUnitImperial, nil); err != nil {
+		return nil, err
 	}
 
 	// GO-KONZEPT: UUID Generierung
@@ -71,10 +97,12 @@ func NewProjectile(name string, weight valueobjects.Mass, bc float64) (*Projecti
 	// Wir erstellen eine Instanz und geben ihre ADRESSE zurück (&)
 	// Das & (Address-Of Operator) macht aus Projectile einen *Projectile
 	return &Projectile{
-		ID:     id,
-		Name:   name,
-		Weight: weight,
-		BC:     bc,
+		ID:        id,
+		Name:      name,
+		Weight:    weight,
+		BC:        bc,
+		DragModel: ballistics.G1,
+		BCUnit:    ballistics.BCUnitImperial,
 	}, nil
 }
 
@@ -90,7 +118,7 @@ func NewProjectile(name string, weight valueobjects.Mass, bc float64) (*Projecti
 //
 // REGEL: Entities -> Pointer Receiver, Value Objects -> Value Receiver
 func (p *Projectile) String() string {
-	return fmt.Sprintf("%s (%.3f g, BC: %.3f)", p.Name, p.Weight.Grams(), p.BC)
+	return fmt.Sprintf("%s (%.3f g, BC: %.3f %s)", p.Name, p.Weight.Grams(), p.BC, p.DragModel)
 }
 
 // UpdateBC aktualisiert den ballistischen Koeffizienten.
@@ -102,9 +130,64 @@ func (p *Projectile) String() string {
 // WICHTIG: Diese Methode ist nur für MASTER-Daten gedacht.
 // Snapshots in Sessions sind IMMUTABLE und haben diese Methode nicht!
 func (p *Projectile) UpdateBC(newBC float64) error {
-	if newBC < 0 {
-		return fmt.Errorf("ballistic coefficient cannot be negative")
+	if err := ballistics.ValidateBC(newBC, p.DragModel, p.BCUnit, p.DragTable); err != nil {
+		return err
 	}
 	p.BC = newBC
 	return nil
 }
+
+// SetDrag setzt BC, Modell, Einheit und (bei CUSTOM) die eigene Kurve.
+//
+// Alle vier gehören zusammen und werden gemeinsam validiert: Ein BC ist nur
+// im Kontext seines Modells und seiner Einheit gültig. Bei einem Fehler
+// bleibt das Projectile unverändert.
+func (p *Projectile) SetDrag(bc float64, model ballistics.DragModel, unit ballistics.BCUnit, table ballistics.DragTable) error {
+	if err := ballistics.ValidateBC(bc, model, unit, table); err != nil {
+		return err
+	}
+	p.BC = bc
+	p.DragModel = model
+	p.BCUnit = unit
+	p.DragTable = append(ballistics.DragTable(nil), table...)
+	if len(p.DragTable) == 0 {
+		p.DragTable = nil
+	}
+	return nil
+}
+
+// BCImperial gibt den BC in lb/in² zurück (Eingabe des Solvers).
+func (p *Projectile) BCImperial() float64 {
+	return p.BCUnit.ToImperial(p.BC)
+}
+
+// MaxCaliber ist das größte plausible Kaliber (50 mm). Größere Werte sind
+// fast immer ein Einheitenfehler (Inch statt mm oder mm statt Zoll·100).
+const MaxCaliber valueobjects.Length = 50
+
+// SetCaliber setzt das Kaliber. nil löscht es.
+func (p *Projectile) SetCaliber(caliber *valueobjects.Length) error {
+	if caliber == nil {
+		p.Caliber = nil
+		return nil
+	}
+	if caliber.Millimeters() <= 0 || *caliber > MaxCaliber {
+		return fmt.Errorf("caliber must be between 0 and %.0f mm, got: %.2f mm", MaxCaliber.Millimeters(), caliber.Millimeters())
+	}
+	value := *caliber
+	p.Caliber = &value
+	return nil
+}
+
+// EnergyDensity berechnet die Energiedichte einer Energie über den
+// Querschnitt des Kalibers. ok = false ohne Kaliber.
+func (p *Projectile) EnergyDensity(energy valueobjects.Energy) (valueobjects.EnergyDensity, bool) {
+	if p.Caliber == nil {
+		return 0, false
+	}
+	density, err := valueobjects.CalculateEnergyDensity(energy, *p.Caliber)
+	if err != nil {
+		return 0, false
+	}
+	return density, true
+}
@@ -0,0 +1,183 @@
+package entities
+
+import (
+	"fmt"
+	"metric-neo/internal/domain/valueobjects"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectileLot ist eine Charge (Los) eines Projektils.
+//
+// Munition aus unterschiedlichen Chargen kann abweichende Gewichte und
+// Geschwindigkeiten haben. Jede Session friert die gewählte Charge in
+// ihrem ProjectileSnapshot ein (Session.ProjectileSnapshot.Lot), damit
+// Statistiken pro Charge vergleichbar sind: eine schlechte Dose Diabolos
+// von einem nachlassenden Sportgerät unterscheiden.
+//
+// DOMAIN MODEL: Siehe docs/specs/domain-model.md Abschnitt 3.4 (Charge)
+type ProjectileLot struct {
+	ID string `json:"id"`
+
+	// Number ist die Chargen-/Losnummer auf der Verpackung (z.B. "LOT-2023-11-A").
+	// Innerhalb eines Projektils eindeutig.
+	Number string `json:"number"`
+
+	// Optional: Kaufdatum (nur das Datum ist relevant)
+	PurchaseDate *time.Time `json:"purchase_date,omitempty"`
+
+	// Optional: Selbst gewogenes Durchschnittsgewicht der Charge.
+	// Ist es gesetzt, rechnet eine Session mit dieser Charge damit statt
+	// mit dem Nenngewicht des Projektils.
+	AverageWeight *valueobjects.Mass `json:"average_weight,omitempty"`
+
+	// CountOnHand ist der Bestand (Stück).
+	CountOnHand int `json:"count_on_hand"`
+}
+
+// NewProjectileLot erstellt eine Charge.
+func NewProjectileLot(number string, countOnHand int) (*ProjectileLot, error) {
+	number = strings.TrimSpace(number)
+	if number == "" {
+		return nil, fmt.Errorf("lot number cannot be empty")
+	}
+	if countOnHand < 0 {
+		return nil, fmt.Errorf("count on hand cannot be negative, got: %d", countOnHand)
+	}
+
+	return &ProjectileLot{
+		ID:          uuid.New().String(),
+		Number:      number,
+		CountOnHand: countOnHand,
+	}, nil
+}
+
+// SetPurchaseDate setzt das Kaufdatum (Uhrzeit wird verworfen).
+func (l *ProjectileLot) SetPurchaseDate(date time.Time) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	l.PurchaseDate = &day
+}
+
+// SetAverageWeight setzt das gemessene Durchschnittsgewicht.
+func (l *ProjectileLot) SetAverageWeight(weight valueobjects.Mass) {
+	l.AverageWeight = &weight
+}
+
+// String implementiert fmt.Stringer.
+func (l *ProjectileLot) String() string {
+	return l.Number
+}
+
+// CopyLot erstellt eine Deep Copy einer Charge.
+func CopyLot(l *ProjectileLot) *ProjectileLot {
+	if l == nil {
+		return nil
+	}
+
+	copy := *l
+	if l.PurchaseDate != nil {
+		date := *l.PurchaseDate
+		copy.PurchaseDate = &date
+	}
+	if l.AverageWeight != nil {
+		weight := *l.AverageWeight
+		copy.AverageWeight = &weight
+	}
+	return &copy
+}
+
+// FindLot sucht eine Charge anhand ID oder Losnummer.
+func (p *Projectile) FindLot(idOrNumber string) *ProjectileLot {
+	for i := range p.Lots {
+		if p.Lots[i].ID == idOrNumber {
+			return &p.Lots[i]
+		}
+	}
+	for i := range p.Lots {
+		if strings.EqualFold(p.Lots[i].Number, idOrNumber) {
+			return &p.Lots[i]
+		}
+	}
+	return nil
+}
+
+// AddLot fügt eine Charge hinzu. Losnummern müssen eindeutig sein.
+func (p *Projectile) AddLot(lot *ProjectileLot) error {
+	if lot == nil {
+		return fmt.Errorf("lot cannot be nil")
+	}
+	if err := p.checkLotNumber(lot.ID, lot.Number); err != nil {
+		return err
+	}
+	p.Lots = append(p.Lots, *CopyLot(lot))
+	return nil
+}
+
+// UpdateLot ersetzt eine vorhandene Charge (gleiche ID).
+func (p *Projectile) UpdateLot(lot *ProjectileLot) error {
+	if lot == nil {
+		return fmt.Errorf("lot cannot be nil")
+	}
+	if err := p.checkLotNumber(lot.ID, lot.Number); err != nil {
+		return err
+	}
+	for i := range p.Lots {
+		if p.Lots[i].ID == lot.ID {
+			p.Lots[i] = *CopyLot(lot)
+			return nil
+		}
+	}
+	return fmt.Errorf("lot not found: %s", lot.ID)
+}
+
+// RemoveLot entfernt eine Charge. Sessions behalten ihren Snapshot.
+func (p *Projectile) RemoveLot(id string) error {
+	for i := range p.Lots {
+		if p.Lots[i].ID == id {
+			p.Lots = append(p.Lots[:i], p.Lots[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("lot not found: %s", id)
+}
+
+// checkLotNumber verhindert doppelte Losnummern (ohne die Charge id selbst).
+func (p *Projectile) checkLotNumber(id, number string) error {
+	if strings.TrimSpace(number) == "" {
+		return fmt.Errorf("lot number cannot be empty")
+	}
+	for _, existing := range p.Lots {
+		if existing.ID != id && strings.EqualFold(existing.Number, number) {
+			return fmt.Errorf("lot %q already exists", number)
+		}
+	}
+	return nil
+}
+
+// SnapshotForLot erstellt den Projectile-Snapshot einer Session mit Charge.
+//
+// Der Snapshot enthält nur die gewählte Charge (Lot), nicht das Lager
+// aller Chargen (Lots) - Bestände ändern sich, die Session nicht.
+// Hat die Charge ein gemessenes Durchschnittsgewicht, wird es zum Gewicht
+// des Snapshots und damit zur Basis der Energieberechnung.
+// lotID "" = ohne Charge.
+func (p *Projectile) SnapshotForLot(lotID string) (*Projectile, error) {
+	snapshot := CopyProjectile(p)
+	snapshot.Lots = nil
+	snapshot.Lot = nil
+	if lotID == "" {
+		return snapshot, nil
+	}
+
+	lot := p.FindLot(lotID)
+	if lot == nil {
+		return nil, fmt.Errorf("lot not found: %s", lotID)
+	}
+	snapshot.Lot = CopyLot(lot)
+	if lot.AverageWeight != nil {
+		snapshot.Weight = *lot.AverageWeight
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,113 @@
+package entities
+
+import (
+	"metric-neo/internal/domain/valueobjects"
+	"testing"
+)
+
+func TestNewProjectileLot(t *testing.T) {
+	tests := []struct {
+		name      string
+		number    string
+		count     int
+		wantError bool
+	}{
+		{name: "valid lot", number: "LOT-2023-11-A", count: 500},
+		{name: "empty stock is valid", number: "8347", count: 0},
+		{name: "empty number is invalid", number: "  ", count: 500, wantError: true},
+		{name: "negative count is invalid", number: "8347", count: -1, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lot, err := NewProjectileLot(tt.number, tt.count)
+			if tt.wantError {
+				if err == nil {
+					t.Error("NewProjectileLot() expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewProjectileLot() unexpected error: %v", err)
+			}
+			if lot.ID == "" || lot.Number != tt.number || lot.CountOnHand != tt.count {
+				t.Errorf("unexpected lot: %+v", lot)
+			}
+		})
+	}
+}
+
+func TestProjectile_AddLotRejectsDuplicateNumber(t *testing.T) {
+	weight, _ := valueobjects.NewMass(0.547)
+	projectile, _ := NewProjectile("JSB Exact", weight, 0.024)
+
+	first, _ := NewProjectileLot("LOT-A", 500)
+	if err := projectile.AddLot(first); err != nil {
+		t.Fatalf("AddLot() failed: %v", err)
+	}
+	second, _ := NewProjectileLot("lot-a", 250)
+	if err := projectile.AddLot(second); err == nil {
+		t.Error("AddLot() should reject a duplicate lot number")
+	}
+
+	if found := projectile.FindLot("LOT-A"); found == nil || found.ID != first.ID {
+		t.Error("FindLot() by number failed")
+	}
+	if err := projectile.RemoveLot(first.ID); err != nil || len(projectile.Lots) != 0 {
+		t.Errorf("RemoveLot() = %v, %d lots left", err, len(projectile.Lots))
+	}
+}
+
+// KRITISCHER TEST: Die Session friert die Charge ein
+func TestNewSessionWithLot_FreezesLot(t *testing.T) {
+	weight, _ := valueobjects.NewMass(0.547)
+	projectile, _ := NewProjectile("JSB Exact", weight, 0.024)
+
+	lot, _ := NewProjectileLot("LOT-A", 500)
+	measured, _ := valueobjects.NewMass(0.552)
+	lot.SetAverageWeight(measured)
+	projectile.AddLot(lot)
+	other, _ := NewProjectileLot("LOT-B", 500)
+	projectile.AddLot(other)
+
+	session, err := NewSessionWithLot(createTestProfile(), projectile, lot.ID)
+	if err != nil {
+		t.Fatalf("NewSessionWithLot() failed: %v", err)
+	}
+
+	snapshot := session.ProjectileSnapshot
+	if snapshot.Lot == nil || snapshot.Lot.Number != "LOT-A" {
+		t.Fatalf("lot not frozen into snapshot: %+v", snapshot.Lot)
+	}
+	if snapshot.Weight.Grams() != 0.552 {
+		t.Errorf("snapshot weight = %.3f, want measured lot weight 0.552", snapshot.Weight.Grams())
+	}
+	if len(snapshot.Lots) != 0 {
+		t.Error("snapshot should not carry the lot inventory")
+	}
+
+	// Änderungen im Inventar dürfen den Snapshot nicht verändern
+	projectile.Lots[0].Number = "RENAMED"
+	projectile.Lots[0].CountOnHand = 0
+	if snapshot.Lot.Number != "LOT-A" || snapshot.Lot.CountOnHand != 500 {
+		t.Error("snapshot lot changed with the inventory")
+	}
+
+	if _, err := NewSessionWithLot(createTestProfile(), projectile, "unknown"); err == nil {
+		t.Error("NewSessionWithLot() should fail for an unknown lot")
+	}
+}
+
+func TestCopyProjectile_CopiesLots(t *testing.T) {
+	weight, _ := valueobjects.NewMass(0.547)
+	projectile, _ := NewProjectile("JSB Exact", weight, 0.024)
+	lot, _ := NewProjectileLot("LOT-A", 500)
+	projectile.AddLot(lot)
+
+	copy := CopyProjectile(projectile)
+	copy.Lots[0].CountOnHand = 1
+
+	if projectile.Lots[0].CountOnHand != 500 {
+		t.Error("CopyProjectile() shares the lots slice with the original")
+	}
+}
@@ -2,6 +2,7 @@ package entities
 
 import (
 	"encoding/json"
+	"metric-neo/internal/domain/ballistics"
 	"metric-neo/internal/domain/valueobjects"
 	"testing"
 )
@@ -146,6 +147,80 @@ func TestProjectile_UpdateBC(t *testing.T) {
 	}
 }
 
+// Test: SetDrag validiert BC gegen Modell und Einheit, Snapshots kopieren die Kurve
+func TestProjectile_SetDrag(t *testing.T) {
+	weight, _ := valueobjects.NewMass(0.547)
+	p, _ := NewProjectile("JSB Exact", weight, 0.022)
+	if p.DragModel != ballistics.G1 || p.BCUnit != ballistics.BCUnitImperial {
+		t.Fatalf("defaults = %s %s, want G1 lb/in2", p.DragModel, p.BCUnit)
+	}
+
+	// GA in kg/m²
+	if err := p.SetDrag(16.9, ballistics.GA, ballistics.BCUnitMetric, nil); err != nil {
+		t.Fatalf("SetDrag(GA, kg/m2) failed: %v", err)
+	}
+	if got := p.BCImperial(); got < 0.0240 || got > 0.0241 {
+		t.Errorf("BCImperial() = %v, want ~0.02404", got)
+	}
+
+	// Ungültig: bleibt unverändert
+	if err := p.SetDrag(0.5, ballistics.GA, ballistics.BCUnitImperial, nil); err == nil {
+		t.Error("SetDrag(0.5, GA) expected error")
+	}
+	if p.BC != 16.9 || p.DragModel != ballistics.GA {
+		t.Errorf("after failed SetDrag: %v %s, want 16.9 GA", p.BC, p.DragModel)
+	}
+
+	// UpdateBC prüft gegen das aktuelle Modell
+	if err := p.UpdateBC(0.024); err == nil {
+		t.Error("UpdateBC(0.024 kg/m2) expected error")
+	}
+
+	table := ballistics.DragTable{{Mach: 0.3, Cd: 0.25}, {Mach: 0.8, Cd: 0.3}}
+	if err := p.SetDrag(1.0, ballistics.Custom, ballistics.BCUnitImperial, table); err != nil {
+		t.Fatalf("SetDrag(CUSTOM) failed: %v", err)
+	}
+	table[0].Cd = 0.9
+	snapshot := CopyProjectile(p)
+	p.DragTable[1].Cd = 0.9
+	if p.DragTable[0].Cd != 0.25 || snapshot.DragTable[1].Cd != 0.3 {
+		t.Errorf("drag table shared: projectile %v, snapshot %v", p.DragTable, snapshot.DragTable)
+	}
+}
+
+func TestProjectile_SetCaliber(t *testing.T) {
+	weight, _ := valueobjects.NewMass(0.547)
+	p, _ := NewProjectile("JSB Exact", weight, 0.022)
+	if _, ok := p.EnergyDensity(7.5); ok {
+		t.Error("EnergyDensity() without caliber should not be ok")
+	}
+
+	for _, mm := range []float64{0, -4.5, 177} {
+		caliber := valueobjects.Length(mm)
+		if err := p.SetCaliber(&caliber); err == nil {
+			t.Errorf("SetCaliber(%v mm) expected error", mm)
+		}
+	}
+
+	caliber := valueobjects.Length(4.5)
+	if err := p.SetCaliber(&caliber); err != nil {
+		t.Fatalf("SetCaliber(4.5 mm) failed: %v", err)
+	}
+	if density, ok := p.EnergyDensity(7.5); !ok || density < 47.1 || density > 47.2 {
+		t.Errorf("EnergyDensity(7.5 J) = %v, %v, want ~47.16 J/cm²", density, ok)
+	}
+
+	snapshot := CopyProjectile(p)
+	*p.Caliber = 5.5
+	if snapshot.Caliber.Millimeters() != 4.5 {
+		t.Errorf("caliber shared: snapshot %v", snapshot.Caliber)
+	}
+
+	if err := p.SetCaliber(nil); err != nil || p.Caliber != nil {
+		t.Errorf("SetCaliber(nil) = %v, caliber %v, want cleared", err, p.Caliber)
+	}
+}
+
 // Test: String() Methode
 func TestProjectile_String(t *testing.T) {
 	weight, _ := valueobjects.NewMass(0.547)
@@ -3,6 +3,7 @@ package entities
 import (
 	"fmt"
 	"math"
+	"metric-neo/internal/domain/ballistics"
 	"metric-neo/internal/domain/valueobjects"
 	"time"
 
@@ -22,8 +23,14 @@ type Session struct {
 	CreatedAt time.Time `json:"created_at"`
 	Note      string    `json:"note,omitempty"` // Optional: Notizen
 
-	// Optional: Umgebungsbedingungen
+	// Optional: Umgebungsbedingungen (siehe Atmosphere)
 	Temperature *valueobjects.Temperature `json:"temperature,omitempty"`
+	Pressure    *valueobjects.Pressure    `json:"pressure,omitempty"` // Stationsdruck in hPa
+	Humidity    *valueobjects.Humidity    `json:"humidity,omitempty"` // relative Feuchte in %
+	Altitude    *valueobjects.Altitude    `json:"altitude,omitempty"` // Höhe des Schießstands in m
+
+	// Optional: Session wurde hinter einer anderen gemessen (BC-Bestimmung)
+	Downrange *Downrange `json:"downrange,omitempty"`
 
 	// GO-KONZEPT: Deep Copy Snapshots (KERN des Patterns!)
 	// Diese Felder sind KOPIEN zum Zeitpunkt der Session-Erstellung!
@@ -37,6 +44,21 @@ type Session struct {
 	// []*Shot = "Slice (Array) von Pointern zu Shot"
 	// Warum Pointer? Shots sind Entities (Identität)
 	Shots []*Shot `json:"shots"`
+
+	// Optional: Schussserien (z.B. je Füllung); leer = eine Serie
+	Strings []*ShotString `json:"strings,omitempty"`
+}
+
+// Downrange verknüpft eine Session mit der Session am ersten Messpunkt.
+//
+// Für die BC-Bestimmung wird dasselbe Projektil zweimal gemessen: nah
+// (meist an der Mündung) und DistanceMeters weiter. Mit zwei Chronographen
+// entstehen beide Sessions gleichzeitig (Schuss i = Schuss i), mit einem
+// nacheinander. Die Verknüpfung liegt an der fernen Session, die nahe
+// bleibt eine gewöhnliche Session.
+type Downrange struct {
+	NearSessionID  string  `json:"near_session_id"`
+	DistanceMeters float64 `json:"distance_meters"` // Abstand der Messpunkte
 }
 
 // NewSession erstellt eine neue Session mit Snapshots.
@@ -63,6 +85,20 @@ func NewSession(profile *Profile, projectile *Projectile) *Session {
 	}
 }
 
+// NewSessionWithLot erstellt eine Session, deren ProjectileSnapshot die
+// gewählte Charge einfriert (siehe Projectile.SnapshotForLot).
+// lotID "" = ohne Charge, wie NewSession.
+func NewSessionWithLot(profile *Profile, projectile *Projectile, lotID string) (*Session, error) {
+	snapshot, err := projectile.SnapshotForLot(lotID)
+	if err != nil {
+		return nil, err
+	}
+
+	session := NewSession(profile, nil)
+	session.ProjectileSnapshot = snapshot
+	return session, nil
+}
+
 // CopyProfile erstellt eine Deep Copy eines Profile.
 //
 // GO-KONZEPT: Deep Copy bei nested Pointers
@@ -95,18 +131,38 @@ func CopyProfile(p *Profile) *Profile {
 		copy.DefaultAmmoID = &idCopy
 	}
 
+	if p.RegulatorSetpoint != nil {
+		regulatorCopy := *p.RegulatorSetpoint
+		copy.RegulatorSetpoint = &regulatorCopy
+	}
+
 	return &copy
 }
 
 // CopyProjectile erstellt eine Deep Copy eines Projectile.
 //
-// Einfacher als Profile, da Projectile keine Pointer-Felder hat!
+// Slices werden wie Pointer geteilt: Lots muss neu angelegt werden,
+// sonst ändert ein Update der Charge im Inventar auch die Kopie.
 func CopyProjectile(p *Projectile) *Projectile {
 	if p == nil {
 		return nil
 	}
 
 	copy := *p
+	if p.Lots != nil {
+		copy.Lots = make([]ProjectileLot, len(p.Lots))
+		for i := range p.Lots {
+			copy.Lots[i] = *CopyLot(&p.Lots[i])
+		}
+	}
+	copy.Lot = CopyLot(p.Lot)
+	if p.Caliber != nil {
+		caliber := *p.Caliber
+		copy.Caliber = &caliber
+	}
+	if p.DragTable != nil {
+		copy.DragTable = append(ballistics.DragTable(nil), p.DragTable...)
+	}
 	return &copy
 }
 
@@ -170,29 +226,50 @@ func (s *Session) CalculateAverageVelocity() (valueobjects.Velocity, error) {
 // GO-KONZEPT: Mathematische Berechnungen
 // SD = √(Σ(x - μ)² / n)
 func (s *Session) CalculateStandardDeviation() (float64, error) {
+	sumSquares, n, err := s.sumSquaredDeviations()
+	if err != nil {
+		return 0, err
+	}
+
+	// Standardabweichung = Wurzel der Varianz
+	return math.Sqrt(sumSquares / float64(n)), nil
+}
+
+// CalculateSampleStandardDeviation berechnet die Stichproben-Standardabweichung.
+//
+// SD = √(Σ(x - μ)² / (n-1))
+// CalculateStandardDeviation beschreibt die Streuung genau dieser Schüsse;
+// die Stichproben-SD schätzt die Streuung der Waffe. Bei wenigen Schüssen
+// ist sie spürbar größer (3 Schüsse: Faktor 1.22).
+func (s *Session) CalculateSampleStandardDeviation() (float64, error) {
+	sumSquares, n, err := s.sumSquaredDeviations()
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(sumSquares / float64(n-1)), nil
+}
+
+// sumSquaredDeviations liefert Σ(x - μ)² und n der gültigen Schüsse.
+func (s *Session) sumSquaredDeviations() (float64, int, error) {
 	validShots := s.getValidShots()
 
 	if len(validShots) < 2 {
-		return 0, fmt.Errorf("need at least 2 valid shots for standard deviation")
+		return 0, 0, fmt.Errorf("need at least 2 valid shots for standard deviation")
 	}
 
 	// Durchschnitt berechnen
 	avg, err := s.CalculateAverageVelocity()
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 	avgValue := avg.MetersPerSecond()
 
-	// Varianz berechnen
-	variance := 0.0
+	sumSquares := 0.0
 	for _, shot := range validShots {
 		diff := shot.Velocity.MetersPerSecond() - avgValue
-		variance += diff * diff
+		sumSquares += diff * diff
 	}
-	variance /= float64(len(validShots))
-
-	// Standardabweichung = Wurzel der Varianz
-	return math.Sqrt(variance), nil
+	return sumSquares, len(validShots), nil
 }
 
 // CalculateAverageEnergy berechnet die durchschnittliche Energie.
@@ -287,6 +364,52 @@ func (s *Session) SetTemperature(temp valueobjects.Temperature) {
 	s.Temperature = &temp
 }
 
+// SetPressure setzt den Luftdruck (Stationsdruck).
+func (s *Session) SetPressure(pressure valueobjects.Pressure) {
+	s.Pressure = &pressure
+}
+
+// SetHumidity setzt die relative Luftfeuchtigkeit.
+func (s *Session) SetHumidity(humidity valueobjects.Humidity) {
+	s.Humidity = &humidity
+}
+
+// SetAltitude setzt die Höhe des Schießstands.
+func (s *Session) SetAltitude(altitude valueobjects.Altitude) {
+	s.Altitude = &altitude
+}
+
+// Atmosphere gibt die Umgebungsbedingungen der Session zurück.
+// Luftdichte und Dichtehöhe werden daraus abgeleitet, nicht gespeichert.
+func (s *Session) Atmosphere() valueobjects.Atmosphere {
+	return valueobjects.Atmosphere{
+		Temperature: s.Temperature,
+		Pressure:    s.Pressure,
+		Humidity:    s.Humidity,
+		Altitude:    s.Altitude,
+	}
+}
+
+// SetDownrange markiert die Session als Messung distanceMeters hinter nearSessionID.
+func (s *Session) SetDownrange(nearSessionID string, distanceMeters float64) error {
+	if nearSessionID == "" {
+		return fmt.Errorf("near session ID cannot be empty")
+	}
+	if nearSessionID == s.ID {
+		return fmt.Errorf("session cannot be its own near session")
+	}
+	if distanceMeters <= 0 {
+		return fmt.Errorf("chronograph distance must be positive, got: %.2f m", distanceMeters)
+	}
+	s.Downrange = &Downrange{NearSessionID: nearSessionID, DistanceMeters: distanceMeters}
+	return nil
+}
+
+// ClearDownrange hebt die Verknüpfung wieder auf.
+func (s *Session) ClearDownrange() {
+	s.Downrange = nil
+}
+
 // SetNote setzt eine Notiz.
 func (s *Session) SetNote(note string) {
 	s.Note = note
@@ -175,6 +175,15 @@ func TestSession_CalculateStandardDeviation(t *testing.T) {
 	}
 
 	t.Logf("Standard Deviation: %.2f m/s", sd)
+
+	// Stichprobe (n-1): √(50/2) = 5.0
+	sampleSD, err := session.CalculateSampleStandardDeviation()
+	if err != nil {
+		t.Fatalf("CalculateSampleStandardDeviation() failed: %v", err)
+	}
+	if math.Abs(sampleSD-5.0) > 1e-9 {
+		t.Errorf("sample SD = %.4f, want 5.0", sampleSD)
+	}
 }
 
 func TestSession_MinMaxVelocity(t *testing.T) {
@@ -314,6 +323,32 @@ func TestSession_EmptySession_Errors(t *testing.T) {
 	}
 }
 
+func TestSession_SetDownrange(t *testing.T) {
+	session := NewSession(createTestProfile(), createTestProjectile())
+
+	if err := session.SetDownrange("near-id", 10); err != nil {
+		t.Fatalf("SetDownrange() failed: %v", err)
+	}
+	if session.Downrange.NearSessionID != "near-id" || session.Downrange.DistanceMeters != 10 {
+		t.Errorf("Downrange = %+v", session.Downrange)
+	}
+
+	if err := session.SetDownrange(session.ID, 10); err == nil {
+		t.Error("SetDownrange() should reject the session itself")
+	}
+	if err := session.SetDownrange("", 10); err == nil {
+		t.Error("SetDownrange() should reject an empty ID")
+	}
+	if err := session.SetDownrange("near-id", 0); err == nil {
+		t.Error("SetDownrange() should reject distance 0")
+	}
+
+	session.ClearDownrange()
+	if session.Downrange != nil {
+		t.Error("ClearDownrange() should remove the link")
+	}
+}
+
 // Helper: Erstellt Velocity oder panic (für Tests)
 func mustVelocity(mps float64) valueobjects.Velocity {
 	v, err := valueobjects.NewVelocity(mps)
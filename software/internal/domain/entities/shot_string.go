@@ -0,0 +1,147 @@
+package entities
+
+import (
+	"fmt"
+	"metric-neo/internal/domain/valueobjects"
+	"time"
+)
+
+// ShotString ist eine Schussserie innerhalb einer Session, z.B. eine
+// Füllung einer Pressluftwaffe (PCP).
+//
+// GO-KONZEPT: Grenzen statt Zuordnung
+// Session.Shots bleibt eine flache Liste. Eine Serie merkt sich nur, bei
+// welchem Schuss sie beginnt; sie endet vor dem Beginn der nächsten.
+// Statistik, Export und Index arbeiten unverändert auf allen Schüssen.
+//
+// DOMAIN MODEL: Siehe docs/specs/domain-model.md Abschnitt 3.6
+type ShotString struct {
+	Name      string    `json:"name,omitempty"`
+	FirstShot int       `json:"first_shot"` // Index in Session.Shots (0-basiert)
+	StartedAt time.Time `json:"started_at"`
+
+	// Fill ist die Füllung einer PCP, mit der die Serie geschossen wurde
+	// (nil = nicht erfasst)
+	Fill *FillEvent `json:"fill,omitempty"`
+}
+
+// FillEvent ist der Tankdruck zu Beginn und am Ende einer Serie.
+//
+// Der Enddruck wird erst nach dem letzten Schuss abgelesen und ist bis
+// dahin nil.
+type FillEvent struct {
+	StartPressure valueobjects.FillPressure  `json:"start_pressure"`
+	EndPressure   *valueobjects.FillPressure `json:"end_pressure,omitempty"`
+}
+
+// NewFillEvent erstellt ein FillEvent mit Validierung.
+func NewFillEvent(start valueobjects.FillPressure, end *valueobjects.FillPressure) (*FillEvent, error) {
+	if start.Bar() <= 0 {
+		return nil, fmt.Errorf("start pressure must be positive")
+	}
+	if end != nil && end.Bar() > start.Bar() {
+		return nil, fmt.Errorf("end pressure (%s) exceeds start pressure (%s)", end, start)
+	}
+	return &FillEvent{StartPressure: start, EndPressure: end}, nil
+}
+
+// DropPerShot gibt den mittleren Druckverlust pro Schuss in bar zurück.
+// Ohne Enddruck oder Schüsse ist ok false.
+func (f *FillEvent) DropPerShot(shotCount int) (bar float64, ok bool) {
+	if f.EndPressure == nil || shotCount == 0 {
+		return 0, false
+	}
+	return (f.StartPressure.Bar() - f.EndPressure.Bar()) / float64(shotCount), true
+}
+
+// StartString beginnt eine neue Serie; folgende Schüsse gehören zu ihr.
+//
+// Ohne Serien ist die ganze Session eine Serie. Hat sie schon Schüsse,
+// werden diese beim ersten Aufruf zur (unbenannten) ersten Serie.
+func (s *Session) StartString(name string) (*ShotString, error) {
+	if n := len(s.Strings); n > 0 && s.Strings[n-1].FirstShot >= len(s.Shots) {
+		return nil, fmt.Errorf("current string has no shots yet")
+	}
+	if len(s.Strings) == 0 && len(s.Shots) > 0 {
+		s.Strings = append(s.Strings, &ShotString{FirstShot: 0, StartedAt: s.Shots[0].Timestamp})
+	}
+
+	str := &ShotString{Name: name, FirstShot: len(s.Shots), StartedAt: time.Now()}
+	s.Strings = append(s.Strings, str)
+	return str, nil
+}
+
+// RenameString ändert den Namen der Serie i (0-basiert).
+func (s *Session) RenameString(i int, name string) error {
+	if i < 0 || i >= len(s.Strings) {
+		return fmt.Errorf("string %d does not exist (session has %d)", i+1, len(s.Strings))
+	}
+	s.Strings[i].Name = name
+	return nil
+}
+
+// SetStringFill setzt die Füllung der Serie i (0-basiert); nil entfernt sie.
+//
+// Ohne Serien wird die ganze Session zur ersten Serie, damit eine einzelne
+// Füllung keine Serie voraussetzt.
+func (s *Session) SetStringFill(i int, fill *FillEvent) error {
+	if len(s.Strings) == 0 && i == 0 {
+		if fill == nil {
+			return nil
+		}
+		started := time.Now()
+		if len(s.Shots) > 0 {
+			started = s.Shots[0].Timestamp
+		}
+		s.Strings = append(s.Strings, &ShotString{FirstShot: 0, StartedAt: started})
+	}
+	if i < 0 || i >= len(s.Strings) {
+		return fmt.Errorf("string %d does not exist (session has %d)", i+1, len(s.Strings))
+	}
+	s.Strings[i].Fill = fill
+	return nil
+}
+
+// StringCount gibt die Anzahl der Serien zurück (ohne Serien: 1).
+func (s *Session) StringCount() int {
+	return max(1, len(s.Strings))
+}
+
+// StringBounds gibt den Bereich [first, end) der Serie i in Shots zurück.
+func (s *Session) StringBounds(i int) (first, end int) {
+	if len(s.Strings) == 0 {
+		return 0, len(s.Shots)
+	}
+	first = min(s.Strings[i].FirstShot, len(s.Shots))
+	end = len(s.Shots)
+	if i+1 < len(s.Strings) {
+		end = min(s.Strings[i+1].FirstShot, len(s.Shots))
+	}
+	return first, end
+}
+
+// StringShots gibt die Schüsse der Serie i zurück.
+func (s *Session) StringShots(i int) []*Shot {
+	first, end := s.StringBounds(i)
+	return s.Shots[first:end]
+}
+
+// StringOf gibt die Serie (0-basiert) des Schusses mit Index shotIndex zurück.
+func (s *Session) StringOf(shotIndex int) int {
+	for i := len(s.Strings) - 1; i > 0; i-- {
+		if shotIndex >= s.Strings[i].FirstShot {
+			return i
+		}
+	}
+	return 0
+}
+
+// SubSession gibt eine Kopie der Session zurück, die nur die Schüsse der
+// Serie i enthält. Snapshots und Bedingungen werden geteilt - nur zum
+// Rechnen (Statistik je Serie), nicht zum Speichern.
+func (s *Session) SubSession(i int) *Session {
+	sub := *s
+	sub.Shots = s.StringShots(i)
+	sub.Strings = nil
+	return &sub
+}
@@ -0,0 +1,118 @@
+package entities
+
+import (
+	"encoding/json"
+	"metric-neo/internal/domain/valueobjects"
+	"testing"
+)
+
+func TestSession_Strings(t *testing.T) {
+	session := NewSession(createTestProfile(), createTestProjectile())
+	record := func(velocities ...float64) {
+		for _, v := range velocities {
+			velocity, _ := valueobjects.NewVelocity(v)
+			session.RecordShot(velocity)
+		}
+	}
+
+	// Ohne Serien ist die ganze Session eine Serie
+	record(280, 281)
+	if session.StringCount() != 1 || session.StringOf(1) != 0 {
+		t.Fatalf("StringCount() = %d, want 1", session.StringCount())
+	}
+	if first, end := session.StringBounds(0); first != 0 || end != 2 {
+		t.Errorf("StringBounds(0) = %d, %d, want 0, 2", first, end)
+	}
+
+	// Die vorhandenen Schüsse werden zur ersten Serie
+	if _, err := session.StartString("Fill 2"); err != nil {
+		t.Fatalf("StartString() failed: %v", err)
+	}
+	if _, err := session.StartString("Fill 3"); err == nil {
+		t.Error("a new string without shots in the current one should fail")
+	}
+	record(279, 281, 283)
+
+	if session.StringCount() != 2 || session.Strings[0].Name != "" || session.Strings[1].Name != "Fill 2" {
+		t.Fatalf("strings = %+v", session.Strings)
+	}
+	if shots := session.StringShots(1); len(shots) != 3 || shots[0].Velocity.MetersPerSecond() != 279 {
+		t.Errorf("StringShots(1) = %v", shots)
+	}
+	if session.StringOf(1) != 0 || session.StringOf(2) != 1 || session.StringOf(4) != 1 {
+		t.Errorf("StringOf() assigns shots to the wrong string")
+	}
+
+	sub := session.SubSession(1)
+	if avg, _ := sub.CalculateAverageVelocity(); avg.MetersPerSecond() != 281 || session.ShotCount() != 5 {
+		t.Errorf("SubSession(1) average = %.2f, want 281", avg.MetersPerSecond())
+	}
+
+	if err := session.RenameString(0, "Fill 1"); err != nil || session.Strings[0].Name != "Fill 1" {
+		t.Errorf("RenameString(0) = %v, strings %+v", err, session.Strings)
+	}
+	if err := session.RenameString(2, "x"); err == nil {
+		t.Error("renaming a missing string should fail")
+	}
+
+	data, _ := json.Marshal(session)
+	var loaded Session
+	if err := json.Unmarshal(data, &loaded); err != nil || len(loaded.Strings) != 2 || loaded.Strings[1].FirstShot != 2 {
+		t.Errorf("strings not persisted: %+v, %v", loaded.Strings, err)
+	}
+}
+
+func TestSession_StartStringOnEmptySession(t *testing.T) {
+	session := NewSession(createTestProfile(), createTestProjectile())
+
+	// Noch keine Schüsse: die erste Serie bekommt gleich den Namen
+	if _, err := session.StartString("Fill 1"); err != nil {
+		t.Fatalf("StartString() failed: %v", err)
+	}
+	if len(session.Strings) != 1 || session.Strings[0].FirstShot != 0 || session.StringCount() != 1 {
+		t.Errorf("strings = %+v", session.Strings)
+	}
+}
+
+func TestSession_SetStringFill(t *testing.T) {
+	session := NewSession(createTestProfile(), createTestProjectile())
+	for _, v := range []float64{280, 281, 282, 283} {
+		velocity, _ := valueobjects.NewVelocity(v)
+		session.RecordShot(velocity)
+	}
+
+	start, _ := valueobjects.NewFillPressure(250)
+	end, _ := valueobjects.NewFillPressure(170)
+	if _, err := NewFillEvent(end, &start); err == nil {
+		t.Error("end pressure above start pressure should fail")
+	}
+	fill, err := NewFillEvent(start, &end)
+	if err != nil {
+		t.Fatalf("NewFillEvent() failed: %v", err)
+	}
+	if drop, ok := fill.DropPerShot(4); !ok || drop != 20 {
+		t.Errorf("DropPerShot(4) = %.2f, %v, want 20", drop, ok)
+	}
+
+	// Ohne Serien wird die ganze Session zur ersten Serie
+	if err := session.SetStringFill(0, fill); err != nil {
+		t.Fatalf("SetStringFill(0) failed: %v", err)
+	}
+	if len(session.Strings) != 1 || session.StringCount() != 1 || len(session.StringShots(0)) != 4 {
+		t.Fatalf("strings = %+v", session.Strings)
+	}
+	if err := session.SetStringFill(1, fill); err == nil {
+		t.Error("setting the fill of a missing string should fail")
+	}
+
+	data, _ := json.Marshal(session)
+	var loaded Session
+	if err := json.Unmarshal(data, &loaded); err != nil || loaded.Strings[0].Fill == nil ||
+		loaded.Strings[0].Fill.EndPressure.Bar() != 170 {
+		t.Errorf("fill not persisted: %+v, %v", loaded.Strings[0], err)
+	}
+
+	if err := session.SetStringFill(0, nil); err != nil || session.Strings[0].Fill != nil {
+		t.Errorf("SetStringFill(0, nil) = %v, fill %+v", err, session.Strings[0].Fill)
+	}
+}
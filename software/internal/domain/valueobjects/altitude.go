@@ -0,0 +1,45 @@
+package valueobjects
+
+import "fmt"
+
+// Altitude repräsentiert eine Höhe über Normalnull in Metern (m).
+//
+// Eigener Typ statt Length: Length ist in Millimetern und nie negativ,
+// eine Höhe kann unter dem Meeresspiegel liegen (Totes Meer: -430 m).
+type Altitude float64
+
+const (
+	MinAltitudeMeters = -500.0
+	MaxAltitudeMeters = 9000.0
+)
+
+// NewAltitude erstellt eine neue Altitude mit Validierung.
+func NewAltitude(meters float64) (Altitude, error) {
+	if meters < MinAltitudeMeters || meters > MaxAltitudeMeters {
+		return 0, fmt.Errorf("altitude out of bounds (%.0f - %.0f m), got: %.2f m", MinAltitudeMeters, MaxAltitudeMeters, meters)
+	}
+	return Altitude(meters), nil
+}
+
+// feetToMeters rechnet Fuß in Meter um (1 ft = 0.3048 m), in beide Richtungen.
+const feetToMeters = 0.3048
+
+// NewAltitudeFromFeet erstellt eine Altitude aus Fuß.
+func NewAltitudeFromFeet(feet float64) (Altitude, error) {
+	return NewAltitude(feet * feetToMeters)
+}
+
+// Meters gibt die Höhe in Metern zurück (Basiseinheit).
+func (a Altitude) Meters() float64 {
+	return float64(a)
+}
+
+// Feet gibt die Höhe in Fuß zurück.
+func (a Altitude) Feet() float64 {
+	return float64(a) / feetToMeters
+}
+
+// String implementiert fmt.Stringer für schöne Ausgabe.
+func (a Altitude) String() string {
+	return fmt.Sprintf("%.0f m", a.Meters())
+}
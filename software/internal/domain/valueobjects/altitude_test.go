@@ -0,0 +1,24 @@
+package valueobjects
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewAltitude(t *testing.T) {
+	for _, meters := range []float64{-430, 0, 2500} {
+		if _, err := NewAltitude(meters); err != nil {
+			t.Errorf("NewAltitude(%v) unexpected error: %v", meters, err)
+		}
+	}
+	for _, meters := range []float64{-501, 9001} {
+		if _, err := NewAltitude(meters); err == nil {
+			t.Errorf("NewAltitude(%v) expected error, got nil", meters)
+		}
+	}
+
+	a, _ := NewAltitudeFromFeet(5280)
+	if math.Abs(a.Meters()-1609.344) > 1e-9 {
+		t.Errorf("5280 ft = %v m, want 1609.344", a.Meters())
+	}
+}
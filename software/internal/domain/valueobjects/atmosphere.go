@@ -0,0 +1,113 @@
+package valueobjects
+
+import "math"
+
+// Atmosphere fasst die Umgebungsbedingungen einer Messung zusammen und
+// leitet daraus Luftdichte und Dichtehöhe ab.
+//
+// Alle Werte sind optional. Fehlende Werte werden aus der ICAO-Standard-
+// atmosphäre (ISA) ergänzt: ohne Druck der Standarddruck der Höhe, ohne
+// Temperatur die Standardtemperatur der Höhe (15 °C auf Meereshöhe), ohne
+// Feuchte trockene Luft. Ohne Druck UND Höhe ist keine Aussage möglich.
+//
+// GO-KONZEPT: Value Object aus Value Objects
+// Pointer-Felder unterscheiden "nicht gemessen" (nil) von 0.
+type Atmosphere struct {
+	Temperature *Temperature
+	Pressure    *Pressure
+	Humidity    *Humidity
+	Altitude    *Altitude
+}
+
+// ICAO-Standardatmosphäre (Troposphäre)
+const (
+	StandardTemperatureCelsius = 15.0
+	StandardPressureHPa        = 1013.25
+	StandardAirDensity         = 1.225  // kg/m³ bei 15 °C und 1013.25 hPa, trocken
+	standardLapseRate          = 0.0065 // K/m
+	standardKelvin             = 288.15
+	pressureExponent           = 5.25588 // g·M / (R·L)
+
+	gasConstantDryAir = 287.058 // J/(kg·K)
+	gasConstantVapor  = 461.495 // J/(kg·K)
+)
+
+// StandardPressure gibt den ISA-Druck in der Höhe zurück.
+func StandardPressure(altitude Altitude) Pressure {
+	ratio := 1 - standardLapseRate*altitude.Meters()/standardKelvin
+	return Pressure(StandardPressureHPa * math.Pow(ratio, pressureExponent))
+}
+
+// StandardTemperature gibt die ISA-Temperatur in der Höhe zurück.
+func StandardTemperature(altitude Altitude) Temperature {
+	return Temperature(StandardTemperatureCelsius - standardLapseRate*altitude.Meters())
+}
+
+// AirDensity berechnet die Luftdichte in kg/m³.
+// ok ist false, wenn weder Druck noch Höhe bekannt sind.
+//
+// Formel: ρ = p_d / (R_d·T) + p_v / (R_v·T)
+// mit Dampfdruck p_v = RH · p_sat(T) (Magnus/Tetens) und p_d = p - p_v.
+func (a Atmosphere) AirDensity() (density float64, ok bool) {
+	var altitude Altitude
+	if a.Altitude != nil {
+		altitude = *a.Altitude
+	}
+
+	var pressure Pressure
+	switch {
+	case a.Pressure != nil:
+		pressure = *a.Pressure
+	case a.Altitude != nil:
+		pressure = StandardPressure(altitude)
+	default:
+		return 0, false
+	}
+
+	temperature := StandardTemperature(altitude)
+	if a.Temperature != nil {
+		temperature = *a.Temperature
+	}
+	celsius := temperature.Celsius()
+	kelvin := celsius + 273.15
+
+	vapor := 0.0 // Pa
+	if a.Humidity != nil {
+		saturation := 6.1078 * math.Pow(10, 7.5*celsius/(celsius+237.3)) // hPa
+		vapor = a.Humidity.Fraction() * saturation * 100
+	}
+	dry := pressure.Pascals() - vapor
+
+	return dry/(gasConstantDryAir*kelvin) + vapor/(gasConstantVapor*kelvin), true
+}
+
+// SpeedOfSound gibt die Schallgeschwindigkeit in m/s zurück.
+// Sie hängt (für ideale Gase) nur von der Temperatur ab; ohne Messung gilt
+// die Standardtemperatur der Höhe bzw. 15 °C.
+//
+// Formel: c = 331.3 · √(1 + ϑ / 273.15)
+func (a Atmosphere) SpeedOfSound() float64 {
+	temperature := Temperature(StandardTemperatureCelsius)
+	if a.Altitude != nil {
+		temperature = StandardTemperature(*a.Altitude)
+	}
+	if a.Temperature != nil {
+		temperature = *a.Temperature
+	}
+	return 331.3 * math.Sqrt(1+temperature.Celsius()/273.15)
+}
+
+// DensityAltitude ist die Höhe, in der die ISA-Atmosphäre dieselbe
+// Luftdichte hat. Sie fasst Druck, Temperatur und Feuchte in einer Zahl
+// zusammen, die direkt mit anderen Tagen vergleichbar ist.
+//
+// Das Ergebnis wird nicht gegen MinAltitudeMeters/MaxAltitudeMeters
+// geprüft - an heißen Tagen liegt die Dichtehöhe weit über der echten Höhe.
+func (a Atmosphere) DensityAltitude() (Altitude, bool) {
+	density, ok := a.AirDensity()
+	if !ok {
+		return 0, false
+	}
+	ratio := math.Pow(density/StandardAirDensity, 1/(pressureExponent-1))
+	return Altitude(standardKelvin / standardLapseRate * (1 - ratio)), true
+}
@@ -0,0 +1,87 @@
+package valueobjects
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAtmosphere_AirDensity(t *testing.T) {
+	temp := func(c float64) *Temperature { v := Temperature(c); return &v }
+	pressure := func(hPa float64) *Pressure { v := Pressure(hPa); return &v }
+	humidity := func(pct float64) *Humidity { v := Humidity(pct); return &v }
+	altitude := func(m float64) *Altitude { v := Altitude(m); return &v }
+
+	tests := []struct {
+		name                string
+		atmosphere          Atmosphere
+		wantDensity         float64
+		wantDensityAltitude float64
+		wantOK              bool
+	}{
+		{
+			name:                "ISA sea level",
+			atmosphere:          Atmosphere{Temperature: temp(15), Pressure: pressure(1013.25)},
+			wantDensity:         1.2250,
+			wantDensityAltitude: 0,
+			wantOK:              true,
+		},
+		{
+			name:                "altitude only uses ISA pressure and temperature",
+			atmosphere:          Atmosphere{Altitude: altitude(1000)},
+			wantDensity:         1.1117,
+			wantDensityAltitude: 1000,
+			wantOK:              true,
+		},
+		{
+			name:                "hot day at sea level",
+			atmosphere:          Atmosphere{Temperature: temp(30), Pressure: pressure(1013.25)},
+			wantDensity:         1.1644,
+			wantDensityAltitude: 526,
+			wantOK:              true,
+		},
+		{
+			name:                "humid air is lighter",
+			atmosphere:          Atmosphere{Temperature: temp(30), Pressure: pressure(1013.25), Humidity: humidity(100)},
+			wantDensity:         1.1460,
+			wantDensityAltitude: 690,
+			wantOK:              true,
+		},
+		{
+			name:       "temperature alone is not enough",
+			atmosphere: Atmosphere{Temperature: temp(20), Humidity: humidity(50)},
+			wantOK:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			density, ok := tt.atmosphere.AirDensity()
+			if ok != tt.wantOK {
+				t.Fatalf("AirDensity() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if math.Abs(density-tt.wantDensity) > 0.0005 {
+				t.Errorf("AirDensity() = %.4f kg/m³, want %.4f", density, tt.wantDensity)
+			}
+
+			da, _ := tt.atmosphere.DensityAltitude()
+			if math.Abs(da.Meters()-tt.wantDensityAltitude) > 5 {
+				t.Errorf("DensityAltitude() = %.0f m, want %.0f", da.Meters(), tt.wantDensityAltitude)
+			}
+		})
+	}
+}
+
+func TestAtmosphere_SpeedOfSound(t *testing.T) {
+	standard := Atmosphere{}
+	if c := standard.SpeedOfSound(); math.Abs(c-340.3) > 0.1 {
+		t.Errorf("SpeedOfSound() at 15 °C = %.1f m/s, want 340.3", c)
+	}
+
+	cold := Temperature(-10)
+	if c := (Atmosphere{Temperature: &cold}).SpeedOfSound(); math.Abs(c-325.2) > 0.1 {
+		t.Errorf("SpeedOfSound() at -10 °C = %.1f m/s, want 325.2", c)
+	}
+}
@@ -17,6 +17,12 @@ func (e Energy) Joules() float64 {
 	return float64(e)
 }
 
+// FootPounds gibt die Energie in Foot-Pounds (ft·lbf) zurück.
+// 1 ft·lbf = 1.3558179483 J
+func (e Energy) FootPounds() float64 {
+	return e.Joules() / 1.3558179483
+}
+
 // String implementiert fmt.Stringer.
 func (e Energy) String() string {
 	return fmt.Sprintf("%.2f J", e.Joules())
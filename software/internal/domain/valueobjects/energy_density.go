@@ -0,0 +1,43 @@
+package valueobjects
+
+import (
+	"fmt"
+	"math"
+)
+
+// EnergyDensity repräsentiert die Energie pro Querschnittsfläche des
+// Projektils in J/cm² (Basiseinheit).
+//
+// Jäger beurteilen die Wirkung nicht nur nach der Energie, sondern danach,
+// auf welche Fläche sie trifft: 7.5 J aus 4.5 mm sind 47 J/cm², aus 5.5 mm
+// nur 32 J/cm².
+//
+// Wie Energy wird EnergyDensity nie direkt erstellt, sondern berechnet
+// (siehe CalculateEnergyDensity).
+type EnergyDensity float64
+
+// JoulesPerSquareCentimeter gibt die Energiedichte in J/cm² zurück (Basiseinheit).
+func (d EnergyDensity) JoulesPerSquareCentimeter() float64 {
+	return float64(d)
+}
+
+// FootPoundsPerSquareInch gibt die Energiedichte in ft·lbf/in² zurück.
+// 1 in² = 6.4516 cm²
+func (d EnergyDensity) FootPoundsPerSquareInch() float64 {
+	return Energy(d.JoulesPerSquareCentimeter() * 6.4516).FootPounds()
+}
+
+// String implementiert fmt.Stringer.
+func (d EnergyDensity) String() string {
+	return fmt.Sprintf("%.1f J/cm²", d.JoulesPerSquareCentimeter())
+}
+
+// CalculateEnergyDensity berechnet E / A mit A = π·d²/4 (Querschnitt
+// aus dem Kaliber d).
+func CalculateEnergyDensity(energy Energy, caliber Length) (EnergyDensity, error) {
+	if caliber.Centimeters() <= 0 {
+		return 0, fmt.Errorf("caliber must be positive, got: %.2f mm", caliber.Millimeters())
+	}
+	area := math.Pi * caliber.Centimeters() * caliber.Centimeters() / 4
+	return EnergyDensity(energy.Joules() / area), nil
+}
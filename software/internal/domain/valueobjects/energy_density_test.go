@@ -0,0 +1,26 @@
+package valueobjects
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculateEnergyDensity(t *testing.T) {
+	// 7.5 J aus 4.5 mm: A = π·0.45²/4 = 0.159 cm²
+	density, err := CalculateEnergyDensity(Energy(7.5), Length(4.5))
+	if err != nil {
+		t.Fatalf("CalculateEnergyDensity() failed: %v", err)
+	}
+	if math.Abs(density.JoulesPerSquareCentimeter()-47.16) > 0.01 {
+		t.Errorf("density = %.2f J/cm², want 47.16", density.JoulesPerSquareCentimeter())
+	}
+
+	// 1 J/cm² = 6.4516 J/in² = 4.758 ft·lbf/in²
+	if got := EnergyDensity(1).FootPoundsPerSquareInch(); math.Abs(got-4.7585) > 0.001 {
+		t.Errorf("1 J/cm² = %.4f ft·lbf/in², want 4.7585", got)
+	}
+
+	if _, err := CalculateEnergyDensity(Energy(7.5), Length(0)); err == nil {
+		t.Error("zero caliber should fail")
+	}
+}
@@ -61,3 +61,11 @@ func TestEnergy_String(t *testing.T) {
 		t.Errorf("Energy.String() = %q, want %q", got, want)
 	}
 }
+
+func TestEnergy_FootPounds(t *testing.T) {
+	// 16.27 J = 12 ft·lbf (UK-Grenze für Luftgewehre)
+	got := Energy(16.27).FootPounds()
+	if math.Abs(got-12.0) > 0.01 {
+		t.Errorf("FootPounds() = %.3f, want ~12.0", got)
+	}
+}
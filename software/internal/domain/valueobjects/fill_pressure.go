@@ -0,0 +1,47 @@
+package valueobjects
+
+import "fmt"
+
+// FillPressure ist der Druck im Tank einer Pressluftwaffe (PCP) in bar
+// (Überdruck, wie ihn das Manometer der Waffe anzeigt).
+//
+// Nicht zu verwechseln mit Pressure, dem Luftdruck am Schießstand in hPa:
+// 200 bar Fülldruck sind 200000 hPa, eine Verwechslung soll auffallen.
+type FillPressure float64
+
+const (
+	// MaxFillPressureBar liegt über den üblichen 300-bar-Kartuschen und
+	// HPA-Flaschen (bis ~450 bar)
+	MaxFillPressureBar = 500.0
+
+	// barPerPSI: 1 psi = 0.0689476 bar
+	barPerPSI = 0.0689476
+)
+
+// NewFillPressure erstellt einen FillPressure mit Validierung (0 = leer).
+func NewFillPressure(bar float64) (FillPressure, error) {
+	if bar < 0 || bar > MaxFillPressureBar {
+		return 0, fmt.Errorf("fill pressure out of bounds (0 - %.0f bar), got: %.2f bar", MaxFillPressureBar, bar)
+	}
+	return FillPressure(bar), nil
+}
+
+// NewFillPressureFromPSI erstellt einen FillPressure aus psi.
+func NewFillPressureFromPSI(psi float64) (FillPressure, error) {
+	return NewFillPressure(psi * barPerPSI)
+}
+
+// Bar gibt den Druck in bar zurück (Basiseinheit).
+func (p FillPressure) Bar() float64 {
+	return float64(p)
+}
+
+// PSI gibt den Druck in psi zurück (Manometer US-amerikanischer Waffen).
+func (p FillPressure) PSI() float64 {
+	return float64(p) / barPerPSI
+}
+
+// String implementiert fmt.Stringer für schöne Ausgabe.
+func (p FillPressure) String() string {
+	return fmt.Sprintf("%.0f bar", p.Bar())
+}
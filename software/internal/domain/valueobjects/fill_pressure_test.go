@@ -0,0 +1,51 @@
+package valueobjects
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewFillPressure(t *testing.T) {
+	tests := []struct {
+		name    string
+		bar     float64
+		wantErr bool
+	}{
+		{name: "carbon tank", bar: 300},
+		{name: "empty", bar: 0},
+		{name: "upper bound", bar: MaxFillPressureBar},
+		{name: "negative", bar: -1, wantErr: true},
+		{name: "psi by mistake", bar: 3000, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewFillPressure(tt.bar)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NewFillPressure(%v) expected error, got nil", tt.bar)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewFillPressure(%v) unexpected error: %v", tt.bar, err)
+			}
+			if p.Bar() != tt.bar {
+				t.Errorf("Bar() = %v, want %v", p.Bar(), tt.bar)
+			}
+		})
+	}
+}
+
+func TestNewFillPressureFromPSI(t *testing.T) {
+	p, err := NewFillPressureFromPSI(3000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(p.Bar()-206.84) > 0.01 {
+		t.Errorf("3000 psi = %.2f bar, want 206.84", p.Bar())
+	}
+	if math.Abs(p.PSI()-3000) > 1e-9 {
+		t.Errorf("PSI() = %v, want 3000", p.PSI())
+	}
+}
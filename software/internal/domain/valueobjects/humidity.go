@@ -0,0 +1,29 @@
+package valueobjects
+
+import "fmt"
+
+// Humidity repräsentiert eine relative Luftfeuchtigkeit in Prozent (0-100 %).
+type Humidity float64
+
+// NewHumidity erstellt eine neue Humidity mit Validierung.
+func NewHumidity(percent float64) (Humidity, error) {
+	if percent < 0 || percent > 100 {
+		return 0, fmt.Errorf("relative humidity out of bounds (0 - 100 %%), got: %.2f %%", percent)
+	}
+	return Humidity(percent), nil
+}
+
+// Percent gibt die relative Feuchte in Prozent zurück (Basiseinheit).
+func (h Humidity) Percent() float64 {
+	return float64(h)
+}
+
+// Fraction gibt die relative Feuchte als Anteil 0..1 zurück (für Formeln).
+func (h Humidity) Fraction() float64 {
+	return float64(h) / 100.0
+}
+
+// String implementiert fmt.Stringer für schöne Ausgabe.
+func (h Humidity) String() string {
+	return fmt.Sprintf("%.0f %%", h.Percent())
+}
@@ -0,0 +1,16 @@
+package valueobjects
+
+import "testing"
+
+func TestNewHumidity(t *testing.T) {
+	for _, percent := range []float64{0, 45.5, 100} {
+		if _, err := NewHumidity(percent); err != nil {
+			t.Errorf("NewHumidity(%v) unexpected error: %v", percent, err)
+		}
+	}
+	for _, percent := range []float64{-1, 100.1} {
+		if _, err := NewHumidity(percent); err == nil {
+			t.Errorf("NewHumidity(%v) expected error, got nil", percent)
+		}
+	}
+}
@@ -15,6 +15,9 @@ import (
 // 3. Semantik im Code zu verbessern (Mass statt float64)
 type Mass float64
 
+// grainToGram rechnet Grain in Gramm um (1 Grain = 0.06479891 g), in beide Richtungen.
+const grainToGram = 0.06479891
+
 // NewMass erstellt eine neue Mass-Instanz mit Validierung.
 //
 // GO-KONZEPT: Constructor Pattern
@@ -54,10 +57,14 @@ func (m Mass) Kilograms() float64 {
 // GO-KONZEPT: Package-Level Function
 // Diese Funktion gehört zum Package, nicht zu einem spezifischen Typ.
 func MassFromGrain(grain float64) (Mass, error) {
-	const grainToGram = 0.06479891
 	return NewMass(grain * grainToGram)
 }
 
+// Grains gibt die Masse in Grain zurück (Umkehrung von MassFromGrain).
+func (m Mass) Grains() float64 {
+	return m.Grams() / grainToGram
+}
+
 // String implementiert das fmt.Stringer Interface.
 //
 // GO-KONZEPT: Interfaces
@@ -164,3 +164,10 @@ func TestMass_UnmarshalJSON_Invalid(t *testing.T) {
 		})
 	}
 }
+
+func TestMass_Grains(t *testing.T) {
+	m, _ := MassFromGrain(8.44)
+	if got := m.Grains(); got < 8.4399 || got > 8.4401 {
+		t.Errorf("Grains() = %.4f, want 8.44", got)
+	}
+}
@@ -0,0 +1,51 @@
+package valueobjects
+
+import "fmt"
+
+// Pressure repräsentiert einen Luftdruck in Hektopascal (hPa).
+//
+// Gemeint ist der Stationsdruck (absoluter Druck am Schießstand), wie ihn
+// Wetterstationen und Handmessgeräte als "Station Pressure" anzeigen -
+// nicht der auf Meereshöhe reduzierte QNH-Wert aus dem Wetterbericht.
+type Pressure float64
+
+const (
+	// MinPressureHPa liegt unter dem Druck auf dem Mount Everest (~337 hPa)
+	MinPressureHPa = 300.0
+	// MaxPressureHPa liegt über dem höchsten je gemessenen Bodendruck (~1084 hPa)
+	MaxPressureHPa = 1100.0
+)
+
+// NewPressure erstellt einen neuen Pressure mit Validierung.
+func NewPressure(hPa float64) (Pressure, error) {
+	if hPa < MinPressureHPa || hPa > MaxPressureHPa {
+		return 0, fmt.Errorf("pressure out of bounds (%.0f - %.0f hPa), got: %.2f hPa", MinPressureHPa, MaxPressureHPa, hPa)
+	}
+	return Pressure(hPa), nil
+}
+
+// NewPressureFromInHg erstellt einen Pressure aus Inch Quecksilbersäule.
+// 1 inHg = 33.8639 hPa
+func NewPressureFromInHg(inHg float64) (Pressure, error) {
+	return NewPressure(inHg * 33.8639)
+}
+
+// HectoPascals gibt den Druck in hPa zurück (Basiseinheit, = mbar).
+func (p Pressure) HectoPascals() float64 {
+	return float64(p)
+}
+
+// Pascals gibt den Druck in Pa zurück (SI, für Formeln).
+func (p Pressure) Pascals() float64 {
+	return float64(p) * 100.0
+}
+
+// InHg gibt den Druck in Inch Quecksilbersäule zurück.
+func (p Pressure) InHg() float64 {
+	return float64(p) / 33.8639
+}
+
+// String implementiert fmt.Stringer für schöne Ausgabe.
+func (p Pressure) String() string {
+	return fmt.Sprintf("%.1f hPa", p.HectoPascals())
+}
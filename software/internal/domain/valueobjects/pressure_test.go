@@ -0,0 +1,52 @@
+package valueobjects
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewPressure(t *testing.T) {
+	tests := []struct {
+		name    string
+		hPa     float64
+		wantErr bool
+	}{
+		{name: "standard sea level", hPa: 1013.25},
+		{name: "high range", hPa: 700.0},
+		{name: "lower bound", hPa: MinPressureHPa},
+		{name: "upper bound", hPa: MaxPressureHPa},
+		{name: "QNH in kPa by mistake", hPa: 101.3, wantErr: true},
+		{name: "Pa by mistake", hPa: 101325, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPressure(tt.hPa)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NewPressure(%v) expected error, got nil", tt.hPa)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewPressure(%v) unexpected error: %v", tt.hPa, err)
+			}
+			if p.HectoPascals() != tt.hPa {
+				t.Errorf("HectoPascals() = %v, want %v", p.HectoPascals(), tt.hPa)
+			}
+		})
+	}
+}
+
+func TestNewPressureFromInHg(t *testing.T) {
+	p, err := NewPressureFromInHg(29.92)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(p.HectoPascals()-1013.21) > 0.01 {
+		t.Errorf("29.92 inHg = %.2f hPa, want 1013.21", p.HectoPascals())
+	}
+	if math.Abs(p.InHg()-29.92) > 1e-9 {
+		t.Errorf("InHg() = %v, want 29.92", p.InHg())
+	}
+}
@@ -13,6 +13,12 @@ func NewTemperature(celsius float64) (Temperature, error) {
 	return Temperature(celsius), nil
 }
 
+// NewTemperatureFromFahrenheit erstellt eine Temperature aus Grad Fahrenheit.
+// Formel: °C = (°F - 32) × 5/9
+func NewTemperatureFromFahrenheit(fahrenheit float64) (Temperature, error) {
+	return NewTemperature((fahrenheit - 32.0) * 5.0 / 9.0)
+}
+
 // Celsius gibt die Temperatur in Grad Celsius zurück.
 func (t Temperature) Celsius() float64 {
 	return float64(t)
@@ -189,3 +189,17 @@ func ExampleTemperature_Fahrenheit() {
 	// Output zeigt das erwartete Ergebnis
 	println(fahrenheit) // Ungefähr 69.8°F
 }
+
+func TestNewTemperatureFromFahrenheit(t *testing.T) {
+	temp, err := NewTemperatureFromFahrenheit(68.0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := temp.Celsius(); got < 19.999 || got > 20.001 {
+		t.Errorf("Celsius() = %.3f, want 20", got)
+	}
+
+	if _, err := NewTemperatureFromFahrenheit(-500); err == nil {
+		t.Error("expected error below absolute zero")
+	}
+}
@@ -17,6 +17,9 @@ const (
 	MaxVelocityMPS = 5000.0 // Extreme Rifles können bis ~4500 m/s erreichen
 )
 
+// fpsToMps rechnet fps in m/s um (1 fps = 0.3048 m/s), in beide Richtungen.
+const fpsToMps = 0.3048
+
 // NewVelocity erstellt eine neue Velocity mit Validierung.
 func NewVelocity(metersPerSecond float64) (Velocity, error) {
 	if metersPerSecond < 0 {
@@ -41,10 +44,15 @@ func (v Velocity) MetersPerSecond() float64 {
 // WICHTIG für Metric Neo: Die LMBR-Hardware sendet in fps,
 // aber wir speichern IMMER in m/s (siehe domain-model.md Abschnitt 5.4).
 func VelocityFromFPS(fps float64) (Velocity, error) {
-	const fpsToMps = 0.3048
 	return NewVelocity(fps * fpsToMps)
 }
 
+// FeetPerSecond gibt die Geschwindigkeit in fps zurück.
+// Nur für Export/Anzeige - gespeichert wird immer in m/s.
+func (v Velocity) FeetPerSecond() float64 {
+	return v.MetersPerSecond() / fpsToMps
+}
+
 // String implementiert fmt.Stringer für schöne Ausgabe.
 func (v Velocity) String() string {
 	return fmt.Sprintf("%.2f m/s", v.MetersPerSecond())
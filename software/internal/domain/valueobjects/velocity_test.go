@@ -1,6 +1,9 @@
 package valueobjects
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestNewVelocity(t *testing.T) {
 	tests := []struct {
@@ -71,3 +74,16 @@ func TestVelocity_String(t *testing.T) {
 		t.Errorf("Velocity.String() = %q, want %q", got, want)
 	}
 }
+
+func TestVelocity_FeetPerSecond(t *testing.T) {
+	v, _ := NewVelocity(175.0)
+	if got := v.FeetPerSecond(); math.Abs(got-574.15) > 0.01 {
+		t.Errorf("FeetPerSecond() = %.2f, want ~574.15", got)
+	}
+
+	// Roundtrip über VelocityFromFPS
+	back, _ := VelocityFromFPS(v.FeetPerSecond())
+	if math.Abs(back.MetersPerSecond()-175.0) > 1e-9 {
+		t.Errorf("roundtrip = %v, want 175 m/s", back)
+	}
+}
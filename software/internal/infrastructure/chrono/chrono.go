@@ -4,9 +4,6 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
-	"io"
-	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -156,24 +153,48 @@ func (m *MockChrono) StopAutoRead() {
 
 // SerialChrono ist die echte RS232-Implementierung.
 // Nutzt go.bug.st/serial für Kommunikation mit Chronograph.
+// Das Protokoll (Framing, Einheit, Parser) kommt vom Driver.
 type SerialChrono struct {
 	mu          sync.Mutex
 	connected   bool
 	autoRunning bool
+	driver      Driver
 	port        serial.Port
 	reader      *bufio.Reader
 	stopChan    chan struct{}
+	wire        *WireLog
 }
 
-// NewSerialChrono erstellt einen echten Chronograph.
+// NewSerialChrono erstellt einen echten Chronograph mit dem Standard-Treiber (LMBR).
 func NewSerialChrono() *SerialChrono {
+	driver, _ := LookupDriver(DefaultDriverName)
+	return NewSerialChronoWithDriver(driver)
+}
+
+// NewSerialChronoWithDriver erstellt einen Chronograph für ein bestimmtes Protokoll.
+func NewSerialChronoWithDriver(driver Driver) *SerialChrono {
 	return &SerialChrono{
 		connected:   false,
 		autoRunning: false,
+		driver:      driver,
 	}
 }
 
+// Driver gibt den verwendeten Treiber zurück.
+func (s *SerialChrono) Driver() Driver {
+	return s.driver
+}
+
+// SetWireLog protokolliert ab jetzt jeden empfangenen Frame (nil = aus).
+func (s *SerialChrono) SetWireLog(wire *WireLog) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.wire = wire
+}
+
 // Connect stellt RS232-Verbindung her.
+// baudRate <= 0 verwendet die Baudrate des Treibers.
 func (s *SerialChrono) Connect(port string, baudRate int) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -181,17 +202,12 @@ func (s *SerialChrono) Connect(port string, baudRate int) error {
 	if port == "" {
 		return fmt.Errorf("port cannot be empty")
 	}
-	if baudRate <= 0 {
-		return fmt.Errorf("baud rate must be > 0")
-	}
 
 	if s.port != nil {
 		return fmt.Errorf("already connected")
 	}
 
-	mode := &serial.Mode{
-		BaudRate: baudRate,
-	}
+	mode := s.driver.Mode(baudRate)
 
 	serialPort, err := serial.Open(port, mode)
 	if err != nil {
@@ -201,6 +217,9 @@ func (s *SerialChrono) Connect(port string, baudRate int) error {
 	s.port = serialPort
 	s.reader = bufio.NewReader(serialPort)
 	s.connected = true
+	if s.wire != nil {
+		s.wire.Connected(s.driver, port)
+	}
 	return nil
 }
 
@@ -235,44 +254,22 @@ func (s *SerialChrono) IsConnected() bool {
 }
 
 // ReadVelocity liest von RS232.
-// Erwartet Linie mit Geschwindigkeit in m/s oder fps (wird konvertiert).
-// Kommas werden als Dezimaltrennzeichen erkannt (z.B. "175,23" oder "175.23").
+// Frames ohne Messwert (z.B. Statuszeilen) werden übersprungen,
+// fps-Werte werden vom Treiber in m/s konvertiert.
 func (s *SerialChrono) ReadVelocity() (float32, error) {
 	s.mu.Lock()
 	if !s.connected || s.reader == nil {
 		s.mu.Unlock()
 		return 0, fmt.Errorf("chrono not connected")
 	}
-	reader := s.reader
+	reader, wire := s.reader, s.wire
 	s.mu.Unlock()
 
-	line, err := reader.ReadString('\n')
-	if err != nil {
-		if errors.Is(err, io.EOF) {
-			return 0, fmt.Errorf("chrono disconnected (EOF)")
-		}
-		return 0, fmt.Errorf("failed to read from chrono: %w", err)
+	velocity, err := s.driver.readVelocity(reader, wire)
+	if wire != nil && errors.Is(err, ErrDisconnected) {
+		wire.Note(err.Error())
 	}
-
-	line = strings.TrimSpace(line)
-	if line == "" {
-		return 0, fmt.Errorf("empty chrono line")
-	}
-
-	// Ersetze Komma durch Punkt für Dezimalzahl-Parsing
-	line = strings.ReplaceAll(line, ",", ".")
-
-	value, err := strconv.ParseFloat(line, 32)
-	if err != nil {
-		return 0, fmt.Errorf("invalid chrono value format: %s (error: %w)", line, err)
-	}
-
-	// Validierung: Bounds-Check (0.1 - 5000 m/s für Debugging bis Extreme Rifles)
-	if value < 0.1 || value > 5000 {
-		return 0, fmt.Errorf("velocity out of bounds (0.1-5000 m/s), got: %.2f", value)
-	}
-
-	return float32(value), nil
+	return velocity, err
 }
 
 // StartAutoRead startet Leseschleife im Hintergrund.
@@ -298,6 +295,15 @@ func (s *SerialChrono) StartAutoRead(velocityChannel chan<- float32, errorChanne
 				return
 			default:
 				velocity, err := s.ReadVelocity()
+				if errors.Is(err, ErrDisconnected) {
+					// Port ist tot: einmal melden und aufhören, statt den Fehler
+					// endlos zu wiederholen. Wiederverbinden ist Sache des Supervisors.
+					s.markDisconnected()
+					if errorChannel != nil {
+						errorChannel <- err
+					}
+					return
+				}
 				if err != nil {
 					if errorChannel != nil {
 						errorChannel <- err
@@ -314,6 +320,21 @@ func (s *SerialChrono) StartAutoRead(velocityChannel chan<- float32, errorChanne
 	}()
 }
 
+// markDisconnected schließt den Port nach einem Verbindungsabbruch.
+// Die Leseschleife beendet sich selbst, daher wird stopChan nicht geschlossen.
+func (s *SerialChrono) markDisconnected() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.port != nil {
+		s.port.Close()
+		s.port = nil
+		s.reader = nil
+	}
+	s.connected = false
+	s.autoRunning = false
+}
+
 // StopAutoRead stoppt Leseschleife.
 func (s *SerialChrono) StopAutoRead() {
 	s.mu.Lock()
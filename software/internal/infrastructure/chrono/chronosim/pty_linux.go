@@ -0,0 +1,58 @@
+//go:build linux
+
+package chronosim
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// openPTY öffnet ein Pseudo-Terminal-Paar über /dev/ptmx.
+//
+// Die Slave-Seite wird sofort in den Raw-Modus versetzt und offen gehalten:
+// So gibt es kein Echo der geschriebenen Daten, und das PTY bleibt bestehen,
+// auch wenn SerialChrono den Port schließt und neu öffnet.
+func openPTY() (master, slave *os.File, path string, err error) {
+	master, err = os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	fd := int(master.Fd())
+	if err := unix.IoctlSetPointerInt(fd, unix.TIOCSPTLCK, 0); err != nil {
+		master.Close()
+		return nil, nil, "", fmt.Errorf("unlockpt: %w", err)
+	}
+	n, err := unix.IoctlGetInt(fd, unix.TIOCGPTN)
+	if err != nil {
+		master.Close()
+		return nil, nil, "", fmt.Errorf("ptsname: %w", err)
+	}
+	path = fmt.Sprintf("/dev/pts/%d", n)
+
+	slave, err = os.OpenFile(path, os.O_RDWR|unix.O_NOCTTY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		master.Close()
+		return nil, nil, "", err
+	}
+
+	termios, err := unix.IoctlGetTermios(int(slave.Fd()), unix.TCGETS)
+	if err == nil {
+		// cfmakeraw
+		termios.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+		termios.Oflag &^= unix.OPOST
+		termios.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+		termios.Cflag &^= unix.CSIZE | unix.PARENB
+		termios.Cflag |= unix.CS8
+		err = unix.IoctlSetTermios(int(slave.Fd()), unix.TCSETS, termios)
+	}
+	if err != nil {
+		slave.Close()
+		master.Close()
+		return nil, nil, "", fmt.Errorf("raw mode: %w", err)
+	}
+
+	return master, slave, path, nil
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+
+package chronosim
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// openPTY ist nur unter Linux implementiert.
+func openPTY() (master, slave *os.File, path string, err error) {
+	return nil, nil, "", fmt.Errorf("chrono simulator is not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,270 @@
+// Package chronosim simuliert einen seriellen Chronographen über ein Pseudo-Terminal.
+//
+// Der Simulator öffnet ein PTY-Paar: Die Slave-Seite (Path) verhält sich wie
+// /dev/ttyUSB0 und kann mit chrono.SerialChrono geöffnet werden, die
+// Master-Seite schreibt der Simulator. So lassen sich Framing, Parser,
+// Fehlerbehandlung und Verbindungsabbrüche ohne Hardware testen.
+//
+// Nur unter Linux verfügbar (siehe pty_linux.go).
+//
+//	sim, _ := chronosim.New(chronosim.Options{Encoding: chronosim.EncodeLMBR})
+//	defer sim.Close()
+//	device := chrono.NewSerialChrono()
+//	device.Connect(sim.Path(), 19200)
+//	sim.Play(chronosim.Shot(175.2), chronosim.Garbage("ERR"), chronosim.Disconnect())
+package chronosim
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Encoding wandelt eine Geschwindigkeit (m/s) in die Bytes eines Geräts um.
+type Encoding func(mps float64) []byte
+
+const fpsPerMPS = 1 / 0.3048
+
+// Encodings passend zu den Treibern in chrono/driver.go
+var (
+	// EncodeLMBR: "175.23\n" (m/s)
+	EncodeLMBR Encoding = func(mps float64) []byte {
+		return []byte(strconv.FormatFloat(mps, 'f', 2, 64) + "\n")
+	}
+	// EncodeLMBRComma: "175,23\r\n" (m/s, Dezimalkomma)
+	EncodeLMBRComma Encoding = func(mps float64) []byte {
+		return []byte(strings.Replace(strconv.FormatFloat(mps, 'f', 2, 64), ".", ",", 1) + "\r\n")
+	}
+	// EncodeFPS: "574.8\n" (fps)
+	EncodeFPS Encoding = func(mps float64) []byte {
+		return []byte(strconv.FormatFloat(mps*fpsPerMPS, 'f', 1, 64) + "\n")
+	}
+	// EncodeTagged: "V=574.8 FPS\r"
+	EncodeTagged Encoding = func(mps float64) []byte {
+		return []byte("V=" + strconv.FormatFloat(mps*fpsPerMPS, 'f', 1, 64) + " FPS\r")
+	}
+	// EncodeSTXETX: <STX>574.8<ETX> (fps)
+	EncodeSTXETX Encoding = func(mps float64) []byte {
+		return []byte("\x02" + strconv.FormatFloat(mps*fpsPerMPS, 'f', 1, 64) + "\x03")
+	}
+)
+
+// EncodingFor gibt die passende Encoding für einen Treibernamen zurück.
+func EncodingFor(driver string) (Encoding, error) {
+	switch driver {
+	case "", "lmbr":
+		return EncodeLMBR, nil
+	case "generic-fps":
+		return EncodeFPS, nil
+	case "tagged":
+		return EncodeTagged, nil
+	case "stx-etx":
+		return EncodeSTXETX, nil
+	default:
+		return nil, fmt.Errorf("no simulator encoding for driver %s", driver)
+	}
+}
+
+// Options konfiguriert den Simulator.
+type Options struct {
+	// Encoding der Schüsse (Default: EncodeLMBR)
+	Encoding Encoding
+	// Interval ist die Pause nach jedem Schuss (Default: 0)
+	Interval time.Duration
+	// Link ist ein fester Pfad, der als Symlink auf das PTY zeigt - wie
+	// /dev/serial/by-id/... bei echten Adaptern. Path() gibt dann Link zurück.
+	// Der Link verschwindet beim Trennen und zeigt nach Replug auf das neue PTY.
+	Link string
+}
+
+// Simulator ist ein virtueller Chronograph an einem Pseudo-Terminal.
+type Simulator struct {
+	mu     sync.Mutex
+	opts   Options
+	master *os.File
+	slave  *os.File
+	path   string
+	closed bool
+}
+
+// New öffnet ein PTY-Paar und gibt den Simulator zurück.
+func New(opts Options) (*Simulator, error) {
+	if opts.Encoding == nil {
+		opts.Encoding = EncodeLMBR
+	}
+
+	s := &Simulator{opts: opts}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// open öffnet ein neues PTY-Paar und setzt ggf. den Link darauf.
+func (s *Simulator) open() error {
+	master, slave, path, err := openPTY()
+	if err != nil {
+		return fmt.Errorf("failed to open pseudo terminal: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.master, s.slave, s.path, s.closed = master, slave, path, false
+	if s.opts.Link == "" {
+		return nil
+	}
+
+	// Symlink atomar ersetzen: erst temporär anlegen, dann umbenennen
+	tmp := s.opts.Link + ".tmp"
+	os.Remove(tmp)
+	if err := os.Symlink(path, tmp); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.opts.Link)
+}
+
+// Path ist der Gerätepfad, den SerialChrono öffnen soll (z.B. /dev/pts/3
+// oder Options.Link).
+func (s *Simulator) Path() string {
+	if s.opts.Link != "" {
+		return s.opts.Link
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.path
+}
+
+// Play spielt die Events nacheinander ab.
+// Nach Disconnect() liefert jedes weitere Event einen Fehler (bis Replug).
+func (s *Simulator) Play(events ...Event) error {
+	for _, event := range events {
+		if err := event(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close trennt den Simulator (wie ein abgezogenes Kabel).
+func (s *Simulator) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	err := s.master.Close()
+	if s.slave != nil {
+		s.slave.Close()
+	}
+	if s.opts.Link != "" {
+		os.Remove(s.opts.Link)
+	}
+	return err
+}
+
+func (s *Simulator) write(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("simulator disconnected")
+	}
+	_, err := s.master.Write(data)
+	return err
+}
+
+// ==================== EVENTS ====================
+
+// Event ist ein Schritt im Datenstrom des Simulators.
+//
+// GO-KONZEPT: Funktionstyp als Kommando
+// Events sind einfache Funktionen - eigene Events brauchen keine neuen Typen.
+type Event func(s *Simulator) error
+
+// Shot sendet einen Schuss mit der Encoding des Simulators.
+func Shot(mps float64) Event {
+	return ShotWith(nil, mps)
+}
+
+// ShotWith sendet einen Schuss mit einer abweichenden Encoding (z.B. fps statt m/s).
+// nil verwendet die Encoding des Simulators.
+func ShotWith(encoding Encoding, mps float64) Event {
+	return func(s *Simulator) error {
+		encode := encoding
+		if encode == nil {
+			encode = s.opts.Encoding
+		}
+		if err := s.write(encode(mps)); err != nil {
+			return err
+		}
+		if s.opts.Interval > 0 {
+			time.Sleep(s.opts.Interval)
+		}
+		return nil
+	}
+}
+
+// Garbage sendet eine ungültige Zeile (Rauschen, Statusmeldungen).
+func Garbage(line string) Event {
+	return Raw([]byte(line + "\n"))
+}
+
+// Raw sendet Bytes unverändert - z.B. eine halbe Zeile ohne Zeilenende.
+func Raw(data []byte) Event {
+	return func(s *Simulator) error {
+		return s.write(data)
+	}
+}
+
+// Pause wartet, bevor das nächste Event gesendet wird.
+func Pause(d time.Duration) Event {
+	return func(s *Simulator) error {
+		time.Sleep(d)
+		return nil
+	}
+}
+
+// Disconnect schließt das PTY abrupt (Kabel gezogen, Gerät ausgeschaltet).
+func Disconnect() Event {
+	return func(s *Simulator) error {
+		return s.Close()
+	}
+}
+
+// Replug trennt das Gerät und steckt es nach downtime wieder ein.
+// Das neue PTY hat einen anderen Pfad; stabil bleibt nur Options.Link.
+func Replug(downtime time.Duration) Event {
+	return func(s *Simulator) error {
+		if err := s.Close(); err != nil {
+			return err
+		}
+		time.Sleep(downtime)
+		return s.open()
+	}
+}
+
+// ==================== VERTEILUNGEN ====================
+
+// NormalShots erzeugt n Schüsse mit normalverteilter Geschwindigkeit.
+// Ein fester Seed macht Testläufe reproduzierbar.
+func NormalShots(seed int64, meanMPS, sdMPS float64, n int) ([]Event, []float64) {
+	r := rand.New(rand.NewSource(seed))
+	events := make([]Event, n)
+	velocities := make([]float64, n)
+	for i := range events {
+		v := meanMPS + r.NormFloat64()*sdMPS
+		velocities[i] = v
+		events[i] = Shot(v)
+	}
+	return events, velocities
+}
@@ -0,0 +1,194 @@
+package chrono
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.bug.st/serial"
+	"go.bug.st/serial/enumerator"
+)
+
+// PortInfo beschreibt einen seriellen Port des Systems.
+// Bei USB-Adaptern sind VID/PID und (meist) die Seriennummer gesetzt.
+type PortInfo struct {
+	Name         string // z.B. /dev/ttyUSB0 oder COM3
+	IsUSB        bool
+	VID          string
+	PID          string
+	SerialNumber string
+	Product      string
+}
+
+// ProbeStatus ist das Ergebnis eines Probe-Versuchs.
+type ProbeStatus string
+
+const (
+	// ProbeDetected: Frames empfangen, die der Treiber versteht
+	ProbeDetected ProbeStatus = "detected"
+	// ProbeUnrecognized: Daten empfangen, aber kein gültiger Frame
+	ProbeUnrecognized ProbeStatus = "unrecognized"
+	// ProbeSilent: Port geöffnet, aber keine Daten (Chrono wartet auf einen Schuss)
+	ProbeSilent ProbeStatus = "silent"
+	// ProbeUnavailable: Port konnte nicht geöffnet werden (belegt, keine Rechte)
+	ProbeUnavailable ProbeStatus = "unavailable"
+)
+
+// ProbeResult ist das Ergebnis von Probe für einen Port.
+type ProbeResult struct {
+	Port   PortInfo
+	Status ProbeStatus
+	Err    error // Grund bei ProbeUnavailable/ProbeUnrecognized
+}
+
+// enumeratePorts ist austauschbar, damit Tests ohne echte Hardware laufen.
+var enumeratePorts = func() ([]PortInfo, error) {
+	details, err := enumerator.GetDetailedPortsList()
+	if err != nil {
+		return nil, err
+	}
+
+	ports := make([]PortInfo, 0, len(details))
+	for _, d := range details {
+		ports = append(ports, PortInfo{
+			Name:         d.Name,
+			IsUSB:        d.IsUSB,
+			VID:          strings.ToLower(d.VID),
+			PID:          strings.ToLower(d.PID),
+			SerialNumber: d.SerialNumber,
+			Product:      d.Product,
+		})
+	}
+	return ports, nil
+}
+
+// ListPorts listet alle seriellen Ports, USB-Adapter zuerst.
+func ListPorts() ([]PortInfo, error) {
+	ports, err := enumeratePorts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list serial ports: %w", err)
+	}
+
+	sort.SliceStable(ports, func(i, j int) bool {
+		if ports[i].IsUSB != ports[j].IsUSB {
+			return ports[i].IsUSB
+		}
+		return ports[i].Name < ports[j].Name
+	})
+	return ports, nil
+}
+
+// ResolvePort findet das Gerät anhand der USB-Seriennummer wieder.
+//
+// Linux vergibt /dev/ttyUSBn in Reihenfolge des Einsteckens - nach einem
+// Neustart kann der Chrono an einem anderen Namen hängen. Ist serialNumber
+// gesetzt und ein Port mit dieser Seriennummer vorhanden, wird dessen Name
+// zurückgegeben, sonst der konfigurierte Port.
+func ResolvePort(port, serialNumber string) string {
+	if serialNumber == "" {
+		return port
+	}
+
+	ports, err := enumeratePorts()
+	if err != nil {
+		return port
+	}
+	for _, p := range ports {
+		if p.SerialNumber == serialNumber {
+			return p.Name
+		}
+	}
+	return port
+}
+
+// Probe öffnet einen Port mit dem Treiber und lauscht für timeout.
+//
+// Erkannt ist ein Gerät erst, wenn ein Messwert geparst wurde. Ein
+// Chronograph sendet meist nur nach einem Schuss; ProbeSilent heißt
+// deshalb "Port nutzbar, Gerät unbekannt". baudRate <= 0 nutzt den Treiber-Default.
+func Probe(port string, baudRate int, driver Driver, timeout time.Duration) (ProbeStatus, error) {
+	serialPort, err := serial.Open(port, driver.Mode(baudRate))
+	if err != nil {
+		return ProbeUnavailable, err
+	}
+
+	type frameResult struct {
+		valid bool
+		err   error
+	}
+	results := make(chan frameResult, 1)
+
+	// GO-KONZEPT: Blockierendes Lesen mit Timeout
+	// Die Goroutine liest, bis der Port geschlossen wird.
+	// Close() nach dem Timeout beendet das blockierende Read.
+	go func() {
+		defer close(results)
+		reader := bufio.NewReader(serialPort)
+		var lastErr error
+		for {
+			frame, err := driver.ReadFrame(reader)
+			if err != nil {
+				if lastErr != nil {
+					results <- frameResult{err: lastErr}
+				}
+				return
+			}
+			_, err = driver.Velocity(frame)
+			if err == nil {
+				results <- frameResult{valid: true}
+				return
+			}
+			// Leer- und Statuszeilen sendet fast jedes Gerät: kein Nachweis
+			// für einen Chronographen, aber auch kein Fehler
+			if !errors.Is(err, ErrSkipFrame) {
+				lastErr = err
+			}
+		}
+	}()
+
+	var status ProbeStatus
+	select {
+	case result, ok := <-results:
+		switch {
+		case ok && result.valid:
+			status = ProbeDetected
+		case ok:
+			status, err = ProbeUnrecognized, result.err
+		default:
+			status = ProbeSilent
+		}
+	case <-time.After(timeout):
+		status = ProbeSilent
+	}
+
+	serialPort.Close()
+	for result := range results {
+		// Fehlerhafte Frames bis zum Timeout: Daten kamen, aber nichts Gültiges
+		if status == ProbeSilent && result.err != nil {
+			status, err = ProbeUnrecognized, result.err
+		}
+	}
+	return status, err
+}
+
+// ProbePorts prüft alle Ports parallel mit dem Treiber.
+func ProbePorts(ports []PortInfo, baudRate int, driver Driver, timeout time.Duration) []ProbeResult {
+	results := make([]ProbeResult, len(ports))
+
+	var wg sync.WaitGroup
+	for i, port := range ports {
+		wg.Add(1)
+		go func(i int, port PortInfo) {
+			defer wg.Done()
+			status, err := Probe(port.Name, baudRate, driver, timeout)
+			results[i] = ProbeResult{Port: port, Status: status, Err: err}
+		}(i, port)
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,61 @@
+package chrono
+
+import (
+	"testing"
+)
+
+// fakePorts ersetzt die Port-Enumeration für die Dauer eines Tests.
+func fakePorts(t *testing.T, ports ...PortInfo) {
+	t.Helper()
+	original := enumeratePorts
+	enumeratePorts = func() ([]PortInfo, error) { return append([]PortInfo(nil), ports...), nil }
+	t.Cleanup(func() { enumeratePorts = original })
+}
+
+func TestListPorts_USBFirst(t *testing.T) {
+	fakePorts(t,
+		PortInfo{Name: "/dev/ttyS0"},
+		PortInfo{Name: "/dev/ttyUSB1", IsUSB: true, VID: "0403", PID: "6001", SerialNumber: "B"},
+		PortInfo{Name: "/dev/ttyUSB0", IsUSB: true, VID: "067b", PID: "2303", SerialNumber: "A"},
+	)
+
+	ports, err := ListPorts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{}
+	for _, p := range ports {
+		got = append(got, p.Name)
+	}
+	want := []string{"/dev/ttyUSB0", "/dev/ttyUSB1", "/dev/ttyS0"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ports = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestResolvePort(t *testing.T) {
+	// Chrono wurde als /dev/ttyUSB0 eingerichtet und hängt jetzt an ttyUSB1
+	fakePorts(t,
+		PortInfo{Name: "/dev/ttyUSB0", IsUSB: true, SerialNumber: "OTHER"},
+		PortInfo{Name: "/dev/ttyUSB1", IsUSB: true, SerialNumber: "CHRONO42"},
+	)
+
+	tests := []struct {
+		name         string
+		port, serial string
+		want         string
+	}{
+		{"found by serial number", "/dev/ttyUSB0", "CHRONO42", "/dev/ttyUSB1"},
+		{"unknown serial keeps port", "/dev/ttyUSB0", "GONE", "/dev/ttyUSB0"},
+		{"no serial keeps port", "/dev/ttyUSB0", "", "/dev/ttyUSB0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolvePort(tt.port, tt.serial); got != tt.want {
+				t.Errorf("ResolvePort(%s, %s) = %s, want %s", tt.port, tt.serial, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,389 @@
+package chrono
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"metric-neo/internal/domain/valueobjects"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.bug.st/serial"
+)
+
+// Unit ist die Geschwindigkeitseinheit, in der ein Chronograph sendet.
+type Unit string
+
+const (
+	UnitMPS Unit = "m/s"
+	UnitFPS Unit = "fps"
+)
+
+// DefaultDriverName ist der Treiber, wenn nichts konfiguriert ist.
+const DefaultDriverName = "lmbr"
+
+// ErrSkipFrame signalisiert einen Frame ohne Messwert (Statuszeile, Mittelwert, Leerzeile).
+// SerialChrono liest in diesem Fall einfach den nächsten Frame.
+var ErrSkipFrame = errors.New("frame contains no velocity")
+
+// ErrDisconnected signalisiert, dass die Verbindung zum Gerät abgerissen ist
+// (Kabel gezogen, Gerät aus). Weitere Lesevorgänge am Port sind zwecklos;
+// erst ein neues Connect hilft. Ungültige Frames sind dagegen kein Verbindungsfehler.
+var ErrDisconnected = errors.New("chrono disconnected")
+
+// FrameReader liest genau einen Frame (ohne Begrenzungszeichen) vom Gerät.
+type FrameReader func(r *bufio.Reader) ([]byte, error)
+
+// Parser extrahiert den Messwert aus einem Frame.
+// unit == "" bedeutet: Einheit des Treibers verwenden.
+type Parser func(frame []byte) (value float64, unit Unit, err error)
+
+// Driver beschreibt das Protokoll eines Chronographen.
+//
+// GO-KONZEPT: Funktionen als Felder
+// Statt einer Interface-Hierarchie pro Gerät kombiniert ein Driver
+// eine Framing-Funktion mit einem Parser. Neue Geräte sind meist nur
+// eine neue Kombination vorhandener Bausteine.
+type Driver struct {
+	Name        string
+	Description string
+
+	// Unit ist die Einheit, wenn der Frame selbst keine angibt.
+	Unit Unit
+
+	// Serielle Defaults des Geräts
+	BaudRate int
+	DataBits int
+	Parity   serial.Parity
+	StopBits serial.StopBits
+
+	ReadFrame FrameReader
+	Parse     Parser
+}
+
+// Mode gibt die serielle Konfiguration zurück.
+// baudRate <= 0 verwendet die Baudrate des Treibers.
+func (d Driver) Mode(baudRate int) *serial.Mode {
+	if baudRate <= 0 {
+		baudRate = d.BaudRate
+	}
+	return &serial.Mode{
+		BaudRate: baudRate,
+		DataBits: d.DataBits,
+		Parity:   d.Parity,
+		StopBits: d.StopBits,
+	}
+}
+
+// ModeString gibt Datenbits/Parität/Stoppbits in Kurzform zurück (z.B. "8N1", "7E1").
+func (d Driver) ModeString() string {
+	parity := map[serial.Parity]string{
+		serial.NoParity: "N", serial.OddParity: "O", serial.EvenParity: "E",
+		serial.MarkParity: "M", serial.SpaceParity: "S",
+	}
+	stopBits := map[serial.StopBits]string{
+		serial.OneStopBit: "1", serial.OnePointFiveStopBits: "1.5", serial.TwoStopBits: "2",
+	}
+	return fmt.Sprintf("%d%s%s", d.DataBits, parity[d.Parity], stopBits[d.StopBits])
+}
+
+// Velocity parst einen Frame und gibt die Geschwindigkeit in m/s zurück.
+//
+// fps wird über valueobjects.VelocityFromFPS konvertiert - gespeichert
+// wird immer in m/s (domain-model.md 5.4).
+func (d Driver) Velocity(frame []byte) (float32, error) {
+	value, unit, err := d.Parse(frame)
+	if err != nil {
+		return 0, err
+	}
+	if unit == "" {
+		unit = d.Unit
+	}
+
+	var velocity valueobjects.Velocity
+	switch unit {
+	case UnitFPS:
+		velocity, err = valueobjects.VelocityFromFPS(value)
+	case UnitMPS:
+		velocity, err = valueobjects.NewVelocity(value)
+	default:
+		return 0, fmt.Errorf("driver %s: unknown unit %q", d.Name, unit)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid chrono value %q: %w", frame, err)
+	}
+
+	return float32(velocity.MetersPerSecond()), nil
+}
+
+// ==================== FRAMING ====================
+
+// LineFraming liest Frames bis zum Zeichen delim (z.B. '\n' oder '\r').
+// Leerraum und übrige Zeilenenden werden abgeschnitten.
+func LineFraming(delim byte) FrameReader {
+	return func(r *bufio.Reader) ([]byte, error) {
+		line, err := r.ReadBytes(delim)
+		if err != nil {
+			// Unvollständiger Frame am Ende des Streams zählt nicht
+			return nil, err
+		}
+		return bytes.TrimSpace(line), nil
+	}
+}
+
+// STXETXFraming liest Frames der Form <STX>payload<ETX>.
+// Bytes vor dem STX (Rauschen beim Einschalten) werden verworfen.
+func STXETXFraming() FrameReader {
+	const stx, etx = 0x02, 0x03
+	return func(r *bufio.Reader) ([]byte, error) {
+		if _, err := r.ReadBytes(stx); err != nil {
+			return nil, err
+		}
+		payload, err := r.ReadBytes(etx)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.TrimSpace(payload[:len(payload)-1]), nil
+	}
+}
+
+// ==================== PARSER ====================
+
+// ParseDecimal liest eine reine Dezimalzahl ("175,23" oder "175.23").
+// Die Einheit bestimmt der Treiber.
+func ParseDecimal(frame []byte) (float64, Unit, error) {
+	line := strings.TrimSpace(string(frame))
+	if line == "" {
+		return 0, "", ErrSkipFrame
+	}
+
+	// Ersetze Komma durch Punkt für Dezimalzahl-Parsing
+	line = strings.ReplaceAll(line, ",", ".")
+
+	value, err := strconv.ParseFloat(line, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid chrono value format: %s (error: %w)", line, err)
+	}
+	return value, "", nil
+}
+
+// taggedValue findet eine Zahl am Zeilenende, davor optional ein
+// Geschwindigkeits-Tag und dahinter optional eine Einheit,
+// z.B. "V=574.2 FPS", "VEL 175,3" oder "175,3m/s".
+var taggedValue = regexp.MustCompile(`(?i)(?:\b(V|VEL|VELOCITY)\s*[=:]?\s*)?([0-9]+(?:[.,][0-9]+)?)\s*(m/s|mps|fps|ft/s)?\s*$`)
+
+// unitValue ist ein Frame, der nur aus Zahl und optionaler Einheit besteht.
+var unitValue = regexp.MustCompile(`(?i)^([0-9]+(?:[.,][0-9]+)?)\s*(m/s|mps|fps|ft/s)?$`)
+
+// summaryTags sind Statistik-Zeilen, die viele Geräte nach einer Serie senden.
+var summaryTags = []string{"AVG", "AV", "MEAN", "SD", "ES", "HI", "LO", "MAX", "MIN"}
+
+// ParseTagged liest Frames mit Präfix und Einheit, z.B. "#03 V=574.2 FPS".
+//
+// Als Messwert zählt nur eine Zahl mit Geschwindigkeits-Tag (V=, VEL) oder
+// mit Einheit. Zähler- und Statuszeilen ("#04", "BATT 4", "STRING 2"),
+// Statistik-Zeilen (AVG, SD, ES, ...) und Frames ohne Zahl werden
+// übersprungen, sonst würde z.B. eine Batterieanzeige als Schuss gespeichert.
+func ParseTagged(frame []byte) (float64, Unit, error) {
+	line := strings.TrimSpace(string(frame))
+	if line == "" {
+		return 0, "", ErrSkipFrame
+	}
+
+	upper := strings.ToUpper(line)
+	for _, tag := range summaryTags {
+		if upper == tag || strings.HasPrefix(upper, tag+" ") || strings.HasPrefix(upper, tag+"=") || strings.HasPrefix(upper, tag+":") {
+			return 0, "", ErrSkipFrame
+		}
+	}
+
+	match := taggedValue.FindStringSubmatch(line)
+	if match == nil || (match[1] == "" && match[3] == "") {
+		return 0, "", ErrSkipFrame
+	}
+	return parseValueUnit(line, match[2], match[3])
+}
+
+// ParseValueUnit liest einen Frame, der nur einen Wert mit optionaler
+// Einheit enthält ("574.2" oder "175,5 m/s"), z.B. aus STX/ETX-Rahmen.
+// Ohne Einheit gilt die des Treibers.
+func ParseValueUnit(frame []byte) (float64, Unit, error) {
+	line := strings.TrimSpace(string(frame))
+	if line == "" {
+		return 0, "", ErrSkipFrame
+	}
+
+	match := unitValue.FindStringSubmatch(line)
+	if match == nil {
+		return 0, "", fmt.Errorf("invalid chrono value format: %s", line)
+	}
+	return parseValueUnit(line, match[1], match[2])
+}
+
+// parseValueUnit wandelt Zahl und Einheit aus einem Regex-Treffer um.
+func parseValueUnit(line, number, unit string) (float64, Unit, error) {
+	value, err := strconv.ParseFloat(strings.ReplaceAll(number, ",", "."), 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid chrono value format: %s (error: %w)", line, err)
+	}
+
+	switch strings.ToLower(unit) {
+	case "fps", "ft/s":
+		return value, UnitFPS, nil
+	case "m/s", "mps":
+		return value, UnitMPS, nil
+	default:
+		return value, "", nil
+	}
+}
+
+// ==================== REGISTRY ====================
+
+// GO-KONZEPT: Package-Level Registry
+// Map + RWMutex, damit Treiber auch zur Laufzeit registriert werden können
+// (z.B. in Tests), ohne dass Leser blockieren.
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Driver{}
+)
+
+// Eingebaute Treiber
+var builtinDrivers = []Driver{
+	{
+		Name:        "lmbr",
+		Description: "LMBR: one decimal value in m/s per line (comma or dot)",
+		Unit:        UnitMPS,
+		BaudRate:    19200,
+		DataBits:    8,
+		Parity:      serial.NoParity,
+		StopBits:    serial.OneStopBit,
+		ReadFrame:   LineFraming('\n'),
+		Parse:       ParseDecimal,
+	},
+	{
+		Name:        "generic-fps",
+		Description: "Generic: one decimal value in fps per line",
+		Unit:        UnitFPS,
+		BaudRate:    9600,
+		DataBits:    8,
+		Parity:      serial.NoParity,
+		StopBits:    serial.OneStopBit,
+		ReadFrame:   LineFraming('\n'),
+		Parse:       ParseDecimal,
+	},
+	{
+		Name:        "tagged",
+		Description: "Tagged lines with unit, CR terminated (e.g. \"#03 V=574.2 FPS\"); counter, status and summary lines are ignored",
+		Unit:        UnitMPS,
+		BaudRate:    9600,
+		DataBits:    8,
+		Parity:      serial.NoParity,
+		StopBits:    serial.OneStopBit,
+		ReadFrame:   LineFraming('\r'),
+		Parse:       ParseTagged,
+	},
+	{
+		Name:        "stx-etx",
+		Description: "STX/ETX framed value in fps, 7E1 (unit suffix optional)",
+		Unit:        UnitFPS,
+		BaudRate:    4800,
+		DataBits:    7,
+		Parity:      serial.EvenParity,
+		StopBits:    serial.OneStopBit,
+		ReadFrame:   STXETXFraming(),
+		Parse:       ParseValueUnit,
+	},
+}
+
+func init() {
+	for _, d := range builtinDrivers {
+		if err := RegisterDriver(d); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterDriver fügt einen Treiber zur Registry hinzu.
+func RegisterDriver(d Driver) error {
+	if d.Name == "" {
+		return fmt.Errorf("driver name cannot be empty")
+	}
+	if d.ReadFrame == nil || d.Parse == nil {
+		return fmt.Errorf("driver %s: framing and parser are required", d.Name)
+	}
+	if d.Unit != UnitMPS && d.Unit != UnitFPS {
+		return fmt.Errorf("driver %s: unknown unit %q", d.Name, d.Unit)
+	}
+	if d.BaudRate <= 0 {
+		return fmt.Errorf("driver %s: baud rate must be > 0", d.Name)
+	}
+	if d.DataBits == 0 {
+		d.DataBits = 8
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, exists := registry[d.Name]; exists {
+		return fmt.Errorf("driver %s already registered", d.Name)
+	}
+	registry[d.Name] = d
+	return nil
+}
+
+// LookupDriver gibt den Treiber mit dem Namen zurück ("" = DefaultDriverName).
+func LookupDriver(name string) (Driver, error) {
+	if name == "" {
+		name = DefaultDriverName
+	}
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	d, ok := registry[name]
+	if !ok {
+		return Driver{}, fmt.Errorf("unknown chrono driver: %s", name)
+	}
+	return d, nil
+}
+
+// Drivers gibt alle registrierten Treiber sortiert nach Name zurück.
+func Drivers() []Driver {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	drivers := make([]Driver, 0, len(registry))
+	for _, d := range registry {
+		drivers = append(drivers, d)
+	}
+	sort.Slice(drivers, func(i, j int) bool { return drivers[i].Name < drivers[j].Name })
+	return drivers
+}
+
+// readVelocity liest Frames, bis einer einen Messwert enthält.
+// Ist wire gesetzt, wird jeder Frame mit Ergebnis protokolliert.
+func (d Driver) readVelocity(r *bufio.Reader, wire *WireLog) (float32, error) {
+	for {
+		frame, err := d.ReadFrame(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return 0, fmt.Errorf("%w (EOF)", ErrDisconnected)
+			}
+			return 0, fmt.Errorf("%w: failed to read from chrono: %w", ErrDisconnected, err)
+		}
+
+		velocity, err := d.Velocity(frame)
+		if wire != nil {
+			wire.Record(frame, velocity, err)
+		}
+		if errors.Is(err, ErrSkipFrame) {
+			continue
+		}
+		return velocity, err
+	}
+}
@@ -0,0 +1,129 @@
+package chrono
+
+import (
+	"bufio"
+	"math"
+	"strings"
+	"testing"
+
+	"go.bug.st/serial"
+)
+
+// readAll liest alle Messwerte aus einem aufgezeichneten Byte-Stream.
+// Parserfehler werden gezählt, EOF beendet den Stream.
+func readAll(t *testing.T, d Driver, stream string) (velocities []float32, parseErrors int) {
+	t.Helper()
+	r := bufio.NewReader(strings.NewReader(stream))
+	for i := 0; i < 100; i++ {
+		v, err := d.readVelocity(r, nil)
+		if err != nil {
+			if strings.Contains(err.Error(), "EOF") {
+				return velocities, parseErrors
+			}
+			parseErrors++
+			continue
+		}
+		velocities = append(velocities, v)
+	}
+	t.Fatal("stream did not end")
+	return nil, 0
+}
+
+func TestDrivers_RecordedStreams(t *testing.T) {
+	tests := []struct {
+		driver     string
+		stream     string
+		want       []float64 // m/s
+		wantErrors int
+	}{
+		{
+			driver: "lmbr",
+			stream: "175,23\r\n176.10\n\n174.9\n",
+			want:   []float64{175.23, 176.10, 174.9},
+		},
+		{
+			driver:     "lmbr",
+			stream:     "175.0\nERR\n9999\n176.0\n17",
+			want:       []float64{175.0, 176.0},
+			wantErrors: 2, // "ERR" + außerhalb der Grenzen; "17" ohne Zeilenende ist unvollständig
+		},
+		{
+			driver: "generic-fps",
+			stream: "574.2\n580\n",
+			want:   []float64{175.016, 176.784},
+		},
+		{
+			driver: "tagged",
+			stream: "READY\r\n#01 V=574.2 FPS\r\n#02 V=175,3 M/S\r\nAVG 577.1 FPS\r\nSD 2.1\r\n#03 VEL 176.0\r\n#04\r\nBATT 4\r\nSTRING 2\r\n#05 176.0\r\n",
+			want:   []float64{175.016, 175.3, 176.0}, // Zähler, Status und Zahl ohne Tag/Einheit sind keine Schüsse
+		},
+		{
+			driver: "stx-etx",
+			stream: "\xff\x00\x02 574.2\x03\x02580.0 FPS\x03\r\n\x02175.5 m/s\x03\x02577",
+			want:   []float64{175.016, 176.784, 175.5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			d, err := LookupDriver(tt.driver)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, parseErrors := readAll(t, d, tt.stream)
+			if parseErrors != tt.wantErrors {
+				t.Errorf("parse errors = %d, want %d", parseErrors, tt.wantErrors)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("velocities = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if math.Abs(float64(got[i])-tt.want[i]) > 0.01 {
+					t.Errorf("velocity[%d] = %.3f, want %.3f", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDriverRegistry(t *testing.T) {
+	d, err := LookupDriver("")
+	if err != nil || d.Name != DefaultDriverName {
+		t.Errorf("empty name should return default driver, got %q (%v)", d.Name, err)
+	}
+
+	if _, err := LookupDriver("does-not-exist"); err == nil {
+		t.Error("unknown driver should fail")
+	}
+
+	if err := RegisterDriver(d); err == nil {
+		t.Error("duplicate registration should fail")
+	}
+	if err := RegisterDriver(Driver{Name: "broken", Unit: UnitMPS, BaudRate: 9600}); err == nil {
+		t.Error("driver without parser should fail")
+	}
+
+	names := []string{}
+	for _, d := range Drivers() {
+		names = append(names, d.Name)
+	}
+	if strings.Join(names, ",") != "generic-fps,lmbr,stx-etx,tagged" {
+		t.Errorf("Drivers() = %v", names)
+	}
+}
+
+func TestDriver_Mode(t *testing.T) {
+	d, _ := LookupDriver("stx-etx")
+
+	mode := d.Mode(0)
+	if mode.BaudRate != 4800 || mode.DataBits != 7 || mode.Parity != serial.EvenParity {
+		t.Errorf("default mode = %+v", mode)
+	}
+	if d.ModeString() != "7E1" {
+		t.Errorf("ModeString() = %s, want 7E1", d.ModeString())
+	}
+	if d.Mode(9600).BaudRate != 9600 {
+		t.Error("explicit baud rate should override driver default")
+	}
+}
@@ -0,0 +1,211 @@
+package chrono
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// ReplayChrono spielt ein Wire-Log als Chronograph ab.
+//
+// Die Frames werden mit dem (aktuellen) Treiber neu geparst - ein Replay
+// zeigt also, was der heutige Parser aus den damaligen Rohdaten macht.
+// Am Ende des Logs meldet ReadVelocity ErrDisconnected wie ein gezogenes Kabel.
+//
+// GO-KONZEPT: Interface statt Sonderfall
+// ReplayChrono implementiert ChronoService. CaptureService, Supervisor und
+// CLI merken keinen Unterschied zu einem echten Gerät.
+type ReplayChrono struct {
+	mu          sync.Mutex
+	driver      Driver
+	speed       float64
+	file        io.Closer
+	log         *WireLogReader
+	last        time.Duration
+	connected   bool
+	autoRunning bool
+	stopChan    chan struct{}
+}
+
+// Compile-time Check: ReplayChrono implementiert ChronoService
+var _ ChronoService = (*ReplayChrono)(nil)
+
+// NewReplayChrono erstellt ein Replay mit dem angegebenen Treiber.
+// speed 1 spielt im Originaltempo, 10 zehnmal so schnell, 0 ohne Pausen.
+func NewReplayChrono(driver Driver, speed float64) *ReplayChrono {
+	if speed < 0 {
+		speed = 0
+	}
+	return &ReplayChrono{driver: driver, speed: speed}
+}
+
+// Connect öffnet das Wire-Log. port ist der Dateipfad, baudRate wird ignoriert.
+func (r *ReplayChrono) Connect(port string, baudRate int) error {
+	file, err := os.Open(port)
+	if err != nil {
+		return fmt.Errorf("failed to open wire log %s: %w", port, err)
+	}
+	log, err := NewWireLogReader(file)
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("%s: %w", port, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.connected {
+		file.Close()
+		return fmt.Errorf("already connected")
+	}
+	r.file, r.log, r.last = file, log, 0
+	r.connected = true
+	return nil
+}
+
+// Header gibt die Metadaten des geöffneten Logs zurück.
+func (r *ReplayChrono) Header() WireLogHeader {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.log == nil {
+		return WireLogHeader{}
+	}
+	return r.log.Header()
+}
+
+// Disconnect schließt das Wire-Log.
+func (r *ReplayChrono) Disconnect() error {
+	r.StopAutoRead()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.connected {
+		return nil
+	}
+	r.connected = false
+	r.log = nil
+	return r.file.Close()
+}
+
+// IsConnected gibt zurück, ob ein Log geöffnet ist.
+func (r *ReplayChrono) IsConnected() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.connected
+}
+
+// ReadVelocity liefert den nächsten Messwert des Logs.
+// Zwischen zwei Frames wird der aufgezeichnete Abstand (geteilt durch speed) gewartet.
+func (r *ReplayChrono) ReadVelocity() (float32, error) {
+	r.mu.Lock()
+	log, stop := r.log, r.stopChan
+	r.mu.Unlock()
+
+	if log == nil {
+		return 0, fmt.Errorf("not connected")
+	}
+
+	for {
+		entry, err := log.Next()
+		if errors.Is(err, io.EOF) {
+			return 0, fmt.Errorf("%w (end of wire log)", ErrDisconnected)
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		if !r.wait(entry.Elapsed, stop) {
+			return 0, fmt.Errorf("%w (replay stopped)", ErrDisconnected)
+		}
+
+		velocity, err := r.driver.Velocity(entry.Frame)
+		if errors.Is(err, ErrSkipFrame) {
+			continue
+		}
+		return velocity, err
+	}
+}
+
+// wait hält das Originaltempo ein. Ein Rücksprung der Zeit (neues Segment
+// nach erneutem Scharfschalten im selben Log) wird ohne Pause übernommen.
+// Gibt false zurück, wenn das Replay währenddessen gestoppt wurde.
+func (r *ReplayChrono) wait(elapsed time.Duration, stop <-chan struct{}) bool {
+	delta := elapsed - r.last
+	r.last = elapsed
+	if r.speed == 0 || delta <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(time.Duration(float64(delta) / r.speed))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-stop:
+		return false
+	}
+}
+
+// StartAutoRead spielt das Log im Hintergrund ab.
+// Nach dem letzten Frame wird ErrDisconnected gemeldet und die Schleife endet.
+func (r *ReplayChrono) StartAutoRead(velocityChannel chan<- float32, errorChannel chan<- error) {
+	r.mu.Lock()
+	if r.autoRunning || !r.connected {
+		r.mu.Unlock()
+		if errorChannel != nil {
+			errorChannel <- fmt.Errorf("already running or not connected")
+		}
+		return
+	}
+
+	r.autoRunning = true
+	r.stopChan = make(chan struct{})
+	stop := r.stopChan
+	r.mu.Unlock()
+
+	go func() {
+		for {
+			velocity, err := r.ReadVelocity()
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if errors.Is(err, ErrDisconnected) {
+				r.mu.Lock()
+				r.autoRunning = false
+				r.mu.Unlock()
+				if errorChannel != nil {
+					errorChannel <- err
+				}
+				return
+			}
+			if err != nil {
+				if errorChannel != nil {
+					errorChannel <- err
+				}
+				continue
+			}
+			if velocityChannel != nil {
+				velocityChannel <- velocity
+			}
+		}
+	}()
+}
+
+// StopAutoRead stoppt die Hintergrund-Wiedergabe.
+func (r *ReplayChrono) StopAutoRead() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.autoRunning {
+		close(r.stopChan)
+		r.autoRunning = false
+	}
+}
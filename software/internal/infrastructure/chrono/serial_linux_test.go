@@ -0,0 +1,377 @@
+//go:build linux
+
+package chrono_test
+
+import (
+	"errors"
+	"math"
+	"metric-neo/internal/infrastructure/chrono"
+	"metric-neo/internal/infrastructure/chrono/chronosim"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// connect startet einen Simulator und verbindet einen SerialChrono damit.
+func connect(t *testing.T, driverName string, opts chronosim.Options) (*chronosim.Simulator, *chrono.SerialChrono) {
+	t.Helper()
+
+	sim, err := chronosim.New(opts)
+	if err != nil {
+		t.Skipf("pseudo terminal not available: %v", err)
+	}
+	t.Cleanup(func() { sim.Close() })
+
+	driver, err := chrono.LookupDriver(driverName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	device := chrono.NewSerialChronoWithDriver(driver)
+	if err := device.Connect(sim.Path(), 0); err != nil {
+		t.Fatalf("Connect(%s) failed: %v", sim.Path(), err)
+	}
+	t.Cleanup(func() { device.Disconnect() })
+
+	return sim, device
+}
+
+// receive wartet auf n Messwerte und sammelt Fehler bis dahin.
+func receive(t *testing.T, velocities <-chan float32, errs <-chan error, n int) ([]float32, []error) {
+	t.Helper()
+
+	var got []float32
+	var gotErrs []error
+	timeout := time.After(5 * time.Second)
+	for len(got) < n {
+		select {
+		case v := <-velocities:
+			got = append(got, v)
+		case err := <-errs:
+			gotErrs = append(gotErrs, err)
+		case <-timeout:
+			t.Fatalf("timeout: received %d of %d velocities (errors: %v)", len(got), n, gotErrs)
+		}
+	}
+
+	// Fehler vor dem letzten Messwert liegen bereits im Channel
+	for {
+		select {
+		case err := <-errs:
+			gotErrs = append(gotErrs, err)
+		default:
+			return got, gotErrs
+		}
+	}
+}
+
+func TestSerialChrono_DriversEndToEnd(t *testing.T) {
+	for _, driverName := range []string{"lmbr", "generic-fps", "tagged", "stx-etx"} {
+		t.Run(driverName, func(t *testing.T) {
+			encoding, err := chronosim.EncodingFor(driverName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sim, device := connect(t, driverName, chronosim.Options{Encoding: encoding})
+
+			velocities := make(chan float32, 16)
+			errs := make(chan error, 16)
+			device.StartAutoRead(velocities, errs)
+
+			shots, want := chronosim.NormalShots(42, 175, 1.5, 10)
+			if err := sim.Play(shots...); err != nil {
+				t.Fatal(err)
+			}
+
+			got, gotErrs := receive(t, velocities, errs, len(want))
+			if len(gotErrs) > 0 {
+				t.Errorf("unexpected errors: %v", gotErrs)
+			}
+			for i := range want {
+				// fps wird mit 0.1 fps Auflösung gesendet (~0.03 m/s)
+				if math.Abs(float64(got[i])-want[i]) > 0.05 {
+					t.Errorf("shot %d = %.3f m/s, want %.3f", i, got[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSerialChrono_GarbageAndPartialLines(t *testing.T) {
+	sim, device := connect(t, "lmbr", chronosim.Options{Encoding: chronosim.EncodeLMBRComma})
+
+	velocities := make(chan float32, 16)
+	errs := make(chan error, 16)
+	device.StartAutoRead(velocities, errs)
+
+	err := sim.Play(
+		chronosim.Shot(175.2),
+		chronosim.Garbage("ERR 7"),
+		chronosim.Garbage("99999"),
+		// Zeile in zwei Teilen (langsame UART, Puffergrenze)
+		chronosim.Raw([]byte("176")),
+		chronosim.Pause(50*time.Millisecond),
+		chronosim.Raw([]byte(",5\r\n")),
+		chronosim.Garbage(""),
+		chronosim.Shot(174.8),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, gotErrs := receive(t, velocities, errs, 3)
+	want := []float64{175.2, 176.5, 174.8}
+	for i := range want {
+		if math.Abs(float64(got[i])-want[i]) > 0.01 {
+			t.Errorf("shot %d = %.2f, want %.2f", i, got[i], want[i])
+		}
+	}
+	if len(gotErrs) != 2 {
+		t.Errorf("errors = %v, want 2 (invalid format + out of bounds)", gotErrs)
+	}
+}
+
+func TestSerialChrono_Disconnect(t *testing.T) {
+	sim, device := connect(t, "lmbr", chronosim.Options{})
+
+	if err := sim.Play(chronosim.Shot(175.0)); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := device.ReadVelocity(); err != nil || math.Abs(float64(v)-175.0) > 0.01 {
+		t.Fatalf("first read = %.2f, %v", v, err)
+	}
+
+	// Halbe Zeile, dann Kabel ziehen: der Rest kommt nie an
+	if err := sim.Play(chronosim.Raw([]byte("176.")), chronosim.Disconnect()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := device.ReadVelocity(); err == nil {
+		t.Fatal("read after disconnect should fail")
+	}
+
+	if err := sim.Play(chronosim.Shot(175.0)); err == nil {
+		t.Error("simulator should reject events after disconnect")
+	}
+}
+
+func TestProbe(t *testing.T) {
+	lmbr, _ := chrono.LookupDriver("lmbr")
+
+	tests := []struct {
+		name   string
+		events []chronosim.Event
+		want   chrono.ProbeStatus
+	}{
+		{"live chrono", []chronosim.Event{chronosim.Shot(175.3)}, chrono.ProbeDetected},
+		{"waiting for a shot", nil, chrono.ProbeSilent},
+		{"other device", []chronosim.Event{chronosim.Garbage("AT+OK"), chronosim.Garbage("AT+OK")}, chrono.ProbeUnrecognized},
+		{"blank lines only", []chronosim.Event{chronosim.Garbage(""), chronosim.Garbage("")}, chrono.ProbeSilent},
+		{"blank line before shot", []chronosim.Event{chronosim.Garbage(""), chronosim.Shot(175.3)}, chrono.ProbeDetected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim, err := chronosim.New(chronosim.Options{})
+			if err != nil {
+				t.Skipf("pseudo terminal not available: %v", err)
+			}
+			defer sim.Close()
+
+			go sim.Play(append([]chronosim.Event{chronosim.Pause(20 * time.Millisecond)}, tt.events...)...)
+
+			status, err := chrono.Probe(sim.Path(), 0, lmbr, 300*time.Millisecond)
+			if status != tt.want {
+				t.Errorf("status = %s (%v), want %s", status, err, tt.want)
+			}
+		})
+	}
+
+	// Zähler- und Statuszeilen sind für den tagged-Treiber kein Chrono
+	tagged, _ := chrono.LookupDriver("tagged")
+	sim, err := chronosim.New(chronosim.Options{})
+	if err != nil {
+		t.Skipf("pseudo terminal not available: %v", err)
+	}
+	defer sim.Close()
+	go sim.Play(chronosim.Pause(20*time.Millisecond), chronosim.Raw([]byte("#04\rBATT 4\rSTRING 2\r")))
+	if status, err := chrono.Probe(sim.Path(), 0, tagged, 300*time.Millisecond); status == chrono.ProbeDetected {
+		t.Errorf("status lines: status = %s (%v), want not detected", status, err)
+	}
+
+	if status, _ := chrono.Probe("/dev/does-not-exist", 0, lmbr, 100*time.Millisecond); status != chrono.ProbeUnavailable {
+		t.Errorf("missing port: status = %s, want unavailable", status)
+	}
+}
+
+func TestSerialChrono_AutoReadStopsOnDisconnect(t *testing.T) {
+	sim, device := connect(t, "lmbr", chronosim.Options{})
+
+	velocities := make(chan float32, 16)
+	errs := make(chan error, 16)
+	device.StartAutoRead(velocities, errs)
+
+	if err := sim.Play(chronosim.Shot(175.0)); err != nil {
+		t.Fatal(err)
+	}
+	if _, gotErrs := receive(t, velocities, errs, 1); len(gotErrs) > 0 {
+		t.Fatalf("unexpected errors before disconnect: %v", gotErrs)
+	}
+	if err := sim.Play(chronosim.Disconnect()); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, chrono.ErrDisconnected) {
+			t.Errorf("error = %v, want ErrDisconnected", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no error after disconnect")
+	}
+
+	// Früher: alle 100ms derselbe Fehler, für immer
+	time.Sleep(300 * time.Millisecond)
+	if len(errs) > 0 {
+		t.Errorf("auto read kept reporting errors after disconnect: %d", len(errs))
+	}
+	if device.IsConnected() {
+		t.Error("device should be disconnected")
+	}
+}
+
+func TestSupervisor_ReconnectAfterReplug(t *testing.T) {
+	link := filepath.Join(t.TempDir(), "usb-chrono")
+	sim, err := chronosim.New(chronosim.Options{Link: link})
+	if err != nil {
+		t.Skipf("pseudo terminal not available: %v", err)
+	}
+	defer sim.Close()
+
+	states := make(chan chrono.StateChange, 64)
+	driver, _ := chrono.LookupDriver("lmbr")
+	supervisor := chrono.NewSupervisor(driver, chrono.SupervisorOptions{
+		Port:           sim.Path(),
+		InitialBackoff: 20 * time.Millisecond,
+		MaxBackoff:     100 * time.Millisecond,
+		OnState:        func(c chrono.StateChange) { states <- c },
+	})
+
+	velocities := make(chan float32, 16)
+	errs := make(chan error, 16)
+	supervisor.Start(velocities, errs)
+	defer supervisor.Stop()
+
+	waitState := func(want chrono.ConnectionState) {
+		t.Helper()
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case c := <-states:
+				if c.State == want {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("timeout waiting for state %s", want)
+			}
+		}
+	}
+
+	waitState(chrono.StateConnected)
+	if err := sim.Play(chronosim.Shot(175.0)); err != nil {
+		t.Fatal(err)
+	}
+	receive(t, velocities, errs, 1)
+
+	// Kabel kurz ziehen: mehrere Verbindungsversuche schlagen fehl
+	if err := sim.Play(chronosim.Replug(250 * time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	waitState(chrono.StateConnected)
+
+	if err := sim.Play(chronosim.Shot(176.0)); err != nil {
+		t.Fatal(err)
+	}
+	got, gotErrs := receive(t, velocities, errs, 1)
+	if math.Abs(float64(got[0])-176.0) > 0.01 {
+		t.Errorf("velocity after reconnect = %.2f, want 176.00", got[0])
+	}
+	if len(gotErrs) == 0 || !errors.Is(gotErrs[0], chrono.ErrDisconnected) {
+		t.Errorf("errors = %v, want the disconnect to be reported", gotErrs)
+	}
+}
+
+// Aufnahme über den echten Port, danach Replay aus dem Wire-Log:
+// dieselben Messwerte und Fehler, ohne Gerät.
+func TestWireLog_RecordAndReplay(t *testing.T) {
+	sim, err := chronosim.New(chronosim.Options{Encoding: chronosim.EncodeLMBRComma})
+	if err != nil {
+		t.Skipf("pseudo terminal not available: %v", err)
+	}
+	defer sim.Close()
+
+	path := filepath.Join(t.TempDir(), "session"+chrono.WireLogExt)
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	driver, _ := chrono.LookupDriver("lmbr")
+	device := chrono.NewSerialChronoWithDriver(driver)
+	device.SetWireLog(chrono.NewWireLog(file))
+	if err := device.Connect(sim.Path(), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	velocities := make(chan float32, 32)
+	errs := make(chan error, 32)
+	device.StartAutoRead(velocities, errs)
+
+	shots, want := chronosim.NormalShots(3, 175, 2, 10)
+	events := append([]chronosim.Event{chronosim.Garbage("ERR 7")}, shots...)
+	if err := sim.Play(events...); err != nil {
+		t.Fatal(err)
+	}
+	recorded, recordedErrs := receive(t, velocities, errs, len(want))
+	device.Disconnect()
+	file.Close()
+
+	replay := chrono.NewReplayChrono(driver, 0)
+	if err := replay.Connect(path, 0); err != nil {
+		t.Fatal(err)
+	}
+	defer replay.Disconnect()
+	if header := replay.Header(); header.Driver != "lmbr" || header.Port != sim.Path() {
+		t.Errorf("header = %+v", header)
+	}
+
+	var replayed []float32
+	var replayErrs int
+	for {
+		v, err := replay.ReadVelocity()
+		if errors.Is(err, chrono.ErrDisconnected) {
+			break
+		}
+		if err != nil {
+			replayErrs++
+			continue
+		}
+		replayed = append(replayed, v)
+	}
+
+	if len(replayed) != len(recorded) {
+		t.Fatalf("replayed %d velocities, recorded %d", len(replayed), len(recorded))
+	}
+	for i := range recorded {
+		if replayed[i] != recorded[i] {
+			t.Errorf("shot %d: replay %.2f, recorded %.2f", i, replayed[i], recorded[i])
+		}
+	}
+	// Parserfehler (ohne Disconnect) gleich wie bei der Aufnahme
+	parseErrs := 0
+	for _, err := range recordedErrs {
+		if !errors.Is(err, chrono.ErrDisconnected) {
+			parseErrs++
+		}
+	}
+	if replayErrs != parseErrs || replayErrs != 1 {
+		t.Errorf("replay errors = %d, recorded %d, want 1", replayErrs, parseErrs)
+	}
+}
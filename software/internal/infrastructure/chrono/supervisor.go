@@ -0,0 +1,343 @@
+package chrono
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ConnectionState ist der Zustand der überwachten Chrono-Verbindung.
+type ConnectionState string
+
+const (
+	// StateDisconnected: kein Port offen, nächster Versuch nach RetryIn
+	StateDisconnected ConnectionState = "disconnected"
+	// StateConnecting: Port wird geöffnet
+	StateConnecting ConnectionState = "connecting"
+	// StateConnected: Port offen, Frames werden verstanden
+	StateConnected ConnectionState = "connected"
+	// StateDegraded: Port offen, aber wiederholt ungültige Frames
+	// (Wackelkontakt, falsche Baudrate, Störungen)
+	StateDegraded ConnectionState = "degraded"
+)
+
+// StateChange beschreibt einen Zustandswechsel der Verbindung.
+type StateChange struct {
+	State   ConnectionState
+	Port    string        // tatsächlich verwendeter Port (nach ResolvePort)
+	Attempt int           // fehlgeschlagene Verbindungsversuche in Folge
+	RetryIn time.Duration // nur bei StateDisconnected: Wartezeit bis zum nächsten Versuch
+	Err     error         // Grund für StateDisconnected/StateDegraded
+	At      time.Time
+}
+
+// SupervisorOptions konfiguriert einen Supervisor.
+// Nullwerte werden durch sinnvolle Defaults ersetzt.
+type SupervisorOptions struct {
+	Port         string
+	SerialNumber string // USB-Seriennummer: Port wird bei jedem Versuch neu aufgelöst
+	BaudRate     int    // <= 0: Baudrate des Treibers
+
+	// Exponentielles Backoff zwischen Verbindungsversuchen
+	InitialBackoff time.Duration // Default 250ms
+	MaxBackoff     time.Duration // Default 10s
+
+	// DegradedAfter ungültige Frames in Folge schalten auf StateDegraded (Default 3)
+	DegradedAfter int
+
+	// OnState wird bei jedem Zustandswechsel aufgerufen (aus der Supervisor-Goroutine)
+	OnState func(StateChange)
+
+	// NewChrono erzeugt das Gerät pro Verbindungsversuch (Default: SerialChrono)
+	NewChrono func(driver Driver) ChronoService
+
+	// WireLog protokolliert die Rohdaten über alle Verbindungen hinweg
+	// (nur bei Geräten mit SetWireLog). Stop() schließt das Log.
+	WireLog *WireLog
+}
+
+// wireLogger wird von Geräten implementiert, die Rohdaten protokollieren können.
+type wireLogger interface {
+	SetWireLog(wire *WireLog)
+}
+
+const (
+	defaultInitialBackoff = 250 * time.Millisecond
+	defaultMaxBackoff     = 10 * time.Second
+	defaultDegradedAfter  = 3
+
+	// stopDeliveryTimeout begrenzt das Warten auf den Verbraucher während Stop()
+	stopDeliveryTimeout = time.Second
+)
+
+// Supervisor hält die Verbindung zum Chronographen am Leben.
+//
+// Reißt die Verbindung ab (ErrDisconnected), wird der Port geschlossen und
+// mit exponentiellem Backoff neu geöffnet - ein Wackler am USB-Kabel
+// beendet damit nicht die Session. Messwerte und Frame-Fehler landen in
+// den Channels von Start, Zustandswechsel in OnState.
+//
+// GO-KONZEPT: Supervisor-Goroutine
+// Eine einzige Goroutine besitzt das Gerät (Connect, Lesen, Disconnect).
+// Stop() signalisiert nur über stopChan und schließt den Port, um ein
+// blockierendes Read zu beenden; aufgeräumt wird in der Goroutine selbst.
+type Supervisor struct {
+	driver Driver
+	opts   SupervisorOptions
+
+	mu       sync.Mutex
+	state    StateChange
+	device   ChronoService
+	running  bool
+	stopChan chan struct{}
+	done     chan struct{}
+}
+
+// NewSupervisor erstellt einen Supervisor für den Treiber. Start() verbindet.
+func NewSupervisor(driver Driver, opts SupervisorOptions) *Supervisor {
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = defaultInitialBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = defaultMaxBackoff
+	}
+	opts.MaxBackoff = max(opts.MaxBackoff, opts.InitialBackoff)
+	if opts.DegradedAfter <= 0 {
+		opts.DegradedAfter = defaultDegradedAfter
+	}
+	if opts.NewChrono == nil {
+		opts.NewChrono = func(driver Driver) ChronoService { return NewSerialChronoWithDriver(driver) }
+	}
+
+	return &Supervisor{
+		driver: driver,
+		opts:   opts,
+		state:  StateChange{State: StateDisconnected, Port: opts.Port, At: time.Now()},
+	}
+}
+
+// Start verbindet im Hintergrund und liefert Messwerte, bis Stop() aufgerufen wird.
+// Ein zweiter Aufruf ohne Stop() ist wirkungslos.
+func (s *Supervisor) Start(velocityChannel chan<- float32, errorChannel chan<- error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return
+	}
+	s.running = true
+	s.stopChan = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go s.run(velocityChannel, errorChannel)
+}
+
+// Stop trennt die Verbindung und wartet, bis die Goroutine beendet ist.
+// Ein WireLog aus den Optionen wird dabei geschlossen.
+func (s *Supervisor) Stop() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = false
+	close(s.stopChan)
+	if s.device != nil {
+		// Beendet ein blockierendes ReadVelocity
+		s.device.Disconnect()
+	}
+	done := s.done
+	s.mu.Unlock()
+
+	<-done
+}
+
+// State gibt den aktuellen Verbindungszustand zurück.
+func (s *Supervisor) State() StateChange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state
+}
+
+// IsRunning prüft, ob der Supervisor gestartet ist (unabhängig vom Verbindungszustand).
+func (s *Supervisor) IsRunning() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.running
+}
+
+func (s *Supervisor) run(velocityChannel chan<- float32, errorChannel chan<- error) {
+	defer close(s.done)
+	defer func() {
+		if s.opts.WireLog != nil {
+			s.opts.WireLog.Close()
+		}
+	}()
+	defer func() {
+		s.setState(StateChange{State: StateDisconnected, Port: s.State().Port})
+	}()
+
+	attempt := 0
+	reported := false
+	for {
+		port := s.opts.Port
+		if s.opts.SerialNumber != "" {
+			port = ResolvePort(port, s.opts.SerialNumber)
+		}
+		s.setState(StateChange{State: StateConnecting, Port: port, Attempt: attempt})
+
+		device := s.opts.NewChrono(s.driver)
+		if logger, ok := device.(wireLogger); ok && s.opts.WireLog != nil {
+			logger.SetWireLog(s.opts.WireLog)
+		}
+		err := device.Connect(port, s.opts.BaudRate)
+		if err == nil && !s.attach(device) {
+			device.Disconnect()
+			return
+		}
+
+		if err == nil {
+			attempt, reported = 0, false
+			s.setState(StateChange{State: StateConnected, Port: port})
+			err = s.read(device, port, velocityChannel, errorChannel)
+			s.detach()
+			device.Disconnect()
+			if s.stopped() {
+				return
+			}
+		} else {
+			attempt++
+		}
+
+		delay := s.backoff(attempt)
+		s.setState(StateChange{State: StateDisconnected, Port: port, Attempt: attempt, RetryIn: delay, Err: err})
+		if err != nil && errorChannel != nil && !reported {
+			// Nur den ersten Fehler eines Ausfalls melden, nicht jeden Versuch
+			reported = true
+			select {
+			case errorChannel <- err:
+			case <-s.stopChan:
+				return
+			}
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
+// read liest, bis die Verbindung abreißt oder Stop() aufgerufen wird.
+func (s *Supervisor) read(device ChronoService, port string, velocityChannel chan<- float32, errorChannel chan<- error) error {
+	invalid := 0
+	for {
+		velocity, err := device.ReadVelocity()
+		if err == nil {
+			// Erst zustellen, dann auf Stop prüfen: Ein Schuss, der während
+			// Stop() gelesen wurde, ist gemessen und darf nicht verloren gehen
+			if invalid >= s.opts.DegradedAfter {
+				s.setState(StateChange{State: StateConnected, Port: port})
+			}
+			invalid = 0
+			if !s.deliver(velocityChannel, velocity) || s.stopped() {
+				return nil
+			}
+			continue
+		}
+		if s.stopped() {
+			return nil
+		}
+		if errors.Is(err, ErrDisconnected) {
+			return err
+		}
+
+		invalid++
+		if invalid == s.opts.DegradedAfter {
+			s.setState(StateChange{State: StateDegraded, Port: port, Err: err})
+		}
+		if errorChannel != nil {
+			select {
+			case errorChannel <- err:
+			case <-s.stopChan:
+				return nil
+			}
+		}
+	}
+}
+
+// deliver übergibt einen Messwert. Läuft Stop(), bekommt der Verbraucher
+// noch stopDeliveryTimeout Zeit: Der CaptureService leert die Channels,
+// bis Stop() zurückkehrt. false = nicht zugestellt.
+func (s *Supervisor) deliver(velocityChannel chan<- float32, velocity float32) bool {
+	if velocityChannel == nil {
+		return true
+	}
+	select {
+	case velocityChannel <- velocity:
+		return true
+	case <-s.stopChan:
+	}
+
+	select {
+	case velocityChannel <- velocity:
+		return true
+	case <-time.After(stopDeliveryTimeout):
+		return false
+	}
+}
+
+// backoff verdoppelt die Wartezeit pro Fehlversuch bis MaxBackoff.
+// Nach einem Abbruch einer bestehenden Verbindung (attempt 0) wird sofort
+// mit InitialBackoff neu versucht.
+func (s *Supervisor) backoff(attempt int) time.Duration {
+	delay := s.opts.InitialBackoff
+	for i := 1; i < attempt && delay < s.opts.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, s.opts.MaxBackoff)
+}
+
+// attach merkt sich das Gerät, damit Stop() den Port schließen kann.
+// false: Stop() wurde inzwischen aufgerufen.
+func (s *Supervisor) attach(device ChronoService) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return false
+	}
+	s.device = device
+	return true
+}
+
+func (s *Supervisor) detach() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.device = nil
+}
+
+func (s *Supervisor) stopped() bool {
+	select {
+	case <-s.stopChan:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Supervisor) setState(change StateChange) {
+	change.At = time.Now()
+
+	s.mu.Lock()
+	s.state = change
+	s.mu.Unlock()
+
+	if s.opts.OnState != nil {
+		s.opts.OnState(change)
+	}
+}
@@ -0,0 +1,273 @@
+package chrono
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// scriptedChrono spielt pro Verbindung eine feste Folge von Lese-Ergebnissen ab.
+// Connect schlägt fehl, solange failConnects > 0 ist.
+type scriptedChrono struct {
+	*MockChrono
+	shared *script
+	reads  []scriptedRead
+}
+
+type scriptedRead struct {
+	velocity float32
+	err      error
+}
+
+type script struct {
+	mu           sync.Mutex
+	failConnects int
+	connections  [][]scriptedRead
+}
+
+func (s *script) newChrono(Driver) ChronoService {
+	return &scriptedChrono{MockChrono: NewMockChrono(), shared: s}
+}
+
+func (c *scriptedChrono) Connect(port string, baudRate int) error {
+	c.shared.mu.Lock()
+	defer c.shared.mu.Unlock()
+
+	if c.shared.failConnects > 0 {
+		c.shared.failConnects--
+		return fmt.Errorf("failed to open serial port %s: no such file or directory", port)
+	}
+	if len(c.shared.connections) > 0 {
+		c.reads = c.shared.connections[0]
+		c.shared.connections = c.shared.connections[1:]
+	}
+	return c.MockChrono.Connect(port, baudRate)
+}
+
+// ReadVelocity liefert das nächste Ergebnis; danach blockiert es wie ein
+// wartender Chrono, bis Disconnect den Port schließt.
+func (c *scriptedChrono) ReadVelocity() (float32, error) {
+	if len(c.reads) > 0 {
+		next := c.reads[0]
+		c.reads = c.reads[1:]
+		return next.velocity, next.err
+	}
+	for c.IsConnected() {
+		time.Sleep(time.Millisecond)
+	}
+	return 0, fmt.Errorf("%w: port closed", ErrDisconnected)
+}
+
+// stateRecorder sammelt Zustandswechsel und wartet auf einen bestimmten Zustand.
+type stateRecorder struct {
+	mu      sync.Mutex
+	changes []StateChange
+	notify  chan struct{}
+}
+
+func newStateRecorder() *stateRecorder {
+	return &stateRecorder{notify: make(chan struct{}, 100)}
+}
+
+func (r *stateRecorder) record(change StateChange) {
+	r.mu.Lock()
+	r.changes = append(r.changes, change)
+	r.mu.Unlock()
+	r.notify <- struct{}{}
+}
+
+func (r *stateRecorder) states() []ConnectionState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	states := make([]ConnectionState, len(r.changes))
+	for i, c := range r.changes {
+		states[i] = c.State
+	}
+	return states
+}
+
+// waitFor wartet, bis n Zustandswechsel in state aufgetreten sind.
+func (r *stateRecorder) waitFor(t *testing.T, state ConnectionState, n int) {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		count := 0
+		for _, s := range r.states() {
+			if s == state {
+				count++
+			}
+		}
+		if count >= n {
+			return
+		}
+		select {
+		case <-r.notify:
+		case <-timeout:
+			t.Fatalf("timeout waiting for %d x %s, got %v", n, state, r.states())
+		}
+	}
+}
+
+func TestSupervisor_BackoffAndReconnect(t *testing.T) {
+	sc := &script{
+		failConnects: 3,
+		connections: [][]scriptedRead{
+			{{velocity: 175.1}, {err: fmt.Errorf("%w (EOF)", ErrDisconnected)}},
+			{{velocity: 176.2}},
+		},
+	}
+	recorder := newStateRecorder()
+	driver, _ := LookupDriver(DefaultDriverName)
+	supervisor := NewSupervisor(driver, SupervisorOptions{
+		Port:           "/dev/ttyUSB0",
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     4 * time.Millisecond,
+		OnState:        recorder.record,
+		NewChrono:      sc.newChrono,
+	})
+
+	velocities := make(chan float32, 8)
+	errs := make(chan error, 8)
+	supervisor.Start(velocities, errs)
+	recorder.waitFor(t, StateConnected, 2)
+
+	for _, want := range []float32{175.1, 176.2} {
+		if got := <-velocities; got != want {
+			t.Errorf("velocity = %.1f, want %.1f", got, want)
+		}
+	}
+
+	supervisor.Stop()
+	if got := supervisor.State().State; got != StateDisconnected {
+		t.Errorf("state after Stop = %s, want disconnected", got)
+	}
+
+	// Nur der erste Fehler jedes Ausfalls wird gemeldet
+	close(errs)
+	var got []error
+	for err := range errs {
+		got = append(got, err)
+	}
+	if len(got) != 2 || !errors.Is(got[1], ErrDisconnected) {
+		t.Errorf("errors = %v, want open failure and disconnect", got)
+	}
+
+	// Backoff verdoppelt sich bis MaxBackoff und beginnt nach Verbindung neu
+	var delays []time.Duration
+	recorder.mu.Lock()
+	for _, c := range recorder.changes {
+		if c.State == StateDisconnected && c.RetryIn > 0 {
+			delays = append(delays, c.RetryIn)
+		}
+	}
+	recorder.mu.Unlock()
+	want := []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, time.Millisecond}
+	if fmt.Sprint(delays) != fmt.Sprint(want) {
+		t.Errorf("backoff = %v, want %v", delays, want)
+	}
+}
+
+func TestSupervisor_Degraded(t *testing.T) {
+	invalid := fmt.Errorf("invalid velocity format: %q", "ERR")
+	sc := &script{
+		connections: [][]scriptedRead{
+			{{err: invalid}, {err: invalid}, {err: invalid}, {velocity: 175.0}},
+		},
+	}
+	recorder := newStateRecorder()
+	driver, _ := LookupDriver(DefaultDriverName)
+	supervisor := NewSupervisor(driver, SupervisorOptions{
+		Port:      "/dev/ttyUSB0",
+		OnState:   recorder.record,
+		NewChrono: sc.newChrono,
+	})
+
+	velocities := make(chan float32, 8)
+	errs := make(chan error, 8)
+	supervisor.Start(velocities, errs)
+	defer supervisor.Stop()
+
+	<-velocities
+	recorder.waitFor(t, StateConnected, 2)
+
+	want := []ConnectionState{StateConnecting, StateConnected, StateDegraded, StateConnected}
+	if got := recorder.states(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("states = %v, want %v", got, want)
+	}
+	if len(errs) != 3 {
+		t.Errorf("frame errors = %d, want 3", len(errs))
+	}
+}
+
+func TestSupervisor_StopWhileConnecting(t *testing.T) {
+	sc := &script{failConnects: 1000}
+	driver, _ := LookupDriver(DefaultDriverName)
+	supervisor := NewSupervisor(driver, SupervisorOptions{
+		Port:      "/dev/ttyUSB0",
+		NewChrono: sc.newChrono,
+	})
+
+	supervisor.Start(nil, nil)
+	if !supervisor.IsRunning() {
+		t.Fatal("supervisor should be running")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		supervisor.Stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Stop() blocked during backoff")
+	}
+	if supervisor.IsRunning() {
+		t.Error("supervisor should be stopped")
+	}
+}
+
+// lateShotChrono liefert einen Schuss genau in dem Moment, in dem Stop()
+// den Port schließt (Schuss während des Entschärfens).
+type lateShotChrono struct {
+	*MockChrono
+	delivered bool
+}
+
+func (c *lateShotChrono) ReadVelocity() (float32, error) {
+	for c.IsConnected() {
+		time.Sleep(time.Millisecond)
+	}
+	if !c.delivered {
+		c.delivered = true
+		return 175.5, nil
+	}
+	return 0, fmt.Errorf("%w: port closed", ErrDisconnected)
+}
+
+func TestSupervisor_DeliversShotReadDuringStop(t *testing.T) {
+	recorder := newStateRecorder()
+	driver, _ := LookupDriver(DefaultDriverName)
+	supervisor := NewSupervisor(driver, SupervisorOptions{
+		Port:      "/dev/ttyUSB0",
+		OnState:   recorder.record,
+		NewChrono: func(Driver) ChronoService { return &lateShotChrono{MockChrono: NewMockChrono()} },
+	})
+
+	velocities := make(chan float32, 1)
+	supervisor.Start(velocities, nil)
+	recorder.waitFor(t, StateConnected, 1)
+	supervisor.Stop()
+
+	select {
+	case got := <-velocities:
+		if got != 175.5 {
+			t.Errorf("velocity = %.1f, want 175.5", got)
+		}
+	default:
+		t.Error("shot read during Stop() was dropped")
+	}
+}
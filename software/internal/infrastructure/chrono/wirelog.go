@@ -0,0 +1,298 @@
+package chrono
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Wire-Log: Rohdaten des Chronographen für Nachweis und Replay.
+//
+// Jede Zeile ist ein empfangener Frame (ohne Begrenzungszeichen) mit
+// monotoner Zeit seit Log-Beginn und dem Ergebnis des Parsers:
+//
+//	# metric-neo wire log v1
+//	# driver: lmbr
+//	# port: /dev/ttyUSB0
+//	# started: 2026-10-16T10:00:00.123456789+02:00
+//	0.000000	"175,23"	175.23
+//	1.503211	"ERR 7"	! invalid velocity format: "ERR 7"
+//	2.100000	"AVG 174.2"	-
+//	# 3.000000 disconnected
+//
+// Ergebnis: Geschwindigkeit in m/s, "-" (Frame ohne Messwert) oder "! Fehler".
+// Zeilen mit "#" sind Kommentare (Header, Verbindungsereignisse).
+const wireLogMagic = "# metric-neo wire log v1"
+
+// WireLogExt ist die Dateiendung von Wire-Logs (sessions/<id>.wire.log).
+const WireLogExt = ".wire.log"
+
+// WireLog schreibt empfangene Frames mit Zeitstempel in einen Writer.
+// Sicher für die gleichzeitige Nutzung aus mehreren Goroutinen.
+type WireLog struct {
+	mu      sync.Mutex
+	w       io.Writer
+	start   time.Time
+	started bool
+	driver  string
+	port    string
+}
+
+// NewWireLog erstellt ein Wire-Log. Der Header wird beim ersten Eintrag
+// geschrieben (erst dann sind Treiber und Port bekannt).
+func NewWireLog(w io.Writer) *WireLog {
+	return &WireLog{w: w}
+}
+
+// begin schreibt den Header, falls noch nicht geschehen.
+// Aufrufer hält mu.
+func (l *WireLog) begin() {
+	if l.started {
+		return
+	}
+	l.started = true
+	l.start = time.Now()
+	fmt.Fprintf(l.w, "%s\n# driver: %s\n# port: %s\n# started: %s\n",
+		wireLogMagic, l.driver, l.port, l.start.Format(time.RFC3339Nano))
+}
+
+// elapsed sind die Sekunden seit Log-Beginn (monotone Uhr).
+func (l *WireLog) elapsed() float64 {
+	return time.Since(l.start).Seconds()
+}
+
+// Connected vermerkt eine (Wieder-)Verbindung.
+func (l *WireLog) Connected(driver Driver, port string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.started {
+		l.driver, l.port = driver.Name, port
+		l.begin()
+	}
+	fmt.Fprintf(l.w, "# %.6f connected %s\n", l.elapsed(), port)
+}
+
+// Note vermerkt ein Ereignis als Kommentar (z.B. "disconnected").
+func (l *WireLog) Note(text string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.begin()
+	fmt.Fprintf(l.w, "# %.6f %s\n", l.elapsed(), strings.ReplaceAll(text, "\n", " "))
+}
+
+// Record schreibt einen Frame mit dem Ergebnis des Parsers.
+func (l *WireLog) Record(frame []byte, velocity float32, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.begin()
+	fmt.Fprintf(l.w, "%.6f\t%s\t%s\n", l.elapsed(), strconv.Quote(string(frame)), formatWireResult(velocity, err))
+}
+
+// Close schließt den Writer, falls er io.Closer implementiert.
+func (l *WireLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if closer, ok := l.w.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func formatWireResult(velocity float32, err error) string {
+	switch {
+	case errors.Is(err, ErrSkipFrame):
+		return "-"
+	case err != nil:
+		return "! " + strings.ReplaceAll(err.Error(), "\n", " ")
+	default:
+		return strconv.FormatFloat(float64(velocity), 'f', 2, 32)
+	}
+}
+
+// ==================== LESEN ====================
+
+// WireLogHeader enthält die Metadaten aus dem Kopf eines Wire-Logs.
+type WireLogHeader struct {
+	Driver  string
+	Port    string
+	Started time.Time
+}
+
+// WireLogEntry ist ein aufgezeichneter Frame.
+type WireLogEntry struct {
+	Line    int           // Zeilennummer in der Datei (für Fehlermeldungen)
+	Elapsed time.Duration // Zeit seit Log-Beginn
+	Frame   []byte
+	// Aufgezeichnetes Ergebnis: Velocity (m/s), Skipped oder Err
+	Velocity float64
+	Skipped  bool
+	Err      string
+}
+
+// Result gibt das aufgezeichnete Ergebnis im Log-Format zurück.
+func (e WireLogEntry) Result() string {
+	switch {
+	case e.Skipped:
+		return "-"
+	case e.Err != "":
+		return "! " + e.Err
+	default:
+		return strconv.FormatFloat(e.Velocity, 'f', 2, 32)
+	}
+}
+
+// WireLogReader liest ein Wire-Log Eintrag für Eintrag.
+type WireLogReader struct {
+	scanner *bufio.Scanner
+	header  WireLogHeader
+	line    int
+	pending *WireLogEntry
+}
+
+// NewWireLogReader liest den Header und bereitet das Lesen der Einträge vor.
+func NewWireLogReader(r io.Reader) (*WireLogReader, error) {
+	reader := &WireLogReader{scanner: bufio.NewScanner(r)}
+	reader.scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	// Header: Kommentarzeilen bis zum ersten Eintrag
+	for reader.scanner.Scan() {
+		reader.line++
+		text := reader.scanner.Text()
+		if reader.line == 1 && text != wireLogMagic {
+			return nil, fmt.Errorf("not a wire log (missing %q)", wireLogMagic)
+		}
+		if !strings.HasPrefix(text, "#") {
+			entry, err := parseWireEntry(text, reader.line)
+			if err != nil {
+				return nil, err
+			}
+			reader.pending = &entry
+			break
+		}
+
+		key, value, ok := strings.Cut(strings.TrimPrefix(text, "# "), ": ")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "driver":
+			reader.header.Driver = value
+		case "port":
+			reader.header.Port = value
+		case "started":
+			reader.header.Started, _ = time.Parse(time.RFC3339Nano, value)
+		}
+	}
+	if err := reader.scanner.Err(); err != nil {
+		return nil, err
+	}
+	if reader.line == 0 {
+		return nil, fmt.Errorf("not a wire log (empty file)")
+	}
+	return reader, nil
+}
+
+// Header gibt die Metadaten des Logs zurück.
+func (r *WireLogReader) Header() WireLogHeader {
+	return r.header
+}
+
+// Next liefert den nächsten Eintrag; io.EOF am Ende des Logs.
+func (r *WireLogReader) Next() (WireLogEntry, error) {
+	if r.pending != nil {
+		entry := *r.pending
+		r.pending = nil
+		return entry, nil
+	}
+
+	for r.scanner.Scan() {
+		r.line++
+		text := r.scanner.Text()
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		return parseWireEntry(text, r.line)
+	}
+	if err := r.scanner.Err(); err != nil {
+		return WireLogEntry{}, err
+	}
+	return WireLogEntry{}, io.EOF
+}
+
+func parseWireEntry(text string, line int) (WireLogEntry, error) {
+	fields := strings.SplitN(text, "\t", 3)
+	if len(fields) != 3 {
+		return WireLogEntry{}, fmt.Errorf("wire log line %d: expected 3 tab-separated fields", line)
+	}
+
+	seconds, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return WireLogEntry{}, fmt.Errorf("wire log line %d: invalid time %q", line, fields[0])
+	}
+	frame, err := strconv.Unquote(fields[1])
+	if err != nil {
+		return WireLogEntry{}, fmt.Errorf("wire log line %d: invalid frame %s", line, fields[1])
+	}
+
+	entry := WireLogEntry{
+		Line:    line,
+		Elapsed: time.Duration(seconds * float64(time.Second)),
+		Frame:   []byte(frame),
+	}
+	switch result := fields[2]; {
+	case result == "-":
+		entry.Skipped = true
+	case strings.HasPrefix(result, "! "):
+		entry.Err = strings.TrimPrefix(result, "! ")
+	default:
+		entry.Velocity, err = strconv.ParseFloat(result, 64)
+		if err != nil {
+			return WireLogEntry{}, fmt.Errorf("wire log line %d: invalid result %q", line, result)
+		}
+	}
+	return entry, nil
+}
+
+// ==================== VERIFIZIEREN ====================
+
+// WireLogMismatch beschreibt einen Frame, den der Parser heute anders liest.
+type WireLogMismatch struct {
+	Entry WireLogEntry
+	Got   string // aktuelles Ergebnis im Log-Format
+}
+
+// VerifyWireLog parst alle Frames erneut mit dem Treiber und vergleicht mit
+// dem aufgezeichneten Ergebnis. So werden Parser-Änderungen gegen echte
+// Gerätedaten regressionsgetestet. Fehlertexte werden nicht verglichen,
+// nur ob ein Fehler auftrat.
+func VerifyWireLog(r *WireLogReader, driver Driver) (checked int, mismatches []WireLogMismatch, err error) {
+	for {
+		entry, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return checked, mismatches, nil
+		}
+		if err != nil {
+			return checked, mismatches, err
+		}
+		checked++
+
+		velocity, parseErr := driver.Velocity(entry.Frame)
+		got := formatWireResult(velocity, parseErr)
+		ok := got == entry.Result()
+		if entry.Err != "" {
+			ok = strings.HasPrefix(got, "! ")
+		}
+		if !ok {
+			mismatches = append(mismatches, WireLogMismatch{Entry: entry, Got: got})
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package persistence
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// StorageBackend wählt die Speicherung eines Datenverzeichnisses.
+//
+// JSON (ADR 003) ist der Standard. SQLite ist eine Alternative für
+// Auswertungen mit SQL über viele Sessions (siehe Package sqlite).
+// Die Wahl steht im Manifest, damit Desktop-App und CLI (auch mit
+// --data-dir) dasselbe Verzeichnis immer gleich öffnen.
+type StorageBackend string
+
+const (
+	BackendJSON   StorageBackend = "json"
+	BackendSQLite StorageBackend = "sqlite"
+)
+
+// SQLiteFile ist die Datenbank im Datenverzeichnis (Backend sqlite).
+const SQLiteFile = "metric-neo.db"
+
+// ParseStorageBackend prüft einen Backend-Namen (leer = JSON).
+func ParseStorageBackend(name string) (StorageBackend, error) {
+	switch StorageBackend(name) {
+	case "", BackendJSON:
+		return BackendJSON, nil
+	case BackendSQLite:
+		return BackendSQLite, nil
+	default:
+		return "", fmt.Errorf("unknown storage backend %q (json or sqlite)", name)
+	}
+}
+
+// ReadStorageBackend liest das Backend aus dem Manifest (ohne Manifest: JSON).
+func ReadStorageBackend(dataDir string) (StorageBackend, error) {
+	manifest, _, err := ReadManifest(dataDir)
+	if err != nil {
+		return "", err
+	}
+	return ParseStorageBackend(string(manifest.StorageBackend))
+}
+
+// WriteStorageBackend trägt das Backend ins Manifest ein.
+func WriteStorageBackend(dataDir string, backend StorageBackend) error {
+	if _, err := ParseStorageBackend(string(backend)); err != nil {
+		return err
+	}
+	return updateManifest(dataDir, func(m *Manifest) { m.StorageBackend = backend })
+}
+
+// SQLitePath gibt den Pfad der Datenbank im Datenverzeichnis zurück.
+func SQLitePath(dataDir string) string {
+	return filepath.Join(dataDir, SQLiteFile)
+}
+
+// MoveDocumentsAside verschiebt alle JSON-Dokumente nach backups/<label>-<zeit>/.
+//
+// Wird vor einer Konvertierung nach JSON aufgerufen, damit das Ziel eine
+// exakte Kopie wird: Veraltete Dateien (z.B. inzwischen in SQLite gelöschte
+// Sessions) tauchen sonst wieder auf. Rohdaten-Logs bleiben liegen, sie
+// gehören beiden Backends. Gibt "" zurück, wenn es keine Dokumente gab.
+func MoveDocumentsAside(dataDir, label string) (string, error) {
+	empty, err := isEmptyDataDir(dataDir)
+	if err != nil || empty {
+		return "", err
+	}
+
+	backup, err := copyDocuments(dataDir, backupPath(dataDir, label))
+	if err != nil {
+		return "", err
+	}
+	for _, kind := range DocumentKinds() {
+		store := NewFileStore(DocumentDir(dataDir, kind))
+		ids, err := store.IDs()
+		if err != nil {
+			return backup, err
+		}
+		for _, id := range ids {
+			if err := store.Remove(id); err != nil {
+				return backup, err
+			}
+		}
+	}
+	return backup, nil
+}
@@ -0,0 +1,202 @@
+package persistence
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+)
+
+// SchemaVersion ist die Version des Datenformats, die diese Programmversion schreibt.
+// Jede Erhöhung braucht eine Migration in migrations (siehe migration.go).
+const SchemaVersion = 2
+
+// schemaVersionKey ist das Feld, das jedes gespeicherte Dokument trägt.
+const schemaVersionKey = "schema_version"
+
+// DocumentKind ist die Art eines gespeicherten Dokuments.
+type DocumentKind string
+
+const (
+	KindSession    DocumentKind = "session"
+	KindProfile    DocumentKind = "profile"
+	KindProjectile DocumentKind = "projectile"
+	KindSight      DocumentKind = "sight"
+)
+
+// documentDirs ordnet jeder Dokumentart ihr Verzeichnis relativ zum dataDir zu (ADR 003).
+// Slice statt Map: Migrationen laufen in fester Reihenfolge, Stammdaten zuerst.
+var documentDirs = []struct {
+	kind DocumentKind
+	dir  string
+}{
+	{KindProfile, filepath.Join("inventory", "profiles")},
+	{KindProjectile, filepath.Join("inventory", "projectiles")},
+	{KindSight, filepath.Join("inventory", "sights")},
+	{KindSession, "sessions"},
+}
+
+// DocumentKinds gibt alle Dokumentarten zurück.
+func DocumentKinds() []DocumentKind {
+	kinds := make([]DocumentKind, 0, len(documentDirs))
+	for _, d := range documentDirs {
+		kinds = append(kinds, d.kind)
+	}
+	return kinds
+}
+
+// DocumentDir gibt das Verzeichnis einer Dokumentart im dataDir zurück.
+func DocumentDir(dataDir string, kind DocumentKind) string {
+	for _, d := range documentDirs {
+		if d.kind == kind {
+			return filepath.Join(dataDir, d.dir)
+		}
+	}
+	panic(fmt.Sprintf("unknown document kind %q", kind))
+}
+
+// Document ist ein JSON-Objekt mit erhaltener Feldreihenfolge.
+//
+// Migrationen arbeiten auf Documents statt auf Entities: Eine Migration
+// muss alte Formate lesen können, die keine heutige Struct mehr abbildet.
+//
+// GO-KONZEPT: json.RawMessage
+// Die Werte bleiben unverändertes JSON, bis eine Migration sie liest oder
+// ersetzt. So übersteht jedes Feld die Migration exakt, auch wenn sie es
+// nicht kennt. Die Reihenfolge der Schlüssel bleibt wie in der Datei.
+type Document struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+// ParseDocument liest ein JSON-Objekt.
+func ParseDocument(data []byte) (*Document, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("document is not a JSON object")
+	}
+
+	doc := &Document{values: map[string]json.RawMessage{}}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, fmt.Errorf("field %q: %w", key, err)
+		}
+		if _, exists := doc.values[key]; !exists {
+			doc.keys = append(doc.keys, key)
+		}
+		doc.values[key] = value
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Has prüft, ob das Feld existiert.
+func (d *Document) Has(key string) bool {
+	_, ok := d.values[key]
+	return ok
+}
+
+// Get liest ein Feld nach v. Ein fehlendes Feld lässt v unverändert.
+func (d *Document) Get(key string, v any) error {
+	raw, ok := d.values[key]
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// Set setzt ein Feld. Neue Felder werden hinten angehängt.
+func (d *Document) Set(key string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, exists := d.values[key]; !exists {
+		d.keys = append(d.keys, key)
+	}
+	d.values[key] = raw
+	return nil
+}
+
+// Rename benennt ein Feld um und behält seine Position.
+func (d *Document) Rename(oldKey, newKey string) {
+	raw, ok := d.values[oldKey]
+	if !ok || oldKey == newKey {
+		return
+	}
+	d.Delete(newKey)
+	for i, key := range d.keys {
+		if key == oldKey {
+			d.keys[i] = newKey
+		}
+	}
+	delete(d.values, oldKey)
+	d.values[newKey] = raw
+}
+
+// Delete entfernt ein Feld.
+func (d *Document) Delete(key string) {
+	if _, ok := d.values[key]; !ok {
+		return
+	}
+	delete(d.values, key)
+	for i, k := range d.keys {
+		if k == key {
+			d.keys = append(d.keys[:i], d.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// SchemaVersion gibt die Version des Dokuments zurück (0 = vor der Versionierung).
+func (d *Document) SchemaVersion() int {
+	var version int
+	d.Get(schemaVersionKey, &version)
+	return version
+}
+
+// setSchemaVersion stempelt die Version als erstes Feld des Dokuments.
+func (d *Document) setSchemaVersion(version int) {
+	d.Delete(schemaVersionKey)
+	d.keys = append([]string{schemaVersionKey}, d.keys...)
+	d.values[schemaVersionKey] = json.RawMessage(fmt.Sprint(version))
+}
+
+// MarshalJSON schreibt die Felder in ihrer Reihenfolge.
+func (d *Document) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range d.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(d.values[key])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// marshalDocument serialisiert v eingerückt mit schema_version als erstem Feld.
+func marshalDocument(v any, version int) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := ParseDocument(raw)
+	if err != nil {
+		return nil, err
+	}
+	doc.setSchemaVersion(version)
+	return json.MarshalIndent(doc, "", "  ")
+}
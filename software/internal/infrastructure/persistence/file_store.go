@@ -0,0 +1,344 @@
+package persistence
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// FileStore speichert Entities als <id>.json in einem Verzeichnis.
+//
+// Alle Repositories schreiben über den FileStore, damit eine Datei nie
+// halb geschrieben auf der Platte liegt: Ein Stromausfall während
+// RecordShot darf höchstens den letzten Schuss kosten, nicht die Session.
+//
+// GO-KONZEPT: Atomares Schreiben (write-temp + fsync + rename)
+// Die neuen Daten landen zuerst in einer temporären Datei im selben
+// Verzeichnis, werden per fsync auf die Platte gezwungen und dann per
+// os.Rename über die alte Datei gelegt. Rename ist auf demselben
+// Dateisystem atomar: Leser sehen entweder die alte oder die neue Version.
+type FileStore struct {
+	dir string
+}
+
+// tempSuffix kennzeichnet temporäre Dateien (".<id>.json.<zufall>.tmp").
+// Durch den führenden Punkt und die Endung tauchen sie nie in IDs() auf.
+const tempSuffix = ".tmp"
+
+// corruptSuffix wird an unlesbare Dateien gehängt, die Recover() beiseitelegt.
+const corruptSuffix = ".corrupt"
+
+// staleTempAge schützt laufende Schreibvorgänge eines anderen Prozesses
+// (CLI und Desktop-App gleichzeitig): Jüngere Temp-Dateien bleiben liegen.
+const staleTempAge = time.Minute
+
+// ErrInvalidID meldet eine ID, die kein einfacher Dateiname ist.
+var ErrInvalidID = errors.New("invalid document id")
+
+// NewFileStore erstellt einen FileStore für das Verzeichnis.
+// Das Verzeichnis wird erst beim ersten Schreiben angelegt.
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+// Dir gibt das Verzeichnis des Stores zurück.
+func (s *FileStore) Dir() string {
+	return s.dir
+}
+
+// Path gibt den Dateipfad einer ID zurück. IDs mit Pfadtrennern oder ".."
+// (z.B. aus einer importierten Datei) würden aus dem Verzeichnis
+// herausführen und werden abgelehnt.
+func (s *FileStore) Path(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, "/\\\x00") || strings.Contains(id, "..") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidID, id)
+	}
+	return filepath.Join(s.dir, id+".json"), nil
+}
+
+// Write serialisiert v als eingerücktes JSON und schreibt es atomar.
+func (s *FileStore) Write(id string, v any) error {
+	path, err := s.Path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	// Jedes Dokument trägt die Schema-Version, mit der es geschrieben wurde
+	data, err := marshalDocument(v, SchemaVersion)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", id, err)
+	}
+
+	if err := WriteFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// Read liest <id>.json nach v.
+// Lesefehler werden unverändert zurückgegeben (os.IsNotExist funktioniert).
+// Dokumente einer neueren Schema-Version werden abgelehnt (ErrNewerSchema).
+func (s *FileStore) Read(id string, v any) error {
+	path, err := s.Path(id)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var header struct {
+		SchemaVersion int `json:"schema_version"`
+	}
+	if err := json.Unmarshal(data, &header); err == nil && header.SchemaVersion > SchemaVersion {
+		return fmt.Errorf("%s: %w (schema %d, supported %d)", filepath.Base(path), ErrNewerSchema, header.SchemaVersion, SchemaVersion)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// readDocument liest <id>.json als Document (für Migrationen).
+func (s *FileStore) readDocument(id string) (*Document, error) {
+	path, err := s.Path(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := ParseDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return doc, nil
+}
+
+// writeDocument schreibt ein Document atomar (für Migrationen).
+func (s *FileStore) writeDocument(id string, doc *Document) error {
+	path, err := s.Path(id)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(path, data, 0644)
+}
+
+// IDs listet alle gespeicherten IDs. Ein fehlendes Verzeichnis ergibt eine leere Liste.
+func (s *FileStore) IDs() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || filepath.Ext(name) != ".json" {
+			continue
+		}
+		id := strings.TrimSuffix(name, ".json")
+		if _, err := s.Path(id); err != nil {
+			continue // über Path nicht adressierbar
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Remove löscht <id>.json. Fehler werden unverändert zurückgegeben.
+func (s *FileStore) Remove(id string) error {
+	path, err := s.Path(id)
+	if err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// WriteFileAtomic schreibt data so nach path, dass path jederzeit entweder
+// den alten oder den vollständigen neuen Inhalt hat.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+name+".*"+tempSuffix)
+	if err != nil {
+		return err
+	}
+	// Bei jedem Fehler bleibt keine Temp-Datei zurück
+	committed := false
+	defer func() {
+		if !committed {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil && runtime.GOOS != "windows" {
+		return err
+	}
+	// fsync: Daten sind auf der Platte, bevor der Name umgebogen wird
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	committed = true
+
+	syncDir(dir)
+	return nil
+}
+
+// syncDir macht den Rename selbst dauerhaft (Verzeichniseintrag).
+// Unter Windows lassen sich Verzeichnisse nicht syncen; NTFS journalisiert
+// Metadaten ohnehin. Fehler sind hier nicht fatal: Die Daten sind geschrieben.
+func syncDir(dir string) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+}
+
+// ==================== RECOVERY ====================
+
+// CorruptFile ist eine unlesbare JSON-Datei, die beiseitegelegt wurde.
+type CorruptFile struct {
+	Path          string // ursprünglicher Pfad
+	QuarantinedAs string // neuer Pfad (<id>.json.corrupt), Inhalt unverändert
+	Reason        string
+}
+
+// RecoveryReport beschreibt, was Recover() gefunden und aufgeräumt hat.
+type RecoveryReport struct {
+	RemovedTempFiles []string
+	CorruptFiles     []CorruptFile
+}
+
+// Empty gibt true zurück, wenn nichts gefunden wurde.
+func (r RecoveryReport) Empty() bool {
+	return len(r.RemovedTempFiles) == 0 && len(r.CorruptFiles) == 0
+}
+
+// Merge hängt einen weiteren Report an.
+func (r *RecoveryReport) Merge(other RecoveryReport) {
+	r.RemovedTempFiles = append(r.RemovedTempFiles, other.RemovedTempFiles...)
+	r.CorruptFiles = append(r.CorruptFiles, other.CorruptFiles...)
+}
+
+// Recover räumt nach einem Absturz auf und wird beim Start aufgerufen.
+//
+//   - Übrig gebliebene Temp-Dateien (Absturz vor dem Rename) werden gelöscht:
+//     Die eigentliche Datei hat noch den alten, vollständigen Inhalt.
+//   - Unlesbare JSON-Dateien (z.B. abgeschnitten von einer Version vor dem
+//     atomaren Schreiben) werden in <id>.json.corrupt umbenannt, statt sie
+//     bei jedem List() stillschweigend zu überspringen. Der Inhalt bleibt
+//     für eine manuelle Reparatur erhalten.
+//
+// Ein fehlendes Verzeichnis ist kein Fehler.
+func (s *FileStore) Recover() (RecoveryReport, error) {
+	var report RecoveryReport
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return report, nil
+		}
+		return report, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		path := filepath.Join(s.dir, name)
+		if entry.IsDir() {
+			continue
+		}
+
+		if strings.HasPrefix(name, ".") && strings.HasSuffix(name, tempSuffix) {
+			info, err := entry.Info()
+			if err != nil || time.Since(info.ModTime()) < staleTempAge {
+				continue
+			}
+			if err := os.Remove(path); err != nil {
+				return report, fmt.Errorf("failed to remove temp file: %w", err)
+			}
+			report.RemovedTempFiles = append(report.RemovedTempFiles, path)
+			continue
+		}
+
+		if strings.HasPrefix(name, ".") || filepath.Ext(name) != ".json" {
+			continue
+		}
+		reason := checkJSON(path)
+		if reason == "" {
+			continue
+		}
+		quarantined, err := quarantine(path)
+		if err != nil {
+			return report, err
+		}
+		report.CorruptFiles = append(report.CorruptFiles, CorruptFile{Path: path, QuarantinedAs: quarantined, Reason: reason})
+	}
+
+	return report, nil
+}
+
+// checkJSON gibt einen Grund zurück, wenn die Datei kein gültiges JSON-Objekt ist.
+func checkJSON(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err.Error()
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		if len(data) == 0 {
+			return "empty file"
+		}
+		return err.Error()
+	}
+	return ""
+}
+
+// quarantine benennt eine unlesbare Datei um, ohne eine ältere zu überschreiben.
+func quarantine(path string) (string, error) {
+	target := path + corruptSuffix
+	for i := 1; ; i++ {
+		if _, err := os.Stat(target); os.IsNotExist(err) {
+			break
+		}
+		target = fmt.Sprintf("%s%s.%d", path, corruptSuffix, i)
+	}
+	if err := os.Rename(path, target); err != nil {
+		return "", fmt.Errorf("failed to quarantine %s: %w", path, err)
+	}
+	return target, nil
+}
@@ -0,0 +1,175 @@
+package persistence
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type storeItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestFileStore_WriteReadIDs(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "items"))
+
+	if ids, err := store.IDs(); err != nil || len(ids) != 0 {
+		t.Fatalf("IDs on missing dir = %v, %v; want empty", ids, err)
+	}
+
+	for _, id := range []string{"a", "b"} {
+		if err := store.Write(id, storeItem{ID: id, Name: "first"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Überschreiben ersetzt den Inhalt vollständig
+	if err := store.Write("a", storeItem{ID: "a", Name: "second"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got storeItem
+	if err := store.Read("a", &got); err != nil || got.Name != "second" {
+		t.Errorf("Read = %+v, %v; want second", got, err)
+	}
+	if err := store.Read("missing", &got); !os.IsNotExist(err) {
+		t.Errorf("Read missing: err = %v, want not exist", err)
+	}
+
+	// Nur <id>.json zählt, keine Temp-Dateien, Wire-Logs oder Quarantäne
+	for _, name := range []string{".a.json.123.tmp", "a.wire.log", "c.json.corrupt"} {
+		os.WriteFile(filepath.Join(store.Dir(), name), []byte("x"), 0644)
+	}
+	ids, err := store.IDs()
+	if err != nil || strings.Join(ids, ",") != "a,b" {
+		t.Errorf("IDs = %v, %v; want a,b", ids, err)
+	}
+
+	info, err := os.Stat(mustPath(t, store, "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0644 && os.PathSeparator == '/' {
+		t.Errorf("permissions = %v, want 0644", perm)
+	}
+}
+
+func TestFileStore_RejectsUnsafeIDs(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileStore(filepath.Join(dir, "items"))
+
+	for _, id := range []string{"", "../../pwned", "a/b", `a\b`, ".."} {
+		if err := store.Write(id, storeItem{ID: id}); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("Write(%q): err = %v, want ErrInvalidID", id, err)
+		}
+		if err := store.Read(id, &storeItem{}); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("Read(%q): err = %v, want ErrInvalidID", id, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pwned.json")); !os.IsNotExist(err) {
+		t.Errorf("file outside the store was written: %v", err)
+	}
+}
+
+// mustPath gibt den Dateipfad einer gültigen ID zurück.
+func mustPath(t *testing.T, store *FileStore, id string) string {
+	t.Helper()
+	path, err := store.Path(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestWriteFileAtomic_FailureKeepsOldContent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "session.json")
+	if err := WriteFileAtomic(path, []byte(`{"shots": 1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Ein Verzeichnis als Ziel lässt das Rename scheitern
+	blocked := filepath.Join(dir, "blocked.json")
+	os.MkdirAll(filepath.Join(blocked, "child"), 0755)
+	if err := WriteFileAtomic(blocked, []byte(`{}`), 0644); err == nil {
+		t.Fatal("expected rename onto a directory to fail")
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != `{"shots": 1}` {
+		t.Errorf("content = %s, want old content", data)
+	}
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), tempSuffix) {
+			t.Errorf("temp file left behind: %s", entry.Name())
+		}
+	}
+}
+
+func TestFileStore_Recover(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+	store.Write("good", storeItem{ID: "good"})
+
+	write := func(name, content string, age time.Duration) string {
+		path := filepath.Join(store.Dir(), name)
+		os.WriteFile(path, []byte(content), 0644)
+		modTime := time.Now().Add(-age)
+		os.Chtimes(path, modTime, modTime)
+		return path
+	}
+	// Absturz mitten im Schreiben (Version ohne atomares Schreiben)
+	truncated := write("truncated.json", `{"id": "truncated", "shots": [{"velocity`, 0)
+	empty := write("empty.json", "", 0)
+	// Absturz vor dem Rename: alte Temp-Datei; junge gehört evtl. einem anderen Prozess
+	stale := write(".good.json.111.tmp", `{"id": "good"`, time.Hour)
+	fresh := write(".good.json.222.tmp", `{"id": "good"`, 0)
+	wireLog := write("good.wire.log", "# metric-neo wire log v1\n", 0)
+
+	report, err := store.Recover()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.RemovedTempFiles) != 1 || report.RemovedTempFiles[0] != stale {
+		t.Errorf("removed temp files = %v, want %s", report.RemovedTempFiles, stale)
+	}
+	if len(report.CorruptFiles) != 2 {
+		t.Fatalf("corrupt files = %+v, want 2", report.CorruptFiles)
+	}
+	for _, corrupt := range report.CorruptFiles {
+		if corrupt.Path != truncated && corrupt.Path != empty {
+			t.Errorf("unexpected corrupt file %s", corrupt.Path)
+		}
+		if corrupt.QuarantinedAs != corrupt.Path+corruptSuffix || corrupt.Reason == "" {
+			t.Errorf("corrupt file = %+v", corrupt)
+		}
+	}
+
+	// Inhalt bleibt für die Reparatur erhalten
+	if data, _ := os.ReadFile(truncated + corruptSuffix); !strings.HasPrefix(string(data), `{"id": "truncated"`) {
+		t.Errorf("quarantined content = %q", data)
+	}
+	for _, path := range []string{fresh, wireLog, mustPath(t, store, "good")} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s must be kept: %v", filepath.Base(path), err)
+		}
+	}
+	if ids, _ := store.IDs(); len(ids) != 1 || ids[0] != "good" {
+		t.Errorf("IDs after recover = %v, want [good]", ids)
+	}
+
+	// Zweiter Lauf: nichts mehr zu tun; erneute Quarantäne überschreibt nicht
+	write("truncated.json", `{`, 0)
+	report, _ = store.Recover()
+	if len(report.CorruptFiles) != 1 || report.CorruptFiles[0].QuarantinedAs != truncated+corruptSuffix+".1" {
+		t.Errorf("second recover = %+v", report)
+	}
+
+	if report, err := NewFileStore(filepath.Join(store.Dir(), "missing")).Recover(); err != nil || !report.Empty() {
+		t.Errorf("missing dir: %+v, %v", report, err)
+	}
+}
@@ -0,0 +1,341 @@
+package persistence
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrNewerSchema signalisiert Daten einer neueren Programmversion.
+// Sie werden nicht geöffnet: Ein Zurückschreiben im alten Format würde
+// Felder verlieren, die diese Version nicht kennt.
+var ErrNewerSchema = errors.New("data was written by a newer version of metric-neo")
+
+// ManifestFile liegt im Wurzelverzeichnis der Daten und beschreibt ihren Stand.
+const ManifestFile = "manifest.json"
+
+// BackupDir enthält die Originale vor jeder Migration (backups/schema-v<n>-<zeit>/).
+const BackupDir = "backups"
+
+// Manifest beschreibt das Datenverzeichnis als Ganzes.
+type Manifest struct {
+	SchemaVersion int       `json:"schema_version"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	// StorageBackend ist die aktive Speicherung (leer = JSON, siehe backend.go)
+	StorageBackend StorageBackend `json:"storage_backend,omitempty"`
+}
+
+// Migration hebt Dokumente auf Version Version.
+type Migration struct {
+	Version     int
+	Description string
+	// Migrate ändert ein Dokument der Art kind. nil = nur die Version stempeln.
+	// Die Funktion darf nur das Document ändern, keine Dateien.
+	Migrate func(kind DocumentKind, doc *Document) error
+}
+
+// migrations sind alle Schritte in aufsteigender Reihenfolge.
+// Neue Felder (z.B. Charge, Pressure aus der Domain-Spec) bekommen hier
+// einen Schritt, der alte Dokumente ergänzt oder umbaut.
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "add schema_version to every document and write the manifest",
+	},
+	{
+		Version:     2,
+		Description: "add drag_model and bc_unit to projectiles and projectile snapshots",
+		Migrate:     migrateDragModel,
+	},
+}
+
+// migrateDragModel macht das Widerstandsmodell explizit. Vor Schema 2
+// kannte ein Projectile nur "bc" - gemeint war immer G1 in lb/in².
+// Bei Sessions betrifft das den eingebetteten projectile_snapshot.
+func migrateDragModel(kind DocumentKind, doc *Document) error {
+	switch kind {
+	case KindProjectile:
+		return setDragDefaults(doc)
+	case KindSession:
+		var raw json.RawMessage
+		if err := doc.Get("projectile_snapshot", &raw); err != nil || raw == nil || string(raw) == "null" {
+			return err
+		}
+		snapshot, err := ParseDocument(raw)
+		if err != nil {
+			return fmt.Errorf("projectile_snapshot: %w", err)
+		}
+		if err := setDragDefaults(snapshot); err != nil {
+			return err
+		}
+		return doc.Set("projectile_snapshot", snapshot)
+	}
+	return nil
+}
+
+// setDragDefaults setzt drag_model G1 und bc_unit lb/in2, wo sie fehlen.
+func setDragDefaults(doc *Document) error {
+	defaults := []struct{ key, value string }{
+		{"drag_model", "G1"},
+		{"bc_unit", "lb/in2"},
+	}
+	for _, d := range defaults {
+		var current string
+		doc.Get(d.key, &current)
+		if current != "" {
+			continue
+		}
+		if err := doc.Set(d.key, d.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MigrationReport beschreibt eine durchgeführte Migration.
+type MigrationReport struct {
+	FromVersion int
+	ToVersion   int
+	Documents   int    // umgeschriebene Dokumente
+	BackupDir   string // leer, wenn nichts migriert wurde
+	Steps       []string
+}
+
+// Migrated gibt true zurück, wenn Dateien umgeschrieben wurden.
+func (r MigrationReport) Migrated() bool {
+	return r.FromVersion != r.ToVersion
+}
+
+// ReadManifest liest das Manifest. ok=false, wenn es (noch) keins gibt.
+func ReadManifest(dataDir string) (manifest Manifest, ok bool, err error) {
+	data, err := os.ReadFile(filepath.Join(dataDir, ManifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Manifest{}, false, nil
+		}
+		return Manifest{}, false, err
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return Manifest{}, false, fmt.Errorf("failed to read %s: %w", ManifestFile, err)
+	}
+	return manifest, true, nil
+}
+
+// writeManifest setzt die Schema-Version; übrige Felder bleiben erhalten.
+func writeManifest(dataDir string, version int) error {
+	return updateManifest(dataDir, func(m *Manifest) { m.SchemaVersion = version })
+}
+
+func updateManifest(dataDir string, update func(m *Manifest)) error {
+	manifest, ok, err := ReadManifest(dataDir)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		manifest.SchemaVersion = SchemaVersion
+	}
+	update(&manifest)
+	manifest.UpdatedAt = time.Now()
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return err
+	}
+	return WriteFileAtomic(filepath.Join(dataDir, ManifestFile), data, 0644)
+}
+
+// Migrate bringt das Datenverzeichnis auf SchemaVersion und wird beim Start
+// vor dem Erstellen der Services aufgerufen.
+//
+// Ablauf:
+//  1. Manifest lesen. Ohne Manifest gelten vorhandene Daten als Version 0,
+//     ein leeres Verzeichnis bekommt direkt ein aktuelles Manifest.
+//  2. Neuere Version als SchemaVersion: ErrNewerSchema, nichts wird angefasst.
+//  3. Alle Dokumente nach backups/schema-v<alt>-<zeit>/ kopieren.
+//  4. Jede Migration nacheinander auf alle Dokumente anwenden. Nach jedem
+//     Schritt wird das Manifest fortgeschrieben; Dokumente, die den Schritt
+//     schon haben, werden übersprungen. Ein abgebrochener Lauf setzt daher
+//     beim nächsten Start einfach fort.
+func Migrate(dataDir string) (MigrationReport, error) {
+	return migrate(dataDir, migrations, SchemaVersion)
+}
+
+func migrate(dataDir string, steps []Migration, target int) (MigrationReport, error) {
+	manifest, ok, err := ReadManifest(dataDir)
+	if err != nil {
+		return MigrationReport{}, err
+	}
+
+	from := manifest.SchemaVersion
+	if !ok {
+		empty, err := isEmptyDataDir(dataDir)
+		if err != nil {
+			return MigrationReport{}, err
+		}
+		if empty {
+			return MigrationReport{FromVersion: target, ToVersion: target}, writeManifest(dataDir, target)
+		}
+		from = 0
+	}
+
+	report := MigrationReport{FromVersion: from, ToVersion: from}
+	if from > target {
+		return report, fmt.Errorf("%w (schema %d, supported %d) - please update metric-neo", ErrNewerSchema, from, target)
+	}
+	if from == target {
+		return report, nil
+	}
+
+	report.BackupDir, err = backupDocuments(dataDir, from)
+	if err != nil {
+		return report, fmt.Errorf("backup before migration failed: %w", err)
+	}
+
+	for _, step := range steps {
+		if step.Version <= from || step.Version > target {
+			continue
+		}
+		migrated, err := applyMigration(dataDir, step, target)
+		report.Documents += migrated
+		if err != nil {
+			return report, fmt.Errorf("migration to schema %d failed (originals in %s): %w", step.Version, report.BackupDir, err)
+		}
+		if err := writeManifest(dataDir, step.Version); err != nil {
+			return report, err
+		}
+		report.ToVersion = step.Version
+		report.Steps = append(report.Steps, fmt.Sprintf("v%d: %s", step.Version, step.Description))
+	}
+	return report, nil
+}
+
+// applyMigration wendet einen Schritt auf alle Dokumente an.
+func applyMigration(dataDir string, step Migration, target int) (int, error) {
+	migrated := 0
+	for _, kind := range DocumentKinds() {
+		store := NewFileStore(DocumentDir(dataDir, kind))
+		ids, err := store.IDs()
+		if err != nil {
+			return migrated, err
+		}
+
+		for _, id := range ids {
+			path, err := store.Path(id)
+			if err != nil {
+				return migrated, err
+			}
+			doc, err := store.readDocument(id)
+			if err != nil {
+				return migrated, err
+			}
+			version := doc.SchemaVersion()
+			if version > target {
+				return migrated, fmt.Errorf("%s: %w (schema %d, supported %d)", path, ErrNewerSchema, version, target)
+			}
+			if version >= step.Version {
+				continue // bereits migriert (abgebrochener Lauf)
+			}
+
+			if step.Migrate != nil {
+				if err := step.Migrate(kind, doc); err != nil {
+					return migrated, fmt.Errorf("%s: %w", path, err)
+				}
+			}
+			doc.setSchemaVersion(step.Version)
+			if err := store.writeDocument(id, doc); err != nil {
+				return migrated, err
+			}
+			migrated++
+		}
+	}
+	return migrated, nil
+}
+
+// isEmptyDataDir prüft, ob es noch keine Dokumente gibt (Erststart).
+func isEmptyDataDir(dataDir string) (bool, error) {
+	for _, kind := range DocumentKinds() {
+		ids, err := NewFileStore(DocumentDir(dataDir, kind)).IDs()
+		if err != nil {
+			return false, err
+		}
+		if len(ids) > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// backupDocuments kopiert alle Dokumente (und das Manifest) in ein neues Backup-Verzeichnis.
+func backupDocuments(dataDir string, version int) (string, error) {
+	return copyDocuments(dataDir, backupPath(dataDir, fmt.Sprintf("schema-v%d", version)))
+}
+
+// backupPath gibt ein neues, noch nicht existierendes Verzeichnis unter backups/ zurück.
+func backupPath(dataDir, label string) string {
+	backup := filepath.Join(dataDir, BackupDir, label+"-"+time.Now().Format("20060102-150405"))
+	if _, err := os.Stat(backup); err == nil {
+		// Zwei Backups in derselben Sekunde (z.B. Tests): nicht überschreiben
+		backup += fmt.Sprintf("-%d", time.Now().UnixNano())
+	}
+	return backup
+}
+
+// copyDocuments kopiert alle Dokumente (und das Manifest) nach backup.
+func copyDocuments(dataDir, backup string) (string, error) {
+	for _, kind := range DocumentKinds() {
+		store := NewFileStore(DocumentDir(dataDir, kind))
+		ids, err := store.IDs()
+		if err != nil {
+			return "", err
+		}
+		rel, _ := filepath.Rel(dataDir, store.Dir())
+		for _, id := range ids {
+			path, err := store.Path(id)
+			if err != nil {
+				return "", err
+			}
+			if err := copyFile(path, filepath.Join(backup, rel, id+".json")); err != nil {
+				return "", err
+			}
+		}
+	}
+	manifest := filepath.Join(dataDir, ManifestFile)
+	if _, err := os.Stat(manifest); err == nil {
+		if err := copyFile(manifest, filepath.Join(backup, ManifestFile)); err != nil {
+			return "", err
+		}
+	}
+	return backup, nil
+}
+
+func copyFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
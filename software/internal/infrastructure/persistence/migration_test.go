@@ -0,0 +1,240 @@
+package persistence
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDocument_KeepsFieldOrder(t *testing.T) {
+	doc, err := ParseDocument([]byte(`{"id": "a", "name": "Steyr", "unknown": {"x": [1, 2]}, "shots": []}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.SchemaVersion() != 0 {
+		t.Errorf("SchemaVersion = %d, want 0", doc.SchemaVersion())
+	}
+
+	doc.Rename("name", "title")
+	doc.Delete("shots")
+	doc.Set("note", "neu")
+	doc.setSchemaVersion(2)
+
+	data, _ := doc.MarshalJSON()
+	want := `{"schema_version":2,"id":"a","title":"Steyr","unknown":{"x": [1, 2]},"note":"neu"}`
+	if string(data) != want {
+		t.Errorf("document = %s\nwant       %s", data, want)
+	}
+
+	var title string
+	if err := doc.Get("title", &title); err != nil || title != "Steyr" || doc.Has("name") {
+		t.Errorf("title = %q, %v; name present: %v", title, err, doc.Has("name"))
+	}
+
+	if _, err := ParseDocument([]byte(`[1, 2]`)); err == nil {
+		t.Error("expected an error for a JSON array")
+	}
+}
+
+// writeLegacyDir legt ein Datenverzeichnis wie vor der Versionierung an (Schema 0).
+func writeLegacyDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[docKey]string{
+		{KindProfile, "p1"}:  `{"id": "p1", "name": "Steyr"}`,
+		{KindSession, "s1"}:  `{"id": "s1", "shots": [{"velocity_mps": 170}]}`,
+		{KindSight, "sight"}: `{"id": "sight", "click_unit": "MRAD"}`,
+	}
+	for key, content := range files {
+		path := mustPath(t, NewFileStore(DocumentDir(dir, key.Kind)), key.ID)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// docKey adressiert ein Dokument in den Tests.
+type docKey struct {
+	Kind DocumentKind
+	ID   string
+}
+
+func TestMigrate_LegacyDirectory(t *testing.T) {
+	dir := writeLegacyDir(t)
+
+	var seen []string
+	steps := []Migration{
+		{Version: 1, Description: "stamp"},
+		{Version: 2, Description: "rename", Migrate: func(kind DocumentKind, doc *Document) error {
+			seen = append(seen, string(kind))
+			doc.Rename("name", "title")
+			return nil
+		}},
+	}
+	report, err := migrate(dir, steps, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.FromVersion != 0 || report.ToVersion != 2 || report.Documents != 6 || len(report.Steps) != 2 {
+		t.Errorf("report = %+v", report)
+	}
+	// Stammdaten zuerst, Sessions zuletzt
+	if strings.Join(seen, ",") != "profile,sight,session" {
+		t.Errorf("migration order = %v", seen)
+	}
+
+	profiles := NewFileStore(DocumentDir(dir, KindProfile))
+	data, _ := os.ReadFile(mustPath(t, profiles, "p1"))
+	if !strings.HasPrefix(string(data), "{\n  \"schema_version\": 2,\n  \"id\": \"p1\",\n  \"title\": \"Steyr\"") {
+		t.Errorf("migrated profile:\n%s", data)
+	}
+
+	// Originale unverändert im Backup
+	backup, _ := os.ReadFile(filepath.Join(report.BackupDir, "inventory", "profiles", "p1.json"))
+	if string(backup) != `{"id": "p1", "name": "Steyr"}` {
+		t.Errorf("backup = %s", backup)
+	}
+
+	manifest, ok, err := ReadManifest(dir)
+	if err != nil || !ok || manifest.SchemaVersion != 2 {
+		t.Errorf("manifest = %+v, %v, %v", manifest, ok, err)
+	}
+
+	// Zweiter Start: nichts mehr zu tun, kein neues Backup
+	report, err = migrate(dir, steps, 2)
+	if err != nil || report.Migrated() || report.BackupDir != "" {
+		t.Errorf("second run = %+v, %v", report, err)
+	}
+}
+
+func TestMigrate_ResumesAfterCrash(t *testing.T) {
+	dir := writeLegacyDir(t)
+
+	// Absturz mitten in Schritt 1: ein Dokument ist schon gestempelt, das Manifest fehlt
+	sessions := NewFileStore(DocumentDir(dir, KindSession))
+	doc, _ := sessions.readDocument("s1")
+	doc.setSchemaVersion(1)
+	sessions.writeDocument("s1", doc)
+
+	report, err := Migrate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// v1 nur für die zwei übrigen Dokumente, v2 für alle drei
+	if report.Documents != 2+3 || report.ToVersion != SchemaVersion {
+		t.Errorf("report = %+v, want the two remaining documents stamped", report)
+	}
+
+	for _, kind := range DocumentKinds() {
+		store := NewFileStore(DocumentDir(dir, kind))
+		ids, _ := store.IDs()
+		for _, id := range ids {
+			doc, _ := store.readDocument(id)
+			if doc.SchemaVersion() != SchemaVersion {
+				t.Errorf("%s/%s: schema %d", kind, id, doc.SchemaVersion())
+			}
+		}
+	}
+}
+
+func TestMigrate_RefusesNewerData(t *testing.T) {
+	dir := writeLegacyDir(t)
+	os.WriteFile(filepath.Join(dir, ManifestFile), []byte(`{"schema_version": 99}`), 0644)
+	before, _ := os.ReadFile(mustPath(t, NewFileStore(DocumentDir(dir, KindProfile)), "p1"))
+
+	if _, err := Migrate(dir); !errors.Is(err, ErrNewerSchema) {
+		t.Fatalf("err = %v, want ErrNewerSchema", err)
+	}
+	after, _ := os.ReadFile(mustPath(t, NewFileStore(DocumentDir(dir, KindProfile)), "p1"))
+	if string(before) != string(after) {
+		t.Error("data from a newer version must not be touched")
+	}
+	if _, err := os.Stat(filepath.Join(dir, BackupDir)); !os.IsNotExist(err) {
+		t.Error("no backup expected when refusing")
+	}
+
+	// Einzelnes neueres Dokument (z.B. von einer neueren CLI kopiert)
+	store := NewFileStore(t.TempDir())
+	os.MkdirAll(store.Dir(), 0755)
+	os.WriteFile(mustPath(t, store, "x"), []byte(`{"schema_version": 99, "id": "x"}`), 0644)
+	var item storeItem
+	if err := store.Read("x", &item); !errors.Is(err, ErrNewerSchema) {
+		t.Errorf("Read: err = %v, want ErrNewerSchema", err)
+	}
+}
+
+func TestMigrate_FreshDirectory(t *testing.T) {
+	dir := t.TempDir()
+	report, err := Migrate(dir)
+	if err != nil || report.Migrated() {
+		t.Fatalf("report = %+v, %v", report, err)
+	}
+	if manifest, ok, _ := ReadManifest(dir); !ok || manifest.SchemaVersion != SchemaVersion {
+		t.Errorf("fresh directory should get a current manifest, got %+v", manifest)
+	}
+
+	// Neue Dokumente tragen die aktuelle Version als erstes Feld
+	store := NewFileStore(DocumentDir(dir, KindProjectile))
+	store.Write("a", storeItem{ID: "a"})
+	data, _ := os.ReadFile(mustPath(t, store, "a"))
+	if !strings.HasPrefix(string(data), fmt.Sprintf("{\n  \"schema_version\": %d,", SchemaVersion)) {
+		t.Errorf("written document:\n%s", data)
+	}
+}
+
+func TestMigrate_DragModel(t *testing.T) {
+	dir := t.TempDir()
+	files := map[docKey]string{
+		{KindProjectile, "jsb"}: `{"schema_version": 1, "id": "jsb", "bc": 0.024}`,
+		{KindProjectile, "ga"}:  `{"schema_version": 1, "id": "ga", "bc": 0.03, "drag_model": "GA", "bc_unit": "lb/in2"}`,
+		{KindSession, "s1"}:     `{"schema_version": 1, "id": "s1", "projectile_snapshot": {"id": "jsb", "bc": 0.024}}`,
+		{KindSession, "s2"}:     `{"schema_version": 1, "id": "s2", "projectile_snapshot": null}`,
+	}
+	for key, content := range files {
+		path := mustPath(t, NewFileStore(DocumentDir(dir, key.Kind)), key.ID)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(content), 0644)
+	}
+	os.WriteFile(filepath.Join(dir, ManifestFile), []byte(`{"schema_version": 1}`), 0644)
+
+	if _, err := Migrate(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(kind DocumentKind, id string) *Document {
+		doc, err := NewFileStore(DocumentDir(dir, kind)).readDocument(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return doc
+	}
+	field := func(doc *Document, key string) string {
+		var value string
+		doc.Get(key, &value)
+		return value
+	}
+
+	if jsb := read(KindProjectile, "jsb"); field(jsb, "drag_model") != "G1" || field(jsb, "bc_unit") != "lb/in2" {
+		t.Errorf("legacy projectile: %s %s, want G1 lb/in2", field(jsb, "drag_model"), field(jsb, "bc_unit"))
+	}
+	if ga := read(KindProjectile, "ga"); field(ga, "drag_model") != "GA" {
+		t.Errorf("existing drag model overwritten: %s", field(ga, "drag_model"))
+	}
+
+	var snapshot struct {
+		DragModel string `json:"drag_model"`
+		BCUnit    string `json:"bc_unit"`
+	}
+	read(KindSession, "s1").Get("projectile_snapshot", &snapshot)
+	if snapshot.DragModel != "G1" || snapshot.BCUnit != "lb/in2" {
+		t.Errorf("snapshot = %+v, want G1 lb/in2", snapshot)
+	}
+	if s2 := read(KindSession, "s2"); s2.SchemaVersion() != SchemaVersion {
+		t.Errorf("session without snapshot: schema %d", s2.SchemaVersion())
+	}
+}
@@ -1,11 +1,9 @@
 package persistence
 
 import (
-	"encoding/json"
 	"fmt"
 	"metric-neo/internal/domain/entities"
 	"os"
-	"path/filepath"
 )
 
 // ProjectileRepository verwaltet die Persistierung von Projectile-Entities.
@@ -19,9 +17,9 @@ import (
 // - Tests können ein Mock-Repository nutzen
 // - Klare Trennung: Domain-Logik vs. Technik
 type ProjectileRepository struct {
-	// dataDir ist das Verzeichnis, wo JSON-Dateien gespeichert werden
+	// store schreibt die JSON-Dateien atomar (siehe FileStore)
 	// Laut ADR 003: /data/inventory/
-	dataDir string
+	store *FileStore
 }
 
 // NewProjectileRepository erstellt eine neue Repository-Instanz.
@@ -31,7 +29,7 @@ type ProjectileRepository struct {
 // Das macht Tests einfacher (wir können ein temp-Verzeichnis nutzen).
 func NewProjectileRepository(dataDir string) *ProjectileRepository {
 	return &ProjectileRepository{
-		dataDir: dataDir,
+		store: NewFileStore(dataDir),
 	}
 }
 
@@ -42,32 +40,11 @@ func NewProjectileRepository(dataDir string) *ProjectileRepository {
 // Das hilft beim Debugging: "failed to save projectile: permission denied"
 // statt nur: "permission denied"
 func (r *ProjectileRepository) Save(p *entities.Projectile) error {
-	// GO-KONZEPT: os.MkdirAll() - Rekursive Directory-Erstellung
-	// Entspricht `mkdir -p` in der Shell
-	// 0755 = Berechtigungen (rwxr-xr-x)
-	if err := os.MkdirAll(r.dataDir, 0755); err != nil {
-		return fmt.Errorf("failed to create data directory: %w", err)
-	}
-
-	// Dateiname: {UUID}.json
-	// filepath.Join() ist platform-agnostic (Windows: \, Linux: /)
-	filename := filepath.Join(r.dataDir, p.ID+".json")
-
-	// GO-KONZEPT: json.MarshalIndent() für lesbare JSON-Dateien
-	// Parameter:
-	// - p: Das zu serialisierende Objekt
-	// - "": Kein Prefix
-	// - "  ": 2 Spaces Einrückung (macht JSON schön lesbar)
-	data, err := json.MarshalIndent(p, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal projectile: %w", err)
-	}
-
-	// GO-KONZEPT: os.WriteFile() - Atomic File Write
-	// 0644 = Berechtigungen (rw-r--r--)
-	// Entspricht: Owner kann lesen+schreiben, Rest nur lesen
-	if err := os.WriteFile(filename, data, 0644); err != nil {
-		return fmt.Errorf("failed to write file %s: %w", filename, err)
+	// GO-KONZEPT: Delegation an eine gemeinsame Infrastruktur
+	// Verzeichnis anlegen, json.MarshalIndent() und atomares Schreiben
+	// (write-temp + fsync + rename) übernimmt der FileStore für alle Repositories.
+	if err := r.store.Write(p.ID, p); err != nil {
+		return fmt.Errorf("failed to save projectile %s: %w", p.ID, err)
 	}
 
 	return nil
@@ -78,23 +55,13 @@ func (r *ProjectileRepository) Save(p *entities.Projectile) error {
 // GO-KONZEPT: Pointer Return für Entities
 // Wir geben *entities.Projectile zurück (nicht eine Kopie).
 func (r *ProjectileRepository) Load(id string) (*entities.Projectile, error) {
-	filename := filepath.Join(r.dataDir, id+".json")
-
-	// GO-KONZEPT: os.ReadFile() - File lesen
-	// Gibt []byte (Byte-Array) zurück
-	data, err := os.ReadFile(filename)
-	if err != nil {
+	var projectile entities.Projectile
+	if err := r.store.Read(id, &projectile); err != nil {
 		// GO-KONZEPT: os.IsNotExist() für spezifische Fehlerprüfung
 		if os.IsNotExist(err) {
 			return nil, fmt.Errorf("projectile %s not found", id)
 		}
-		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
-	}
-
-	// Unmarshal JSON zu Projectile
-	var projectile entities.Projectile
-	if err := json.Unmarshal(data, &projectile); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal projectile: %w", err)
+		return nil, fmt.Errorf("failed to load projectile %s: %w", id, err)
 	}
 
 	// GO-KONZEPT: Address-Of Operator (&)
@@ -108,38 +75,25 @@ func (r *ProjectileRepository) Load(id string) (*entities.Projectile, error) {
 // Ein Slice ist wie ein Array, aber dynamisch wachsend.
 // []*entities.Projectile = "Slice von Pointern auf Projectile"
 func (r *ProjectileRepository) List() ([]*entities.Projectile, error) {
-	// GO-KONZEPT: os.ReadDir() - Verzeichnis auflisten
-	// Gibt []os.DirEntry zurück
-	entries, err := os.ReadDir(r.dataDir)
+	// IDs() liefert eine leere Liste, wenn das Verzeichnis noch nicht existiert
+	ids, err := r.store.IDs()
 	if err != nil {
-		// Wenn Verzeichnis nicht existiert, geben wir leere Liste zurück
-		if os.IsNotExist(err) {
-			return []*entities.Projectile{}, nil
-		}
-		return nil, fmt.Errorf("failed to read directory: %w", err)
+		return nil, err
 	}
 
 	// GO-KONZEPT: Slice mit Capacity pre-allocieren
-	// make([]*entities.Projectile, 0, len(entries))
+	// make([]*entities.Projectile, 0, len(ids))
 	// - Länge 0 (leer)
 	// - Capacity = Anzahl Dateien (vermeidet Reallocations)
-	projectiles := make([]*entities.Projectile, 0, len(entries))
+	projectiles := make([]*entities.Projectile, 0, len(ids))
 
 	// GO-KONZEPT: Range Loop über Slice
-	for _, entry := range entries {
-		// Überspringe Verzeichnisse, nur .json Dateien laden
-		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
-			continue
-		}
-
-		// Extrahiere ID aus Dateiname (entferne .json Extension)
-		id := entry.Name()[:len(entry.Name())-5] // "abc.json" -> "abc"
-
+	for _, id := range ids {
 		// Lade Projectile
 		p, err := r.Load(id)
 		if err != nil {
-			// Bei Fehler: Überspringe diese Datei, aber fahre fort
-			// In Production würdest du das loggen!
+			// Bei Fehler: Überspringe diese Datei, aber fahre fort.
+			// Unlesbare Dateien legt Recover() beim Start beiseite und meldet sie.
 			continue
 		}
 
@@ -153,15 +107,17 @@ func (r *ProjectileRepository) List() ([]*entities.Projectile, error) {
 
 // Delete löscht ein Projectile.
 func (r *ProjectileRepository) Delete(id string) error {
-	filename := filepath.Join(r.dataDir, id+".json")
-
-	// GO-KONZEPT: os.Remove() - Datei löschen
-	if err := os.Remove(filename); err != nil {
+	if err := r.store.Remove(id); err != nil {
 		if os.IsNotExist(err) {
 			return fmt.Errorf("projectile %s not found", id)
 		}
-		return fmt.Errorf("failed to delete file %s: %w", filename, err)
+		return fmt.Errorf("failed to delete projectile %s: %w", id, err)
 	}
 
 	return nil
 }
+
+// Recover räumt Temp-Dateien und unlesbare Dateien auf (siehe FileStore.Recover).
+func (r *ProjectileRepository) Recover() (RecoveryReport, error) {
+	return r.store.Recover()
+}
@@ -4,7 +4,6 @@ import (
 	"metric-neo/internal/domain/entities"
 	"metric-neo/internal/domain/valueobjects"
 	"os"
-	"path/filepath"
 	"testing"
 )
 
@@ -149,7 +148,7 @@ func TestProjectileRepository_Delete(t *testing.T) {
 	repo.Save(p)
 
 	// Prüfe, dass Datei existiert
-	filename := filepath.Join(repo.dataDir, p.ID+".json")
+	filename := mustPath(t, repo.store, p.ID)
 	if _, err := os.Stat(filename); os.IsNotExist(err) {
 		t.Fatal("File was not created")
 	}
@@ -0,0 +1,300 @@
+package persistence
+
+import (
+	"fmt"
+	"metric-neo/internal/domain/entities"
+	"os"
+	"sort"
+	"time"
+)
+
+// sessionIndexID ist der Dateiname des Index im Session-Verzeichnis (.index.json).
+// Der führende Punkt hält ihn aus IDs(), Recover() und Migrationen heraus.
+const sessionIndexID = ".index"
+
+// sessionIndexVersion wird erhöht, wenn sich SessionIndexEntry ändert.
+// Ein Index mit anderer Version wird verworfen und neu aufgebaut.
+const sessionIndexVersion = 2
+
+// SessionIndexEntry sind die Metadaten einer Session für Listen und Filter.
+type SessionIndexEntry struct {
+	ID              string    `json:"id"`
+	CreatedAt       time.Time `json:"created_at"`
+	Note            string    `json:"note,omitempty"`
+	ProfileID       string    `json:"profile_id"`
+	ProfileName     string    `json:"profile_name"`
+	ProjectileID    string    `json:"projectile_id"`
+	ProjectileName  string    `json:"projectile_name"`
+	LotID           string    `json:"lot_id,omitempty"`
+	LotNumber       string    `json:"lot_number,omitempty"`
+	ShotCount       int       `json:"shot_count"`
+	ValidShotCount  int       `json:"valid_shot_count"`
+	AvgVelocityMPS  float64   `json:"avg_velocity_mps,omitempty"`
+	AvgEnergyJoules float64   `json:"avg_energy_joules,omitempty"`
+
+	// FileModTime und FileSize der Session-JSON beim Indexieren.
+	// Weichen sie ab, wurde die Datei außerhalb dieses Repositories geändert
+	// (CLI parallel zur App, Absturz zwischen Session und Index) und der
+	// Eintrag wird neu erzeugt.
+	FileModTime int64 `json:"file_mod_time"`
+	FileSize    int64 `json:"file_size"`
+}
+
+// sessionIndex ist der Inhalt von sessions/.index.json.
+type sessionIndex struct {
+	IndexVersion int                           `json:"index_version"`
+	Sessions     map[string]*SessionIndexEntry `json:"sessions"`
+}
+
+// SessionQuery filtert und blättert den Session-Index.
+// Leere Felder filtern nicht; Limit 0 = alle.
+type SessionQuery struct {
+	ProfileID    string
+	ProjectileID string
+	LotID        string
+	From         time.Time // inklusive
+	To           time.Time // exklusive
+	OldestFirst  bool      // Standard: neueste zuerst
+	Offset       int
+	Limit        int
+}
+
+// newSessionIndexEntry berechnet die Metadaten einer Session.
+func newSessionIndexEntry(session *entities.Session, info os.FileInfo) *SessionIndexEntry {
+	entry := &SessionIndexEntry{
+		ID:             session.ID,
+		CreatedAt:      session.CreatedAt,
+		Note:           session.Note,
+		ShotCount:      session.ShotCount(),
+		ValidShotCount: session.ValidShotCount(),
+		FileModTime:    info.ModTime().UnixNano(),
+		FileSize:       info.Size(),
+	}
+	if session.ProfileSnapshot != nil {
+		entry.ProfileID = session.ProfileSnapshot.ID
+		entry.ProfileName = session.ProfileSnapshot.Name
+	}
+	if session.ProjectileSnapshot != nil {
+		entry.ProjectileID = session.ProjectileSnapshot.ID
+		entry.ProjectileName = session.ProjectileSnapshot.Name
+		if lot := session.ProjectileSnapshot.Lot; lot != nil {
+			entry.LotID = lot.ID
+			entry.LotNumber = lot.Number
+		}
+	}
+
+	if session.ValidShotCount() > 0 {
+		if avg, err := session.CalculateAverageVelocity(); err == nil {
+			entry.AvgVelocityMPS = avg.MetersPerSecond()
+		}
+		if avgEnergy, err := session.CalculateAverageEnergy(); err == nil {
+			entry.AvgEnergyJoules = avgEnergy.Joules()
+		}
+	}
+	return entry
+}
+
+// matches prüft, ob der Eintrag zur Datei auf der Platte passt.
+func (e *SessionIndexEntry) matches(info os.FileInfo) bool {
+	return e.FileModTime == info.ModTime().UnixNano() && e.FileSize == info.Size()
+}
+
+// readIndex liest den Index. Fehlt er oder ist er unbrauchbar, gibt es einen leeren.
+func (r *SessionRepository) readIndex() *sessionIndex {
+	var index sessionIndex
+	if err := r.store.Read(sessionIndexID, &index); err != nil || index.IndexVersion != sessionIndexVersion || index.Sessions == nil {
+		return &sessionIndex{IndexVersion: sessionIndexVersion, Sessions: map[string]*SessionIndexEntry{}}
+	}
+	return &index
+}
+
+func (r *SessionRepository) writeIndex(index *sessionIndex) error {
+	if err := r.store.Write(sessionIndexID, index); err != nil {
+		return fmt.Errorf("failed to write session index: %w", err)
+	}
+	return nil
+}
+
+// cachedIndex gibt den Index im Speicher zurück und liest ihn beim ersten
+// Zugriff von der Platte. Er ist nur ein Startpunkt: Query() gleicht ihn
+// immer erst mit dem Verzeichnis ab. Aufrufer hält r.mu.
+func (r *SessionRepository) cachedIndex() *sessionIndex {
+	if r.index == nil {
+		r.index = r.readIndex()
+	}
+	return r.index
+}
+
+// updateIndex trägt eine gerade gespeicherte Session ein (nil = entfernen).
+//
+// Nur der Eintrag im Speicher wird geändert; .index.json schreibt erst der
+// nächste Query() (siehe flushIndex). So kostet ein Save unabhängig von der
+// Anzahl der Sessions nur ein Stat. Schlägt das Stat fehl, fliegt der Eintrag
+// raus und Query() liest die Session-Datei neu. Aufrufer hält r.mu.
+func (r *SessionRepository) updateIndex(id string, session *entities.Session) error {
+	index := r.cachedIndex()
+	r.dirty = true
+	if session == nil {
+		delete(index.Sessions, id)
+		return nil
+	}
+
+	path, err := r.store.Path(id)
+	if err == nil {
+		var info os.FileInfo
+		if info, err = os.Stat(path); err == nil {
+			index.Sessions[id] = newSessionIndexEntry(session, info)
+			return nil
+		}
+	}
+	delete(index.Sessions, id)
+	return fmt.Errorf("failed to index session %s: %w", id, err)
+}
+
+// flushIndex schreibt den Index, falls er seit dem letzten Schreiben geändert
+// wurde. Ein nicht schreibbarer Index ist kein Fehler für die Abfrage: Er
+// bleibt als geändert markiert, und die Einträge auf der Platte werden beim
+// Lesen ohnehin gegen die Session-Dateien geprüft. Aufrufer hält r.mu.
+func (r *SessionRepository) flushIndex() error {
+	if !r.dirty {
+		return nil
+	}
+	if err := r.writeIndex(r.index); err != nil {
+		return err
+	}
+	r.dirty = false
+	return nil
+}
+
+// IndexWarning gibt den letzten Index-Fehler zurück, der weder Save noch
+// Query hat scheitern lassen (nil = Index aktuell gespeichert). Query
+// setzt ihn neu, sobald der Index abgeglichen und geschrieben ist.
+func (r *SessionRepository) IndexWarning() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.indexErr
+}
+
+// syncIndex gleicht den Index mit dem Verzeichnis ab.
+//
+// Kosten: ein Verzeichnis-Listing plus ein Stat pro Datei. Nur neue oder
+// geänderte Sessions werden geparst. Aufrufer hält r.mu.
+func (r *SessionRepository) syncIndex(index *sessionIndex) error {
+	ids, err := r.store.IDs()
+	if err != nil {
+		return err
+	}
+
+	present := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		present[id] = true
+		path, err := r.store.Path(id)
+		if err != nil {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			continue // zwischen Listing und Stat gelöscht
+		}
+		if entry, ok := index.Sessions[id]; ok && entry.matches(info) {
+			continue
+		}
+
+		session, err := r.Load(id)
+		if err != nil {
+			// Unlesbar (z.B. neueres Schema): nicht listen, wie bisher
+			if _, ok := index.Sessions[id]; ok {
+				delete(index.Sessions, id)
+				r.dirty = true
+			}
+			continue
+		}
+		index.Sessions[id] = newSessionIndexEntry(session, info)
+		r.dirty = true
+	}
+	for id := range index.Sessions {
+		if !present[id] {
+			delete(index.Sessions, id)
+			r.dirty = true
+		}
+	}
+	return nil
+}
+
+// Query gibt die passenden Index-Einträge sortiert nach Datum zurück,
+// dazu die Gesamtzahl der Treffer vor Offset/Limit (für die Paginierung).
+func (r *SessionRepository) Query(q SessionQuery) ([]SessionIndexEntry, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index := r.cachedIndex()
+	if err := r.syncIndex(index); err != nil {
+		return nil, 0, err
+	}
+	// Der Abgleich hat fehlende Einträge aus Save ersetzt; übrig bleibt
+	// höchstens, dass der Index nicht geschrieben werden konnte
+	r.indexErr = r.flushIndex()
+
+	entries := make([]SessionIndexEntry, 0, len(index.Sessions))
+	for _, entry := range index.Sessions {
+		if q.ProfileID != "" && entry.ProfileID != q.ProfileID {
+			continue
+		}
+		if q.ProjectileID != "" && entry.ProjectileID != q.ProjectileID {
+			continue
+		}
+		if q.LotID != "" && entry.LotID != q.LotID {
+			continue
+		}
+		if !q.From.IsZero() && entry.CreatedAt.Before(q.From) {
+			continue
+		}
+		if !q.To.IsZero() && !entry.CreatedAt.Before(q.To) {
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			if q.OldestFirst {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID < b.ID // stabile Seiten bei gleichem Zeitstempel
+	})
+
+	total := len(entries)
+	if q.Offset > 0 {
+		if q.Offset >= total {
+			return []SessionIndexEntry{}, total, nil
+		}
+		entries = entries[q.Offset:]
+	}
+	if q.Limit > 0 && q.Limit < len(entries) {
+		entries = entries[:q.Limit]
+	}
+	return entries, total, nil
+}
+
+// RebuildIndex verwirft den Index und baut ihn aus allen Session-Dateien neu auf.
+// Gibt die Anzahl der indexierten Sessions zurück.
+func (r *SessionRepository) RebuildIndex() (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index := &sessionIndex{IndexVersion: sessionIndexVersion, Sessions: map[string]*SessionIndexEntry{}}
+	if err := r.syncIndex(index); err != nil {
+		return 0, err
+	}
+	r.index = index
+	// Auch ohne Änderung schreiben (leeres Verzeichnis, beschädigter alter Index)
+	if err := r.writeIndex(index); err != nil {
+		r.dirty, r.indexErr = true, err
+		return 0, err
+	}
+	r.dirty, r.indexErr = false, nil
+	return len(index.Sessions), nil
+}
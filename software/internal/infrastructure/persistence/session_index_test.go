@@ -0,0 +1,183 @@
+package persistence
+
+import (
+	"metric-neo/internal/domain/entities"
+	"metric-neo/internal/domain/valueobjects"
+	"os"
+	"testing"
+	"time"
+)
+
+// saveSessionAt speichert eine Session mit festem Erstellungsdatum.
+func saveSessionAt(t *testing.T, repo *SessionRepository, profile *entities.Profile, createdAt time.Time, velocities ...float64) *entities.Session {
+	t.Helper()
+	session := entities.NewSession(profile, createTestProjectile())
+	session.CreatedAt = createdAt
+	for _, v := range velocities {
+		velocity, _ := valueobjects.NewVelocity(v)
+		session.RecordShot(velocity)
+	}
+	if err := repo.Save(session); err != nil {
+		t.Fatal(err)
+	}
+	return session
+}
+
+func TestSessionIndex_QueryFiltersSortsAndPages(t *testing.T) {
+	repo := NewSessionRepository(t.TempDir())
+	steyr, walther := createTestProfile(), createTestProfile()
+	day := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	oldest := saveSessionAt(t, repo, steyr, day, 175, 176)
+	middle := saveSessionAt(t, repo, walther, day.AddDate(0, 0, 1))
+	newest := saveSessionAt(t, repo, steyr, day.AddDate(0, 0, 2), 170)
+
+	entries, total, err := repo.Query(SessionQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || entries[0].ID != newest.ID || entries[2].ID != oldest.ID {
+		t.Fatalf("default order = %+v, want newest first", entries)
+	}
+	if entries[2].ShotCount != 2 || entries[2].AvgVelocityMPS != 175.5 || entries[2].ProfileID != steyr.ID {
+		t.Errorf("entry = %+v", entries[2])
+	}
+
+	entries, total, _ = repo.Query(SessionQuery{ProfileID: steyr.ID, OldestFirst: true})
+	if total != 2 || entries[0].ID != oldest.ID || entries[1].ID != newest.ID {
+		t.Errorf("profile filter = %+v", entries)
+	}
+
+	entries, total, _ = repo.Query(SessionQuery{From: day.AddDate(0, 0, 1), To: day.AddDate(0, 0, 2)})
+	if total != 1 || entries[0].ID != middle.ID {
+		t.Errorf("date range = %+v, want only the middle session", entries)
+	}
+
+	entries, total, _ = repo.Query(SessionQuery{Offset: 1, Limit: 1})
+	if total != 3 || len(entries) != 1 || entries[0].ID != middle.ID {
+		t.Errorf("page 2 = %+v (total %d)", entries, total)
+	}
+	if entries, _, _ := repo.Query(SessionQuery{Offset: 5}); len(entries) != 0 {
+		t.Errorf("offset past the end = %+v", entries)
+	}
+
+	// Delete entfernt den Eintrag sofort
+	repo.Delete(middle.ID)
+	if _, total, _ := repo.Query(SessionQuery{}); total != 2 {
+		t.Errorf("total after delete = %d, want 2", total)
+	}
+}
+
+func TestSessionIndex_HealsExternalChanges(t *testing.T) {
+	dir := t.TempDir()
+	repo := NewSessionRepository(dir)
+	session := saveSessionAt(t, repo, createTestProfile(), time.Now(), 175)
+
+	// Ein zweiter Prozess (CLI) ändert die Session an diesem Repository vorbei
+	other := NewSessionRepository(dir)
+	velocity, _ := valueobjects.NewVelocity(180)
+	session.RecordShot(velocity)
+	if err := other.store.Write(session.ID, session); err != nil {
+		t.Fatal(err)
+	}
+	// ...und legt eine Session ohne Index-Eintrag an
+	added := entities.NewSession(createTestProfile(), createTestProjectile())
+	other.store.Write(added.ID, added)
+
+	entries, total, err := repo.Query(SessionQuery{})
+	if err != nil || total != 2 {
+		t.Fatalf("Query = %d entries, %v; want 2", total, err)
+	}
+	for _, entry := range entries {
+		if entry.ID == session.ID && entry.ShotCount != 2 {
+			t.Errorf("stale entry: %+v", entry)
+		}
+	}
+
+	// Manuell gelöschte Datei verschwindet aus dem Index
+	os.Remove(mustPath(t, repo.store, added.ID))
+	if _, total, _ := repo.Query(SessionQuery{}); total != 1 {
+		t.Errorf("total after external delete = %d, want 1", total)
+	}
+
+	// Beschädigter Index wird neu aufgebaut, statt Listen zu leeren
+	os.WriteFile(mustPath(t, repo.store, sessionIndexID), []byte(`{"index_version": 1, "sessions": [`), 0644)
+	if _, total, _ := repo.Query(SessionQuery{}); total != 1 {
+		t.Errorf("total with damaged index = %d, want 1", total)
+	}
+
+	count, err := repo.RebuildIndex()
+	if err != nil || count != 1 {
+		t.Errorf("RebuildIndex = %d, %v; want 1", count, err)
+	}
+	// Der Index ist keine Session
+	if ids, _ := repo.List(); len(ids) != 1 {
+		t.Errorf("List = %v, index must not be listed", ids)
+	}
+}
+
+func TestSessionIndex_SaveUpdatesOnlyItsEntry(t *testing.T) {
+	dir := t.TempDir()
+	repo := NewSessionRepository(dir)
+	profile := createTestProfile()
+	session := saveSessionAt(t, repo, profile, time.Now(), 175)
+	saveSessionAt(t, repo, profile, time.Now().Add(-time.Hour), 170)
+	if _, total, err := repo.Query(SessionQuery{}); err != nil || total != 2 {
+		t.Fatalf("Query = %d, %v; want 2", total, err)
+	}
+	indexPath := mustPath(t, repo.store, sessionIndexID)
+	before, err := os.ReadFile(indexPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Save schreibt nur die Session, nicht den ganzen Index
+	velocity, _ := valueobjects.NewVelocity(180)
+	session.RecordShot(velocity)
+	if err := repo.Save(session); err != nil {
+		t.Fatal(err)
+	}
+	if after, _ := os.ReadFile(indexPath); string(after) != string(before) {
+		t.Error("Save rewrote .index.json")
+	}
+
+	entries, _, _ := repo.Query(SessionQuery{})
+	if entries[0].ID != session.ID || entries[0].ShotCount != 2 {
+		t.Errorf("entry after Save = %+v, want 2 shots", entries[0])
+	}
+	// Query schreibt den geänderten Index für den nächsten Prozess
+	if after, _ := os.ReadFile(indexPath); string(after) == string(before) {
+		t.Error("Query did not persist the changed index")
+	}
+	entries, _, _ = NewSessionRepository(dir).Query(SessionQuery{})
+	if entries[0].ShotCount != 2 {
+		t.Errorf("entry in a new repository = %+v, want 2 shots", entries[0])
+	}
+}
+
+func TestSessionIndex_ReportsUnwritableIndex(t *testing.T) {
+	repo := NewSessionRepository(t.TempDir())
+	saveSessionAt(t, repo, createTestProfile(), time.Now(), 175)
+
+	// Ein Verzeichnis an Stelle von .index.json: Schreiben scheitert
+	indexPath := mustPath(t, repo.store, sessionIndexID)
+	if err := os.MkdirAll(indexPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, total, err := repo.Query(SessionQuery{}); err != nil || total != 1 {
+		t.Fatalf("Query = %d, %v; want 1 session despite unwritable index", total, err)
+	}
+	if repo.IndexWarning() == nil {
+		t.Error("IndexWarning() = nil, want write error")
+	}
+
+	// Wieder schreibbar: der nächste Query holt das Schreiben nach
+	os.Remove(indexPath)
+	repo.Query(SessionQuery{})
+	if err := repo.IndexWarning(); err != nil {
+		t.Errorf("IndexWarning() after recovery = %v", err)
+	}
+	if _, err := os.Stat(indexPath); err != nil {
+		t.Errorf("index not written after recovery: %v", err)
+	}
+}
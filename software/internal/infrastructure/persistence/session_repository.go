@@ -1,20 +1,34 @@
 package persistence
 
 import (
-	"encoding/json"
 	"fmt"
 	"metric-neo/internal/domain/entities"
 	"os"
 	"path/filepath"
+	"sync"
 )
 
+// SessionRepository speichert Sessions als sessions/<uuid>.json und pflegt
+// daneben einen Metadaten-Index (sessions/.index.json, siehe session_index.go),
+// damit Listen nicht jede Session mit allen Schüssen parsen müssen.
 type SessionRepository struct {
-	storageDir string
+	store *FileStore
+
+	// mu schützt index, dirty und indexErr
+	mu sync.Mutex
+
+	// index ist der Index im Speicher (nil = noch nicht gelesen), dirty
+	// markiert Änderungen, die noch nicht in .index.json stehen.
+	index *sessionIndex
+	dirty bool
+
+	// indexErr ist der letzte nicht fatale Index-Fehler (siehe IndexWarning)
+	indexErr error
 }
 
 func NewSessionRepository(storageDir string) *SessionRepository {
 	return &SessionRepository{
-		storageDir: storageDir,
+		store: NewFileStore(storageDir),
 	}
 }
 
@@ -24,48 +38,33 @@ func (r *SessionRepository) Save(session *entities.Session) error {
 		return fmt.Errorf("session cannot be nil")
 	}
 
-	// Erstelle Verzeichnis falls nicht vorhanden
-	err := os.MkdirAll(r.storageDir, 0755)
-	if err != nil {
-		return fmt.Errorf("failed to create storage directory: %w", err)
-	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	// Serialisiere zu JSON (mit Einrückung für Lesbarkeit)
-	data, err := json.MarshalIndent(session, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal session: %w", err)
+	// Atomar schreiben: <uuid>.json ist nie halb geschrieben (siehe FileStore)
+	if err := r.store.Write(session.ID, session); err != nil {
+		return err
 	}
 
-	// Dateiname: <uuid>.json
-	filename := filepath.Join(r.storageDir, session.ID+".json")
-
-	// Schreibe Datei (0644 = rw-r--r--)
-	err = os.WriteFile(filename, data, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+	// Erst die Session, dann der Index: Die Session-Datei ist die Wahrheit.
+	// Scheitert der Index (oder stürzt das Programm dazwischen ab), passt
+	// der Eintrag nicht mehr zur Datei und Query() erneuert ihn. Der Fehler
+	// wird deshalb nur für IndexWarning vermerkt: Die Session ist gespeichert,
+	// und ein Fehler hier ließe Aufrufer das Speichern wiederholen (doppelte Schüsse).
+	if err := r.updateIndex(session.ID, session); err != nil {
+		r.indexErr = err
 	}
-
 	return nil
 }
 
 // Load lädt eine Session anhand ihrer ID
 func (r *SessionRepository) Load(id string) (*entities.Session, error) {
-	filename := filepath.Join(r.storageDir, id+".json")
-
-	// Lese Datei
-	data, err := os.ReadFile(filename)
-	if err != nil {
+	var session entities.Session
+	if err := r.store.Read(id, &session); err != nil {
 		if os.IsNotExist(err) {
 			return nil, fmt.Errorf("session not found: %w", err)
 		}
-		return nil, fmt.Errorf("failed to read file: %w", err)
-	}
-
-	// Deserialisiere JSON
-	var session entities.Session
-	err = json.Unmarshal(data, &session)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+		return nil, fmt.Errorf("failed to load session: %w", err)
 	}
 
 	return &session, nil
@@ -73,31 +72,20 @@ func (r *SessionRepository) Load(id string) (*entities.Session, error) {
 
 // List gibt alle gespeicherten Session-IDs zurück
 func (r *SessionRepository) List() ([]string, error) {
-	entries, err := os.ReadDir(r.storageDir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return []string{}, nil // Leeres Array statt Fehler
-		}
-		return nil, fmt.Errorf("failed to read directory: %w", err)
-	}
-
-	var ids []string
-	for _, entry := range entries {
-		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".json" {
-			// Entferne .json Extension um ID zu erhalten
-			id := entry.Name()[:len(entry.Name())-5]
-			ids = append(ids, id)
-		}
-	}
+	return r.store.IDs()
+}
 
-	return ids, nil
+// Recover räumt Temp-Dateien und unlesbare Dateien auf (siehe FileStore.Recover).
+func (r *SessionRepository) Recover() (RecoveryReport, error) {
+	return r.store.Recover()
 }
 
 // Delete löscht eine Session anhand ihrer ID
 func (r *SessionRepository) Delete(id string) error {
-	filename := filepath.Join(r.storageDir, id+".json")
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	err := os.Remove(filename)
+	err := r.store.Remove(id)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return fmt.Errorf("session not found: %w", err)
@@ -105,5 +93,31 @@ func (r *SessionRepository) Delete(id string) error {
 		return fmt.Errorf("failed to delete file: %w", err)
 	}
 
+	// Rohdaten gehören zur Session und werden mit gelöscht
+	if err := os.Remove(r.WireLogPath(id)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete wire log: %w", err)
+	}
+
+	r.updateIndex(id, nil)
 	return nil
 }
+
+// WireLogPath gibt den Pfad des Chrono-Rohdaten-Logs einer Session zurück
+// (neben der Session-JSON, siehe chrono.WireLogExt).
+func (r *SessionRepository) WireLogPath(id string) string {
+	return filepath.Join(r.store.Dir(), id+".wire.log")
+}
+
+// OpenWireLog öffnet das Rohdaten-Log einer Session zum Anhängen.
+// Jedes Scharfschalten hängt ein neues Segment an.
+func (r *SessionRepository) OpenWireLog(id string) (*os.File, error) {
+	if err := os.MkdirAll(r.store.Dir(), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	file, err := os.OpenFile(r.WireLogPath(id), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open wire log: %w", err)
+	}
+	return file, nil
+}
@@ -0,0 +1,285 @@
+// Package sqlite speichert Sessions und Inventar in einer eingebetteten
+// SQLite-Datenbank (<dataDir>/metric-neo.db).
+//
+// Das Backend ist optional, Standard bleibt der JSON Document Store aus
+// ADR 003. Es richtet sich an Nutzer, die über viele Sessions hinweg mit
+// SQL auswerten wollen (z.B. mit dem sqlite3-Kommandozeilenwerkzeug oder
+// "metric-neo storage query").
+//
+// GO-KONZEPT: Pure-Go SQLite (modernc.org/sqlite)
+// Der Treiber ist eine Übersetzung von SQLite nach Go und braucht kein cgo.
+// Cross-Compiling für Windows/macOS bleibt so einfach wie bisher.
+//
+// Tabellenaufbau: Jede Zeile trägt das vollständige Entity-JSON in der
+// Spalte document - das ist die Wahrheit und macht den Weg zurück nach JSON
+// verlustfrei. Die übrigen Spalten und die Tabelle shots sind redundante,
+// flache Kopien für SQL-Abfragen und werden bei jedem Save neu geschrieben.
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"metric-neo/internal/infrastructure/persistence"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// schemaVersion ist die Version der Tabellen (PRAGMA user_version).
+const schemaVersion = 5
+
+// migrations heben das Tabellenschema um je eine Version: migrations[0]
+// erzeugt Version 1, migrations[1] Version 2 usw. Neue Spalten bekommen
+// einen weiteren Eintrag, vorhandene Einträge werden nie geändert.
+var migrations = []string{schemaV1, schemaV2, schemaV3, schemaV4, schemaV5}
+
+// schemaV1 legt die Tabellen an. Zeitstempel sind UTC-Text mit fester Breite
+// (timeLayout), damit sie sortierbar sind und SQLite-Datumsfunktionen verstehen.
+const schemaV1 = `
+CREATE TABLE IF NOT EXISTS profiles (
+	id       TEXT PRIMARY KEY,
+	name     TEXT NOT NULL,
+	category TEXT NOT NULL,
+	document TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS projectiles (
+	id           TEXT PRIMARY KEY,
+	name         TEXT NOT NULL,
+	weight_grams REAL NOT NULL,
+	bc           REAL NOT NULL,
+	document     TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS sights (
+	id         TEXT PRIMARY KEY,
+	type       TEXT NOT NULL,
+	model_name TEXT NOT NULL,
+	document   TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS sessions (
+	id                  TEXT PRIMARY KEY,
+	created_at          TEXT NOT NULL,
+	note                TEXT NOT NULL,
+	temperature_celsius REAL,
+	profile_id          TEXT NOT NULL,
+	profile_name        TEXT NOT NULL,
+	projectile_id       TEXT NOT NULL,
+	projectile_name     TEXT NOT NULL,
+	shot_count          INTEGER NOT NULL,
+	valid_shot_count    INTEGER NOT NULL,
+	avg_velocity_mps    REAL,
+	avg_energy_joules   REAL,
+	document            TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS sessions_created_at ON sessions(created_at);
+CREATE INDEX IF NOT EXISTS sessions_profile ON sessions(profile_id);
+CREATE INDEX IF NOT EXISTS sessions_projectile ON sessions(projectile_id);
+
+CREATE TABLE IF NOT EXISTS shots (
+	session_id    TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+	seq           INTEGER NOT NULL,
+	timestamp     TEXT NOT NULL,
+	velocity_mps  REAL NOT NULL,
+	energy_joules REAL NOT NULL,
+	valid         INTEGER NOT NULL,
+	PRIMARY KEY (session_id, seq)
+);
+`
+
+// schemaV2 ergänzt die Charge aus dem ProjectileSnapshot und füllt sie
+// für vorhandene Sessions aus dem Dokument.
+const schemaV2 = `
+ALTER TABLE sessions ADD COLUMN lot_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN lot_number TEXT NOT NULL DEFAULT '';
+UPDATE sessions SET
+	lot_id = COALESCE(json_extract(document, '$.projectile_snapshot.lot.id'), ''),
+	lot_number = COALESCE(json_extract(document, '$.projectile_snapshot.lot.number'), '');
+CREATE INDEX IF NOT EXISTS sessions_lot ON sessions(lot_id);
+`
+
+// schemaV3 ergänzt die übrigen Umgebungsbedingungen neben temperature_celsius.
+// Luftdichte und Dichtehöhe sind abgeleitet und werden nicht gespeichert.
+const schemaV3 = `
+ALTER TABLE sessions ADD COLUMN pressure_hpa REAL;
+ALTER TABLE sessions ADD COLUMN humidity_percent REAL;
+ALTER TABLE sessions ADD COLUMN altitude_meters REAL;
+UPDATE sessions SET
+	pressure_hpa = json_extract(document, '$.pressure'),
+	humidity_percent = json_extract(document, '$.humidity'),
+	altitude_meters = json_extract(document, '$.altitude');
+`
+
+// schemaV4 ergänzt Widerstandsmodell und BC-Einheit der Projectiles.
+// Ältere Dokumente (auch die Snapshots in Sessions) meinten immer G1 in
+// lb/in² und bekommen das explizit (wie die Datei-Migration v2).
+const schemaV4 = `
+ALTER TABLE projectiles ADD COLUMN drag_model TEXT NOT NULL DEFAULT 'G1';
+ALTER TABLE projectiles ADD COLUMN bc_unit TEXT NOT NULL DEFAULT 'lb/in2';
+UPDATE projectiles SET document = json_set(document, '$.drag_model', 'G1')
+	WHERE IFNULL(json_extract(document, '$.drag_model'), '') = '';
+UPDATE projectiles SET document = json_set(document, '$.bc_unit', 'lb/in2')
+	WHERE IFNULL(json_extract(document, '$.bc_unit'), '') = '';
+UPDATE projectiles SET
+	drag_model = json_extract(document, '$.drag_model'),
+	bc_unit = json_extract(document, '$.bc_unit');
+UPDATE sessions SET document = json_set(document, '$.projectile_snapshot.drag_model', 'G1')
+	WHERE json_type(document, '$.projectile_snapshot') = 'object'
+	AND IFNULL(json_extract(document, '$.projectile_snapshot.drag_model'), '') = '';
+UPDATE sessions SET document = json_set(document, '$.projectile_snapshot.bc_unit', 'lb/in2')
+	WHERE json_type(document, '$.projectile_snapshot') = 'object'
+	AND IFNULL(json_extract(document, '$.projectile_snapshot.bc_unit'), '') = '';
+`
+
+// schemaV5 ergänzt die Schussserie (1-basiert). Vor Version 5 gab es keine
+// Serien, alle vorhandenen Schüsse gehören zur ersten.
+const schemaV5 = `
+ALTER TABLE shots ADD COLUMN string_number INTEGER NOT NULL DEFAULT 1;
+`
+
+// DB ist eine geöffnete Metric-Neo-Datenbank.
+type DB struct {
+	db *sql.DB
+
+	// wireLogDir nimmt die Chrono-Rohdaten-Logs auf. Sie bleiben Dateien
+	// (sessions/<id>.wire.log), unabhängig vom Backend.
+	wireLogDir string
+}
+
+// Open öffnet (oder erstellt) die Datenbank im Datenverzeichnis.
+// Datenbanken einer neueren Programmversion werden abgelehnt (persistence.ErrNewerSchema).
+func Open(dataDir string) (*DB, error) {
+	path := persistence.SQLitePath(dataDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	// WAL: Desktop-App und CLI können gleichzeitig lesen und schreiben,
+	// busy_timeout wartet auf die Schreibsperre des anderen Prozesses.
+	dsn := "file:" + path + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	store := &DB{
+		db:         db,
+		wireLogDir: persistence.DocumentDir(dataDir, persistence.KindSession),
+	}
+	if err := store.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// migrate legt das Schema an bzw. hebt es auf schemaVersion.
+// Alle ausstehenden Schritte laufen in einer Transaktion.
+func (s *DB) migrate() error {
+	var version int
+	if err := s.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read database version: %w", err)
+	}
+	if version > schemaVersion {
+		return fmt.Errorf("%s: %w (schema %d, supported %d)", persistence.SQLiteFile, persistence.ErrNewerSchema, version, schemaVersion)
+	}
+	if version == schemaVersion {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i := version; i < schemaVersion; i++ {
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			return fmt.Errorf("failed to migrate database to schema %d: %w", i+1, err)
+		}
+	}
+	if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", schemaVersion)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Close schließt die Datenbank.
+func (s *DB) Close() error {
+	return s.db.Close()
+}
+
+// Query führt eine lesende SQL-Abfrage aus und gibt Spalten und Zeilen zurück.
+//
+// Für Auswertungen durch den Nutzer: Die Abfrage läuft in einer
+// Read-Only-Transaktion, schreibende Anweisungen schlagen fehl.
+func (s *DB) Query(query string, args ...any) ([]string, [][]any, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	// query_only gilt für die Verbindung: nach der Abfrage wieder zurücksetzen
+	if _, err := tx.Exec("PRAGMA query_only = ON"); err != nil {
+		return nil, nil, err
+	}
+	defer tx.Exec("PRAGMA query_only = OFF")
+
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result [][]any
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, nil, err
+		}
+		// TEXT kommt je nach Treiber als []byte: für die Ausgabe in string wandeln
+		for i, v := range values {
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		result = append(result, values)
+	}
+	return columns, result, rows.Err()
+}
+
+// MoveAside verschiebt eine vorhandene Datenbank (samt WAL-Dateien) nach
+// backups/<label>-<zeit>/, bevor eine Konvertierung sie neu befüllt.
+// Die Datenbank darf dabei nicht geöffnet sein. Gibt "" zurück, wenn es
+// keine Datenbank gab.
+func MoveAside(dataDir, label string) (string, error) {
+	path := persistence.SQLitePath(dataDir)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return "", nil
+	}
+
+	backup := filepath.Join(dataDir, persistence.BackupDir, label+"-"+time.Now().Format("20060102-150405"))
+	if err := os.MkdirAll(backup, 0755); err != nil {
+		return "", err
+	}
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		err := os.Rename(path+suffix, filepath.Join(backup, persistence.SQLiteFile+suffix))
+		if err != nil && !os.IsNotExist(err) {
+			return backup, fmt.Errorf("failed to move database aside: %w", err)
+		}
+	}
+	return backup, nil
+}
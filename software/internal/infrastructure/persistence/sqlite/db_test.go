@@ -0,0 +1,219 @@
+package sqlite
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"metric-neo/internal/domain/ballistics"
+	"metric-neo/internal/domain/entities"
+	"metric-neo/internal/domain/valueobjects"
+	"metric-neo/internal/infrastructure/persistence"
+	"testing"
+	"time"
+)
+
+func openTestDB(t *testing.T, dir string) *DB {
+	t.Helper()
+	db, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func createTestSession(t *testing.T, velocities ...float64) *entities.Session {
+	t.Helper()
+	barrelLength, _ := valueobjects.NewLength(420.0)
+	triggerWeight, _ := valueobjects.NewMass(500.0)
+	sightHeight, _ := valueobjects.NewLength(50.0)
+	profile, _ := entities.NewProfile("Test Profile", entities.CategoryAirRifle, barrelLength, triggerWeight, sightHeight)
+	weight, _ := valueobjects.NewMass(0.547)
+	projectile, _ := entities.NewProjectile("JSB Exact", weight, 0.024)
+
+	session := entities.NewSession(profile, projectile)
+	for _, v := range velocities {
+		velocity, _ := valueobjects.NewVelocity(v)
+		session.RecordShot(velocity)
+	}
+	return session
+}
+
+func TestSessionRepository_SaveLoadDelete(t *testing.T) {
+	repo := openTestDB(t, t.TempDir()).Sessions()
+
+	session := createTestSession(t, 175.0, 176.0)
+	if err := repo.Save(session); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	loaded, err := repo.Load(session.ID)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if loaded.ShotCount() != 2 || loaded.ProfileSnapshot.Name != "Test Profile" {
+		t.Errorf("loaded session differs: %d shots, profile %q", loaded.ShotCount(), loaded.ProfileSnapshot.Name)
+	}
+
+	if err := repo.Delete(session.ID); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if _, err := repo.Load(session.ID); err == nil {
+		t.Error("Load() after Delete() should fail")
+	}
+}
+
+func TestSessionRepository_Query(t *testing.T) {
+	repo := openTestDB(t, t.TempDir()).Sessions()
+
+	base := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		session := createTestSession(t, 175.0+float64(i))
+		session.CreatedAt = base.AddDate(0, 0, i)
+		if err := repo.Save(session); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, total, err := repo.Query(persistence.SessionQuery{From: base.AddDate(0, 0, 1), Limit: 1})
+	if err != nil {
+		t.Fatalf("Query() failed: %v", err)
+	}
+	if total != 2 || len(entries) != 1 {
+		t.Fatalf("got %d entries of %d, want 1 of 2", len(entries), total)
+	}
+	if !entries[0].CreatedAt.Equal(base.AddDate(0, 0, 2)) || entries[0].AvgVelocityMPS != 177.0 {
+		t.Errorf("newest session expected first, got %+v", entries[0])
+	}
+}
+
+func TestDB_QueryShots(t *testing.T) {
+	db := openTestDB(t, t.TempDir())
+	if err := db.Sessions().Save(createTestSession(t, 175.0, 177.0)); err != nil {
+		t.Fatal(err)
+	}
+
+	columns, rows, err := db.Query("SELECT COUNT(*) AS shots, AVG(velocity_mps) AS avg FROM shots")
+	if err != nil {
+		t.Fatalf("Query() failed: %v", err)
+	}
+	if len(columns) != 2 || columns[0] != "shots" || len(rows) != 1 {
+		t.Fatalf("unexpected result: %v %v", columns, rows)
+	}
+	if rows[0][0] != int64(2) || rows[0][1] != 176.0 {
+		t.Errorf("got %v, want [2 176]", rows[0])
+	}
+}
+
+func TestDB_QueryShotsByString(t *testing.T) {
+	db := openTestDB(t, t.TempDir())
+	session := createTestSession(t, 175.0, 177.0)
+	if _, err := session.StartString("Fill 2"); err != nil {
+		t.Fatal(err)
+	}
+	velocity, _ := valueobjects.NewVelocity(180.0)
+	session.RecordShot(velocity)
+	if err := db.Sessions().Save(session); err != nil {
+		t.Fatal(err)
+	}
+
+	_, rows, err := db.Query("SELECT string_number, COUNT(*), AVG(velocity_mps) FROM shots GROUP BY string_number ORDER BY string_number")
+	if err != nil {
+		t.Fatalf("Query() failed: %v", err)
+	}
+	if len(rows) != 2 || rows[0][1] != int64(2) || rows[1][0] != int64(2) || rows[1][2] != 180.0 {
+		t.Errorf("got %v, want [[1 2 176] [2 1 180]]", rows)
+	}
+}
+
+func TestDB_QueryIsReadOnly(t *testing.T) {
+	db := openTestDB(t, t.TempDir())
+	session := createTestSession(t, 175.0)
+	if err := db.Sessions().Save(session); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := db.Query("DELETE FROM sessions"); err == nil {
+		t.Fatal("write statement should fail")
+	}
+	// Die Verbindung muss danach wieder schreiben dürfen
+	if err := db.Sessions().Delete(session.ID); err != nil {
+		t.Errorf("Delete() after Query() failed: %v", err)
+	}
+}
+
+func TestOpen_RefusesNewerSchema(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.db.Exec("PRAGMA user_version = 99"); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	if _, err := Open(dir); !errors.Is(err, persistence.ErrNewerSchema) {
+		t.Errorf("Open() = %v, want ErrNewerSchema", err)
+	}
+}
+
+func TestOpen_MigratesFromSchemaV1(t *testing.T) {
+	dir := t.TempDir()
+
+	// Datenbank im Stand von Schema 1 mit einer Session samt Charge im Dokument
+	session := createTestSession(t, 175.0)
+	lot, _ := entities.NewProjectileLot("LOT-A", 500)
+	session.ProjectileSnapshot.Lot = lot
+	pressure, _ := valueobjects.NewPressure(965.3)
+	session.SetPressure(pressure)
+	document, _ := json.Marshal(session)
+	// Snapshots vor Schema 4 kannten kein Widerstandsmodell
+	document = bytes.Replace(document, []byte(`"drag_model":"G1","bc_unit":"lb/in2",`), nil, 1)
+
+	raw, err := sql.Open("sqlite", "file:"+persistence.SQLitePath(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range []string{migrations[0], "PRAGMA user_version = 1"} {
+		if _, err := raw.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = raw.Exec(`INSERT INTO sessions (id, created_at, note, profile_id, profile_name, projectile_id, projectile_name,
+		shot_count, valid_shot_count, document) VALUES (?, ?, '', '', '', '', '', 1, 1, ?)`,
+		session.ID, formatTime(session.CreatedAt), string(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = raw.Exec(`INSERT INTO projectiles (id, name, weight_grams, bc, document)
+		VALUES ('p1', 'JSB', 0.547, 0.024, '{"id":"p1","name":"JSB","weight":0.547,"bc":0.024}')`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw.Close()
+
+	db := openTestDB(t, dir)
+	entries, _, err := db.Sessions().Query(persistence.SessionQuery{LotID: lot.ID})
+	if err != nil {
+		t.Fatalf("Query() failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].LotNumber != "LOT-A" {
+		t.Errorf("lot columns not filled from the document: %+v", entries)
+	}
+
+	_, rows, err := db.Query("SELECT pressure_hpa, humidity_percent FROM sessions")
+	if err != nil || len(rows) != 1 || rows[0][0] != 965.3 || rows[0][1] != nil {
+		t.Errorf("condition columns not filled from the document: %v %v", rows, err)
+	}
+
+	projectile, err := db.Projectiles().Load("p1")
+	if err != nil || projectile.DragModel != ballistics.G1 || projectile.BCUnit != ballistics.BCUnitImperial {
+		t.Errorf("legacy projectile = %+v, %v, want G1 in lb/in2", projectile, err)
+	}
+	loaded, err := db.Sessions().Load(session.ID)
+	if err != nil || loaded.ProjectileSnapshot.DragModel != ballistics.G1 || loaded.ProjectileSnapshot.BCUnit != ballistics.BCUnitImperial {
+		t.Errorf("legacy snapshot drag model not filled: %+v, %v", loaded.ProjectileSnapshot, err)
+	}
+}
@@ -0,0 +1,444 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"metric-neo/internal/domain/entities"
+	"metric-neo/internal/infrastructure/persistence"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// timeLayout speichert Zeitstempel als UTC-Text mit fester Breite (sortierbar).
+const timeLayout = "2006-01-02T15:04:05.000000000Z"
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// loadDocument liest die document-Spalte einer Zeile nach v.
+// Eine fehlende Zeile ergibt einen Fehler mit "not found" wie im JSON-Backend.
+func (s *DB) loadDocument(table, kind, id string, v any) error {
+	var document string
+	err := s.db.QueryRow("SELECT document FROM "+table+" WHERE id = ?", id).Scan(&document)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%s not found: %s", kind, id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", kind, err)
+	}
+	if err := json.Unmarshal([]byte(document), v); err != nil {
+		return fmt.Errorf("failed to load %s: %w", kind, err)
+	}
+	return nil
+}
+
+// deleteRow löscht eine Zeile; eine fehlende Zeile ist ein Fehler.
+func (s *DB) deleteRow(table, kind, id string) error {
+	result, err := s.db.Exec("DELETE FROM "+table+" WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", kind, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("%s not found: %s", kind, id)
+	}
+	return nil
+}
+
+// listIDs gibt alle IDs einer Tabelle zurück.
+func (s *DB) listIDs(table string) ([]string, error) {
+	rows, err := s.db.Query("SELECT id FROM " + table + " ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// listDocuments lädt alle Dokumente einer Tabelle als Entities vom Typ T.
+func listDocuments[T any](s *DB, table string) ([]*T, error) {
+	rows, err := s.db.Query("SELECT document FROM " + table + " ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []*T{}
+	for rows.Next() {
+		var document string
+		if err := rows.Scan(&document); err != nil {
+			return nil, err
+		}
+		var item T
+		if err := json.Unmarshal([]byte(document), &item); err != nil {
+			continue // wie im JSON-Backend: unlesbare Einträge überspringen
+		}
+		items = append(items, &item)
+	}
+	return items, rows.Err()
+}
+
+// ==================== PROFILES ====================
+
+// ProfileRepository speichert Profile in der Tabelle profiles.
+type ProfileRepository struct{ db *DB }
+
+// Profiles gibt das Profile-Repository der Datenbank zurück.
+func (s *DB) Profiles() *ProfileRepository { return &ProfileRepository{db: s} }
+
+// Save legt ein Profile an oder überschreibt es.
+func (r *ProfileRepository) Save(profile *entities.Profile) error {
+	if profile == nil {
+		return fmt.Errorf("profile cannot be nil")
+	}
+	document, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.db.Exec(`INSERT OR REPLACE INTO profiles (id, name, category, document) VALUES (?, ?, ?, ?)`,
+		profile.ID, profile.Name, string(profile.Category), string(document))
+	if err != nil {
+		return fmt.Errorf("failed to save profile: %w", err)
+	}
+	return nil
+}
+
+// Load lädt ein Profile anhand seiner ID.
+func (r *ProfileRepository) Load(id string) (*entities.Profile, error) {
+	var profile entities.Profile
+	if err := r.db.loadDocument("profiles", "profile", id, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// List gibt alle Profile-IDs zurück.
+func (r *ProfileRepository) List() ([]string, error) {
+	return r.db.listIDs("profiles")
+}
+
+// Delete löscht ein Profile.
+func (r *ProfileRepository) Delete(id string) error {
+	return r.db.deleteRow("profiles", "profile", id)
+}
+
+// ==================== PROJECTILES ====================
+
+// ProjectileRepository speichert Projectiles in der Tabelle projectiles.
+type ProjectileRepository struct{ db *DB }
+
+// Projectiles gibt das Projectile-Repository der Datenbank zurück.
+func (s *DB) Projectiles() *ProjectileRepository { return &ProjectileRepository{db: s} }
+
+// Save legt ein Projectile an oder überschreibt es.
+func (r *ProjectileRepository) Save(p *entities.Projectile) error {
+	if p == nil {
+		return fmt.Errorf("projectile cannot be nil")
+	}
+	document, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.db.Exec(`INSERT OR REPLACE INTO projectiles (id, name, weight_grams, bc, drag_model, bc_unit, document)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		p.ID, p.Name, p.Weight.Grams(), p.BC, string(p.DragModel), string(p.BCUnit), string(document))
+	if err != nil {
+		return fmt.Errorf("failed to save projectile %s: %w", p.ID, err)
+	}
+	return nil
+}
+
+// Load lädt ein Projectile anhand seiner ID.
+func (r *ProjectileRepository) Load(id string) (*entities.Projectile, error) {
+	var projectile entities.Projectile
+	if err := r.db.loadDocument("projectiles", "projectile", id, &projectile); err != nil {
+		return nil, err
+	}
+	return &projectile, nil
+}
+
+// List gibt alle Projectiles zurück.
+func (r *ProjectileRepository) List() ([]*entities.Projectile, error) {
+	return listDocuments[entities.Projectile](r.db, "projectiles")
+}
+
+// Delete löscht ein Projectile.
+func (r *ProjectileRepository) Delete(id string) error {
+	return r.db.deleteRow("projectiles", "projectile", id)
+}
+
+// ==================== SIGHTS ====================
+
+// SightRepository speichert Optiken in der Tabelle sights.
+type SightRepository struct{ db *DB }
+
+// Sights gibt das Sight-Repository der Datenbank zurück.
+func (s *DB) Sights() *SightRepository { return &SightRepository{db: s} }
+
+// Save legt eine Optik an oder überschreibt sie.
+func (r *SightRepository) Save(sight *entities.SightingSystem) error {
+	if sight == nil {
+		return fmt.Errorf("sight cannot be nil")
+	}
+	document, err := json.Marshal(sight)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.db.Exec(`INSERT OR REPLACE INTO sights (id, type, model_name, document) VALUES (?, ?, ?, ?)`,
+		sight.ID, string(sight.Type), sight.ModelName, string(document))
+	if err != nil {
+		return fmt.Errorf("failed to save sight: %w", err)
+	}
+	return nil
+}
+
+// Load lädt eine Optik anhand ihrer ID.
+func (r *SightRepository) Load(id string) (*entities.SightingSystem, error) {
+	var sight entities.SightingSystem
+	if err := r.db.loadDocument("sights", "sight", id, &sight); err != nil {
+		return nil, err
+	}
+	return &sight, nil
+}
+
+// List gibt alle Optiken zurück.
+func (r *SightRepository) List() ([]*entities.SightingSystem, error) {
+	return listDocuments[entities.SightingSystem](r.db, "sights")
+}
+
+// Delete löscht eine Optik.
+func (r *SightRepository) Delete(id string) error {
+	return r.db.deleteRow("sights", "sight", id)
+}
+
+// ==================== SESSIONS ====================
+
+// SessionRepository speichert Sessions in sessions (Metadaten + Dokument)
+// und shots (ein Schuss pro Zeile, für SQL-Auswertungen).
+type SessionRepository struct{ db *DB }
+
+// Sessions gibt das Session-Repository der Datenbank zurück.
+func (s *DB) Sessions() *SessionRepository { return &SessionRepository{db: s} }
+
+// Save schreibt Session und Schüsse in einer Transaktion.
+func (r *SessionRepository) Save(session *entities.Session) error {
+	if session == nil {
+		return fmt.Errorf("session cannot be nil")
+	}
+	document, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	var temperature, pressure, humidity, altitude, avgVelocity, avgEnergy sql.NullFloat64
+	if session.Temperature != nil {
+		temperature = sql.NullFloat64{Float64: session.Temperature.Celsius(), Valid: true}
+	}
+	if session.Pressure != nil {
+		pressure = sql.NullFloat64{Float64: session.Pressure.HectoPascals(), Valid: true}
+	}
+	if session.Humidity != nil {
+		humidity = sql.NullFloat64{Float64: session.Humidity.Percent(), Valid: true}
+	}
+	if session.Altitude != nil {
+		altitude = sql.NullFloat64{Float64: session.Altitude.Meters(), Valid: true}
+	}
+	if session.ValidShotCount() > 0 {
+		if avg, err := session.CalculateAverageVelocity(); err == nil {
+			avgVelocity = sql.NullFloat64{Float64: avg.MetersPerSecond(), Valid: true}
+		}
+		if avg, err := session.CalculateAverageEnergy(); err == nil {
+			avgEnergy = sql.NullFloat64{Float64: avg.Joules(), Valid: true}
+		}
+	}
+	var profileID, profileName, projectileID, projectileName, lotID, lotNumber string
+	if p := session.ProfileSnapshot; p != nil {
+		profileID, profileName = p.ID, p.Name
+	}
+	if p := session.ProjectileSnapshot; p != nil {
+		projectileID, projectileName = p.ID, p.Name
+		if p.Lot != nil {
+			lotID, lotNumber = p.Lot.ID, p.Lot.Number
+		}
+	}
+
+	tx, err := r.db.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`INSERT OR REPLACE INTO sessions (id, created_at, note,
+		temperature_celsius, pressure_hpa, humidity_percent, altitude_meters,
+		profile_id, profile_name, projectile_id, projectile_name, lot_id, lot_number,
+		shot_count, valid_shot_count, avg_velocity_mps, avg_energy_joules, document)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		session.ID, formatTime(session.CreatedAt), session.Note,
+		temperature, pressure, humidity, altitude,
+		profileID, profileName, projectileID, projectileName, lotID, lotNumber,
+		session.ShotCount(), session.ValidShotCount(), avgVelocity, avgEnergy, string(document))
+	if err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+
+	// INSERT OR REPLACE löscht die alte Zeile, ON DELETE CASCADE ihre Schüsse
+	if _, err := tx.Exec("DELETE FROM shots WHERE session_id = ?", session.ID); err != nil {
+		return fmt.Errorf("failed to save shots: %w", err)
+	}
+	insert, err := tx.Prepare(`INSERT INTO shots (session_id, seq, timestamp, velocity_mps, energy_joules, valid, string_number) VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer insert.Close()
+	for i, shot := range session.Shots {
+		energy := 0.0
+		if session.ProjectileSnapshot != nil {
+			energy = shot.CalculateEnergy(session.ProjectileSnapshot.Weight).Joules()
+		}
+		if _, err := insert.Exec(session.ID, i+1, formatTime(shot.Timestamp), shot.Velocity.MetersPerSecond(), energy, shot.Valid, session.StringOf(i)+1); err != nil {
+			return fmt.Errorf("failed to save shots: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Load lädt eine Session anhand ihrer ID.
+func (r *SessionRepository) Load(id string) (*entities.Session, error) {
+	var session entities.Session
+	if err := r.db.loadDocument("sessions", "session", id, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// List gibt alle Session-IDs zurück.
+func (r *SessionRepository) List() ([]string, error) {
+	return r.db.listIDs("sessions")
+}
+
+// Delete löscht eine Session mit Schüssen und Rohdaten-Log.
+func (r *SessionRepository) Delete(id string) error {
+	if err := r.db.deleteRow("sessions", "session", id); err != nil {
+		return err
+	}
+	if err := os.Remove(r.WireLogPath(id)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete wire log: %w", err)
+	}
+	return nil
+}
+
+// Query filtert, sortiert und blättert per SQL (Gegenstück zum JSON-Index).
+func (r *SessionRepository) Query(q persistence.SessionQuery) ([]persistence.SessionIndexEntry, int, error) {
+	var where []string
+	var args []any
+	if q.ProfileID != "" {
+		where = append(where, "profile_id = ?")
+		args = append(args, q.ProfileID)
+	}
+	if q.ProjectileID != "" {
+		where = append(where, "projectile_id = ?")
+		args = append(args, q.ProjectileID)
+	}
+	if q.LotID != "" {
+		where = append(where, "lot_id = ?")
+		args = append(args, q.LotID)
+	}
+	if !q.From.IsZero() {
+		where = append(where, "created_at >= ?")
+		args = append(args, formatTime(q.From))
+	}
+	if !q.To.IsZero() {
+		where = append(where, "created_at < ?")
+		args = append(args, formatTime(q.To))
+	}
+	filter := ""
+	if len(where) > 0 {
+		filter = " WHERE " + strings.Join(where, " AND ")
+	}
+
+	var total int
+	if err := r.db.db.QueryRow("SELECT COUNT(*) FROM sessions"+filter, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	order := "DESC"
+	if q.OldestFirst {
+		order = "ASC"
+	}
+	limit := -1 // SQLite: kein Limit
+	if q.Limit > 0 {
+		limit = q.Limit
+	}
+	rows, err := r.db.db.Query(`SELECT id, created_at, note, profile_id, profile_name, projectile_id, projectile_name,
+		lot_id, lot_number, shot_count, valid_shot_count, avg_velocity_mps, avg_energy_joules
+		FROM sessions`+filter+` ORDER BY created_at `+order+`, id LIMIT ? OFFSET ?`,
+		append(args, limit, q.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entries := []persistence.SessionIndexEntry{}
+	for rows.Next() {
+		var e persistence.SessionIndexEntry
+		var createdAt string
+		var avgVelocity, avgEnergy sql.NullFloat64
+		if err := rows.Scan(&e.ID, &createdAt, &e.Note, &e.ProfileID, &e.ProfileName, &e.ProjectileID, &e.ProjectileName,
+			&e.LotID, &e.LotNumber, &e.ShotCount, &e.ValidShotCount, &avgVelocity, &avgEnergy); err != nil {
+			return nil, 0, err
+		}
+		if e.CreatedAt, err = time.Parse(timeLayout, createdAt); err != nil {
+			return nil, 0, fmt.Errorf("session %s: invalid created_at %q", e.ID, createdAt)
+		}
+		e.AvgVelocityMPS = avgVelocity.Float64
+		e.AvgEnergyJoules = avgEnergy.Float64
+		entries = append(entries, e)
+	}
+	return entries, total, rows.Err()
+}
+
+// RebuildIndex gibt nur die Anzahl der Sessions zurück: Die Spalten der
+// Tabelle sessions werden bei jedem Save geschrieben, einen separaten
+// Index gibt es nicht.
+func (r *SessionRepository) RebuildIndex() (int, error) {
+	var count int
+	err := r.db.db.QueryRow("SELECT COUNT(*) FROM sessions").Scan(&count)
+	return count, err
+}
+
+// IndexWarning gibt immer nil zurück: Die flachen Spalten werden in derselben
+// Transaktion wie das Dokument geschrieben.
+func (r *SessionRepository) IndexWarning() error {
+	return nil
+}
+
+// WireLogPath gibt den Pfad des Chrono-Rohdaten-Logs zurück (wie im JSON-Backend).
+func (r *SessionRepository) WireLogPath(id string) string {
+	return filepath.Join(r.db.wireLogDir, id+".wire.log")
+}
+
+// OpenWireLog öffnet das Rohdaten-Log einer Session zum Anhängen.
+func (r *SessionRepository) OpenWireLog(id string) (*os.File, error) {
+	if err := os.MkdirAll(r.db.wireLogDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	file, err := os.OpenFile(r.WireLogPath(id), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open wire log: %w", err)
+	}
+	return file, nil
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"embed"
 	"log"
+	"metric-neo/internal/cli"
+	"os"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/logger"
@@ -21,6 +23,11 @@ func loadIcon() []byte {
 }
 
 func main() {
+	// Headless CLI mode: "metric-neo session list" etc. runs without a window
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
 	// Create an instance of the app structure
 	app := NewApp()
 
@@ -34,6 +41,7 @@ func main() {
 		},
 		BackgroundColour: &options.RGBA{R: 27, G: 38, B: 54, A: 1},
 		OnStartup:        app.startup,
+		OnShutdown:       app.shutdown,
 		Bind: []interface{}{
 			app,
 		},